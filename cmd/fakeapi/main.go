@@ -0,0 +1,20 @@
+// Command fakeapi serves an in-memory fake of the common Equinix Metal and
+// Fabric API endpoints, which the provider can target for offline plan
+// validation by setting its `endpoint` argument to the server address.
+package main
+
+import (
+	"flag"
+	"log"
+	"net/http"
+
+	"github.com/equinix/terraform-provider-equinix/internal/fakeapi"
+)
+
+func main() {
+	addr := flag.String("addr", "127.0.0.1:8080", "Address to listen on")
+	flag.Parse()
+
+	log.Printf("Serving fake Equinix API on http://%s", *addr)
+	log.Fatal(http.ListenAndServe(*addr, fakeapi.NewServer()))
+}
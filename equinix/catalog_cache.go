@@ -0,0 +1,148 @@
+package equinix
+
+import (
+	"context"
+
+	"github.com/antihax/optional"
+	v4 "github.com/equinix-labs/fabric-go/fabric/v4"
+	"github.com/equinix/equinix-sdk-go/services/metalv1"
+	"github.com/equinix/ne-go"
+	"github.com/equinix/terraform-provider-equinix/internal/cache"
+	"github.com/equinix/terraform-provider-equinix/internal/config"
+	"github.com/packethost/packngo"
+)
+
+// The functions below fetch read-only catalogs through the cache of the
+// configured provider, so that data sources of many module instances share
+// a single request per catalog. Returned slices are shared and must not be
+// modified.
+
+func cachedMetalPlans(c *config.Config, opts *packngo.ListOptions) ([]packngo.Plan, error) {
+	v, err := c.Catalog.Get(cache.Key("metal/plans", opts), func() (interface{}, error) {
+		plans, _, err := c.Metal.Plans.List(opts)
+		return plans, err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return v.([]packngo.Plan), nil
+}
+
+func cachedMetalOperatingSystems(c *config.Config) ([]packngo.OS, error) {
+	v, err := c.Catalog.Get(cache.Key("metal/operating-systems"), func() (interface{}, error) {
+		oss, _, err := c.Metal.OperatingSystems.List()
+		return oss, err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return v.([]packngo.OS), nil
+}
+
+func cachedMetalGoOperatingSystems(ctx context.Context, c *config.Config) ([]metalv1.OperatingSystem, error) {
+	v, err := c.Catalog.Get(cache.Key("metalv1/operating-systems"), func() (interface{}, error) {
+		res, _, err := c.Metalgo.OperatingSystemsApi.FindOperatingSystems(ctx).Execute()
+		if err != nil {
+			return nil, err
+		}
+		return res.GetOperatingSystems(), nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return v.([]metalv1.OperatingSystem), nil
+}
+
+func cachedMetalMetros(c *config.Config) ([]packngo.Metro, error) {
+	v, err := c.Catalog.Get(cache.Key("metal/locations/metros"), func() (interface{}, error) {
+		metros, _, err := c.Metal.Metros.List(nil)
+		return metros, err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return v.([]packngo.Metro), nil
+}
+
+func cachedNeDeviceTypes(c *config.Config) ([]ne.DeviceType, error) {
+	v, err := c.Catalog.Get(cache.Key("ne/deviceTypes"), func() (interface{}, error) {
+		return c.Ne.GetDeviceTypes()
+	})
+	if err != nil {
+		return nil, err
+	}
+	return v.([]ne.DeviceType), nil
+}
+
+func cachedNeDeviceSoftwareVersions(c *config.Config, typeCode string) ([]ne.DeviceSoftwareVersion, error) {
+	v, err := c.Catalog.Get(cache.Key("ne/deviceTypes/softwareVersions", typeCode), func() (interface{}, error) {
+		return c.Ne.GetDeviceSoftwareVersions(typeCode)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return v.([]ne.DeviceSoftwareVersion), nil
+}
+
+func cachedNeDevicePlatforms(c *config.Config, typeCode string) ([]ne.DevicePlatform, error) {
+	v, err := c.Catalog.Get(cache.Key("ne/deviceTypes/platforms", typeCode), func() (interface{}, error) {
+		return c.Ne.GetDevicePlatforms(typeCode)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return v.([]ne.DevicePlatform), nil
+}
+
+func cachedFabricMetros(ctx context.Context, c *config.Config, offset, limit int32) (v4.MetroResponse, error) {
+	v, err := c.Catalog.Get(cache.Key("fabric/metros", offset, limit), func() (interface{}, error) {
+		resp, _, err := c.FabricClient.MetrosApi.GetMetros(ctx, &v4.MetrosApiGetMetrosOpts{
+			Offset: optional.NewInt32(offset),
+			Limit:  optional.NewInt32(limit),
+		})
+		return resp, err
+	})
+	if err != nil {
+		return v4.MetroResponse{}, err
+	}
+	return v.(v4.MetroResponse), nil
+}
+
+func cachedFabricMetroByCode(ctx context.Context, c *config.Config, metroCode string) (v4.Metro, error) {
+	v, err := c.Catalog.Get(cache.Key("fabric/metros/code", metroCode), func() (interface{}, error) {
+		metro, _, err := c.FabricClient.MetrosApi.GetMetroByCode(ctx, metroCode)
+		return metro, err
+	})
+	if err != nil {
+		return v4.Metro{}, err
+	}
+	return v.(v4.Metro), nil
+}
+
+func cachedFabricServiceProfile(ctx context.Context, c *config.Config, uuid string) (v4.ServiceProfile, error) {
+	v, err := c.Catalog.Get(cache.Key("fabric/serviceProfiles", uuid), func() (interface{}, error) {
+		profile, _, err := c.FabricClient.ServiceProfilesApi.GetServiceProfileByUuid(ctx, uuid, nil)
+		return profile, err
+	})
+	if err != nil {
+		return v4.ServiceProfile{}, err
+	}
+	return v.(v4.ServiceProfile), nil
+}
+
+// cachedFabricServiceProfilesSearch searches service profiles as seen from the
+// given view point, the API default when empty.
+func cachedFabricServiceProfilesSearch(ctx context.Context, c *config.Config, request v4.ServiceProfileSearchRequest, viewPoint string) (v4.ServiceProfiles, error) {
+	v, err := c.Catalog.Get(cache.Key("fabric/serviceProfiles/search", request, viewPoint), func() (interface{}, error) {
+		var opts *v4.ServiceProfilesApiSearchServiceProfilesOpts
+		if viewPoint != "" {
+			opts = &v4.ServiceProfilesApiSearchServiceProfilesOpts{ViewPoint: optional.NewString(viewPoint)}
+		}
+		resp, _, err := c.FabricClient.ServiceProfilesApi.SearchServiceProfiles(ctx, request, opts)
+		return resp, err
+	})
+	if err != nil {
+		return v4.ServiceProfiles{}, err
+	}
+	return v.(v4.ServiceProfiles), nil
+}
@@ -0,0 +1,290 @@
+package equinix
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	v4 "github.com/equinix-labs/fabric-go/fabric/v4"
+	"github.com/equinix/terraform-provider-equinix/internal/config"
+	"github.com/equinix/terraform-provider-equinix/internal/converters"
+	equinix_errors "github.com/equinix/terraform-provider-equinix/internal/errors"
+	equinix_schema "github.com/equinix/terraform-provider-equinix/internal/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/retry"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+const fabricCloudRouterRoutesPageSize = 100
+
+// fabricCloudRouterRoutesFilterArgs are the filter arguments of the cloud
+// router routes data source.
+var fabricCloudRouterRoutesFilterArgs = []string{"type", "prefix", "next_hop", "connection_uuid"}
+
+func dataSourceFabricCloudRouterRoutes() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceFabricCloudRouterRoutesRead,
+		Schema:      readFabricCloudRouterRoutesSchema(),
+		Timeouts: &schema.ResourceTimeout{
+			Read: schema.DefaultTimeout(5 * time.Minute),
+		},
+		Description: "Fabric V4 API compatible data resource that allow user to fetch the route table entries of a Fabric Cloud Router, e.g. to check the prefixes learned from a cloud provider",
+	}
+}
+
+func readFabricCloudRouterRoutesSchema() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"cloud_router_id": {
+			Type:         schema.TypeString,
+			Required:     true,
+			ValidateFunc: validation.StringIsNotEmpty,
+			Description:  "Identifier of the Fabric Cloud Router",
+		},
+		"refresh": {
+			Type:        schema.TypeBool,
+			Optional:    true,
+			Default:     false,
+			Description: "Whether to update the route table of the Fabric Cloud Router, and wait for the update to complete, before reading it",
+		},
+		"type": {
+			Type:         schema.TypeString,
+			Optional:     true,
+			ValidateFunc: validation.StringInSlice([]string{"IPv4_BGP_ROUTE", "IPv4_STATIC_ROUTE", "IPv4_DIRECT_ROUTE", "IPv6_BGP_ROUTE", "IPv6_STATIC_ROUTE", "IPv6_DIRECT_ROUTE"}, false),
+			Description:  "Type of the route table entries to fetch, e.g. IPv4_BGP_ROUTE",
+		},
+		"prefix": {
+			Type:         schema.TypeString,
+			Optional:     true,
+			ValidateFunc: validation.StringIsNotEmpty,
+			Description:  "Prefix of the route table entries to fetch",
+		},
+		"next_hop": {
+			Type:         schema.TypeString,
+			Optional:     true,
+			ValidateFunc: validation.StringIsNotEmpty,
+			Description:  "Next hop of the route table entries to fetch",
+		},
+		"connection_uuid": {
+			Type:         schema.TypeString,
+			Optional:     true,
+			ValidateFunc: validation.StringIsNotEmpty,
+			Description:  "Identifier of the connection the route table entries to fetch are learned from",
+		},
+		"data": {
+			Type:        schema.TypeList,
+			Computed:    true,
+			Description: "List of the route table entries matching the filters",
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					"type": {
+						Type:        schema.TypeString,
+						Computed:    true,
+						Description: "Route table entry type, e.g. IPv4_BGP_ROUTE",
+					},
+					"protocol_type": {
+						Type:        schema.TypeString,
+						Computed:    true,
+						Description: "Route table entry protocol type - BGP, STATIC or DIRECT",
+					},
+					"state": {
+						Type:        schema.TypeString,
+						Computed:    true,
+						Description: "Route table entry state",
+					},
+					"age": {
+						Type:        schema.TypeString,
+						Computed:    true,
+						Description: "Age of the route table entry",
+					},
+					"prefix": {
+						Type:        schema.TypeString,
+						Computed:    true,
+						Description: "Route table entry prefix",
+					},
+					"next_hop": {
+						Type:        schema.TypeString,
+						Computed:    true,
+						Description: "Route table entry next hop",
+					},
+					"metric": {
+						Type:        schema.TypeInt,
+						Computed:    true,
+						Description: "Route table entry metric",
+					},
+					"local_preference": {
+						Type:        schema.TypeInt,
+						Computed:    true,
+						Description: "Route table entry local preference",
+					},
+					"as_path": {
+						Type:        schema.TypeList,
+						Computed:    true,
+						Description: "AS path of the route table entry",
+						Elem:        &schema.Schema{Type: schema.TypeInt},
+					},
+					"connection_uuid": {
+						Type:        schema.TypeString,
+						Computed:    true,
+						Description: "Identifier of the connection the route is learned from",
+					},
+					"connection_name": {
+						Type:        schema.TypeString,
+						Computed:    true,
+						Description: "Name of the connection the route is learned from",
+					},
+				},
+			},
+		},
+		"prefixes": {
+			Type:        schema.TypeList,
+			Computed:    true,
+			Description: "Prefixes of the route table entries matching the filters",
+			Elem:        &schema.Schema{Type: schema.TypeString},
+		},
+	}
+}
+
+func dataSourceFabricCloudRouterRoutesRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*config.Config).FabricClient
+	ctx = context.WithValue(ctx, v4.ContextAccessToken, meta.(*config.Config).FabricAuthToken)
+	routerId := d.Get("cloud_router_id").(string)
+
+	if d.Get("refresh").(bool) {
+		if err := refreshFabricCloudRouterRoutes(ctx, client, routerId, d.Timeout(schema.TimeoutRead)); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	filters := map[string]string{}
+	for _, key := range fabricCloudRouterRoutesFilterArgs {
+		if v, ok := d.GetOk(key); ok {
+			filters[key] = v.(string)
+		}
+	}
+	sortDirection, sortBy := v4.ASC_RouteTableEntrySortDirection, v4.PREFIX_RouteTableEntrySortBy
+	search := v4.RouteTableEntrySearchRequest{
+		Sort: []v4.RouteTableEntrySortCriteria{{Direction: &sortDirection, Property: &sortBy}},
+	}
+	var routes []v4.RouteTableEntry
+	for {
+		search.Pagination = &v4.PaginationRequest{
+			Offset: int32(len(routes)),
+			Limit:  fabricCloudRouterRoutesPageSize,
+		}
+		resp, _, err := client.CloudRoutersApi.SearchCloudRouterRoutes(ctx, search, routerId)
+		if err != nil {
+			return diag.FromErr(equinix_errors.FormatFabricError(err))
+		}
+		routes = append(routes, resp.Data...)
+		if len(resp.Data) == 0 || resp.Pagination == nil || len(routes) >= int(resp.Pagination.Total) {
+			break
+		}
+	}
+	routes = filterFabricCloudRouterRoutes(routes, filters)
+
+	d.SetId(fmt.Sprintf("%s/%s", routerId, fabricCloudRoutersSearchId(filters, 0, 0)))
+	prefixes := make([]string, len(routes))
+	for i, route := range routes {
+		prefixes[i] = route.Prefix
+	}
+	err := equinix_schema.SetMap(d, map[string]interface{}{
+		"data":     fabricCloudRouterRoutesToTerra(routes),
+		"prefixes": prefixes,
+	})
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	return nil
+}
+
+// refreshFabricCloudRouterRoutes asks the cloud router to update its route
+// table and waits for the update to complete.
+func refreshFabricCloudRouterRoutes(ctx context.Context, client *v4.APIClient, routerId string, timeout time.Duration) error {
+	actionType := v4.ROUTE_TABLE_ENTRY_UPDATE_CloudRouterActionType
+	action, _, err := client.CloudRoutersApi.CreateCloudRouterAction(ctx, v4.CloudRouterActionRequest{Type_: &actionType}, routerId)
+	if err != nil {
+		return equinix_errors.FormatFabricError(err)
+	}
+	stateConf := &retry.StateChangeConf{
+		Pending: []string{string(v4.PENDING_CloudRouterActionState)},
+		Target:  []string{string(v4.DONE_CloudRouterActionState)},
+		Refresh: func() (interface{}, string, error) {
+			resp, _, err := client.CloudRoutersApi.GetCloudRouterActions(ctx, routerId, nil)
+			if err != nil {
+				return nil, "", equinix_errors.FormatFabricError(err)
+			}
+			if resp.Uuid != action.Uuid || resp.State == nil {
+				// The latest action is not known yet
+				return resp, string(v4.PENDING_CloudRouterActionState), nil
+			}
+			return resp, string(*resp.State), nil
+		},
+		Timeout:    timeout,
+		Delay:      5 * time.Second,
+		MinTimeout: 5 * time.Second,
+	}
+	if _, err := stateConf.WaitForStateContext(ctx); err != nil {
+		return fmt.Errorf("error waiting for the route table of Fabric Cloud Router %s to be updated: %s", routerId, err)
+	}
+	return nil
+}
+
+// filterFabricCloudRouterRoutes returns the route table entries matching all
+// the given filters, keyed by the data source argument names. The route table
+// entry filter model of the SDK has no fields, so the filters can't be part of
+// the search request and are applied to the search results instead.
+func filterFabricCloudRouterRoutes(routes []v4.RouteTableEntry, filters map[string]string) []v4.RouteTableEntry {
+	filtered := make([]v4.RouteTableEntry, 0, len(routes))
+	for _, route := range routes {
+		values := map[string]string{"prefix": route.Prefix, "next_hop": route.NextHop}
+		if route.Type_ != nil {
+			values["type"] = string(*route.Type_)
+		}
+		if route.Connection != nil {
+			values["connection_uuid"] = route.Connection.Uuid
+		}
+		matches := true
+		for key, value := range filters {
+			if values[key] != value {
+				matches = false
+				break
+			}
+		}
+		if matches {
+			filtered = append(filtered, route)
+		}
+	}
+	return filtered
+}
+
+func fabricCloudRouterRoutesToTerra(routes []v4.RouteTableEntry) []interface{} {
+	return converters.SliceToIfArr(routes, func(route v4.RouteTableEntry) interface{} {
+		asPath := make([]int, len(route.AsPath))
+		for i, as := range route.AsPath {
+			asPath[i] = int(as)
+		}
+		mappedRoute := map[string]interface{}{
+			"age":              route.Age,
+			"prefix":           route.Prefix,
+			"next_hop":         route.NextHop,
+			"metric":           int(route.Metric),
+			"local_preference": int(route.LocalPreference),
+			"as_path":          asPath,
+		}
+		if route.Type_ != nil {
+			mappedRoute["type"] = string(*route.Type_)
+		}
+		if route.ProtocolType != nil {
+			mappedRoute["protocol_type"] = string(*route.ProtocolType)
+		}
+		if route.State != nil {
+			mappedRoute["state"] = string(*route.State)
+		}
+		if route.Connection != nil {
+			mappedRoute["connection_uuid"] = route.Connection.Uuid
+			mappedRoute["connection_name"] = route.Connection.Name
+		}
+		return mappedRoute
+	})
+}
@@ -0,0 +1,64 @@
+package equinix
+
+import (
+	"testing"
+
+	v4 "github.com/equinix-labs/fabric-go/fabric/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFabricCloudRouterRoutes_filter(t *testing.T) {
+	// given
+	bgp, static := v4.I_PV4_BGP_ROUTE_RouteTableEntryType, v4.I_PV4_STATIC_ROUTE_RouteTableEntryType
+	routes := []v4.RouteTableEntry{
+		{Type_: &bgp, Prefix: "10.0.0.0/24", NextHop: "169.254.0.1", Connection: &v4.RouteTableEntryConnection{Uuid: "aws-conn"}},
+		{Type_: &bgp, Prefix: "10.1.0.0/24", NextHop: "169.254.1.1", Connection: &v4.RouteTableEntryConnection{Uuid: "azure-conn"}},
+		{Type_: &static, Prefix: "192.168.0.0/24", NextHop: "10.0.0.1"},
+	}
+	// when
+	byConnection := filterFabricCloudRouterRoutes(routes, map[string]string{"type": "IPv4_BGP_ROUTE", "connection_uuid": "aws-conn"})
+	byType := filterFabricCloudRouterRoutes(routes, map[string]string{"type": "IPv4_BGP_ROUTE"})
+	byPrefix := filterFabricCloudRouterRoutes(routes, map[string]string{"prefix": "192.168.0.0/24"})
+	all := filterFabricCloudRouterRoutes(routes, map[string]string{})
+	// then
+	require.Len(t, byConnection, 1, "Only routes matching every filter are kept")
+	assert.Equal(t, "10.0.0.0/24", byConnection[0].Prefix)
+	assert.Len(t, byType, 2)
+	require.Len(t, byPrefix, 1)
+	assert.Equal(t, "10.0.0.1", byPrefix[0].NextHop)
+	assert.Len(t, all, 3, "All routes are kept without filters")
+}
+
+func TestFabricCloudRouterRoutes_toTerra(t *testing.T) {
+	// given
+	bgp, protocol, active := v4.I_PV4_BGP_ROUTE_RouteTableEntryType, v4.BGP_RouteTableEntryProtocolType, v4.ACTIVE_RouteTableEntryState
+	routes := []v4.RouteTableEntry{
+		{
+			Type_:           &bgp,
+			ProtocolType:    &protocol,
+			State:           &active,
+			Age:             "2d",
+			Prefix:          "10.0.0.0/24",
+			NextHop:         "169.254.0.1",
+			Metric:          10,
+			LocalPreference: 100,
+			AsPath:          []int32{64512, 16509},
+			Connection:      &v4.RouteTableEntryConnection{Uuid: "aws-conn", Name: "aws"},
+		},
+		{Prefix: "192.168.0.0/24"},
+	}
+	// when
+	mapped := fabricCloudRouterRoutesToTerra(routes)
+	// then
+	require.Len(t, mapped, 2)
+	route := mapped[0].(map[string]interface{})
+	assert.Equal(t, "IPv4_BGP_ROUTE", route["type"])
+	assert.Equal(t, "BGP", route["protocol_type"])
+	assert.Equal(t, "ACTIVE", route["state"])
+	assert.Equal(t, 100, route["local_preference"])
+	assert.Equal(t, []int{64512, 16509}, route["as_path"])
+	assert.Equal(t, "aws-conn", route["connection_uuid"])
+	assert.Equal(t, "aws", route["connection_name"])
+	assert.NotContains(t, mapped[1].(map[string]interface{}), "connection_uuid", "Connection is not set for routes without one")
+}
@@ -0,0 +1,212 @@
+package equinix
+
+import (
+	"context"
+	"crypto/sha256"
+	"fmt"
+	"sort"
+	"strings"
+
+	v4 "github.com/equinix-labs/fabric-go/fabric/v4"
+	"github.com/equinix/terraform-provider-equinix/internal/config"
+	equinix_errors "github.com/equinix/terraform-provider-equinix/internal/errors"
+	equinix_schema "github.com/equinix/terraform-provider-equinix/internal/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+const fabricCloudRoutersPageSize = 100
+
+// fabricCloudRoutersFilterArgs are the filter arguments of the cloud routers
+// data source.
+var fabricCloudRoutersFilterArgs = []string{"name", "metro_code", "project_id", "state"}
+
+func dataSourceFabricCloudRouters() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceFabricCloudRoutersRead,
+		Schema:      readFabricCloudRoutersSearchSchema(),
+		Description: "Fabric V4 API compatible data resource that allow user to search Fabric Cloud Routers by name, metro, project and state",
+	}
+}
+
+func readFabricCloudRoutersSearchSchema() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"name": {
+			Type:         schema.TypeString,
+			Optional:     true,
+			ValidateFunc: validation.StringIsNotEmpty,
+			Description:  "Name of the Fabric Cloud Routers to fetch",
+		},
+		"metro_code": {
+			Type:         schema.TypeString,
+			Optional:     true,
+			ValidateFunc: validation.StringIsNotEmpty,
+			Description:  "Metro code of the Fabric Cloud Routers to fetch",
+		},
+		"project_id": {
+			Type:         schema.TypeString,
+			Optional:     true,
+			ValidateFunc: validation.StringIsNotEmpty,
+			Description:  "Project identifier of the Fabric Cloud Routers to fetch",
+		},
+		"state": {
+			Type:         schema.TypeString,
+			Optional:     true,
+			ValidateFunc: validation.StringInSlice([]string{"PROVISIONED", "PROVISIONING", "DEPROVISIONING", "DEPROVISIONED", "LOCKED", "NOT_PROVISIONED", "NOT_DEPROVISIONED"}, false),
+			Description:  "State of the Fabric Cloud Routers to fetch, e.g. PROVISIONED",
+		},
+		"pagination": {
+			Type:        schema.TypeSet,
+			Optional:    true,
+			MaxItems:    1,
+			Description: "Page of the matching Fabric Cloud Routers to return. All the matching Fabric Cloud Routers are returned if not set",
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					"offset": {
+						Type:         schema.TypeInt,
+						Optional:     true,
+						Default:      0,
+						ValidateFunc: validation.IntAtLeast(0),
+						Description:  "Index of the first Fabric Cloud Router of the page",
+					},
+					"limit": {
+						Type:         schema.TypeInt,
+						Optional:     true,
+						Default:      20,
+						ValidateFunc: validation.IntBetween(1, fabricCloudRoutersPageSize),
+						Description:  "Maximum number of Fabric Cloud Routers of the page",
+					},
+				},
+			},
+		},
+		"data": {
+			Type:        schema.TypeList,
+			Computed:    true,
+			Description: "List of the Fabric Cloud Routers matching the search filters",
+			Elem: &schema.Resource{
+				Schema: readFabricCloudRoutersDataSchema(),
+			},
+		},
+	}
+}
+
+// readFabricCloudRoutersDataSchema returns the cloud router data source schema
+// with the uuid computed rather than required.
+func readFabricCloudRoutersDataSchema() map[string]*schema.Schema {
+	sch := readFabricCloudRouterResourceSchema()
+	sch["uuid"].Required = false
+	sch["uuid"].Computed = true
+	return sch
+}
+
+func dataSourceFabricCloudRoutersRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*config.Config).FabricClient
+	ctx = context.WithValue(ctx, v4.ContextAccessToken, meta.(*config.Config).FabricAuthToken)
+
+	filters := map[string]string{}
+	for _, key := range fabricCloudRoutersFilterArgs {
+		if v, ok := d.GetOk(key); ok {
+			filters[key] = v.(string)
+		}
+	}
+	sortDirection, sortBy := v4.ASC_CloudRouterSortDirection, v4.NAME_CloudRouterSortBy
+	search := v4.CloudRouterSearchRequest{
+		Sort: []v4.CloudRouterSortCriteria{{Direction: &sortDirection, Property: &sortBy}},
+	}
+
+	var cloudRouters []v4.CloudRouter
+	for {
+		search.Pagination = &v4.PaginationRequest{
+			Offset: int32(len(cloudRouters)),
+			Limit:  fabricCloudRoutersPageSize,
+		}
+		resp, _, err := client.CloudRoutersApi.SearchCloudRouters(ctx, search)
+		if err != nil {
+			return diag.FromErr(equinix_errors.FormatFabricError(err))
+		}
+		cloudRouters = append(cloudRouters, resp.Data...)
+		if len(resp.Data) == 0 || resp.Pagination == nil || len(cloudRouters) >= int(resp.Pagination.Total) {
+			break
+		}
+	}
+	cloudRouters = filterFabricCloudRouters(cloudRouters, filters)
+
+	offset, limit := 0, 0
+	if p, ok := d.GetOk("pagination"); ok {
+		page := p.(*schema.Set).List()[0].(map[string]interface{})
+		offset, limit = page["offset"].(int), page["limit"].(int)
+		cloudRouters = pageFabricCloudRouters(cloudRouters, offset, limit)
+	}
+
+	d.SetId(fabricCloudRoutersSearchId(filters, offset, limit))
+	err := equinix_schema.SetMap(d, map[string]interface{}{
+		"data": fabricCloudRoutersListToTerra(cloudRouters),
+	})
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	return nil
+}
+
+// filterFabricCloudRouters returns the cloud routers matching all the given
+// filters, keyed by the data source argument names. The cloud router search
+// filter model of the SDK has no fields, so the filters can't be part of the
+// search request and are applied to the search results instead.
+func filterFabricCloudRouters(cloudRouters []v4.CloudRouter, filters map[string]string) []v4.CloudRouter {
+	filtered := make([]v4.CloudRouter, 0, len(cloudRouters))
+	for _, fcr := range cloudRouters {
+		values := map[string]string{"name": fcr.Name}
+		if fcr.Location != nil {
+			values["metro_code"] = fcr.Location.MetroCode
+		}
+		if fcr.Project != nil {
+			values["project_id"] = fcr.Project.ProjectId
+		}
+		if fcr.State != nil {
+			values["state"] = string(*fcr.State)
+		}
+		matches := true
+		for key, value := range filters {
+			if values[key] != value {
+				matches = false
+				break
+			}
+		}
+		if matches {
+			filtered = append(filtered, fcr)
+		}
+	}
+	return filtered
+}
+
+func pageFabricCloudRouters(cloudRouters []v4.CloudRouter, offset, limit int) []v4.CloudRouter {
+	if offset >= len(cloudRouters) {
+		return []v4.CloudRouter{}
+	}
+	end := offset + limit
+	if end > len(cloudRouters) {
+		end = len(cloudRouters)
+	}
+	return cloudRouters[offset:end]
+}
+
+func fabricCloudRoutersListToTerra(cloudRouters []v4.CloudRouter) []map[string]interface{} {
+	mappedCloudRouters := make([]map[string]interface{}, 0, len(cloudRouters))
+	for _, fcr := range cloudRouters {
+		mappedCloudRouters = append(mappedCloudRouters, fabricCloudRouterToTerra(fcr))
+	}
+	return mappedCloudRouters
+}
+
+// fabricCloudRoutersSearchId derives a stable data source ID from the search
+// arguments.
+func fabricCloudRoutersSearchId(filters map[string]string, offset, limit int) string {
+	parts := []string{}
+	for key, value := range filters {
+		parts = append(parts, key+"="+value)
+	}
+	sort.Strings(parts)
+	parts = append(parts, fmt.Sprintf("offset=%d", offset), fmt.Sprintf("limit=%d", limit))
+	return fmt.Sprintf("%x", sha256.Sum256([]byte(strings.Join(parts, ","))))
+}
@@ -0,0 +1,77 @@
+package equinix
+
+import (
+	"testing"
+
+	v4 "github.com/equinix-labs/fabric-go/fabric/v4"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFabricCloudRouters_filter(t *testing.T) {
+	// given
+	provisioned, locked := v4.PROVISIONED_CloudRouterAccessPointState, v4.LOCKED_CloudRouterAccessPointState
+	cloudRouters := []v4.CloudRouter{
+		{Uuid: "uuid-1", Name: "fcr", State: &provisioned, Location: &v4.SimplifiedLocationWithoutIbx{MetroCode: "SV"}, Project: &v4.Project{ProjectId: "project-1"}},
+		{Uuid: "uuid-2", Name: "fcr", State: &locked, Location: &v4.SimplifiedLocationWithoutIbx{MetroCode: "SV"}},
+		{Uuid: "uuid-3", Name: "fcr", State: &provisioned, Location: &v4.SimplifiedLocationWithoutIbx{MetroCode: "DC"}},
+		{Uuid: "uuid-4", Name: "other"},
+	}
+	// when
+	bySvState := filterFabricCloudRouters(cloudRouters, map[string]string{"metro_code": "SV", "state": "PROVISIONED"})
+	byProject := filterFabricCloudRouters(cloudRouters, map[string]string{"project_id": "project-1"})
+	byName := filterFabricCloudRouters(cloudRouters, map[string]string{"name": "fcr"})
+	all := filterFabricCloudRouters(cloudRouters, map[string]string{})
+	// then
+	require.Len(t, bySvState, 1, "Only cloud routers matching every filter are kept")
+	assert.Equal(t, "uuid-1", bySvState[0].Uuid)
+	require.Len(t, byProject, 1)
+	assert.Equal(t, "uuid-1", byProject[0].Uuid)
+	assert.Len(t, byName, 3)
+	assert.Len(t, all, 4, "All cloud routers are kept without filters")
+}
+
+func TestFabricCloudRouters_page(t *testing.T) {
+	// given
+	cloudRouters := []v4.CloudRouter{{Uuid: "uuid-1"}, {Uuid: "uuid-2"}, {Uuid: "uuid-3"}}
+	// when
+	firstPage := pageFabricCloudRouters(cloudRouters, 0, 2)
+	lastPage := pageFabricCloudRouters(cloudRouters, 2, 2)
+	pastEnd := pageFabricCloudRouters(cloudRouters, 5, 2)
+	// then
+	require.Len(t, firstPage, 2)
+	assert.Equal(t, "uuid-2", firstPage[1].Uuid)
+	require.Len(t, lastPage, 1)
+	assert.Equal(t, "uuid-3", lastPage[0].Uuid)
+	assert.Empty(t, pastEnd)
+}
+
+func TestFabricCloudRouters_listToTerra(t *testing.T) {
+	// given
+	provisioned := v4.PROVISIONED_CloudRouterAccessPointState
+	cloudRouters := []v4.CloudRouter{
+		{
+			Uuid:             "uuid-1",
+			Name:             "fcr-1",
+			State:            &provisioned,
+			Package_:         &v4.CloudRouterPackageType{Code: "STANDARD"},
+			EquinixAsn:       30000,
+			ConnectionsCount: 3,
+		},
+		{Uuid: "uuid-2", Name: "fcr-2"},
+	}
+	d := schema.TestResourceDataRaw(t, readFabricCloudRoutersSearchSchema(), map[string]interface{}{})
+	// when
+	err := d.Set("data", fabricCloudRoutersListToTerra(cloudRouters))
+	// then
+	require.NoError(t, err)
+	assert.Equal(t, 2, d.Get("data.#"))
+	assert.Equal(t, "uuid-1", d.Get("data.0.uuid"))
+	assert.Equal(t, "PROVISIONED", d.Get("data.0.state"))
+	assert.Equal(t, "STANDARD", d.Get("data.0.package").(*schema.Set).List()[0].(map[string]interface{})["code"])
+	assert.Equal(t, 30000, d.Get("data.0.equinix_asn"))
+	assert.Equal(t, 3, d.Get("data.0.connections_count"))
+	assert.Equal(t, "fcr-2", d.Get("data.1.name"))
+	assert.Equal(t, 0, d.Get("data.1.package").(*schema.Set).Len(), "No package is set if the router has none")
+}
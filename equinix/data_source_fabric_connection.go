@@ -8,6 +8,9 @@ import (
 
 func readFabricConnectionResourceSchema() map[string]*schema.Schema {
 	sch := fabricConnectionResourceSchema()
+	for _, key := range fabricConnectionLocalAttributes {
+		delete(sch, key)
+	}
 	for key, _ := range sch {
 		if key == "uuid" {
 			sch[key].Required = true
@@ -19,6 +22,11 @@ func readFabricConnectionResourceSchema() map[string]*schema.Schema {
 			sch[key].Computed = true
 			sch[key].MaxItems = 0
 			sch[key].ValidateFunc = nil
+			sch[key].ForceNew = false
+			sch[key].AtLeastOneOf = nil
+			sch[key].Default = nil
+			sch[key].DiffSuppressFunc = nil
+			sch[key].StateFunc = nil
 		}
 	}
 	return sch
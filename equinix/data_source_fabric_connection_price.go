@@ -0,0 +1,232 @@
+package equinix
+
+import (
+	"context"
+	"crypto/sha256"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	v4 "github.com/equinix-labs/fabric-go/fabric/v4"
+	"github.com/equinix/terraform-provider-equinix/internal/config"
+	equinix_errors "github.com/equinix/terraform-provider-equinix/internal/errors"
+	equinix_schema "github.com/equinix/terraform-provider-equinix/internal/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+const (
+	fabricPriceMonthlyRecurringCharge = "MONTHLY_RECURRING"
+	fabricPriceNonRecurringCharge     = "NON_RECURRING"
+)
+
+// fabricConnectionPriceSearchFields maps the arguments of the connection price
+// data source to the price search properties they are matched against.
+var fabricConnectionPriceSearchFields = map[string]string{
+	"type":                        "/connection/type",
+	"bandwidth":                   "/connection/bandwidth",
+	"a_side_access_point_type":    "/connection/aSide/accessPoint/type",
+	"a_side_metro_code":           "/connection/aSide/accessPoint/location/metroCode",
+	"z_side_access_point_type":    "/connection/zSide/accessPoint/type",
+	"z_side_metro_code":           "/connection/zSide/accessPoint/location/metroCode",
+	"z_side_service_profile_uuid": "/connection/zSide/accessPoint/profile/uuid",
+}
+
+func dataSourceFabricConnectionPrice() *schema.Resource {
+	accessPointTypes := []string{"COLO", "VD", "SP", "CLOUD_ROUTER", "NETWORK"}
+	return &schema.Resource{
+		ReadContext: dataSourceFabricConnectionPriceRead,
+		Description: "Fabric V4 API compatible data resource that allow user to fetch the estimated price of a prospective Fabric connection",
+		Schema: map[string]*schema.Schema{
+			"type": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validation.StringInSlice([]string{"EVPL_VC", "EPL_VC", "EC_VC", "IP_VC", "VD_CHAIN_VC", "ACCESS_EPL_VC", "EVPLAN_VC", "EPLAN_VC", "IPWAN_VC"}, false),
+				Description:  "Type of the connection, e.g. EVPL_VC",
+			},
+			"bandwidth": {
+				Type:         schema.TypeInt,
+				Required:     true,
+				ValidateFunc: validation.IntAtLeast(1),
+				Description:  "Bandwidth of the connection in Mbps",
+			},
+			"a_side_access_point_type": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "COLO",
+				ValidateFunc: validation.StringInSlice(accessPointTypes, false),
+				Description:  "Type of the A side access point of the connection. Defaults to COLO",
+			},
+			"a_side_metro_code": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validation.StringIsNotEmpty,
+				Description:  "Metro code of the A side access point of the connection",
+			},
+			"z_side_access_point_type": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "COLO",
+				ValidateFunc: validation.StringInSlice(accessPointTypes, false),
+				Description:  "Type of the Z side access point of the connection. Defaults to COLO",
+			},
+			"z_side_metro_code": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.StringIsNotEmpty,
+				Description:  "Metro code of the Z side access point of the connection",
+			},
+			"z_side_service_profile_uuid": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.StringIsNotEmpty,
+				Description:  "Service profile UUID of the Z side access point of the connection, for SP access points",
+			},
+			"code": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Product code of the price",
+			},
+			"name": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Product name of the price",
+			},
+			"currency": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Currency of the charges, e.g. USD",
+			},
+			"term_length": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "Term length of the price in months",
+			},
+			"mrc": {
+				Type:        schema.TypeFloat,
+				Computed:    true,
+				Description: "Estimated monthly recurring charge of the connection",
+			},
+			"nrc": {
+				Type:        schema.TypeFloat,
+				Computed:    true,
+				Description: "Estimated non recurring charge of the connection",
+			},
+			"charges": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "Charges of the price",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"type": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Type of the charge, e.g. MONTHLY_RECURRING or NON_RECURRING",
+						},
+						"price": {
+							Type:        schema.TypeFloat,
+							Computed:    true,
+							Description: "Amount of the charge",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceFabricConnectionPriceRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*config.Config).FabricClient
+	ctx = context.WithValue(ctx, v4.ContextAccessToken, meta.(*config.Config).FabricAuthToken)
+
+	filters := map[string]string{}
+	for key := range fabricConnectionPriceSearchFields {
+		if v, ok := d.GetOk(key); ok {
+			switch v := v.(type) {
+			case int:
+				filters[key] = strconv.Itoa(v)
+			default:
+				filters[key] = v.(string)
+			}
+		}
+	}
+	search := v4.FilterBody{Filter: fabricConnectionPriceSearchExpression(filters)}
+	prices, _, err := client.PricesApi.SearchPrices(ctx, search)
+	if err != nil {
+		return diag.FromErr(equinix_errors.FormatFabricError(err))
+	}
+	if len(prices.Data) == 0 {
+		return diag.Errorf("no price found for a %s connection of %d Mbps from metro %s", filters["type"], d.Get("bandwidth").(int), filters["a_side_metro_code"])
+	}
+
+	d.SetId(fabricConnectionPriceSearchId(filters))
+	err = equinix_schema.SetMap(d, fabricConnectionPriceToTerra(prices.Data[0]))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	return nil
+}
+
+// fabricConnectionPriceSearchExpression combines the given filters, keyed by
+// the data source argument names, into a single virtual connection price search
+// expression matching all of them.
+func fabricConnectionPriceSearchExpression(filters map[string]string) *v4.SearchExpression {
+	keys := make([]string, 0, len(filters))
+	for key := range filters {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	and := []v4.SearchExpression{{
+		Property: "/type",
+		Operator: "=",
+		Values:   []string{string(v4.VIRTUAL_CONNECTION_PRODUCT_ProductType)},
+	}}
+	for _, key := range keys {
+		and = append(and, v4.SearchExpression{
+			Property: fabricConnectionPriceSearchFields[key],
+			Operator: "=",
+			Values:   []string{filters[key]},
+		})
+	}
+	return &v4.SearchExpression{And: &and}
+}
+
+func fabricConnectionPriceToTerra(price v4.Price) map[string]interface{} {
+	mrc, nrc := 0.0, 0.0
+	charges := make([]interface{}, 0, len(price.Charges))
+	for _, charge := range price.Charges {
+		switch charge.Type_ {
+		case fabricPriceMonthlyRecurringCharge:
+			mrc += charge.Price
+		case fabricPriceNonRecurringCharge:
+			nrc += charge.Price
+		}
+		charges = append(charges, map[string]interface{}{
+			"type":  charge.Type_,
+			"price": charge.Price,
+		})
+	}
+	return map[string]interface{}{
+		"code":        price.Code,
+		"name":        price.Name,
+		"currency":    price.Currency,
+		"term_length": int(price.TermLength),
+		"mrc":         mrc,
+		"nrc":         nrc,
+		"charges":     charges,
+	}
+}
+
+// fabricConnectionPriceSearchId derives a stable data source ID from the
+// connection arguments.
+func fabricConnectionPriceSearchId(filters map[string]string) string {
+	parts := []string{}
+	for key, value := range filters {
+		parts = append(parts, key+"="+value)
+	}
+	sort.Strings(parts)
+	return fmt.Sprintf("%x", sha256.Sum256([]byte(strings.Join(parts, ","))))
+}
@@ -0,0 +1,51 @@
+package equinix
+
+import (
+	"testing"
+
+	v4 "github.com/equinix-labs/fabric-go/fabric/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFabricConnectionPrice_searchExpression(t *testing.T) {
+	// given
+	filters := map[string]string{
+		"type":              "EVPL_VC",
+		"bandwidth":         "1000",
+		"a_side_metro_code": "SV",
+	}
+	// when
+	expr := fabricConnectionPriceSearchExpression(filters)
+	// then
+	require.NotNil(t, expr.And)
+	and := *expr.And
+	require.Len(t, and, 4, "Each filter is an AND condition, after the product type")
+	assert.Equal(t, "/type", and[0].Property)
+	assert.Equal(t, []string{"VIRTUAL_CONNECTION_PRODUCT"}, and[0].Values)
+	assert.Equal(t, "/connection/aSide/accessPoint/location/metroCode", and[1].Property, "Conditions are sorted by argument name")
+	assert.Equal(t, "/connection/bandwidth", and[2].Property)
+	assert.Equal(t, []string{"1000"}, and[2].Values)
+	assert.Equal(t, "/connection/type", and[3].Property)
+}
+
+func TestFabricConnectionPrice_toTerra(t *testing.T) {
+	// given
+	price := v4.Price{
+		Code:       "VC-1",
+		Currency:   "USD",
+		TermLength: 12,
+		Charges: []v4.PriceCharge{
+			{Type_: "MONTHLY_RECURRING", Price: 300},
+			{Type_: "MONTHLY_RECURRING", Price: 25.5},
+			{Type_: "NON_RECURRING", Price: 100},
+		},
+	}
+	// when
+	mapped := fabricConnectionPriceToTerra(price)
+	// then
+	assert.Equal(t, 325.5, mapped["mrc"], "Monthly recurring charges are summed")
+	assert.Equal(t, 100.0, mapped["nrc"])
+	assert.Equal(t, 12, mapped["term_length"])
+	assert.Len(t, mapped["charges"], 3)
+}
@@ -0,0 +1,184 @@
+package equinix
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	v4 "github.com/equinix-labs/fabric-go/fabric/v4"
+	"github.com/equinix/terraform-provider-equinix/internal/config"
+	equinix_errors "github.com/equinix/terraform-provider-equinix/internal/errors"
+	equinix_schema "github.com/equinix/terraform-provider-equinix/internal/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func dataSourceFabricConnectionStatistics() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceFabricConnectionStatisticsRead,
+		Schema:      readFabricConnectionStatisticsSchema(),
+		Description: "Fabric V4 API compatible data resource that allow user to fetch the bandwidth utilization statistics of a given connection",
+	}
+}
+
+func readFabricConnectionStatisticsSchema() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"connection_uuid": {
+			Type:        schema.TypeString,
+			Required:    true,
+			Description: "Connection UUID to fetch the statistics of",
+		},
+		"start_date_time": {
+			Type:         schema.TypeString,
+			Required:     true,
+			ValidateFunc: validation.IsRFC3339Time,
+			Description:  "Start of the statistics time window in RFC3339 format, e.g. 2024-01-01T00:00:00Z",
+		},
+		"end_date_time": {
+			Type:         schema.TypeString,
+			Required:     true,
+			ValidateFunc: validation.IsRFC3339Time,
+			Description:  "End of the statistics time window in RFC3339 format, e.g. 2024-01-08T00:00:00Z",
+		},
+		"view_point": {
+			Type:         schema.TypeString,
+			Optional:     true,
+			Default:      string(v4.A_SIDE_ViewPoint),
+			ValidateFunc: validation.StringInSlice([]string{string(v4.A_SIDE_ViewPoint), string(v4.Z_SIDE_ViewPoint)}, false),
+			Description:  "Point of view of the statistics - aSide or zSide. Defaults to aSide",
+		},
+		"bandwidth_utilization": {
+			Type:        schema.TypeList,
+			Computed:    true,
+			Description: "Bandwidth utilization of the connection within the time window",
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					"unit": {
+						Type:        schema.TypeString,
+						Computed:    true,
+						Description: "Unit of the bandwidth values, e.g. Mbps",
+					},
+					"metric_interval": {
+						Type:        schema.TypeString,
+						Computed:    true,
+						Description: "Time interval represented by each of the metrics, e.g. PT5M",
+					},
+					"inbound": {
+						Type:        schema.TypeList,
+						Computed:    true,
+						Description: "Inbound traffic statistics",
+						Elem:        &schema.Resource{Schema: readFabricConnectionStatisticsDirectionSchema()},
+					},
+					"outbound": {
+						Type:        schema.TypeList,
+						Computed:    true,
+						Description: "Outbound traffic statistics",
+						Elem:        &schema.Resource{Schema: readFabricConnectionStatisticsDirectionSchema()},
+					},
+				},
+			},
+		},
+	}
+}
+
+func readFabricConnectionStatisticsDirectionSchema() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"max": {
+			Type:        schema.TypeFloat,
+			Computed:    true,
+			Description: "Maximum bandwidth within the time window",
+		},
+		"mean": {
+			Type:        schema.TypeFloat,
+			Computed:    true,
+			Description: "Mean bandwidth within the time window",
+		},
+		"metrics": {
+			Type:        schema.TypeList,
+			Computed:    true,
+			Description: "Bandwidth statistics of each metric interval of the time window",
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					"interval_end_date_time": {
+						Type:        schema.TypeString,
+						Computed:    true,
+						Description: "End of the metric interval",
+					},
+					"max": {
+						Type:        schema.TypeFloat,
+						Computed:    true,
+						Description: "Maximum bandwidth within the metric interval",
+					},
+					"mean": {
+						Type:        schema.TypeFloat,
+						Computed:    true,
+						Description: "Mean bandwidth within the metric interval",
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceFabricConnectionStatisticsRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*config.Config).FabricClient
+	ctx = context.WithValue(ctx, v4.ContextAccessToken, meta.(*config.Config).FabricAuthToken)
+	connUuid := d.Get("connection_uuid").(string)
+
+	start, _ := time.Parse(time.RFC3339, d.Get("start_date_time").(string))
+	end, _ := time.Parse(time.RFC3339, d.Get("end_date_time").(string))
+	if !end.After(start) {
+		return diag.Errorf("end_date_time must be after start_date_time")
+	}
+	viewPoint := v4.ViewPoint(d.Get("view_point").(string))
+
+	stats, _, err := client.StatisticsApi.GetConnectionStatsByPortUuid(ctx, connUuid, start, end, viewPoint)
+	if err != nil {
+		return diag.FromErr(equinix_errors.FormatFabricError(err))
+	}
+
+	d.SetId(fmt.Sprintf("%s:%s:%d:%d", connUuid, viewPoint, start.Unix(), end.Unix()))
+	err = equinix_schema.SetMap(d, map[string]interface{}{
+		"bandwidth_utilization": fabricBandwidthUtilizationToTerra(stats.BandwidthUtilization),
+	})
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	return nil
+}
+
+func fabricBandwidthUtilizationToTerra(utilization *v4.BandwidthUtilization) []interface{} {
+	if utilization == nil {
+		return nil
+	}
+	return []interface{}{
+		map[string]interface{}{
+			"unit":            utilization.Unit,
+			"metric_interval": utilization.MetricInterval,
+			"inbound":         fabricStatisticsDirectionToTerra(utilization.Inbound),
+			"outbound":        fabricStatisticsDirectionToTerra(utilization.Outbound),
+		},
+	}
+}
+
+func fabricStatisticsDirectionToTerra(direction *v4.Direction) []interface{} {
+	if direction == nil {
+		return nil
+	}
+	metrics := make([]interface{}, 0, len(direction.Metrics))
+	for _, m := range direction.Metrics {
+		metrics = append(metrics, map[string]interface{}{
+			"interval_end_date_time": m.IntervalEndTimestamp.Format(time.RFC3339),
+			"max":                    float64(m.Max),
+			"mean":                   float64(m.Mean),
+		})
+	}
+	return []interface{}{
+		map[string]interface{}{
+			"max":     float64(direction.Max),
+			"mean":    float64(direction.Mean),
+			"metrics": metrics,
+		},
+	}
+}
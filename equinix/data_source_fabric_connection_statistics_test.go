@@ -0,0 +1,39 @@
+package equinix
+
+import (
+	"testing"
+	"time"
+
+	v4 "github.com/equinix-labs/fabric-go/fabric/v4"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFabricConnectionStatistics_bandwidthUtilizationToTerra(t *testing.T) {
+	// given
+	utilization := &v4.BandwidthUtilization{
+		Unit:           "Mbps",
+		MetricInterval: "PT5M",
+		Inbound: &v4.Direction{
+			Max:  120.5,
+			Mean: 60.25,
+			Metrics: []v4.Metrics{
+				{IntervalEndTimestamp: time.Date(2024, 1, 1, 0, 5, 0, 0, time.UTC), Max: 120.5, Mean: 60.25},
+			},
+		},
+	}
+	// when
+	result := fabricBandwidthUtilizationToTerra(utilization)
+	// then
+	assert.Len(t, result, 1)
+	mapped := result[0].(map[string]interface{})
+	assert.Equal(t, "Mbps", mapped["unit"], "Unit matches")
+	assert.Equal(t, "PT5M", mapped["metric_interval"], "Metric interval matches")
+	assert.Nil(t, mapped["outbound"], "Missing direction is not mapped")
+	inbound := mapped["inbound"].([]interface{})[0].(map[string]interface{})
+	assert.Equal(t, 120.5, inbound["max"], "Inbound max matches")
+	assert.Equal(t, 60.25, inbound["mean"], "Inbound mean matches")
+	metric := inbound["metrics"].([]interface{})[0].(map[string]interface{})
+	assert.Equal(t, "2024-01-01T00:05:00Z", metric["interval_end_date_time"], "Metric interval end matches")
+	assert.Equal(t, 120.5, metric["max"], "Metric max matches")
+	assert.Nil(t, fabricBandwidthUtilizationToTerra(nil), "Missing utilization is not mapped")
+}
@@ -0,0 +1,232 @@
+package equinix
+
+import (
+	"context"
+	"crypto/sha256"
+	"fmt"
+	"sort"
+	"strings"
+
+	v4 "github.com/equinix-labs/fabric-go/fabric/v4"
+	"github.com/equinix/terraform-provider-equinix/internal/config"
+	equinix_errors "github.com/equinix/terraform-provider-equinix/internal/errors"
+	equinix_schema "github.com/equinix/terraform-provider-equinix/internal/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+const fabricConnectionsPageSize = 100
+
+// fabricConnectionsSearchFields maps the filter arguments of the connections
+// data source to the search API properties they are matched against.
+var fabricConnectionsSearchFields = map[string]v4.SearchFieldName{
+	"name":              v4.NAME_SearchFieldName,
+	"type":              v4.TYPE__SearchFieldName,
+	"redundancy_group":  v4.REDUNDANCYGROUP_SearchFieldName,
+	"a_side_metro_code": v4.A_SIDEACCESS_POINTLOCATIONMETRO_CODE_SearchFieldName,
+	"z_side_metro_code": v4.Z_SIDEACCESS_POINTLOCATIONMETRO_CODE_SearchFieldName,
+}
+
+func dataSourceFabricConnections() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceFabricConnectionsRead,
+		Schema:      readFabricConnectionsSearchSchema(),
+		Description: "Fabric V4 API compatible data resource that allow user to search connections by name, type, state, redundancy group and metro",
+	}
+}
+
+func readFabricConnectionsSearchSchema() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"name": {
+			Type:         schema.TypeString,
+			Optional:     true,
+			ValidateFunc: validation.StringIsNotEmpty,
+			Description:  "Name of the connections to fetch",
+		},
+		"type": {
+			Type:         schema.TypeString,
+			Optional:     true,
+			ValidateFunc: validation.StringInSlice([]string{"EVPL_VC", "EPL_VC", "IP_VC", "IPWAN_VC", "ACCESS_EPL_VC", "EVPLAN_VC", "EPLAN_VC", "EIA_VC", "EC_VC"}, false),
+			Description:  "Type of the connections to fetch, e.g. EVPL_VC",
+		},
+		"state": {
+			Type:         schema.TypeString,
+			Optional:     true,
+			ValidateFunc: validation.StringInSlice([]string{"ACTIVE", "CANCELLED", "DEPROVISIONED", "DEPROVISIONING", "DRAFT", "FAILED", "PENDING", "PROVISIONED", "PROVISIONING", "REPROVISIONING"}, false),
+			Description:  "State of the connections to fetch, e.g. ACTIVE. The search API can't filter on state, so the connections are filtered after they are fetched",
+		},
+		"redundancy_group": {
+			Type:         schema.TypeString,
+			Optional:     true,
+			ValidateFunc: validation.StringIsNotEmpty,
+			Description:  "Redundancy group UUID of the connections to fetch",
+		},
+		"a_side_metro_code": {
+			Type:         schema.TypeString,
+			Optional:     true,
+			ValidateFunc: validation.StringIsNotEmpty,
+			Description:  "Metro code of the A side access point of the connections to fetch",
+		},
+		"z_side_metro_code": {
+			Type:         schema.TypeString,
+			Optional:     true,
+			ValidateFunc: validation.StringIsNotEmpty,
+			Description:  "Metro code of the Z side access point of the connections to fetch",
+		},
+		"pagination": {
+			Type:        schema.TypeSet,
+			Optional:    true,
+			MaxItems:    1,
+			Description: "Page of the search results to fetch. All the matching connections are fetched if not set",
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					"offset": {
+						Type:         schema.TypeInt,
+						Optional:     true,
+						Default:      0,
+						ValidateFunc: validation.IntAtLeast(0),
+						Description:  "Index of the first connection of the page",
+					},
+					"limit": {
+						Type:         schema.TypeInt,
+						Optional:     true,
+						Default:      20,
+						ValidateFunc: validation.IntBetween(1, fabricConnectionsPageSize),
+						Description:  "Maximum number of connections of the page",
+					},
+				},
+			},
+		},
+		"data": {
+			Type:        schema.TypeList,
+			Computed:    true,
+			Description: "List of the connections matching the search filters",
+			Elem: &schema.Resource{
+				Schema: readFabricConnectionsDataSchema(),
+			},
+		},
+	}
+}
+
+// readFabricConnectionsDataSchema returns the connection data source schema with the
+// uuid computed rather than required. The path and the routing protocols are left
+// out as they take additional API calls per connection.
+func readFabricConnectionsDataSchema() map[string]*schema.Schema {
+	sch := readFabricConnectionResourceSchema()
+	delete(sch, "path")
+	delete(sch, "routing_protocols")
+	sch["uuid"].Required = false
+	sch["uuid"].Computed = true
+	return sch
+}
+
+func dataSourceFabricConnectionsRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*config.Config).FabricClient
+	ctx = context.WithValue(ctx, v4.ContextAccessToken, meta.(*config.Config).FabricAuthToken)
+
+	filters := map[string]string{}
+	for key := range fabricConnectionsSearchFields {
+		if v, ok := d.GetOk(key); ok {
+			filters[key] = v.(string)
+		}
+	}
+	sortDirection, sortBy := v4.ASC_SortDirection, v4.NAME_SortBy
+	search := v4.SearchRequest{
+		Filter: fabricConnectionsSearchExpression(filters),
+		Sort:   []v4.SortCriteria{{Direction: &sortDirection, Property: &sortBy}},
+	}
+
+	offset, limit := 0, 0
+	if p, ok := d.GetOk("pagination"); ok {
+		page := p.(*schema.Set).List()[0].(map[string]interface{})
+		offset, limit = page["offset"].(int), page["limit"].(int)
+	}
+
+	var connections []v4.Connection
+	for {
+		pageSize := fabricConnectionsPageSize
+		if limit > 0 {
+			pageSize = limit
+		}
+		search.Pagination = &v4.PaginationRequest{
+			Offset: int32(offset + len(connections)),
+			Limit:  int32(pageSize),
+		}
+		resp, _, err := client.ConnectionsApi.SearchConnections(ctx, search)
+		if err != nil {
+			return diag.FromErr(equinix_errors.FormatFabricError(err))
+		}
+		connections = append(connections, resp.Data...)
+		if limit > 0 || len(resp.Data) == 0 || resp.Pagination == nil || offset+len(connections) >= int(resp.Pagination.Total) {
+			break
+		}
+	}
+	if state, ok := d.GetOk("state"); ok {
+		connections = filterFabricConnectionsByState(connections, v4.ConnectionState(state.(string)))
+	}
+
+	d.SetId(fabricConnectionsSearchId(filters, d.Get("state").(string), offset, limit))
+	err := equinix_schema.SetMap(d, map[string]interface{}{
+		"data": fabricConnectionsListToTerra(connections),
+	})
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	return nil
+}
+
+// fabricConnectionsSearchExpression combines the given filters, keyed by the
+// data source argument names, into a single search expression matching all of
+// them. It returns nil if there are no filters.
+func fabricConnectionsSearchExpression(filters map[string]string) *v4.Expression {
+	keys := make([]string, 0, len(filters))
+	for key := range filters {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	and := make([]v4.Expression, 0, len(keys))
+	for _, key := range keys {
+		property := fabricConnectionsSearchFields[key]
+		and = append(and, v4.Expression{
+			Property: &property,
+			Operator: "=",
+			Values:   []string{filters[key]},
+		})
+	}
+	if len(and) == 0 {
+		return nil
+	}
+	return &v4.Expression{And: &and}
+}
+
+func filterFabricConnectionsByState(connections []v4.Connection, state v4.ConnectionState) []v4.Connection {
+	filtered := make([]v4.Connection, 0, len(connections))
+	for _, conn := range connections {
+		if conn.State != nil && *conn.State == state {
+			filtered = append(filtered, conn)
+		}
+	}
+	return filtered
+}
+
+func fabricConnectionsListToTerra(connections []v4.Connection) []map[string]interface{} {
+	mappedConnections := make([]map[string]interface{}, 0, len(connections))
+	for _, conn := range connections {
+		mappedConnections = append(mappedConnections, fabricConnectionToTerra(conn, ""))
+	}
+	return mappedConnections
+}
+
+// fabricConnectionsSearchId derives a stable data source ID from the search
+// arguments.
+func fabricConnectionsSearchId(filters map[string]string, state string, offset, limit int) string {
+	parts := []string{}
+	for key, value := range filters {
+		parts = append(parts, key+"="+value)
+	}
+	sort.Strings(parts)
+	parts = append(parts, "state="+state, fmt.Sprintf("offset=%d", offset), fmt.Sprintf("limit=%d", limit))
+	return fmt.Sprintf("%x", sha256.Sum256([]byte(strings.Join(parts, ","))))
+}
@@ -0,0 +1,67 @@
+package equinix
+
+import (
+	"testing"
+
+	v4 "github.com/equinix-labs/fabric-go/fabric/v4"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFabricConnections_searchExpression(t *testing.T) {
+	// given
+	filters := map[string]string{
+		"z_side_metro_code": "DC",
+		"name":              "conn-1",
+		"type":              "EVPL_VC",
+	}
+	// when
+	expr := fabricConnectionsSearchExpression(filters)
+	// then
+	require.NotNil(t, expr)
+	require.NotNil(t, expr.And)
+	and := *expr.And
+	require.Len(t, and, 3, "Each filter is an AND condition")
+	assert.Equal(t, v4.NAME_SearchFieldName, *and[0].Property, "Conditions are sorted by argument name")
+	assert.Equal(t, []string{"conn-1"}, and[0].Values)
+	assert.Equal(t, v4.TYPE__SearchFieldName, *and[1].Property)
+	assert.Equal(t, v4.Z_SIDEACCESS_POINTLOCATIONMETRO_CODE_SearchFieldName, *and[2].Property)
+	assert.Equal(t, "=", and[2].Operator)
+	assert.Nil(t, fabricConnectionsSearchExpression(map[string]string{}), "No filter without arguments")
+}
+
+func TestFabricConnections_filterByState(t *testing.T) {
+	// given
+	active, pending := v4.ACTIVE_ConnectionState, v4.PENDING_ConnectionState
+	connections := []v4.Connection{
+		{Uuid: "active-uuid", State: &active},
+		{Uuid: "pending-uuid", State: &pending},
+		{Uuid: "unknown-uuid"},
+	}
+	// when
+	filtered := filterFabricConnectionsByState(connections, v4.ACTIVE_ConnectionState)
+	// then
+	require.Len(t, filtered, 1)
+	assert.Equal(t, "active-uuid", filtered[0].Uuid)
+}
+
+func TestFabricConnections_listToTerra(t *testing.T) {
+	// given
+	active := v4.ACTIVE_ConnectionState
+	connections := []v4.Connection{
+		{Uuid: "uuid-1", Name: "conn-1", Bandwidth: 1000, State: &active},
+		{Uuid: "uuid-2", Name: "conn-2", Bandwidth: 50},
+	}
+	d := schema.TestResourceDataRaw(t, readFabricConnectionsSearchSchema(), map[string]interface{}{})
+	// when
+	err := d.Set("data", fabricConnectionsListToTerra(connections))
+	// then
+	require.NoError(t, err)
+	assert.Equal(t, 2, d.Get("data.#"))
+	assert.Equal(t, "uuid-1", d.Get("data.0.uuid"))
+	assert.Equal(t, "conn-1", d.Get("data.0.name"))
+	assert.Equal(t, "ACTIVE", d.Get("data.0.state"))
+	assert.Equal(t, 1000, d.Get("data.0.bandwidth"), "Bandwidth is reported in Mbps")
+	assert.Equal(t, "conn-2", d.Get("data.1.name"))
+}
@@ -0,0 +1,182 @@
+package equinix
+
+import (
+	"context"
+
+	v4 "github.com/equinix-labs/fabric-go/fabric/v4"
+	"github.com/equinix/terraform-provider-equinix/internal/config"
+	equinix_errors "github.com/equinix/terraform-provider-equinix/internal/errors"
+	equinix_schema "github.com/equinix/terraform-provider-equinix/internal/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+const fabricMetrosPageSize = 100
+
+func fabricConnectedMetroSch() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"code": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "Code of the connected metro",
+		},
+		"avg_latency": {
+			Type:        schema.TypeFloat,
+			Computed:    true,
+			Description: "Average latency in milliseconds between the two metros",
+		},
+		"remote_vc_bandwidth_max": {
+			Type:        schema.TypeInt,
+			Computed:    true,
+			Description: "Maximum bandwidth in Mbps of a remote connection between the two metros",
+		},
+	}
+}
+
+func fabricMetroSch() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"href": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "Fabric Metro URI information",
+		},
+		"type": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "Indicator of a Fabric Metro",
+		},
+		"code": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "Fabric Metro code",
+		},
+		"name": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "Fabric Metro name",
+		},
+		"region": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "Geographic region of the Fabric Metro, e.g. AMER",
+		},
+		"equinix_asn": {
+			Type:        schema.TypeInt,
+			Computed:    true,
+			Description: "Equinix ASN of the Fabric Metro",
+		},
+		"local_vc_bandwidth_max": {
+			Type:        schema.TypeInt,
+			Computed:    true,
+			Description: "Maximum bandwidth in Mbps of a local connection within the Fabric Metro",
+		},
+		"geo_scopes": {
+			Type:        schema.TypeList,
+			Computed:    true,
+			Description: "Geographic boundaries supported by the Fabric Metro",
+			Elem:        &schema.Schema{Type: schema.TypeString},
+		},
+		"remote_connections_supported": {
+			Type:        schema.TypeBool,
+			Computed:    true,
+			Description: "Whether remote connections to other metros can be created from the Fabric Metro, i.e. it has connected metros",
+		},
+		"connected_metros": {
+			Type:        schema.TypeList,
+			Computed:    true,
+			Description: "Metros that remote connections from the Fabric Metro can reach",
+			Elem: &schema.Resource{
+				Schema: fabricConnectedMetroSch(),
+			},
+		},
+	}
+}
+
+func dataSourceFabricMetros() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceFabricMetrosRead,
+		Description: "Fabric V4 API compatible data resource that allow user to list Fabric Metros, optionally only the one of a given code",
+		Schema: map[string]*schema.Schema{
+			"metro_code": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.StringIsNotEmpty,
+				Description:  "Code of the Fabric Metro to fetch. All the Fabric Metros are fetched if not set",
+			},
+			"data": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "List of the Fabric Metros",
+				Elem: &schema.Resource{
+					Schema: fabricMetroSch(),
+				},
+			},
+		},
+	}
+}
+
+func dataSourceFabricMetrosRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	ctx = context.WithValue(ctx, v4.ContextAccessToken, meta.(*config.Config).FabricAuthToken)
+
+	var metros []v4.Metro
+	if metroCode, ok := d.GetOk("metro_code"); ok {
+		metro, err := cachedFabricMetroByCode(ctx, meta.(*config.Config), metroCode.(string))
+		if err != nil {
+			return diag.FromErr(equinix_errors.FormatFabricError(err))
+		}
+		metros = append(metros, metro)
+		d.SetId(metroCode.(string))
+	} else {
+		for {
+			resp, err := cachedFabricMetros(ctx, meta.(*config.Config), int32(len(metros)), fabricMetrosPageSize)
+			if err != nil {
+				return diag.FromErr(equinix_errors.FormatFabricError(err))
+			}
+			metros = append(metros, resp.Data...)
+			if len(resp.Data) == 0 || resp.Pagination == nil || len(metros) >= int(resp.Pagination.Total) {
+				break
+			}
+		}
+		d.SetId("fabricMetros")
+	}
+
+	err := equinix_schema.SetMap(d, map[string]interface{}{
+		"data": fabricMetrosToTerra(metros),
+	})
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	return nil
+}
+
+func fabricMetrosToTerra(metros []v4.Metro) []interface{} {
+	mappedMetros := make([]interface{}, 0, len(metros))
+	for _, metro := range metros {
+		geoScopes := make([]interface{}, 0, len(metro.GeoScopes))
+		for _, geoScope := range metro.GeoScopes {
+			geoScopes = append(geoScopes, string(geoScope))
+		}
+		connectedMetros := make([]interface{}, 0, len(metro.ConnectedMetros))
+		for _, connectedMetro := range metro.ConnectedMetros {
+			connectedMetros = append(connectedMetros, map[string]interface{}{
+				"code":                    connectedMetro.Code,
+				"avg_latency":             connectedMetro.AvgLatency,
+				"remote_vc_bandwidth_max": int(connectedMetro.RemoteVCBandwidthMax),
+			})
+		}
+		mappedMetros = append(mappedMetros, map[string]interface{}{
+			"href":                         metro.Href,
+			"type":                         metro.Type_,
+			"code":                         metro.Code,
+			"name":                         metro.Name,
+			"region":                       metro.Region,
+			"equinix_asn":                  int(metro.EquinixAsn),
+			"local_vc_bandwidth_max":       int(metro.LocalVCBandwidthMax),
+			"geo_scopes":                   geoScopes,
+			"remote_connections_supported": len(metro.ConnectedMetros) > 0,
+			"connected_metros":             connectedMetros,
+		})
+	}
+	return mappedMetros
+}
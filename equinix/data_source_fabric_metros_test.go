@@ -0,0 +1,44 @@
+package equinix
+
+import (
+	"testing"
+
+	v4 "github.com/equinix-labs/fabric-go/fabric/v4"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFabricMetros_toTerra(t *testing.T) {
+	// given
+	metros := []v4.Metro{
+		{
+			Code:                "SV",
+			Name:                "Silicon Valley",
+			Region:              "AMER",
+			EquinixAsn:          30000,
+			LocalVCBandwidthMax: 50000,
+			GeoScopes:           []v4.GeoScopeType{v4.CANADA_GeoScopeType},
+			ConnectedMetros: []v4.ConnectedMetro{
+				{Code: "DC", AvgLatency: 61.5, RemoteVCBandwidthMax: 10000},
+			},
+		},
+		{Code: "XX", Name: "Local only"},
+	}
+	d := schema.TestResourceDataRaw(t, dataSourceFabricMetros().Schema, map[string]interface{}{})
+	// when
+	err := d.Set("data", fabricMetrosToTerra(metros))
+	// then
+	require.NoError(t, err)
+	assert.Equal(t, 2, d.Get("data.#"))
+	assert.Equal(t, "SV", d.Get("data.0.code"))
+	assert.Equal(t, "AMER", d.Get("data.0.region"))
+	assert.Equal(t, 30000, d.Get("data.0.equinix_asn"))
+	assert.Equal(t, 50000, d.Get("data.0.local_vc_bandwidth_max"))
+	assert.Equal(t, "CANADA", d.Get("data.0.geo_scopes.0"))
+	assert.Equal(t, true, d.Get("data.0.remote_connections_supported"))
+	assert.Equal(t, "DC", d.Get("data.0.connected_metros.0.code"))
+	assert.Equal(t, 61.5, d.Get("data.0.connected_metros.0.avg_latency"))
+	assert.Equal(t, 10000, d.Get("data.0.connected_metros.0.remote_vc_bandwidth_max"))
+	assert.Equal(t, false, d.Get("data.1.remote_connections_supported"), "A metro without connected metros has no remote connectivity")
+}
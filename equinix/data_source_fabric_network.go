@@ -18,6 +18,7 @@ func readFabricNetworkResourceSchema() map[string]*schema.Schema {
 			sch[key].Required = false
 			sch[key].Optional = false
 			sch[key].Computed = true
+			sch[key].ForceNew = false
 			sch[key].MaxItems = 0
 			sch[key].ValidateFunc = nil
 		}
@@ -0,0 +1,210 @@
+package equinix
+
+import (
+	"context"
+	"crypto/sha256"
+	"fmt"
+	"sort"
+	"strings"
+
+	v4 "github.com/equinix-labs/fabric-go/fabric/v4"
+	"github.com/equinix/terraform-provider-equinix/internal/config"
+	equinix_errors "github.com/equinix/terraform-provider-equinix/internal/errors"
+	equinix_schema "github.com/equinix/terraform-provider-equinix/internal/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+const fabricNetworksPageSize = 100
+
+// fabricNetworksSearchFields maps the filter arguments of the networks data
+// source to the network search properties.
+var fabricNetworksSearchFields = map[string]v4.NetworkSearchFieldName{
+	"name":       v4.NAME_NetworkSearchFieldName,
+	"type":       v4.TYPE__NetworkSearchFieldName,
+	"scope":      v4.SCOPE_NetworkSearchFieldName,
+	"project_id": v4.PROJECTPROJECT_ID_NetworkSearchFieldName,
+}
+
+func dataSourceFabricNetworks() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceFabricNetworksRead,
+		Schema:      readFabricNetworksSearchSchema(),
+		Description: "Fabric V4 API compatible data resource that allow user to search Fabric Networks by name, type, scope and project",
+	}
+}
+
+func readFabricNetworksSearchSchema() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"name": {
+			Type:         schema.TypeString,
+			Optional:     true,
+			ValidateFunc: validation.StringIsNotEmpty,
+			Description:  "Name of the Fabric Networks to fetch",
+		},
+		"type": {
+			Type:         schema.TypeString,
+			Optional:     true,
+			ValidateFunc: validation.StringInSlice([]string{"IPWAN", "EPLAN", "EVPLAN"}, false),
+			Description:  "Type of the Fabric Networks to fetch - EVPLAN, EPLAN, IPWAN",
+		},
+		"scope": {
+			Type:         schema.TypeString,
+			Optional:     true,
+			ValidateFunc: validation.StringInSlice([]string{"LOCAL", "REGIONAL", "GLOBAL"}, false),
+			Description:  "Scope of the Fabric Networks to fetch - LOCAL, REGIONAL, GLOBAL",
+		},
+		"project_id": {
+			Type:         schema.TypeString,
+			Optional:     true,
+			ValidateFunc: validation.StringIsNotEmpty,
+			Description:  "Project identifier of the Fabric Networks to fetch",
+		},
+		"pagination": {
+			Type:        schema.TypeSet,
+			Optional:    true,
+			MaxItems:    1,
+			Description: "Page of the matching Fabric Networks to return. All the matching Fabric Networks are returned if not set",
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					"offset": {
+						Type:         schema.TypeInt,
+						Optional:     true,
+						Default:      0,
+						ValidateFunc: validation.IntAtLeast(0),
+						Description:  "Index of the first Fabric Network of the page",
+					},
+					"limit": {
+						Type:         schema.TypeInt,
+						Optional:     true,
+						Default:      20,
+						ValidateFunc: validation.IntBetween(1, fabricNetworksPageSize),
+						Description:  "Maximum number of Fabric Networks of the page",
+					},
+				},
+			},
+		},
+		"data": {
+			Type:        schema.TypeList,
+			Computed:    true,
+			Description: "List of the Fabric Networks matching the search filters",
+			Elem: &schema.Resource{
+				Schema: readFabricNetworksDataSchema(),
+			},
+		},
+	}
+}
+
+// readFabricNetworksDataSchema returns the network data source schema with the
+// uuid computed rather than required. The attached connections and the change
+// history are left out, as the search results don't include them.
+func readFabricNetworksDataSchema() map[string]*schema.Schema {
+	sch := readFabricNetworkResourceSchema()
+	sch["uuid"].Required = false
+	sch["uuid"].Computed = true
+	delete(sch, "connections")
+	delete(sch, "changes")
+	return sch
+}
+
+func dataSourceFabricNetworksRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*config.Config).FabricClient
+	ctx = context.WithValue(ctx, v4.ContextAccessToken, meta.(*config.Config).FabricAuthToken)
+
+	filters := map[string]string{}
+	for key := range fabricNetworksSearchFields {
+		if v, ok := d.GetOk(key); ok {
+			filters[key] = v.(string)
+		}
+	}
+	sortDirection, sortBy := v4.ASC_NetworkSortDirection, v4.NAME_NetworkSortBy
+	search := v4.NetworkSearchRequest{
+		Filter: networksSearchFilter(filters),
+		Sort:   []v4.NetworkSortCriteria{{Direction: &sortDirection, Property: &sortBy}},
+	}
+
+	offset, limit := 0, 0
+	var networks []v4.Network
+	if p, ok := d.GetOk("pagination"); ok {
+		page := p.(*schema.Set).List()[0].(map[string]interface{})
+		offset, limit = page["offset"].(int), page["limit"].(int)
+		search.Pagination = &v4.PaginationRequest{Offset: int32(offset), Limit: int32(limit)}
+		resp, _, err := client.NetworksApi.SearchNetworks(ctx, search)
+		if err != nil {
+			return diag.FromErr(equinix_errors.FormatFabricError(err))
+		}
+		networks = resp.Data
+	} else {
+		for {
+			search.Pagination = &v4.PaginationRequest{
+				Offset: int32(len(networks)),
+				Limit:  fabricNetworksPageSize,
+			}
+			resp, _, err := client.NetworksApi.SearchNetworks(ctx, search)
+			if err != nil {
+				return diag.FromErr(equinix_errors.FormatFabricError(err))
+			}
+			networks = append(networks, resp.Data...)
+			if len(resp.Data) == 0 || resp.Pagination == nil || len(networks) >= int(resp.Pagination.Total) {
+				break
+			}
+		}
+	}
+
+	d.SetId(fabricNetworksSearchId(filters, offset, limit))
+	err := equinix_schema.SetMap(d, map[string]interface{}{
+		"data": fabricNetworksListToTerra(networks),
+	})
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	return nil
+}
+
+// networksSearchFilter returns the search filter matching all the given
+// filters, keyed by the data source argument names, or nil without filters.
+func networksSearchFilter(filters map[string]string) *v4.NetworkFilter {
+	keys := make([]string, 0, len(filters))
+	for key := range filters {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	and := make([]v4.NetworkFilter, 0, len(keys))
+	for _, key := range keys {
+		property := fabricNetworksSearchFields[key]
+		and = append(and, v4.NetworkFilter{
+			Property: &property,
+			Operator: "=",
+			Values:   []string{filters[key]},
+		})
+	}
+	switch len(and) {
+	case 0:
+		return nil
+	case 1:
+		return &and[0]
+	default:
+		return &v4.NetworkFilter{And: &and}
+	}
+}
+
+func fabricNetworksListToTerra(networks []v4.Network) []map[string]interface{} {
+	mappedNetworks := make([]map[string]interface{}, 0, len(networks))
+	for _, nt := range networks {
+		mappedNetworks = append(mappedNetworks, fabricNetworkMap(nt))
+	}
+	return mappedNetworks
+}
+
+// fabricNetworksSearchId derives a stable data source ID from the search
+// arguments.
+func fabricNetworksSearchId(filters map[string]string, offset, limit int) string {
+	parts := []string{}
+	for key, value := range filters {
+		parts = append(parts, key+"="+value)
+	}
+	sort.Strings(parts)
+	parts = append(parts, fmt.Sprintf("offset=%d", offset), fmt.Sprintf("limit=%d", limit))
+	return fmt.Sprintf("%x", sha256.Sum256([]byte(strings.Join(parts, ","))))
+}
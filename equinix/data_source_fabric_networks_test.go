@@ -0,0 +1,78 @@
+package equinix
+
+import (
+	"context"
+	"testing"
+
+	v4 "github.com/equinix-labs/fabric-go/fabric/v4"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFabricNetworks_search(t *testing.T) {
+	// given
+	c := newFakeAPIConfig(t)
+	ctx := context.WithValue(context.Background(), v4.ContextAccessToken, c.FabricAuthToken)
+	for _, network := range []struct {
+		name, netType, scope string
+	}{
+		{"evplan-b", "EVPLAN", "REGIONAL"},
+		{"evplan-a", "EVPLAN", "REGIONAL"},
+		{"eplan", "EPLAN", "REGIONAL"},
+		{"evplan-global", "EVPLAN", "GLOBAL"},
+	} {
+		netType, netScope := v4.NetworkType(network.netType), v4.NetworkScope(network.scope)
+		_, _, err := c.FabricClient.NetworksApi.CreateNetwork(ctx, v4.NetworkPostRequest{
+			Name:    network.name,
+			Type_:   &netType,
+			Scope:   &netScope,
+			Project: &v4.Project{ProjectId: "project-1"},
+		})
+		require.NoError(t, err)
+	}
+	all := schema.TestResourceDataRaw(t, readFabricNetworksSearchSchema(), map[string]interface{}{
+		"type":  "EVPLAN",
+		"scope": "REGIONAL",
+	})
+	page := schema.TestResourceDataRaw(t, readFabricNetworksSearchSchema(), map[string]interface{}{
+		"project_id": "project-1",
+		"pagination": []interface{}{
+			map[string]interface{}{"offset": 1, "limit": 2},
+		},
+	})
+	// when
+	allDiags := dataSourceFabricNetworksRead(context.Background(), all, c)
+	pageDiags := dataSourceFabricNetworksRead(context.Background(), page, c)
+	// then
+	require.False(t, allDiags.HasError(), "Search does not fail: %v", allDiags)
+	require.False(t, pageDiags.HasError(), "Search does not fail: %v", pageDiags)
+	assert.Equal(t, 2, all.Get("data.#"), "Only networks matching every filter are returned")
+	assert.Equal(t, "evplan-a", all.Get("data.0.name"), "Networks are sorted by name")
+	assert.Equal(t, "EVPLAN", all.Get("data.0.type"))
+	assert.Equal(t, "REGIONAL", all.Get("data.0.scope"))
+	assert.Equal(t, "ACTIVE", all.Get("data.0.state"))
+	assert.NotEmpty(t, all.Get("data.0.uuid"))
+	assert.Equal(t, 2, page.Get("data.#"), "Page is limited")
+	assert.Equal(t, "evplan-a", page.Get("data.0.name"), "Page starts at the offset")
+	assert.NotEqual(t, all.Id(), page.Id(), "Searches are identified by their arguments")
+}
+
+func TestFabricNetworks_searchFilter(t *testing.T) {
+	// given
+	single := map[string]string{"name": "evplan"}
+	multiple := map[string]string{"type": "EVPLAN", "project_id": "project-1"}
+	// when
+	none := networksSearchFilter(map[string]string{})
+	singleFilter := networksSearchFilter(single)
+	multipleFilter := networksSearchFilter(multiple)
+	// then
+	assert.Nil(t, none, "No filter without arguments")
+	require.NotNil(t, singleFilter)
+	assert.Equal(t, v4.NAME_NetworkSearchFieldName, *singleFilter.Property)
+	assert.Equal(t, []string{"evplan"}, singleFilter.Values)
+	require.NotNil(t, multipleFilter.And, "Filters are combined")
+	require.Len(t, *multipleFilter.And, 2)
+	assert.Equal(t, v4.PROJECTPROJECT_ID_NetworkSearchFieldName, *(*multipleFilter.And)[0].Property, "Filters are sorted by argument")
+	assert.Equal(t, v4.TYPE__NetworkSearchFieldName, *(*multipleFilter.And)[1].Property)
+}
@@ -2,7 +2,10 @@ package equinix
 
 import (
 	"context"
+	"fmt"
 
+	v4 "github.com/equinix-labs/fabric-go/fabric/v4"
+	"github.com/equinix/terraform-provider-equinix/internal/config"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
@@ -10,13 +13,37 @@ import (
 func dataSourceFabricPort() *schema.Resource {
 	return &schema.Resource{
 		ReadContext: dataSourceFabricPortRead,
-		Schema:      FabricPortResourceSchema(),
-		Description: "Fabric V4 API compatible data resource that allow user to fetch port by uuid",
+		Schema:      readFabricPortDataSourceSchema(),
+		Description: "Fabric V4 API compatible data resource that allow user to fetch port by uuid or name",
 	}
 }
 
+func readFabricPortDataSourceSchema() map[string]*schema.Schema {
+	sch := FabricPortResourceSchema()
+	sch["uuid"].Required = false
+	sch["uuid"].Optional = true
+	sch["uuid"].Computed = true
+	sch["uuid"].ExactlyOneOf = []string{"uuid", "name"}
+	sch["name"].Optional = true
+	sch["name"].Description = "Port name, the port is looked up by its exact name if uuid is not set"
+	return sch
+}
+
 func dataSourceFabricPortRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	uuid, _ := d.Get("uuid").(string)
+	if uuid == "" {
+		name := d.Get("name").(string)
+		client := meta.(*config.Config).FabricClient
+		apiCtx := context.WithValue(ctx, v4.ContextAccessToken, meta.(*config.Config).FabricAuthToken)
+		ports, err := getFabricPorts(apiCtx, client, fabricPortsFilter{name: name, nameMatch: portNameMatchExact})
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		if len(ports) != 1 {
+			return diag.FromErr(fmt.Errorf("%d ports are named %s, exactly one is expected", len(ports), name))
+		}
+		uuid = ports[0].Uuid
+	}
 	d.SetId(uuid)
 	return resourceFabricPortRead(ctx, d, meta)
 }
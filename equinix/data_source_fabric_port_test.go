@@ -0,0 +1,102 @@
+package equinix
+
+import (
+	"testing"
+
+	v4 "github.com/equinix-labs/fabric-go/fabric/v4"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFabricPorts_filter(t *testing.T) {
+	// given
+	port := func(name, encapsulation string, state v4.PortState) v4.Port {
+		return v4.Port{Name: name, Encapsulation: &v4.PortEncapsulation{Type_: encapsulation}, State: &state}
+	}
+	ports := []v4.Port{
+		port("ops-SV5-L-Dot1q-STD-SEC-10G-JN-133", "DOT1Q", v4.ACTIVE_PortState),
+		port("ops-SV5-L-Dot1q-STD-PRI-10G-JN-132", "DOT1Q", v4.ACTIVE_PortState),
+		port("ops-SV5-L-Qinq-STD-PRI-10G-JN-140", "QINQ", v4.ACTIVE_PortState),
+		port("ops-DC6-L-Dot1q-STD-PRI-10G-JN-150", "DOT1Q", v4.DEPROVISIONED_PortState),
+		{Name: "ops-SV5-unknown"},
+	}
+	names := func(filter fabricPortsFilter) []string {
+		matching := []string{}
+		for _, p := range ports {
+			if filter.matches(p) {
+				matching = append(matching, p.Name)
+			}
+		}
+		return matching
+	}
+	// when
+	prefix := fabricPortsFilterFromTerra([]interface{}{map[string]interface{}{
+		"name":               "ops-SV5",
+		"name_match":         "PREFIX",
+		"encapsulation_type": "dot1q",
+		"state":              "ACTIVE",
+	}})
+	exact := fabricPortsFilterFromTerra([]interface{}{map[string]interface{}{
+		"name":       "ops-SV5-L-Qinq-STD-PRI-10G-JN-140",
+		"name_match": "EXACT",
+	}})
+	defaults := fabricPortsFilterFromTerra(nil)
+	// then
+	assert.Equal(t, []string{"ops-SV5-L-Dot1q-STD-SEC-10G-JN-133", "ops-SV5-L-Dot1q-STD-PRI-10G-JN-132"}, names(prefix))
+	assert.Equal(t, []string{"ops-SV5-L-Qinq-STD-PRI-10G-JN-140"}, names(exact))
+	assert.Len(t, names(defaults), len(ports), "All ports match without filters")
+	assert.Empty(t, names(fabricPortsFilter{name: "ops-SV5", nameMatch: portNameMatchExact}), "Prefix doesn't match exactly")
+	assert.NotEqual(t, prefix.id(), exact.id(), "Filters have distinct IDs")
+}
+
+func TestFabricPort_dataSourceSchema(t *testing.T) {
+	// given
+	sch := readFabricPortDataSourceSchema()
+	// when / then
+	assert.True(t, sch["uuid"].Optional, "uuid is optional")
+	assert.True(t, sch["name"].Optional, "name is optional")
+	assert.Equal(t, []string{"uuid", "name"}, sch["uuid"].ExactlyOneOf, "Port is looked up by uuid or name")
+	assert.True(t, FabricPortResourceSchema()["uuid"].Required, "Resource schema is left unchanged")
+}
+
+func TestFabricPort_physicalDetails(t *testing.T) {
+	// given
+	physicalPortType := v4.XF_PHYSICAL_PORT_PhysicalPortType
+	activeState := v4.ACTIVE_PortState
+	port := v4.Port{
+		Uuid:               "c4d9350e-783c-83cd-1ce0-306a5c00a600",
+		Name:               "ops-SV5-L-Dot1q-STD-PRI-10G-JN-132",
+		AvailableBandwidth: 9000,
+		PhysicalPortsSpeed: 10000,
+		PhysicalPortsType:  "10GBASE_LR",
+		PhysicalPortsCount: 1,
+		TetherIbx:          "SV5",
+		Device:             &v4.PortDevice{Name: "sv5-cx1"},
+		Encapsulation:      &v4.PortEncapsulation{Type_: "DOT1Q", TagProtocolId: "0x8100"},
+		LagEnabled:         true,
+		Lag:                &v4.PortLag{Id: "lag-1", Enabled: true, Name: "LAG-1", MemberStatus: "ACTIVE"},
+		PhysicalPorts: []v4.PhysicalPort{{
+			Type_:          &physicalPortType,
+			State:          &activeState,
+			InterfaceSpeed: 10000,
+			Tether:         &v4.PortTether{CrossConnectId: "1-234567", PatchPanel: "PP:0000:1234", Ibx: "SV5"},
+		}},
+	}
+	d := schema.TestResourceDataRaw(t, readFabricPortDataSourceSchema(), map[string]interface{}{})
+	// when
+	diags := setFabricPortMap(d, port)
+	// then
+	require.False(t, diags.HasError(), "Port is set: %v", diags)
+	assert.Equal(t, 9000, d.Get("available_bandwidth"))
+	assert.Equal(t, 10000, d.Get("physical_ports_speed"))
+	assert.Equal(t, "10GBASE_LR", d.Get("physical_ports_type"))
+	assert.Equal(t, "SV5", d.Get("tether_ibx"))
+	assert.Equal(t, "LAG-1", d.Get("lag.0.name"))
+	assert.Equal(t, "ACTIVE", d.Get("lag.0.member_status"))
+	assert.Equal(t, "XF_PHYSICAL_PORT", d.Get("physical_ports.0.type"))
+	assert.Equal(t, "1-234567", d.Get("physical_ports.0.tether.0.cross_connect_id"))
+	assert.Equal(t, "PP:0000:1234", d.Get("physical_ports.0.tether.0.patch_panel"))
+	assert.Equal(t, 1, d.Get("device").(*schema.Set).Len())
+	assert.Equal(t, 1, d.Get("encapsulation").(*schema.Set).Len())
+}
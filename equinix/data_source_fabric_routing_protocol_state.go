@@ -0,0 +1,213 @@
+package equinix
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/antihax/optional"
+	v4 "github.com/equinix-labs/fabric-go/fabric/v4"
+	"github.com/equinix/terraform-provider-equinix/internal/config"
+	equinix_errors "github.com/equinix/terraform-provider-equinix/internal/errors"
+	equinix_schema "github.com/equinix/terraform-provider-equinix/internal/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func dataSourceFabricRoutingProtocolState() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceFabricRoutingProtocolStateRead,
+		Schema:      readFabricRoutingProtocolStateSchema(),
+		Description: "Fabric V4 API compatible data resource that allow user to fetch the operational state of a routing protocol, e.g. for health checks",
+	}
+}
+
+func readFabricRoutingProtocolStateSchema() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"connection_uuid": {
+			Type:        schema.TypeString,
+			Required:    true,
+			Description: "Connection UUID the routing protocol is attached to",
+		},
+		"uuid": {
+			Type:        schema.TypeString,
+			Required:    true,
+			Description: "Routing protocol UUID",
+		},
+		"type": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "Routing protocol type - DIRECT or BGP",
+		},
+		"state": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "Routing protocol provisioning state, e.g. PROVISIONED",
+		},
+		"bgp_ipv4_enabled": {
+			Type:        schema.TypeBool,
+			Computed:    true,
+			Description: "Admin status of the IPv4 BGP session. False for DIRECT routing protocols",
+		},
+		"bgp_ipv6_enabled": {
+			Type:        schema.TypeBool,
+			Computed:    true,
+			Description: "Admin status of the IPv6 BGP session. False for DIRECT routing protocols",
+		},
+		"errors": {
+			Type:        schema.TypeList,
+			Computed:    true,
+			Description: "Messages of the errors reported on the routing protocol operation",
+			Elem:        &schema.Schema{Type: schema.TypeString},
+		},
+		"last_bgp_action": {
+			Type:        schema.TypeList,
+			Computed:    true,
+			Description: "Last BGP action taken on the routing protocol, e.g. a session reset",
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					"uuid": {
+						Type:        schema.TypeString,
+						Computed:    true,
+						Description: "BGP action identifier",
+					},
+					"type": {
+						Type:        schema.TypeString,
+						Computed:    true,
+						Description: "BGP action type, e.g. RESET_BGPIPV4",
+					},
+					"state": {
+						Type:        schema.TypeString,
+						Computed:    true,
+						Description: "BGP action state - PENDING, FAILED or SUCCEEDED",
+					},
+					"created_date_time": {
+						Type:        schema.TypeString,
+						Computed:    true,
+						Description: "Date and time the BGP action was taken",
+					},
+				},
+			},
+		},
+		"healthy": {
+			Type:        schema.TypeBool,
+			Computed:    true,
+			Description: "Whether the routing protocol is provisioned without errors, with a BGP session enabled for BGP routing protocols, and its last BGP action did not fail",
+		},
+	}
+}
+
+func dataSourceFabricRoutingProtocolStateRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*config.Config).FabricClient
+	ctx = context.WithValue(ctx, v4.ContextAccessToken, meta.(*config.Config).FabricAuthToken)
+	connUuid := d.Get("connection_uuid").(string)
+	uuid := d.Get("uuid").(string)
+
+	rp, _, err := client.RoutingProtocolsApi.GetConnectionRoutingProtocolByUuid(ctx, uuid, connUuid)
+	if err != nil {
+		return diag.FromErr(equinix_errors.FormatFabricError(err))
+	}
+	var actions []v4.BgpActionData
+	if rp.Type_ == "BGP" {
+		if actions, err = getFabricRoutingProtocolBgpActions(ctx, client, uuid, connUuid); err != nil {
+			return diag.FromErr(equinix_errors.FormatFabricError(err))
+		}
+	}
+
+	d.SetId(fmt.Sprintf("%s/%s", connUuid, uuid))
+	if err := equinix_schema.SetMap(d, fabricRoutingProtocolStateToTerra(rp, actions)); err != nil {
+		return diag.FromErr(err)
+	}
+	return nil
+}
+
+// getFabricRoutingProtocolBgpActions fetches all the pages of the BGP actions
+// taken on the given routing protocol.
+func getFabricRoutingProtocolBgpActions(ctx context.Context, client *v4.APIClient, uuid, connUuid string) ([]v4.BgpActionData, error) {
+	var actions []v4.BgpActionData
+	for {
+		opts := &v4.RoutingProtocolsApiGetConnectionRoutingProtocolAllBgpActionsOpts{
+			Offset: optional.NewInt32(int32(len(actions))),
+			Limit:  optional.NewInt32(fabricRoutingProtocolsPageSize),
+		}
+		resp, _, err := client.RoutingProtocolsApi.GetConnectionRoutingProtocolAllBgpActions(ctx, uuid, connUuid, opts)
+		if err != nil {
+			return nil, err
+		}
+		actions = append(actions, resp.Data...)
+		if len(resp.Data) == 0 || resp.Pagination == nil || len(actions) >= int(resp.Pagination.Total) {
+			return actions, nil
+		}
+	}
+}
+
+func fabricRoutingProtocolStateToTerra(rp v4.RoutingProtocolData, actions []v4.BgpActionData) map[string]interface{} {
+	var state string
+	var operation *v4.RoutingProtocolOperation
+	bgpIpv4Enabled, bgpIpv6Enabled := false, false
+	switch rp.Type_ {
+	case "BGP":
+		state, operation = rp.RoutingProtocolBgpData.State, rp.RoutingProtocolBgpData.Operation
+		bgpIpv4Enabled = rp.BgpIpv4 != nil && rp.BgpIpv4.Enabled
+		bgpIpv6Enabled = rp.BgpIpv6 != nil && rp.BgpIpv6.Enabled
+	case "DIRECT":
+		state, operation = rp.RoutingProtocolDirectData.State, rp.RoutingProtocolDirectData.Operation
+	}
+	errorMessages := []string{}
+	if operation != nil {
+		for _, e := range operation.Errors {
+			errorMessages = append(errorMessages, fmt.Sprintf("%s: %s", e.ErrorCode, e.ErrorMessage))
+		}
+	}
+
+	lastAction := lastBgpAction(actions)
+	mappedLastAction := []interface{}{}
+	lastActionFailed := false
+	if lastAction != nil {
+		actionType, actionState := "", ""
+		if lastAction.Type_ != nil {
+			actionType = string(*lastAction.Type_)
+		}
+		if lastAction.State != nil {
+			actionState = string(*lastAction.State)
+		}
+		createdDateTime := ""
+		if lastAction.Changelog != nil && !lastAction.Changelog.CreatedDateTime.IsZero() {
+			createdDateTime = lastAction.Changelog.CreatedDateTime.Format(time.RFC3339)
+		}
+		mappedLastAction = append(mappedLastAction, map[string]interface{}{
+			"uuid":              lastAction.Uuid,
+			"type":              actionType,
+			"state":             actionState,
+			"created_date_time": createdDateTime,
+		})
+		lastActionFailed = actionState == string(v4.FAILED_BgpActionStates)
+	}
+
+	healthy := state == string(v4.PROVISIONED_ConnectionState) && len(errorMessages) == 0 && !lastActionFailed
+	if rp.Type_ == "BGP" {
+		healthy = healthy && (bgpIpv4Enabled || bgpIpv6Enabled)
+	}
+	return map[string]interface{}{
+		"type":             rp.Type_,
+		"state":            state,
+		"bgp_ipv4_enabled": bgpIpv4Enabled,
+		"bgp_ipv6_enabled": bgpIpv6Enabled,
+		"errors":           errorMessages,
+		"last_bgp_action":  mappedLastAction,
+		"healthy":          healthy,
+	}
+}
+
+// lastBgpAction returns the most recently created BGP action, or nil if there
+// is none.
+func lastBgpAction(actions []v4.BgpActionData) *v4.BgpActionData {
+	var last *v4.BgpActionData
+	for i := range actions {
+		action := &actions[i]
+		if last == nil || (action.Changelog != nil && (last.Changelog == nil || action.Changelog.CreatedDateTime.After(last.Changelog.CreatedDateTime))) {
+			last = action
+		}
+	}
+	return last
+}
@@ -0,0 +1,76 @@
+package equinix
+
+import (
+	"testing"
+	"time"
+
+	v4 "github.com/equinix-labs/fabric-go/fabric/v4"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFabricRoutingProtocolState_healthyBgp(t *testing.T) {
+	// given
+	rp := v4.RoutingProtocolData{
+		Type_: "BGP",
+		OneOfRoutingProtocolData: v4.OneOfRoutingProtocolData{
+			RoutingProtocolBgpData: v4.RoutingProtocolBgpData{
+				Type_:   "BGP",
+				State:   "PROVISIONED",
+				BgpIpv4: &v4.BgpConnectionIpv4{CustomerPeerIp: "192.168.100.2", Enabled: true},
+			},
+		},
+	}
+	failed, succeeded := v4.FAILED_BgpActionStates, v4.SUCCEEDED_BgpActionStates
+	reset := v4.RESET_BGPIPV4_BgpActions
+	created := time.Date(2026, 10, 1, 12, 0, 0, 0, time.UTC)
+	actions := []v4.BgpActionData{
+		{Uuid: "old", Type_: &reset, State: &failed, Changelog: &v4.Changelog{CreatedDateTime: created}},
+		{Uuid: "new", Type_: &reset, State: &succeeded, Changelog: &v4.Changelog{CreatedDateTime: created.Add(time.Hour)}},
+	}
+	// when
+	result := fabricRoutingProtocolStateToTerra(rp, actions)
+	// then
+	assert.Equal(t, "PROVISIONED", result["state"])
+	assert.Equal(t, true, result["bgp_ipv4_enabled"])
+	assert.Equal(t, false, result["bgp_ipv6_enabled"])
+	assert.Empty(t, result["errors"])
+	lastAction := result["last_bgp_action"].([]interface{})[0].(map[string]interface{})
+	assert.Equal(t, "new", lastAction["uuid"], "Most recent BGP action is picked")
+	assert.Equal(t, "SUCCEEDED", lastAction["state"])
+	assert.Equal(t, "2026-10-01T13:00:00Z", lastAction["created_date_time"])
+	assert.Equal(t, true, result["healthy"], "Provisioned BGP routing protocol with an enabled session is healthy")
+}
+
+func TestFabricRoutingProtocolState_unhealthy(t *testing.T) {
+	// given
+	disabledBgp := v4.RoutingProtocolData{
+		Type_: "BGP",
+		OneOfRoutingProtocolData: v4.OneOfRoutingProtocolData{
+			RoutingProtocolBgpData: v4.RoutingProtocolBgpData{
+				Type_:   "BGP",
+				State:   "PROVISIONED",
+				BgpIpv4: &v4.BgpConnectionIpv4{CustomerPeerIp: "192.168.100.2"},
+			},
+		},
+	}
+	failingDirect := v4.RoutingProtocolData{
+		Type_: "DIRECT",
+		OneOfRoutingProtocolData: v4.OneOfRoutingProtocolData{
+			RoutingProtocolDirectData: v4.RoutingProtocolDirectData{
+				Type_: "DIRECT",
+				State: "PROVISIONED",
+				Operation: &v4.RoutingProtocolOperation{
+					Errors: []v4.ModelError{{ErrorCode: "EQ-3041001", ErrorMessage: "Invalid IP"}},
+				},
+			},
+		},
+	}
+	// when
+	disabledBgpResult := fabricRoutingProtocolStateToTerra(disabledBgp, nil)
+	failingDirectResult := fabricRoutingProtocolStateToTerra(failingDirect, nil)
+	// then
+	assert.Equal(t, false, disabledBgpResult["healthy"], "BGP routing protocol without enabled session is not healthy")
+	assert.Empty(t, disabledBgpResult["last_bgp_action"])
+	assert.Equal(t, []string{"EQ-3041001: Invalid IP"}, failingDirectResult["errors"])
+	assert.Equal(t, false, failingDirectResult["healthy"], "Routing protocol with errors is not healthy")
+}
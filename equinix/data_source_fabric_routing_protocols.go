@@ -0,0 +1,117 @@
+package equinix
+
+import (
+	"context"
+	"log"
+	"strings"
+
+	"github.com/antihax/optional"
+	v4 "github.com/equinix-labs/fabric-go/fabric/v4"
+	"github.com/equinix/terraform-provider-equinix/internal/config"
+	equinix_errors "github.com/equinix/terraform-provider-equinix/internal/errors"
+	equinix_schema "github.com/equinix/terraform-provider-equinix/internal/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+const fabricRoutingProtocolsPageSize = 100
+
+func dataSourceFabricRoutingProtocols() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceFabricRoutingProtocolsRead,
+		Schema:      readFabricRoutingProtocolsResponseSchema(),
+		Description: "Fabric V4 API compatible data resource that allow user to fetch all routing protocols of a given connection",
+	}
+}
+
+func readFabricRoutingProtocolsResponseSchema() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"connection_uuid": {
+			Type:        schema.TypeString,
+			Required:    true,
+			Description: "Connection URI associated with Routing Protocols",
+		},
+		"data": {
+			Type:        schema.TypeList,
+			Computed:    true,
+			Description: "List of DIRECT and BGP Routing Protocols attached to the connection",
+			Elem: &schema.Resource{
+				Schema: readFabricRoutingProtocolsDataSchema(),
+			},
+		},
+	}
+}
+
+// readFabricRoutingProtocolsDataSchema returns the routing protocol data source schema with all
+// the top level attributes computed.
+func readFabricRoutingProtocolsDataSchema() map[string]*schema.Schema {
+	sch := readFabricRoutingProtocolResourceSchema()
+	delete(sch, "connection_uuid")
+	for _, s := range sch {
+		s.Required = false
+		s.Optional = false
+		s.Computed = true
+	}
+	return sch
+}
+
+func dataSourceFabricRoutingProtocolsRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*config.Config).FabricClient
+	ctx = context.WithValue(ctx, v4.ContextAccessToken, meta.(*config.Config).FabricAuthToken)
+	connUuid := d.Get("connection_uuid").(string)
+
+	routingProtocols, err := getFabricConnectionRoutingProtocols(ctx, client, connUuid)
+	if err != nil {
+		log.Printf("[WARN] Routing Protocols of connection %s not found , error %s", connUuid, err)
+		if !strings.Contains(err.Error(), "500") {
+			d.SetId("")
+		}
+		return diag.FromErr(equinix_errors.FormatFabricError(err))
+	}
+
+	d.SetId(connUuid)
+	return setFabricRoutingProtocolsListMap(d, routingProtocols)
+}
+
+// getFabricConnectionRoutingProtocols fetches all the pages of the routing protocols
+// attached to the given connection.
+func getFabricConnectionRoutingProtocols(ctx context.Context, client *v4.APIClient, connUuid string) ([]v4.RoutingProtocolData, error) {
+	var routingProtocols []v4.RoutingProtocolData
+	for {
+		opts := &v4.RoutingProtocolsApiGetConnectionRoutingProtocolsOpts{
+			Offset: optional.NewInt32(int32(len(routingProtocols))),
+			Limit:  optional.NewInt32(fabricRoutingProtocolsPageSize),
+		}
+		resp, _, err := client.RoutingProtocolsApi.GetConnectionRoutingProtocols(ctx, connUuid, opts)
+		if err != nil {
+			return nil, err
+		}
+		routingProtocols = append(routingProtocols, resp.Data...)
+		if len(resp.Data) == 0 || resp.Pagination == nil || len(routingProtocols) >= int(resp.Pagination.Total) {
+			return routingProtocols, nil
+		}
+	}
+}
+
+func setFabricRoutingProtocolsListMap(d *schema.ResourceData, rps []v4.RoutingProtocolData) diag.Diagnostics {
+	diags := diag.Diagnostics{}
+	err := equinix_schema.SetMap(d, map[string]interface{}{
+		"data": fabricRoutingProtocolsListToTerra(rps),
+	})
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	return diags
+}
+
+func fabricRoutingProtocolsListToTerra(rps []v4.RoutingProtocolData) []map[string]interface{} {
+	mappedRps := make([]map[string]interface{}, 0, len(rps))
+	for _, rp := range rps {
+		mappedRp := fabricRoutingProtocolToTerra(rp)
+		if len(mappedRp) == 0 {
+			continue
+		}
+		mappedRps = append(mappedRps, mappedRp)
+	}
+	return mappedRps
+}
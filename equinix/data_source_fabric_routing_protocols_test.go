@@ -0,0 +1,49 @@
+package equinix
+
+import (
+	"testing"
+
+	v4 "github.com/equinix-labs/fabric-go/fabric/v4"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFabricRoutingProtocols_listToTerra(t *testing.T) {
+	// given
+	rps := []v4.RoutingProtocolData{
+		{
+			Type_: "DIRECT",
+			OneOfRoutingProtocolData: v4.OneOfRoutingProtocolData{
+				RoutingProtocolDirectData: v4.RoutingProtocolDirectData{
+					Type_:      "DIRECT",
+					Uuid:       "direct-uuid",
+					State:      "PROVISIONED",
+					DirectIpv4: &v4.DirectConnectionIpv4{EquinixIfaceIp: "192.168.100.1/30"},
+				},
+			},
+		},
+		{
+			Type_: "BGP",
+			OneOfRoutingProtocolData: v4.OneOfRoutingProtocolData{
+				RoutingProtocolBgpData: v4.RoutingProtocolBgpData{
+					Type_:       "BGP",
+					Uuid:        "bgp-uuid",
+					State:       "PROVISIONING",
+					BgpIpv4:     &v4.BgpConnectionIpv4{CustomerPeerIp: "192.168.100.2"},
+					CustomerAsn: 65001,
+				},
+			},
+		},
+		{Type_: "UNKNOWN"},
+	}
+	// when
+	result := fabricRoutingProtocolsListToTerra(rps)
+	// then
+	assert.Len(t, result, 2, "Routing protocols of unknown type are skipped")
+	assert.Equal(t, "direct-uuid", result[0]["uuid"], "DIRECT routing protocol uuid matches")
+	assert.Equal(t, "PROVISIONED", result[0]["state"], "DIRECT routing protocol state matches")
+	assert.NotNil(t, result[0]["direct_ipv4"], "DIRECT routing protocol IPv4 is mapped")
+	assert.Equal(t, "bgp-uuid", result[1]["uuid"], "BGP routing protocol uuid matches")
+	assert.Equal(t, "PROVISIONING", result[1]["state"], "BGP routing protocol state matches")
+	assert.Equal(t, int64(65001), result[1]["customer_asn"], "BGP routing protocol customer ASN matches")
+	assert.NotNil(t, result[1]["bgp_ipv4"], "BGP routing protocol IPv4 is mapped")
+}
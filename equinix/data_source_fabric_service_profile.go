@@ -38,12 +38,70 @@ func readFabricServiceProfileSearchResourceSchema() map[string]*schema.Schema {
 	return sch
 }
 
+const fabricServiceProfilesPageSize = 100
+
+// fabricServiceProfilesSearchFields maps the filter arguments of the service
+// profiles data source to the search API properties they are matched against.
+var fabricServiceProfilesSearchFields = map[string]string{
+	"visibility": "/visibility",
+	"type":       "/type",
+	"metro_code": "/metros/code",
+}
+
 func readFabricServiceProfilesSearchSchema() map[string]*schema.Schema {
 	return map[string]*schema.Schema{
+		"name": {
+			Type:         schema.TypeString,
+			Optional:     true,
+			ValidateFunc: validation.StringIsNotEmpty,
+			Description:  "Name of the service profiles to fetch. * matches any sequence of characters, names with wildcards are matched after the service profiles are fetched",
+		},
+		"visibility": {
+			Type:         schema.TypeString,
+			Optional:     true,
+			ValidateFunc: validation.StringInSlice([]string{"PUBLIC", "PRIVATE"}, false),
+			Description:  "Visibility of the service profiles to fetch - PUBLIC, PRIVATE",
+		},
+		"type": {
+			Type:         schema.TypeString,
+			Optional:     true,
+			ValidateFunc: validation.StringInSlice([]string{"L2_PROFILE", "L3_PROFILE"}, false),
+			Description:  "Type of the service profiles to fetch - L2_PROFILE, L3_PROFILE",
+		},
+		"metro_code": {
+			Type:         schema.TypeString,
+			Optional:     true,
+			ValidateFunc: validation.StringIsNotEmpty,
+			Description:  "Code of a metro the service profiles to fetch are available in",
+		},
+		"pagination": {
+			Type:        schema.TypeSet,
+			Optional:    true,
+			MaxItems:    1,
+			Description: "Page of the search results to fetch. All the matching service profiles are fetched if not set",
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					"offset": {
+						Type:         schema.TypeInt,
+						Optional:     true,
+						Default:      0,
+						ValidateFunc: validation.IntAtLeast(0),
+						Description:  "Index of the first service profile of the page",
+					},
+					"limit": {
+						Type:         schema.TypeInt,
+						Optional:     true,
+						Default:      20,
+						ValidateFunc: validation.IntBetween(1, fabricServiceProfilesPageSize),
+						Description:  "Maximum number of service profiles of the page",
+					},
+				},
+			},
+		},
 		"data": {
 			Type:        schema.TypeList,
 			Computed:    true,
-			Description: "List of Service Profiles",
+			Description: "List of the service profiles matching the search filters",
 			Elem: &schema.Resource{
 				Schema: readFabricServiceProfileSearchResourceSchema(),
 			},
@@ -131,7 +189,7 @@ func dataSourceFabricSearchServiceProfilesByName() *schema.Resource {
 	return &schema.Resource{
 		ReadContext: dataSourceFabricSearchServiceProfilesRead,
 		Schema:      readFabricServiceProfilesSearchSchema(),
-		Description: "Fabric V4 API compatible data resource that allow user to fetch Service Profile by name filter criteria",
+		Description: "Fabric V4 API compatible data resource that allow user to search Service Profiles by name, visibility, type and metro",
 	}
 }
 
@@ -0,0 +1,92 @@
+package equinix
+
+import (
+	"context"
+	"testing"
+
+	v4 "github.com/equinix-labs/fabric-go/fabric/v4"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFabricServiceProfiles_searchExpression(t *testing.T) {
+	// given
+	filterBlock := []interface{}{map[string]interface{}{
+		"property": "/state",
+		"operator": "=",
+		"values":   []interface{}{"ACTIVE"},
+	}}
+	filters := map[string]string{"visibility": "PUBLIC", "metro_code": "SV"}
+	// when
+	expr := serviceProfilesSearchExpression(filterBlock, filters)
+	single := serviceProfilesSearchExpression(nil, map[string]string{"name": "AWS Direct Connect"})
+	// then
+	require.NotNil(t, expr)
+	and, ok := (*expr).(v4.ServiceProfileAndFilter)
+	require.True(t, ok, "Filters are combined with and")
+	require.Len(t, and.And, 3)
+	assert.Equal(t, "/state", and.And[0].Property, "Filter block comes first")
+	assert.Equal(t, "/metros/code", and.And[1].Property, "Conditions are sorted by argument name")
+	assert.Equal(t, []string{"PUBLIC"}, and.And[2].Values)
+	require.NotNil(t, single)
+	assert.Equal(t, v4.ServiceProfileSimpleExpression{Property: "/name", Operator: "=", Values: []string{"AWS Direct Connect"}}, *single, "Single filter is a simple expression")
+	assert.Nil(t, serviceProfilesSearchExpression(nil, map[string]string{}), "No filter without arguments")
+}
+
+func TestFabricServiceProfiles_filterByName(t *testing.T) {
+	// given
+	serviceProfiles := []v4.ServiceProfile{{Name: "AWS Direct Connect"}, {Name: "AWS Direct Connect - High Capacity"}, {Name: "Azure ExpressRoute"}, {Name: "AWS (legacy)"}}
+	// when
+	aws := filterFabricServiceProfilesByName(serviceProfiles, "AWS*")
+	suffix := filterFabricServiceProfilesByName(serviceProfiles, "*Capacity")
+	legacy := filterFabricServiceProfilesByName(serviceProfiles, "AWS (*)")
+	// then
+	assert.Len(t, aws, 3)
+	assert.Equal(t, []v4.ServiceProfile{serviceProfiles[1]}, suffix)
+	assert.Equal(t, []v4.ServiceProfile{serviceProfiles[3]}, legacy, "Other characters are matched literally")
+}
+
+func TestFabricServiceProfiles_read(t *testing.T) {
+	// given
+	c := newFakeAPIConfig(t)
+	ctx := context.WithValue(context.Background(), v4.ContextAccessToken, c.FabricAuthToken)
+	profileType, public, private := v4.L2_PROFILE_ServiceProfileTypeEnum, v4.PUBLIC_ServiceProfileVisibilityEnum, v4.PRIVATE_ServiceProfileVisibilityEnum
+	for _, p := range []struct {
+		name       string
+		visibility *v4.ServiceProfileVisibilityEnum
+		metro      string
+	}{
+		{"Cloud A - SV", &public, "SV"},
+		{"Cloud A - DC", &public, "DC"},
+		{"Cloud B - SV", &public, "SV"},
+		{"Cloud C - SV", &private, "SV"},
+	} {
+		_, _, err := c.FabricClient.ServiceProfilesApi.CreateServiceProfile(ctx, v4.ServiceProfileRequest{
+			Type_: &profileType, Name: p.name, Visibility: p.visibility, Metros: []v4.ServiceMetro{{Code: p.metro}},
+		})
+		require.NoError(t, err)
+	}
+	sch := readFabricServiceProfilesSearchSchema()
+	svPublic := schema.TestResourceDataRaw(t, sch, map[string]interface{}{"visibility": "PUBLIC", "metro_code": "SV"})
+	page := schema.TestResourceDataRaw(t, sch, map[string]interface{}{
+		"pagination": []interface{}{map[string]interface{}{"offset": 1, "limit": 2}},
+	})
+	cloudA := schema.TestResourceDataRaw(t, sch, map[string]interface{}{"name": "Cloud A*"})
+	// when
+	svPublicDiags := resourceServiceProfilesSearchRequest(context.Background(), svPublic, c)
+	pageDiags := resourceServiceProfilesSearchRequest(context.Background(), page, c)
+	cloudADiags := resourceServiceProfilesSearchRequest(context.Background(), cloudA, c)
+	// then
+	require.False(t, svPublicDiags.HasError(), "Search does not fail: %v", svPublicDiags)
+	assert.Equal(t, 2, svPublic.Get("data.#"), "All the matching service profiles are listed")
+	assert.Equal(t, "Cloud A - SV", svPublic.Get("data.0.name"))
+	assert.Equal(t, "Cloud B - SV", svPublic.Get("data.1.name"))
+	assert.NotEmpty(t, svPublic.Id())
+	require.False(t, pageDiags.HasError(), "Search does not fail: %v", pageDiags)
+	assert.Equal(t, 2, page.Get("data.#"), "Only the requested page is listed")
+	assert.Equal(t, "Cloud A - SV", page.Get("data.0.name"))
+	require.False(t, cloudADiags.HasError(), "Search does not fail: %v", cloudADiags)
+	assert.Equal(t, 2, cloudA.Get("data.#"), "Names are matched against the pattern")
+	assert.NotEqual(t, svPublic.Id(), cloudA.Id(), "Searches are identified by their arguments")
+}
@@ -0,0 +1,47 @@
+package equinix
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func readFabricServiceTokenResourceSchema() map[string]*schema.Schema {
+	sch := fabricServiceTokenResourceSchema()
+	for key := range sch {
+		if key == "uuid" {
+			sch[key].Required = true
+			sch[key].Optional = false
+			sch[key].Computed = false
+		} else {
+			sch[key].Required = false
+			sch[key].Optional = false
+			sch[key].Computed = true
+			sch[key].ForceNew = false
+			sch[key].Default = nil
+			sch[key].MaxItems = 0
+			sch[key].ValidateFunc = nil
+			sch[key].DiffSuppressFunc = nil
+		}
+	}
+	// the side blocks are only exclusive when configured
+	connectionSch := sch["service_token_connection"].Elem.(*schema.Resource).Schema
+	connectionSch["a_side"].ExactlyOneOf = nil
+	connectionSch["z_side"].ExactlyOneOf = nil
+	return sch
+}
+
+func dataSourceFabricServiceToken() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceFabricServiceTokenRead,
+		Schema:      readFabricServiceTokenResourceSchema(),
+		Description: "Fabric V4 API compatible data resource that allow user to fetch Fabric Service Token for a given UUID",
+	}
+}
+
+func dataSourceFabricServiceTokenRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	uuid, _ := d.Get("uuid").(string)
+	d.SetId(uuid)
+	return resourceFabricServiceTokenRead(ctx, d, meta)
+}
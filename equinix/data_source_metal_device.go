@@ -7,6 +7,7 @@ import (
 	"path"
 	"sort"
 	"strings"
+	"time"
 
 	"github.com/equinix/terraform-provider-equinix/internal/config"
 	"github.com/equinix/terraform-provider-equinix/internal/network"
@@ -122,10 +123,25 @@ func dataSourceMetalDevice() *schema.Resource {
 			},
 			"root_password": {
 				Type:        schema.TypeString,
-				Description: "Root password to the server (if still available)",
+				Description: "Root password to the server, only available until root_password_expires_at",
 				Computed:    true,
 				Sensitive:   true,
 			},
+			"root_password_expires_at": {
+				Type:        schema.TypeString,
+				Description: "When the root password of the server expires, 24 hours after its creation. The root password is removed from the state once expired",
+				Computed:    true,
+			},
+			"spot_instance": {
+				Type:        schema.TypeBool,
+				Description: "Whether the device is a spot instance, created from a spot market request",
+				Computed:    true,
+			},
+			"spot_price_max": {
+				Type:        schema.TypeFloat,
+				Description: "Maximum price per hour bid for the device, for spot instances",
+				Computed:    true,
+			},
 			"always_pxe": {
 				Type:     schema.TypeBool,
 				Computed: true,
@@ -134,6 +150,11 @@ func dataSourceMetalDevice() *schema.Resource {
 				Type:     schema.TypeString,
 				Computed: true,
 			},
+			"image_url": {
+				Type:        schema.TypeString,
+				Description: "URL of the image the device was provisioned from",
+				Computed:    true,
+			},
 			"network": {
 				Type:        schema.TypeList,
 				Description: "The device's private and public IP (v4 and v6) network details. When a device is run without any special network configuration, it will have 3 networks: ublic IPv4 at equinix_metal_device.name.network.0, IPv6 at equinix_metal_device.name.network.1 and private IPv4 at equinix_metal_device.name.network.2. Elastic addresses then stack by type - an assigned public IPv4 will go after the management public IPv4 (to index 1), and will then shift the indices of the IPv6 and private IPv4. Assigned private IPv4 will go after the management private IPv4 (to the end of the network list).",
@@ -207,6 +228,11 @@ func dataSourceMetalDevice() *schema.Resource {
 				Description: "The hostname to use for [Serial over SSH](https://deploy.equinix.com/developers/docs/metal/resilience-recovery/serial-over-ssh/) access to the device",
 				Computed:    true,
 			},
+			"sos_url": {
+				Type:        schema.TypeString,
+				Description: "The SSH URL of the [Serial over SSH](https://deploy.equinix.com/developers/docs/metal/resilience-recovery/serial-over-ssh/) console of the device, e.g. ssh://<device_id>@sos.<facility>.platformequinix.com. SOS accepts the SSH keys of the project and of its members",
+				Computed:    true,
+			},
 		},
 	}
 }
@@ -261,8 +287,14 @@ func dataSourceMetalDeviceRead(ctx context.Context, d *schema.ResourceData, meta
 	d.Set("billing_cycle", device.GetBillingCycle())
 	d.Set("ipxe_script_url", device.GetIpxeScriptUrl())
 	d.Set("always_pxe", device.GetAlwaysPxe())
-	d.Set("root_password", device.GetRootPassword())
+	d.Set("image_url", device.GetImageUrl())
+	rootPassword, rootPasswordExpiresAt := deviceRootPassword(*device, time.Now())
+	d.Set("root_password", rootPassword)
+	d.Set("root_password_expires_at", rootPasswordExpiresAt)
+	d.Set("spot_instance", device.GetSpotInstance())
+	d.Set("spot_price_max", deviceSpotPriceMax(*device))
 	d.Set("sos_hostname", device.GetSos())
+	d.Set("sos_url", deviceSosURL(*device))
 
 	if device.Storage != nil {
 		rawStorageBytes, err := json.Marshal(device.Storage)
@@ -64,7 +64,7 @@ func dataSourceMetalMetroRead(d *schema.ResourceData, meta interface{}) error {
 		}
 	}
 
-	metros, _, err := client.Metros.List(nil)
+	metros, err := cachedMetalMetros(meta.(*config.Config))
 	if err != nil {
 		return fmt.Errorf("Error listing Metros: %s", err)
 	}
@@ -44,8 +44,6 @@ func dataSourceOperatingSystem() *schema.Resource {
 }
 
 func dataSourceMetalOperatingSystemRead(d *schema.ResourceData, meta interface{}) error {
-	client := meta.(*config.Config).Metal
-
 	name, nameOK := d.GetOk("name")
 	distro, distroOK := d.GetOk("distro")
 	version, versionOK := d.GetOk("version")
@@ -55,7 +53,7 @@ func dataSourceMetalOperatingSystemRead(d *schema.ResourceData, meta interface{}
 		return fmt.Errorf("One of name, distro, version, or provisionable_on must be assigned")
 	}
 
-	oss, _, err := client.OperatingSystems.List()
+	oss, err := cachedMetalOperatingSystems(meta.(*config.Config))
 	if err != nil {
 		return err
 	}
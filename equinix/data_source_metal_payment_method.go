@@ -0,0 +1,172 @@
+package equinix
+
+import (
+	"context"
+	"path"
+	"time"
+
+	"github.com/equinix/equinix-sdk-go/services/metalv1"
+	"github.com/equinix/terraform-provider-equinix/internal/config"
+	equinix_errors "github.com/equinix/terraform-provider-equinix/internal/errors"
+	equinix_schema "github.com/equinix/terraform-provider-equinix/internal/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func dataSourceMetalPaymentMethod() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceMetalPaymentMethodRead,
+
+		Schema: map[string]*schema.Schema{
+			"payment_method_id": {
+				Type:         schema.TypeString,
+				Required:     true,
+				Description:  "ID of the payment method to lookup",
+				ValidateFunc: validation.IsUUID,
+			},
+			"organization_id": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "UUID of the organization the payment method belongs to",
+			},
+			"name": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Name of the payment method",
+			},
+			"type": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Type of the payment method",
+			},
+			"default": {
+				Type:        schema.TypeBool,
+				Computed:    true,
+				Description: "Whether the payment method is the default one of the organization",
+			},
+			"card_type": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Type of the card of the payment method",
+			},
+			"cardholder_name": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Name of the cardholder",
+			},
+			"email": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Email of the payment method",
+			},
+			"expiration_month": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Expiration month of the card",
+			},
+			"expiration_year": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Expiration year of the card",
+			},
+			"project_ids": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "UUIDs of the projects billed to the payment method",
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+			"billing_address": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "Billing address of the payment method",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"street_address": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Street address",
+						},
+						"postal_code": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Postal code",
+						},
+						"country_code": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Two letter country code (ISO 3166-1 alpha-2), e.g. US",
+						},
+					},
+				},
+			},
+			"created": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The timestamp for when the payment method was created",
+			},
+			"updated": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The timestamp for the last time the payment method was updated",
+			},
+		},
+	}
+}
+
+func dataSourceMetalPaymentMethodRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	meta.(*config.Config).AddModuleToMetalGoUserAgent(d)
+	client := meta.(*config.Config).Metalgo
+
+	id := d.Get("payment_method_id").(string)
+	pm, resp, err := client.PaymentMethodsApi.FindPaymentMethodById(ctx, id).Include([]string{"projects"}).Execute()
+	if err != nil {
+		return diag.FromErr(equinix_errors.FriendlyErrorForMetalGo(err, resp))
+	}
+
+	d.SetId(pm.GetId())
+	return diag.FromErr(equinix_schema.SetMap(d, flattenPaymentMethod(*pm)))
+}
+
+func flattenPaymentMethod(pm metalv1.PaymentMethod) map[string]interface{} {
+	projectIDs := make([]string, 0, len(pm.Projects))
+	for _, p := range pm.GetProjects() {
+		projectIDs = append(projectIDs, path.Base(p.GetHref()))
+	}
+	var billingAddress []interface{}
+	if addr, ok := pm.GetBillingAddressOk(); ok {
+		billingAddress = []interface{}{map[string]interface{}{
+			"street_address": addr.GetStreetAddress(),
+			"postal_code":    addr.GetPostalCode(),
+			"country_code":   addr.GetCountryCodeAlpha2(),
+		}}
+	}
+	organizationID := ""
+	if org, ok := pm.GetOrganizationOk(); ok {
+		organizationID = path.Base(org.GetHref())
+	}
+	created, updated := "", ""
+	if t, ok := pm.GetCreatedAtOk(); ok {
+		created = t.Format(time.RFC3339)
+	}
+	if t, ok := pm.GetUpdatedAtOk(); ok {
+		updated = t.Format(time.RFC3339)
+	}
+
+	return map[string]interface{}{
+		"payment_method_id": pm.GetId(),
+		"organization_id":   organizationID,
+		"name":              pm.GetName(),
+		"type":              pm.GetType(),
+		"default":           pm.GetDefault(),
+		"card_type":         pm.GetCardType(),
+		"cardholder_name":   pm.GetCardholderName(),
+		"email":             pm.GetEmail(),
+		"expiration_month":  pm.GetExpirationMonth(),
+		"expiration_year":   pm.GetExpirationYear(),
+		"project_ids":       projectIDs,
+		"billing_address":   billingAddress,
+		"created":           created,
+		"updated":           updated,
+	}
+}
@@ -0,0 +1,40 @@
+package equinix
+
+import (
+	"testing"
+	"time"
+
+	"github.com/equinix/equinix-sdk-go/services/metalv1"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMetalPaymentMethod_flatten(t *testing.T) {
+	// given
+	created := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	pm := metalv1.PaymentMethod{}
+	pm.SetId("pm-id")
+	pm.SetName("corporate card")
+	pm.SetDefault(true)
+	pm.SetCreatedAt(created)
+	pm.SetOrganization(metalv1.Href{Href: "/metal/v1/organizations/org-id"})
+	pm.SetProjects([]metalv1.Href{{Href: "/metal/v1/projects/project-id"}})
+	address := metalv1.PaymentMethodBillingAddress{}
+	address.SetStreetAddress("1 Main St")
+	address.SetPostalCode("94105")
+	address.SetCountryCodeAlpha2("US")
+	pm.SetBillingAddress(address)
+	// when
+	result := flattenPaymentMethod(pm)
+	// then
+	assert.Equal(t, "pm-id", result["payment_method_id"])
+	assert.Equal(t, "org-id", result["organization_id"], "Organization ID is taken from its href")
+	assert.Equal(t, []string{"project-id"}, result["project_ids"], "Project IDs are taken from their hrefs")
+	assert.Equal(t, true, result["default"])
+	assert.Equal(t, "2024-01-02T03:04:05Z", result["created"])
+	assert.Equal(t, "", result["updated"], "Unknown timestamps are empty")
+	assert.Equal(t, []interface{}{map[string]interface{}{
+		"street_address": "1 Main St",
+		"postal_code":    "94105",
+		"country_code":   "US",
+	}}, result["billing_address"])
+}
@@ -0,0 +1,56 @@
+package equinix
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/equinix/equinix-sdk-go/services/metalv1"
+	"github.com/equinix/terraform-provider-equinix/internal/config"
+	"github.com/equinix/terraform-provider-equinix/internal/datalist"
+	equinix_errors "github.com/equinix/terraform-provider-equinix/internal/errors"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func dataSourceMetalPaymentMethods() *schema.Resource {
+	dataListConfig := &datalist.ResourceConfig{
+		RecordSchema:               dataSourceMetalPaymentMethod().Schema,
+		ResultAttributeName:        "payment_methods",
+		ResultAttributeDescription: "List of payment methods of the organization that match specified filters",
+		FlattenRecord:              flattenPaymentMethodRecord,
+		GetRecords:                 getPaymentMethods,
+		ExtraQuerySchema: map[string]*schema.Schema{
+			"organization_id": {
+				Type:         schema.TypeString,
+				Description:  "UUID of the organization to list the payment methods of",
+				Required:     true,
+				ValidateFunc: validation.IsUUID,
+			},
+		},
+	}
+	return datalist.NewResource(dataListConfig)
+}
+
+func getPaymentMethods(meta interface{}, extra map[string]interface{}) ([]interface{}, error) {
+	client := meta.(*config.Config).Metalgo
+	organizationID := extra["organization_id"].(string)
+
+	list, resp, err := client.OrganizationsApi.FindOrganizationPaymentMethods(context.Background(), organizationID).Include([]string{"projects"}).Execute()
+	if err != nil {
+		return nil, equinix_errors.FriendlyErrorForMetalGo(err, resp)
+	}
+
+	pms := make([]interface{}, 0, len(list.GetPaymentMethods()))
+	for _, pm := range list.GetPaymentMethods() {
+		pms = append(pms, pm)
+	}
+	return pms, nil
+}
+
+func flattenPaymentMethodRecord(rawPM interface{}, meta interface{}, extra map[string]interface{}) (map[string]interface{}, error) {
+	pm, ok := rawPM.(metalv1.PaymentMethod)
+	if !ok {
+		return nil, fmt.Errorf("expected payment method to be of type metalv1.PaymentMethod, got %T", rawPM)
+	}
+	return flattenPaymentMethod(pm), nil
+}
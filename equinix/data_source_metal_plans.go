@@ -25,11 +25,10 @@ func dataSourceMetalPlans() *schema.Resource {
 }
 
 func getPlans(meta interface{}, extra map[string]interface{}) ([]interface{}, error) {
-	client := meta.(*config.Config).Metal
 	opts := &packngo.ListOptions{
 		Includes: []string{"available_in", "available_in_metros"},
 	}
-	plans, _, err := client.Plans.List(opts)
+	plans, err := cachedMetalPlans(meta.(*config.Config), opts)
 	plansIf := []interface{}{}
 	for _, p := range plans {
 		plansIf = append(plansIf, p)
@@ -91,9 +90,81 @@ func planSchema() map[string]*schema.Schema {
 			Description: "list of metros where the plan is available",
 			Elem:        &schema.Schema{Type: schema.TypeString},
 		},
+		"cpus": {
+			Type:        schema.TypeList,
+			Description: "list of CPU models of the plan",
+			Elem: &schema.Resource{
+				Schema: planHardwareComponentSchema("CPU"),
+			},
+		},
+		"cpu_count": {
+			Type:        schema.TypeInt,
+			Description: "total number of CPUs of the plan",
+		},
+		"memory": {
+			Type:        schema.TypeString,
+			Description: "total memory of the plan, e.g. 64GB",
+		},
+		"drives": {
+			Type:        schema.TypeList,
+			Description: "list of drive layouts of the plan",
+			Elem: &schema.Resource{
+				Schema: planDriveSchema(),
+			},
+		},
+		"drive_types": {
+			Type:        schema.TypeSet,
+			Description: "list of drive types of the plan, e.g. SSD, NVME, HDD",
+			Elem:        &schema.Schema{Type: schema.TypeString},
+		},
+		"nics": {
+			Type:        schema.TypeList,
+			Description: "list of network interfaces of the plan",
+			Elem: &schema.Resource{
+				Schema: planHardwareComponentSchema("NIC"),
+			},
+		},
+		"nic_types": {
+			Type:        schema.TypeSet,
+			Description: "list of network interface speeds of the plan, e.g. 10Gbps, 25Gbps",
+			Elem:        &schema.Schema{Type: schema.TypeString},
+		},
+		"raid": {
+			Type:        schema.TypeBool,
+			Description: "flag showing if the plan supports RAID",
+		},
+		"txt": {
+			Type:        schema.TypeBool,
+			Description: "flag showing if the plan supports Intel TXT",
+		},
 	}
 }
 
+func planHardwareComponentSchema(component string) map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"count": {
+			Type:        schema.TypeInt,
+			Computed:    true,
+			Description: fmt.Sprintf("number of %ss", component),
+		},
+		"type": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: fmt.Sprintf("%s type", component),
+		},
+	}
+}
+
+func planDriveSchema() map[string]*schema.Schema {
+	sch := planHardwareComponentSchema("drive")
+	sch["size"] = &schema.Schema{
+		Type:        schema.TypeString,
+		Computed:    true,
+		Description: "drive size, e.g. 480GB",
+	}
+	return sch
+}
+
 func flattenPlan(rawPlan interface{}, meta interface{}, extra map[string]interface{}) (map[string]interface{}, error) {
 	plan, ok := rawPlan.(packngo.Plan)
 	if !ok {
@@ -133,5 +204,60 @@ func flattenPlan(rawPlan interface{}, meta interface{}, extra map[string]interfa
 		flattenedPlan["pricing_month"] = float64(plan.Pricing.Month)
 	}
 
+	if plan.Specs != nil {
+		for k, v := range flattenPlanSpecs(plan.Specs) {
+			flattenedPlan[k] = v
+		}
+	}
+
 	return flattenedPlan, nil
 }
+
+func flattenPlanSpecs(specs *packngo.Specs) map[string]interface{} {
+	cpus := []interface{}{}
+	cpuCount := 0
+	for _, c := range specs.Cpus {
+		if c == nil {
+			continue
+		}
+		cpuCount += c.Count
+		cpus = append(cpus, map[string]interface{}{"count": c.Count, "type": c.Type})
+	}
+
+	drives := []interface{}{}
+	driveTypes := []string{}
+	for _, d := range specs.Drives {
+		if d == nil {
+			continue
+		}
+		drives = append(drives, map[string]interface{}{"count": d.Count, "size": d.Size, "type": d.Type})
+		driveTypes = append(driveTypes, d.Type)
+	}
+
+	nics := []interface{}{}
+	nicTypes := []string{}
+	for _, n := range specs.Nics {
+		if n == nil {
+			continue
+		}
+		nics = append(nics, map[string]interface{}{"count": n.Count, "type": n.Type})
+		nicTypes = append(nicTypes, n.Type)
+	}
+
+	flattenedSpecs := map[string]interface{}{
+		"cpus":        cpus,
+		"cpu_count":   cpuCount,
+		"drives":      drives,
+		"drive_types": schema.NewSet(schema.HashString, converters.StringArrToIfArr(driveTypes)),
+		"nics":        nics,
+		"nic_types":   schema.NewSet(schema.HashString, converters.StringArrToIfArr(nicTypes)),
+	}
+	if specs.Memory != nil {
+		flattenedSpecs["memory"] = specs.Memory.Total
+	}
+	if specs.Features != nil {
+		flattenedSpecs["raid"] = specs.Features.Raid
+		flattenedSpecs["txt"] = specs.Features.Txt
+	}
+	return flattenedSpecs
+}
@@ -0,0 +1,46 @@
+package equinix
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/packethost/packngo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMetalPlans_flattenPlanSpecs(t *testing.T) {
+	// given
+	plan := packngo.Plan{
+		ID:   "plan-id",
+		Slug: "m3.large.x86",
+		Specs: &packngo.Specs{
+			Cpus:   []*packngo.Cpus{{Count: 1, Type: "AMD EPYC 7502P"}},
+			Memory: &packngo.Memory{Total: "256GB"},
+			Drives: []*packngo.Drives{
+				{Count: 2, Size: "240GB", Type: "SSD"},
+				{Count: 2, Size: "3.8TB", Type: "NVME"},
+			},
+			Nics:     []*packngo.Nics{{Count: 2, Type: "25Gbps"}},
+			Features: &packngo.Features{Raid: true, Txt: true},
+		},
+	}
+	// when
+	result, err := flattenPlan(plan, nil, nil)
+	// then
+	require.NoError(t, err)
+	assert.Equal(t, 1, result["cpu_count"])
+	assert.Equal(t, "256GB", result["memory"])
+	assert.Len(t, result["drives"], 2)
+	assert.ElementsMatch(t, []interface{}{"SSD", "NVME"}, result["drive_types"].(*schema.Set).List())
+	assert.ElementsMatch(t, []interface{}{"25Gbps"}, result["nic_types"].(*schema.Set).List())
+	assert.Equal(t, true, result["raid"])
+	assert.Equal(t, true, result["txt"])
+}
+
+func TestMetalPlans_schema(t *testing.T) {
+	// when
+	sch := dataSourceMetalPlans().Schema
+	// then
+	assert.NoError(t, schema.InternalMap(sch).InternalValidate(nil), "Schema is valid")
+}
@@ -0,0 +1,103 @@
+package equinix
+
+import (
+	"fmt"
+
+	"github.com/equinix/terraform-provider-equinix/internal/config"
+	"github.com/equinix/terraform-provider-equinix/internal/converters"
+	"github.com/equinix/terraform-provider-equinix/internal/network"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/packethost/packngo"
+)
+
+func dataSourceMetalReservedIPBlockSubnets() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceMetalReservedIPBlockSubnetsRead,
+		Schema: map[string]*schema.Schema{
+			"reserved_ip_block_id": {
+				Type:         schema.TypeString,
+				Required:     true,
+				Description:  "ID of the reserved IP block to split",
+				ValidateFunc: validation.IsUUID,
+			},
+			"sizes": {
+				Type:        schema.TypeList,
+				Required:    true,
+				MinItems:    1,
+				Description: "Prefix lengths of the subnets to find, e.g. 29 for a /29 subnet",
+				Elem: &schema.Schema{
+					Type:         schema.TypeInt,
+					ValidateFunc: validation.IntBetween(0, 128),
+				},
+			},
+			"exclude_cidrs": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				Description: "CIDRs of the block that are in use but not assigned to devices, e.g. ranges of gateways or VRF virtual circuits",
+				Elem: &schema.Schema{
+					Type:         schema.TypeString,
+					ValidateFunc: validation.IsCIDR,
+				},
+			},
+			"cidr_notation": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "CIDR notation of the reserved IP block",
+			},
+			"assigned_cidrs": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "CIDRs of the block currently assigned to devices",
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+			"subnets": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "Free subnets of the block, one for each of the requested sizes, in the same order",
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+		},
+	}
+}
+
+func dataSourceMetalReservedIPBlockSubnetsRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*config.Config).Metal
+
+	blockId := d.Get("reserved_ip_block_id").(string)
+	block, _, err := client.ProjectIPs.Get(blockId, &packngo.GetOptions{Includes: []string{"assignments"}})
+	if err != nil {
+		return err
+	}
+	cidrNotation := fmt.Sprintf("%s/%d", block.Network, block.CIDR)
+
+	assigned := reservedIPBlockAssignedCIDRs(block)
+	used := append(converters.IfArrToStringArr(d.Get("exclude_cidrs").([]interface{})), assigned...)
+	sizes := converters.IfArrToIntArr(d.Get("sizes").([]interface{}))
+
+	subnets, err := network.SplitCIDR(cidrNotation, used, sizes)
+	if err != nil {
+		return fmt.Errorf("error splitting reserved IP block %s: %s", blockId, err)
+	}
+
+	d.SetId(block.ID)
+	if err := d.Set("cidr_notation", cidrNotation); err != nil {
+		return err
+	}
+	if err := d.Set("assigned_cidrs", assigned); err != nil {
+		return err
+	}
+	return d.Set("subnets", subnets)
+}
+
+func reservedIPBlockAssignedCIDRs(block *packngo.IPAddressReservation) []string {
+	assigned := make([]string, 0, len(block.Assignments))
+	for _, a := range block.Assignments {
+		if a == nil {
+			continue
+		}
+		assigned = append(assigned, fmt.Sprintf("%s/%d", a.Network, a.CIDR))
+	}
+	return assigned
+}
@@ -0,0 +1,115 @@
+package equinix
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/equinix/terraform-provider-equinix/internal/config"
+	"github.com/equinix/terraform-provider-equinix/internal/datalist"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/packethost/packngo"
+)
+
+func dataSourceMetalVirtualCircuits() *schema.Resource {
+	sch := dataSourceMetalVirtualCircuit().Schema
+	dataListConfig := &datalist.ResourceConfig{
+		RecordSchema:               sch,
+		ResultAttributeName:        "virtual_circuits",
+		ResultAttributeDescription: "List of virtual circuits that match specified filters",
+		FlattenRecord:              flattenVirtualCircuit,
+		GetRecords:                 getVirtualCircuits,
+		ExtraQuerySchema: map[string]*schema.Schema{
+			"connection_id": {
+				Type:        schema.TypeString,
+				Description: "UUID of the interconnection to query for virtual circuits",
+				Required:    true,
+			},
+			"port_id": {
+				Type:        schema.TypeString,
+				Description: "UUID of the interconnection port to query for virtual circuits. If not set, virtual circuits of all the connection ports are returned",
+				Optional:    true,
+			},
+		},
+	}
+	return datalist.NewResource(dataListConfig)
+}
+
+type virtualCircuitRecord struct {
+	connectionID string
+	portID       string
+	vc           packngo.VirtualCircuit
+}
+
+func getVirtualCircuits(meta interface{}, extra map[string]interface{}) ([]interface{}, error) {
+	client := meta.(*config.Config).Metal
+	connectionID := extra["connection_id"].(string)
+	portIDs := []string{}
+
+	if portID := extra["port_id"].(string); len(portID) > 0 {
+		portIDs = append(portIDs, portID)
+	} else {
+		conn, _, err := client.Connections.Get(connectionID, nil)
+		if err != nil {
+			return nil, err
+		}
+		for _, p := range conn.Ports {
+			portIDs = append(portIDs, p.ID)
+		}
+	}
+
+	vcsIf := []interface{}{}
+	for _, portID := range portIDs {
+		vcs, _, err := client.Connections.VirtualCircuits(
+			connectionID,
+			portID,
+			&packngo.GetOptions{Includes: []string{"project", "virtual_network", "vrf"}},
+		)
+		if err != nil {
+			return nil, err
+		}
+		for _, vc := range vcs {
+			vcsIf = append(vcsIf, virtualCircuitRecord{connectionID: connectionID, portID: portID, vc: vc})
+		}
+	}
+	return vcsIf, nil
+}
+
+func flattenVirtualCircuit(rawVC interface{}, meta interface{}, extra map[string]interface{}) (map[string]interface{}, error) {
+	record, ok := rawVC.(virtualCircuitRecord)
+	if !ok {
+		return nil, fmt.Errorf("expected virtual circuit to be of type virtualCircuitRecord, got %T", rawVC)
+	}
+	vc := record.vc
+
+	vcMap := map[string]interface{}{
+		"virtual_circuit_id": vc.ID,
+		"connection_id":      record.connectionID,
+		"port_id":            record.portID,
+		"status":             string(vc.Status),
+		"nni_vlan":           vc.NniVLAN,
+		"vnid":               vc.VNID,
+		"nni_vnid":           vc.NniVNID,
+		"name":               vc.Name,
+		"speed":              strconv.Itoa(vc.Speed),
+		"description":        vc.Description,
+		"tags":               vc.Tags,
+		"peer_asn":           vc.PeerASN,
+		"subnet":             vc.Subnet,
+		"metal_ip":           vc.MetalIP,
+		"customer_ip":        vc.CustomerIP,
+		"md5":                vc.MD5,
+		"project_id":         "",
+		"vlan_id":            "",
+		"vrf_id":             "",
+	}
+	if vc.Project != nil {
+		vcMap["project_id"] = vc.Project.ID
+	}
+	if vc.VirtualNetwork != nil {
+		vcMap["vlan_id"] = vc.VirtualNetwork.ID
+	}
+	if vc.VRF != nil {
+		vcMap["vrf_id"] = vc.VRF.ID
+	}
+	return vcMap, nil
+}
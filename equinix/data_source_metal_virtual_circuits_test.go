@@ -0,0 +1,41 @@
+package equinix
+
+import (
+	"testing"
+
+	"github.com/packethost/packngo"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMetalVirtualCircuits_flattenVirtualCircuit(t *testing.T) {
+	// given
+	record := virtualCircuitRecord{
+		connectionID: "conn-id",
+		portID:       "port-id",
+		vc: packngo.VirtualCircuit{
+			ID:      "vc-id",
+			Status:  packngo.VCStatusActive,
+			NniVLAN: 1234,
+			Speed:   50000000,
+			Project: &packngo.Project{ID: "project-id"},
+			VRF:     &packngo.VRF{ID: "vrf-id"},
+		},
+	}
+	sch := dataSourceMetalVirtualCircuit().Schema
+	// when
+	vcMap, err := flattenVirtualCircuit(record, nil, nil)
+	// then
+	assert.NoError(t, err)
+	assert.Equal(t, "vc-id", vcMap["virtual_circuit_id"])
+	assert.Equal(t, "conn-id", vcMap["connection_id"])
+	assert.Equal(t, "port-id", vcMap["port_id"])
+	assert.Equal(t, 1234, vcMap["nni_vlan"])
+	assert.Equal(t, "50000000", vcMap["speed"])
+	assert.Equal(t, "project-id", vcMap["project_id"])
+	assert.Equal(t, "vrf-id", vcMap["vrf_id"])
+	assert.Equal(t, "", vcMap["vlan_id"])
+	for k := range vcMap {
+		assert.Contains(t, sch, k, "Flattened attribute is part of the virtual circuit schema")
+	}
+	assert.Len(t, vcMap, len(sch), "All virtual circuit schema attributes are flattened")
+}
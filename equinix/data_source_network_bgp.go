@@ -0,0 +1,68 @@
+package equinix
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/equinix/terraform-provider-equinix/internal/config"
+
+	"github.com/equinix/ne-go"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func dataSourceNetworkBGP() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceNetworkBGPRead,
+		Description: "Use this data source to get details of an existing Network Edge BGP peering configuration for a given device and connection",
+		Schema:      createDataSourceNetworkBGPSchema(),
+	}
+}
+
+func createDataSourceNetworkBGPSchema() map[string]*schema.Schema {
+	sch := createNetworkBGPResourceSchema()
+	for key := range sch {
+		sch[key].Required = false
+		sch[key].Optional = false
+		sch[key].Computed = true
+		sch[key].ForceNew = false
+		sch[key].ValidateFunc = nil
+	}
+	for _, key := range []string{networkBGPSchemaNames["ConnectionUUID"], networkBGPSchemaNames["DeviceUUID"]} {
+		sch[key].Required = true
+		sch[key].Computed = false
+		sch[key].ValidateFunc = validation.StringIsNotEmpty
+	}
+	return sch
+}
+
+func dataSourceNetworkBGPRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*config.Config).Ne
+	m.(*config.Config).AddModuleToNEUserAgent(&client, d)
+	connectionID := d.Get(networkBGPSchemaNames["ConnectionUUID"]).(string)
+	deviceID := d.Get(networkBGPSchemaNames["DeviceUUID"]).(string)
+	bgp, err := getNetworkBGPForDevice(client.GetBGPConfigurationForConnection, connectionID, deviceID)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	if err := updateNetworkBGPResource(bgp, d); err != nil {
+		return diag.FromErr(err)
+	}
+	d.SetId(ne.StringValue(bgp.UUID))
+	return nil
+}
+
+// getNetworkBGPForDevice fetches the BGP peering configuration of a connection
+// and checks that the given device is the local peer of the configuration
+func getNetworkBGPForDevice(fetchFunc getBGPConfig, connectionID, deviceID string) (*ne.BGPConfiguration, error) {
+	bgp, err := fetchFunc(connectionID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch BGP configuration for connection '%s': %s", connectionID, err)
+	}
+	if ne.StringValue(bgp.DeviceUUID) != deviceID {
+		return nil, fmt.Errorf("BGP configuration '%s' of connection '%s' belongs to device '%s', not '%s'",
+			ne.StringValue(bgp.UUID), connectionID, ne.StringValue(bgp.DeviceUUID), deviceID)
+	}
+	return bgp, nil
+}
@@ -0,0 +1,46 @@
+package equinix
+
+import (
+	"testing"
+
+	"github.com/equinix/ne-go"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNetworkBGP_getForDevice(t *testing.T) {
+	// given
+	bgp := &ne.BGPConfiguration{
+		UUID:               ne.String("0cb9759d-58ab-44e6-9c10-6a3cfd18cefb"),
+		DeviceUUID:         ne.String("8895983f-00f9-42f1-a387-85248f2aab49"),
+		ConnectionUUID:     ne.String("6ca8d0df-c71a-4475-a835-53c2df1e6667"),
+		State:              ne.String(ne.BGPStateEstablished),
+		ProvisioningStatus: ne.String(ne.BGPProvisioningStatusProvisioned),
+	}
+	fetchFunc := func(uuid string) (*ne.BGPConfiguration, error) {
+		assert.Equal(t, ne.StringValue(bgp.ConnectionUUID), uuid, "BGP configuration is fetched by connection")
+		return bgp, nil
+	}
+	// when
+	result, err := getNetworkBGPForDevice(fetchFunc, ne.StringValue(bgp.ConnectionUUID), ne.StringValue(bgp.DeviceUUID))
+	_, otherDeviceErr := getNetworkBGPForDevice(fetchFunc, ne.StringValue(bgp.ConnectionUUID), "other-device")
+	// then
+	assert.Nil(t, err, "BGP configuration of the device is returned")
+	assert.Equal(t, bgp, result, "BGP configuration matches")
+	assert.Error(t, otherDeviceErr, "BGP configuration of another device is not returned")
+}
+
+func TestNetworkBGP_dataSourceSchema(t *testing.T) {
+	// when
+	sch := createDataSourceNetworkBGPSchema()
+	// then
+	for key, s := range sch {
+		switch key {
+		case networkBGPSchemaNames["ConnectionUUID"], networkBGPSchemaNames["DeviceUUID"]:
+			assert.True(t, s.Required, "%s is required", key)
+		default:
+			assert.True(t, s.Computed, "%s is computed", key)
+		}
+	}
+	assert.NoError(t, schema.InternalMap(sch).InternalValidate(nil), "Schema is valid")
+}
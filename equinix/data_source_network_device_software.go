@@ -118,7 +118,7 @@ func dataSourceNetworkDeviceSoftwareRead(ctx context.Context, d *schema.Resource
 	var diags diag.Diagnostics
 	typeCode := d.Get(networkDeviceSoftwareSchemaNames["DeviceTypeCode"]).(string)
 	pkgCodes := converters.SetToStringList(d.Get(networkDeviceSoftwareSchemaNames["PackageCodes"]).(*schema.Set))
-	versions, err := conf.Ne.GetDeviceSoftwareVersions(typeCode)
+	versions, err := cachedNeDeviceSoftwareVersions(conf, typeCode)
 	if err != nil {
 		return diag.FromErr(err)
 	}
@@ -22,6 +22,9 @@ var networkDeviceTypeSchemaNames = map[string]string{
 	"Vendor":      "vendor",
 	"Category":    "category",
 	"MetroCodes":  "metro_codes",
+	"Packages":    "packages",
+	"Versions":    "software_versions",
+	"Platforms":   "platforms",
 }
 
 var networkDeviceTypeDescriptions = map[string]string{
@@ -31,6 +34,29 @@ var networkDeviceTypeDescriptions = map[string]string{
 	"Vendor":      "Device type vendor i.e. Cisco, Juniper Networks, VERSA Networks",
 	"Category":    "Device type category, one of: Router, Firewall, SDWAN",
 	"MetroCodes":  "List of metro codes where device type has to be available",
+	"Packages":    "List of software package codes supported by the device type",
+	"Versions":    "List of software versions available for the device type",
+	"Platforms":   "List of platform configurations available for the device type",
+}
+
+var networkDeviceTypeVersionDescriptions = map[string]string{
+	"Version":          "Software version",
+	"ImageName":        "Software image name",
+	"Date":             "Version release date",
+	"Status":           "Version status",
+	"IsStable":         "Indicates if the version is stable",
+	"ReleaseNotesLink": "Link to version release notes",
+	"PackageCodes":     "List of software package codes that support the version",
+}
+
+var networkDeviceTypePlatformDescriptions = map[string]string{
+	"Flavor":          "Device platform flavor that determines number of CPU cores and memory",
+	"CoreCount":       "Number of CPU cores provided by the platform",
+	"Memory":          "The amount of memory provided by the platform",
+	"MemoryUnit":      "Unit of memory provided by the platform",
+	"PackageCodes":    "List of software package codes supported by the platform",
+	"ManagementTypes": "List of device management types supported by the platform",
+	"LicenseOptions":  "List of device licensing options supported by the platform",
 }
 
 func dataSourceNetworkDeviceType() *schema.Resource {
@@ -80,6 +106,112 @@ func dataSourceNetworkDeviceType() *schema.Resource {
 				},
 				Description: networkDeviceTypeDescriptions["MetroCodes"],
 			},
+			networkDeviceTypeSchemaNames["Packages"]: {
+				Type:        schema.TypeSet,
+				Computed:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: networkDeviceTypeDescriptions["Packages"],
+			},
+			networkDeviceTypeSchemaNames["Versions"]: {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: networkDeviceTypeDescriptions["Versions"],
+				Elem: &schema.Resource{
+					Schema: createNetworkDeviceTypeVersionSchema(),
+				},
+			},
+			networkDeviceTypeSchemaNames["Platforms"]: {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: networkDeviceTypeDescriptions["Platforms"],
+				Elem: &schema.Resource{
+					Schema: createNetworkDeviceTypePlatformSchema(),
+				},
+			},
+		},
+	}
+}
+
+func createNetworkDeviceTypeVersionSchema() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		networkDeviceSoftwareSchemaNames["Version"]: {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: networkDeviceTypeVersionDescriptions["Version"],
+		},
+		networkDeviceSoftwareSchemaNames["ImageName"]: {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: networkDeviceTypeVersionDescriptions["ImageName"],
+		},
+		networkDeviceSoftwareSchemaNames["Date"]: {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: networkDeviceTypeVersionDescriptions["Date"],
+		},
+		networkDeviceSoftwareSchemaNames["Status"]: {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: networkDeviceTypeVersionDescriptions["Status"],
+		},
+		networkDeviceSoftwareSchemaNames["IsStable"]: {
+			Type:        schema.TypeBool,
+			Computed:    true,
+			Description: networkDeviceTypeVersionDescriptions["IsStable"],
+		},
+		networkDeviceSoftwareSchemaNames["ReleaseNotesLink"]: {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: networkDeviceTypeVersionDescriptions["ReleaseNotesLink"],
+		},
+		networkDeviceSoftwareSchemaNames["PackageCodes"]: {
+			Type:        schema.TypeSet,
+			Computed:    true,
+			Elem:        &schema.Schema{Type: schema.TypeString},
+			Description: networkDeviceTypeVersionDescriptions["PackageCodes"],
+		},
+	}
+}
+
+func createNetworkDeviceTypePlatformSchema() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		networkDevicePlatformSchemaNames["Flavor"]: {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: networkDeviceTypePlatformDescriptions["Flavor"],
+		},
+		networkDevicePlatformSchemaNames["CoreCount"]: {
+			Type:        schema.TypeInt,
+			Computed:    true,
+			Description: networkDeviceTypePlatformDescriptions["CoreCount"],
+		},
+		networkDevicePlatformSchemaNames["Memory"]: {
+			Type:        schema.TypeInt,
+			Computed:    true,
+			Description: networkDeviceTypePlatformDescriptions["Memory"],
+		},
+		networkDevicePlatformSchemaNames["MemoryUnit"]: {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: networkDeviceTypePlatformDescriptions["MemoryUnit"],
+		},
+		networkDevicePlatformSchemaNames["PackageCodes"]: {
+			Type:        schema.TypeSet,
+			Computed:    true,
+			Elem:        &schema.Schema{Type: schema.TypeString},
+			Description: networkDeviceTypePlatformDescriptions["PackageCodes"],
+		},
+		networkDevicePlatformSchemaNames["ManagementTypes"]: {
+			Type:        schema.TypeSet,
+			Computed:    true,
+			Elem:        &schema.Schema{Type: schema.TypeString},
+			Description: networkDeviceTypePlatformDescriptions["ManagementTypes"],
+		},
+		networkDevicePlatformSchemaNames["LicenseOptions"]: {
+			Type:        schema.TypeSet,
+			Computed:    true,
+			Elem:        &schema.Schema{Type: schema.TypeString},
+			Description: networkDeviceTypePlatformDescriptions["LicenseOptions"],
 		},
 	}
 }
@@ -87,7 +219,7 @@ func dataSourceNetworkDeviceType() *schema.Resource {
 func dataSourceNetworkDeviceTypeRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	conf := m.(*config.Config)
 	var diags diag.Diagnostics
-	types, err := conf.Ne.GetDeviceTypes()
+	types, err := cachedNeDeviceTypes(conf)
 	name := d.Get(networkDeviceTypeSchemaNames["Name"]).(string)
 	vendor := d.Get(networkDeviceTypeSchemaNames["Vendor"]).(string)
 	category := d.Get(networkDeviceTypeSchemaNames["Category"]).(string)
@@ -120,6 +252,18 @@ func dataSourceNetworkDeviceTypeRead(ctx context.Context, d *schema.ResourceData
 	if err := updateNetworkDeviceTypeResource(filtered[0], d); err != nil {
 		return diag.FromErr(err)
 	}
+	typeCode := ne.StringValue(filtered[0].Code)
+	versions, err := cachedNeDeviceSoftwareVersions(conf, typeCode)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	platforms, err := cachedNeDevicePlatforms(conf, typeCode)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	if err := updateNetworkDeviceTypeMatrix(versions, platforms, d); err != nil {
+		return diag.FromErr(err)
+	}
 	return diags
 }
 
@@ -145,3 +289,67 @@ func updateNetworkDeviceTypeResource(deviceType ne.DeviceType, d *schema.Resourc
 	}
 	return nil
 }
+
+func updateNetworkDeviceTypeMatrix(versions []ne.DeviceSoftwareVersion, platforms []ne.DevicePlatform, d *schema.ResourceData) error {
+	if err := d.Set(networkDeviceTypeSchemaNames["Packages"], networkDeviceTypePackageCodes(versions, platforms)); err != nil {
+		return fmt.Errorf("error reading Packages: %s", err)
+	}
+	if err := d.Set(networkDeviceTypeSchemaNames["Versions"], flattenNetworkDeviceTypeVersions(versions)); err != nil {
+		return fmt.Errorf("error reading Versions: %s", err)
+	}
+	if err := d.Set(networkDeviceTypeSchemaNames["Platforms"], flattenNetworkDeviceTypePlatforms(platforms)); err != nil {
+		return fmt.Errorf("error reading Platforms: %s", err)
+	}
+	return nil
+}
+
+func networkDeviceTypePackageCodes(versions []ne.DeviceSoftwareVersion, platforms []ne.DevicePlatform) []string {
+	var codes []string
+	for _, version := range versions {
+		for _, code := range version.PackageCodes {
+			if !isStringInSlice(code, codes) {
+				codes = append(codes, code)
+			}
+		}
+	}
+	for _, platform := range platforms {
+		for _, code := range platform.PackageCodes {
+			if !isStringInSlice(code, codes) {
+				codes = append(codes, code)
+			}
+		}
+	}
+	return codes
+}
+
+func flattenNetworkDeviceTypeVersions(versions []ne.DeviceSoftwareVersion) interface{} {
+	transformed := make([]interface{}, len(versions))
+	for i := range versions {
+		transformed[i] = map[string]interface{}{
+			networkDeviceSoftwareSchemaNames["Version"]:          versions[i].Version,
+			networkDeviceSoftwareSchemaNames["ImageName"]:        versions[i].ImageName,
+			networkDeviceSoftwareSchemaNames["Date"]:             versions[i].Date,
+			networkDeviceSoftwareSchemaNames["Status"]:           versions[i].Status,
+			networkDeviceSoftwareSchemaNames["IsStable"]:         versions[i].IsStable,
+			networkDeviceSoftwareSchemaNames["ReleaseNotesLink"]: versions[i].ReleaseNotesLink,
+			networkDeviceSoftwareSchemaNames["PackageCodes"]:     versions[i].PackageCodes,
+		}
+	}
+	return transformed
+}
+
+func flattenNetworkDeviceTypePlatforms(platforms []ne.DevicePlatform) interface{} {
+	transformed := make([]interface{}, len(platforms))
+	for i := range platforms {
+		transformed[i] = map[string]interface{}{
+			networkDevicePlatformSchemaNames["Flavor"]:          platforms[i].Flavor,
+			networkDevicePlatformSchemaNames["CoreCount"]:       platforms[i].CoreCount,
+			networkDevicePlatformSchemaNames["Memory"]:          platforms[i].Memory,
+			networkDevicePlatformSchemaNames["MemoryUnit"]:      platforms[i].MemoryUnit,
+			networkDevicePlatformSchemaNames["PackageCodes"]:    platforms[i].PackageCodes,
+			networkDevicePlatformSchemaNames["ManagementTypes"]: platforms[i].ManagementTypes,
+			networkDevicePlatformSchemaNames["LicenseOptions"]:  platforms[i].LicenseOptions,
+		}
+	}
+	return transformed
+}
@@ -0,0 +1,54 @@
+package equinix
+
+import (
+	"testing"
+
+	"github.com/equinix/ne-go"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNetworkDeviceType_packageCodes(t *testing.T) {
+	// given
+	versions := []ne.DeviceSoftwareVersion{
+		{Version: ne.String("16.09.05"), PackageCodes: []string{"IPBASE", "SEC"}},
+		{Version: ne.String("17.03.01"), PackageCodes: []string{"IPBASE", "APPX"}},
+	}
+	platforms := []ne.DevicePlatform{
+		{Flavor: ne.String("small"), PackageCodes: []string{"IPBASE", "SEC"}},
+		{Flavor: ne.String("large"), PackageCodes: []string{"AX"}},
+	}
+	// when
+	codes := networkDeviceTypePackageCodes(versions, platforms)
+	// then
+	assert.ElementsMatch(t, []string{"IPBASE", "SEC", "APPX", "AX"}, codes, "Package codes are deduplicated across versions and platforms")
+}
+
+func TestNetworkDeviceType_flattenPlatforms(t *testing.T) {
+	// given
+	platforms := []ne.DevicePlatform{
+		{
+			Flavor:          ne.String("medium"),
+			CoreCount:       ne.Int(4),
+			Memory:          ne.Int(8),
+			MemoryUnit:      ne.String("GB"),
+			PackageCodes:    []string{"IPBASE"},
+			ManagementTypes: []string{"EQUINIX-CONFIGURED", "SELF-CONFIGURED"},
+			LicenseOptions:  []string{"BYOL", "Sub"},
+		},
+	}
+	expected := []interface{}{
+		map[string]interface{}{
+			networkDevicePlatformSchemaNames["Flavor"]:          platforms[0].Flavor,
+			networkDevicePlatformSchemaNames["CoreCount"]:       platforms[0].CoreCount,
+			networkDevicePlatformSchemaNames["Memory"]:          platforms[0].Memory,
+			networkDevicePlatformSchemaNames["MemoryUnit"]:      platforms[0].MemoryUnit,
+			networkDevicePlatformSchemaNames["PackageCodes"]:    platforms[0].PackageCodes,
+			networkDevicePlatformSchemaNames["ManagementTypes"]: platforms[0].ManagementTypes,
+			networkDevicePlatformSchemaNames["LicenseOptions"]:  platforms[0].LicenseOptions,
+		},
+	}
+	// when
+	out := flattenNetworkDeviceTypePlatforms(platforms)
+	// then
+	assert.Equal(t, expected, out, "Flattened platforms match expected result")
+}
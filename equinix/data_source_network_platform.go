@@ -110,7 +110,7 @@ func dataSourceNetworkDevicePlatformRead(ctx context.Context, d *schema.Resource
 	conf := m.(*config.Config)
 	var diags diag.Diagnostics
 	typeCode := d.Get(networkDevicePlatformSchemaNames["DeviceTypeCode"]).(string)
-	platforms, err := conf.Ne.GetDevicePlatforms(typeCode)
+	platforms, err := cachedNeDevicePlatforms(conf, typeCode)
 	if err != nil {
 		return diag.FromErr(err)
 	}
@@ -0,0 +1,102 @@
+package equinix
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/equinix/terraform-provider-equinix/internal/config"
+
+	"github.com/equinix/ne-go"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func dataSourceNetworkSSHUsers() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceNetworkSSHUsersRead,
+		Description: "Use this data source to list existing Network Edge SSH users, optionally only the users with access to a given device",
+		Schema: map[string]*schema.Schema{
+			"device_id": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.StringIsNotEmpty,
+				Description:  "Identifier of a device to list the SSH users of",
+			},
+			"ssh_users": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "List of SSH users",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						networkSSHUserSchemaNames["UUID"]: {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: networkSSHUserDescriptions["UUID"],
+						},
+						networkSSHUserSchemaNames["Username"]: {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: networkSSHUserDescriptions["Username"],
+						},
+						networkSSHUserSchemaNames["DeviceUUIDs"]: {
+							Type:        schema.TypeSet,
+							Computed:    true,
+							Elem:        &schema.Schema{Type: schema.TypeString},
+							Description: "list of device identifiers to which user has access",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceNetworkSSHUsersRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*config.Config).Ne
+	m.(*config.Config).AddModuleToNEUserAgent(&client, d)
+	users, err := client.GetSSHUsers()
+	if err != nil {
+		return diag.Errorf("failed to fetch SSH users: %s", err)
+	}
+	deviceID := d.Get("device_id").(string)
+	if err := d.Set("ssh_users", flattenNetworkSSHUsers(filterNetworkSSHUsers(users, deviceID))); err != nil {
+		return diag.FromErr(fmt.Errorf("error setting ssh_users: %s", err))
+	}
+	if deviceID != "" {
+		d.SetId(deviceID)
+	} else {
+		d.SetId("networkSSHUsers")
+	}
+	return nil
+}
+
+// filterNetworkSSHUsers returns the users with access to the given device, or
+// all the users if no device is given
+func filterNetworkSSHUsers(users []ne.SSHUser, deviceID string) []ne.SSHUser {
+	if deviceID == "" {
+		return users
+	}
+	filtered := make([]ne.SSHUser, 0, len(users))
+	for _, user := range users {
+		for _, id := range user.DeviceUUIDs {
+			if id == deviceID {
+				filtered = append(filtered, user)
+				break
+			}
+		}
+	}
+	return filtered
+}
+
+func flattenNetworkSSHUsers(users []ne.SSHUser) []interface{} {
+	flattened := make([]interface{}, 0, len(users))
+	for _, user := range users {
+		flattened = append(flattened, map[string]interface{}{
+			networkSSHUserSchemaNames["UUID"]:        ne.StringValue(user.UUID),
+			networkSSHUserSchemaNames["Username"]:    ne.StringValue(user.Username),
+			networkSSHUserSchemaNames["DeviceUUIDs"]: user.DeviceUUIDs,
+		})
+	}
+	return flattened
+}
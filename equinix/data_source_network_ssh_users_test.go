@@ -0,0 +1,26 @@
+package equinix
+
+import (
+	"testing"
+
+	"github.com/equinix/ne-go"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNetworkSSHUsers_filterAndFlatten(t *testing.T) {
+	// given
+	users := []ne.SSHUser{
+		{UUID: ne.String("user-1"), Username: ne.String("admin"), DeviceUUIDs: []string{"device-1", "device-2"}},
+		{UUID: ne.String("user-2"), Username: ne.String("ops"), DeviceUUIDs: []string{"device-2"}},
+	}
+	// when
+	all := filterNetworkSSHUsers(users, "")
+	device1 := flattenNetworkSSHUsers(filterNetworkSSHUsers(users, "device-1"))
+	// then
+	assert.Len(t, all, 2, "All users are listed without device")
+	assert.Len(t, device1, 1, "Users are filtered by device")
+	assert.Equal(t, "user-1", device1[0].(map[string]interface{})["uuid"])
+	assert.Equal(t, "admin", device1[0].(map[string]interface{})["username"])
+	assert.Equal(t, []string{"device-1", "device-2"}, device1[0].(map[string]interface{})["device_ids"])
+	assert.Empty(t, filterNetworkSSHUsers(users, "device-3"), "No users of unknown device")
+}
@@ -0,0 +1,223 @@
+package equinix
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+
+	v4 "github.com/equinix-labs/fabric-go/fabric/v4"
+	"github.com/equinix/terraform-provider-equinix/internal/config"
+	equinix_errors "github.com/equinix/terraform-provider-equinix/internal/errors"
+	equinix_fabric_schema "github.com/equinix/terraform-provider-equinix/internal/fabric/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// validateFabricOrder looks up the prices of a Fabric order instead of placing
+// it, as done when the provider dry_run flag is enabled. The matching prices
+// are reported as a warning, followed by an error that stops the apply since
+// the resource was not created.
+func validateFabricOrder(ctx context.Context, client *v4.APIClient, kind, name string, filter *v4.SearchExpression) diag.Diagnostics {
+	prices, _, err := client.PricesApi.SearchPrices(ctx, v4.FilterBody{Filter: filter})
+	if err != nil {
+		return diag.FromErr(equinix_errors.FormatFabricError(err))
+	}
+	return fabricDryRunDiagnostics(kind, name, prices.Data)
+}
+
+// validateFabricOrderPlan looks up the prices of a Fabric order while planning
+// when the provider dry_run flag is enabled, so that the order is validated
+// before anything is applied. Plans of SDKv2 resources can't carry warnings,
+// so the matching prices are reported in the error stopping the plan. Orders
+// whose prices can't be looked up are left to the check done on apply.
+func validateFabricOrderPlan(ctx context.Context, c *config.Config, kind, name string, filter *v4.SearchExpression) error {
+	ctx = context.WithValue(ctx, v4.ContextAccessToken, c.FabricAuthToken)
+	prices, _, err := c.FabricClient.PricesApi.SearchPrices(ctx, v4.FilterBody{Filter: filter})
+	if err != nil {
+		log.Printf("[WARN] Prices of Fabric %s %q can't be looked up, the order is validated on apply: %s", kind, name, equinix_errors.FormatFabricError(err))
+		return nil
+	}
+	return fabricDryRunPlanError(kind, name, prices.Data)
+}
+
+func fabricDryRunPlanError(kind, name string, prices []v4.Price) error {
+	if len(prices) == 0 {
+		return fmt.Errorf("dry_run: Fabric %s %q can not be ordered, no price was found for it", kind, name)
+	}
+	return fmt.Errorf("dry_run: Fabric %s %q order validated, it is not planned because dry_run is enabled. Matching prices:\n%s\nDisable dry_run in the provider configuration to place the order", kind, name, formatFabricPrices(prices))
+}
+
+// fabricDryRunConfig returns the provider configuration when Fabric orders are
+// validated instead of placed.
+func fabricDryRunConfig(meta interface{}) (*config.Config, bool) {
+	c, ok := meta.(*config.Config)
+	if !ok || !c.FabricDryRun || c.FabricClient == nil {
+		return nil, false
+	}
+	return c, true
+}
+
+// validateConnectionDryRun validates the creation and the bandwidth updates of
+// a connection while planning, see validateFabricOrderPlan. Connections with
+// sides not known before apply or resolved from a Metal connection are
+// validated on apply.
+func validateConnectionDryRun(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	c, ok := fabricDryRunConfig(meta)
+	if !ok {
+		return nil
+	}
+	if d.Id() != "" && !d.HasChanges("bandwidth", "bandwidth_unit") {
+		return nil
+	}
+	rawConfig := d.GetRawConfig()
+	if rawConfig.IsNull() || !rawConfig.GetAttr("metal_connection_id").IsNull() {
+		return nil
+	}
+	for _, key := range []string{"name", "type", "bandwidth", "bandwidth_unit", "a_side", "z_side"} {
+		if !rawConfig.GetAttr(key).IsWhollyKnown() {
+			return nil
+		}
+	}
+	aSide, err := connectionSideToFabric(d.Get("a_side").([]interface{}), "a_side")
+	if err != nil {
+		return nil
+	}
+	zSide, err := connectionSideToFabric(d.Get("z_side").([]interface{}), "z_side")
+	if err != nil {
+		return nil
+	}
+	conType := v4.ConnectionType(d.Get("type").(string))
+	req := v4.ConnectionPostRequest{
+		Name:      d.Get("name").(string),
+		Type_:     &conType,
+		Bandwidth: int32(equinix_fabric_schema.BandwidthToMbps(d.Get("bandwidth").(int), d.Get("bandwidth_unit").(string))),
+		ASide:     &aSide,
+		ZSide:     &zSide,
+	}
+	return validateFabricOrderPlan(ctx, c, "connection", req.Name, connectionPriceFilter(req))
+}
+
+// validateCloudRouterDryRun validates the creation and the package changes of
+// a cloud router while planning, see validateFabricOrderPlan.
+func validateCloudRouterDryRun(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	c, ok := fabricDryRunConfig(meta)
+	if !ok {
+		return nil
+	}
+	if d.Id() != "" && !d.HasChange("package") {
+		return nil
+	}
+	rawConfig := d.GetRawConfig()
+	if rawConfig.IsNull() {
+		return nil
+	}
+	for _, key := range []string{"name", "package", "location"} {
+		if !rawConfig.GetAttr(key).IsWhollyKnown() {
+			return nil
+		}
+	}
+	location := locationCloudRouterTerraToGo(d.Get("location").(*schema.Set).List())
+	packages := packageCloudRouterTerraToGo(d.Get("package").(*schema.Set).List())
+	req := v4.CloudRouterPostRequest{
+		Name:     d.Get("name").(string),
+		Location: &location,
+		Package_: &packages,
+	}
+	return validateFabricOrderPlan(ctx, c, "cloud router", req.Name, cloudRouterPriceFilter(req))
+}
+
+func fabricDryRunDiagnostics(kind, name string, prices []v4.Price) diag.Diagnostics {
+	if len(prices) == 0 {
+		return diag.Errorf("dry_run: Fabric %s %q can not be ordered, no price was found for it", kind, name)
+	}
+	return diag.Diagnostics{
+		{
+			Severity: diag.Warning,
+			Summary:  fmt.Sprintf("Fabric %s %q order validated", kind, name),
+			Detail:   formatFabricPrices(prices),
+		},
+		{
+			Severity: diag.Error,
+			Summary:  fmt.Sprintf("Fabric %s %q was not ordered because dry_run is enabled", kind, name),
+			Detail:   "Disable dry_run in the provider configuration to place the order",
+		},
+	}
+}
+
+func formatFabricPrices(prices []v4.Price) string {
+	lines := make([]string, 0, len(prices))
+	for _, price := range prices {
+		charges := make([]string, 0, len(price.Charges))
+		for _, charge := range price.Charges {
+			charges = append(charges, fmt.Sprintf("%s %s %s", charge.Type_, strconv.FormatFloat(charge.Price, 'f', -1, 64), price.Currency))
+		}
+		term := "no term"
+		if price.TermLength > 0 {
+			term = fmt.Sprintf("%d months term", price.TermLength)
+		}
+		lines = append(lines, fmt.Sprintf("%s (%s, %s): %s", price.Name, price.Code, term, strings.Join(charges, ", ")))
+	}
+	return strings.Join(lines, "\n")
+}
+
+func fabricPriceExpression(property string, values ...string) v4.SearchExpression {
+	return v4.SearchExpression{Property: property, Operator: "=", Values: values}
+}
+
+func connectionPriceFilter(req v4.ConnectionPostRequest) *v4.SearchExpression {
+	and := []v4.SearchExpression{
+		fabricPriceExpression("/type", string(v4.VIRTUAL_CONNECTION_PRODUCT_ProductType)),
+		fabricPriceExpression("/connection/bandwidth", strconv.Itoa(int(req.Bandwidth))),
+	}
+	if req.Type_ != nil {
+		and = append(and, fabricPriceExpression("/connection/type", string(*req.Type_)))
+	}
+	and = append(and, connectionSidePriceExpressions("aSide", req.ASide)...)
+	and = append(and, connectionSidePriceExpressions("zSide", req.ZSide)...)
+	return &v4.SearchExpression{And: &and}
+}
+
+func connectionSidePriceExpressions(side string, cs *v4.ConnectionSide) []v4.SearchExpression {
+	if cs == nil || cs.AccessPoint == nil {
+		return nil
+	}
+	prefix := "/connection/" + side + "/accessPoint/"
+	var expressions []v4.SearchExpression
+	ap := cs.AccessPoint
+	if ap.Type_ != nil {
+		expressions = append(expressions, fabricPriceExpression(prefix+"type", string(*ap.Type_)))
+	}
+	if ap.Location != nil && ap.Location.MetroCode != "" {
+		expressions = append(expressions, fabricPriceExpression(prefix+"location/metroCode", ap.Location.MetroCode))
+	}
+	if ap.Profile != nil && ap.Profile.Uuid != "" {
+		expressions = append(expressions, fabricPriceExpression(prefix+"profile/uuid", ap.Profile.Uuid))
+	}
+	return expressions
+}
+
+// connectionBandwidthPriceFilter returns the price filter of the existing
+// connection ordered with another bandwidth, in Mbps.
+func connectionBandwidthPriceFilter(conn v4.Connection, bandwidth int32) *v4.SearchExpression {
+	return connectionPriceFilter(v4.ConnectionPostRequest{
+		Type_:     conn.Type_,
+		Bandwidth: bandwidth,
+		ASide:     conn.ASide,
+		ZSide:     conn.ZSide,
+	})
+}
+
+func cloudRouterPriceFilter(req v4.CloudRouterPostRequest) *v4.SearchExpression {
+	and := []v4.SearchExpression{
+		fabricPriceExpression("/type", string(v4.CLOUD_ROUTER_PRODUCT_ProductType)),
+	}
+	if req.Package_ != nil {
+		and = append(and, fabricPriceExpression("/router/package/code", req.Package_.Code))
+	}
+	if req.Location != nil && req.Location.MetroCode != "" {
+		and = append(and, fabricPriceExpression("/router/location/metroCode", req.Location.MetroCode))
+	}
+	return &v4.SearchExpression{And: &and}
+}
@@ -0,0 +1,89 @@
+package equinix
+
+import (
+	"testing"
+
+	v4 "github.com/equinix-labs/fabric-go/fabric/v4"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFabricDryRun_connectionPriceFilter(t *testing.T) {
+	// given
+	connType := v4.EVPL_VC_ConnectionType
+	apType := v4.COLO_AccessPointType
+	req := v4.ConnectionPostRequest{
+		Type_:     &connType,
+		Bandwidth: 50,
+		ASide: &v4.ConnectionSide{AccessPoint: &v4.AccessPoint{
+			Type_:    &apType,
+			Location: &v4.SimplifiedLocation{MetroCode: "SV"},
+		}},
+		ZSide: &v4.ConnectionSide{ServiceToken: &v4.ServiceToken{Uuid: "token"}},
+	}
+	// when
+	filter := connectionPriceFilter(req)
+	// then
+	assert.Equal(t, []v4.SearchExpression{
+		{Property: "/type", Operator: "=", Values: []string{"VIRTUAL_CONNECTION_PRODUCT"}},
+		{Property: "/connection/bandwidth", Operator: "=", Values: []string{"50"}},
+		{Property: "/connection/type", Operator: "=", Values: []string{"EVPL_VC"}},
+		{Property: "/connection/aSide/accessPoint/type", Operator: "=", Values: []string{"COLO"}},
+		{Property: "/connection/aSide/accessPoint/location/metroCode", Operator: "=", Values: []string{"SV"}},
+	}, *filter.And, "Filter matches the ordered connection")
+}
+
+func TestFabricDryRun_diagnostics(t *testing.T) {
+	// given
+	prices := []v4.Price{{
+		Name:       "Virtual Connection",
+		Code:       "VC-50",
+		Currency:   "USD",
+		TermLength: 12,
+		Charges:    []v4.PriceCharge{{Type_: "MONTHLY_RECURRING", Price: 150.5}},
+	}}
+	// when
+	validated := fabricDryRunDiagnostics("connection", "test", prices)
+	invalid := fabricDryRunDiagnostics("connection", "test", nil)
+	// then
+	assert.Len(t, validated, 2)
+	assert.Equal(t, diag.Warning, validated[0].Severity, "Prices are reported as a warning")
+	assert.Equal(t, "Virtual Connection (VC-50, 12 months term): MONTHLY_RECURRING 150.5 USD", validated[0].Detail)
+	assert.True(t, validated.HasError(), "Apply fails since the order was not placed")
+	assert.True(t, invalid.HasError(), "Order without prices is invalid")
+}
+
+func TestFabricDryRun_planError(t *testing.T) {
+	// given
+	prices := []v4.Price{{
+		Name:     "Cloud Router",
+		Code:     "FCR-STANDARD",
+		Currency: "USD",
+		Charges:  []v4.PriceCharge{{Type_: "MONTHLY_RECURRING", Price: 300}},
+	}}
+	// when
+	validated := fabricDryRunPlanError("cloud router", "test", prices)
+	invalid := fabricDryRunPlanError("cloud router", "test", nil)
+	// then
+	assert.ErrorContains(t, validated, "Cloud Router (FCR-STANDARD, no term): MONTHLY_RECURRING 300 USD", "Plan reports the prices")
+	assert.ErrorContains(t, invalid, "no price was found", "Order without prices is invalid")
+}
+
+func TestFabricDryRun_connectionBandwidthPriceFilter(t *testing.T) {
+	// given
+	connType := v4.EVPL_VC_ConnectionType
+	conn := v4.Connection{
+		Type_:     &connType,
+		Bandwidth: 50,
+		ASide:     &v4.ConnectionSide{AccessPoint: &v4.AccessPoint{Location: &v4.SimplifiedLocation{MetroCode: "SV"}}},
+	}
+	// when
+	filter := connectionBandwidthPriceFilter(conn, 100)
+	// then
+	assert.Equal(t, []v4.SearchExpression{
+		{Property: "/type", Operator: "=", Values: []string{"VIRTUAL_CONNECTION_PRODUCT"}},
+		{Property: "/connection/bandwidth", Operator: "=", Values: []string{"100"}},
+		{Property: "/connection/type", Operator: "=", Values: []string{"EVPL_VC"}},
+		{Property: "/connection/aSide/accessPoint/location/metroCode", Operator: "=", Values: []string{"SV"}},
+	}, *filter.And, "Filter matches the connection with the updated bandwidth")
+}
@@ -1,272 +1,529 @@
 package equinix
 
 import (
+	"errors"
 	"fmt"
+	"github.com/equinix/terraform-provider-equinix/internal/converters"
 	equinix_schema "github.com/equinix/terraform-provider-equinix/internal/fabric/schema"
 	"log"
+	"reflect"
+	"strconv"
+	"strings"
+	"unicode"
 
 	v4 "github.com/equinix-labs/fabric-go/fabric/v4"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"golang.org/x/exp/slices"
 )
 
-func serviceTokenToFabric(serviceTokenRequest []interface{}) (v4.ServiceToken, error) {
-	mappedST := v4.ServiceToken{}
-	for _, str := range serviceTokenRequest {
-		stMap := str.(map[string]interface{})
-		stType := stMap["type"].(string)
-		uuid := stMap["uuid"].(string)
-		if stType != "" {
-			if stType != "VC_TOKEN" {
-				return v4.ServiceToken{}, fmt.Errorf("invalid service token type in config. Must be: VC_TOKEN; Received: %s", stType)
+// fabricErrorAttributePath returns the attribute path, in the given resource
+// schema, of the request property a Fabric API error is about, e.g.
+// "z_side.0.access_point.0.profile.0.uuid" for "/zSide/accessPoint/profile/uuid".
+// It is empty if no property of the error matches an attribute.
+func fabricErrorAttributePath(resourceSchema map[string]*schema.Schema, err error) string {
+	var fabricErr v4.GenericSwaggerError
+	if !errors.As(err, &fabricErr) {
+		return ""
+	}
+	modelErrors, _ := fabricErr.Model().([]v4.ModelError)
+	for _, modelError := range modelErrors {
+		for _, info := range modelError.AdditionalInfo {
+			if attributePath := fabricPropertyAttributePath(resourceSchema, info.Property); attributePath != "" {
+				return attributePath
 			}
-			stTypeObj := v4.ServiceTokenType(stType)
-			mappedST = v4.ServiceToken{Uuid: uuid, Type_: &stTypeObj}
-		} else {
-			mappedST = v4.ServiceToken{Uuid: uuid}
 		}
-
 	}
-	return mappedST, nil
+	return ""
 }
 
-func additionalInfoTerraToGo(additionalInfoRequest []interface{}) []v4.ConnectionSideAdditionalInfo {
-	var mappedaiArray []v4.ConnectionSideAdditionalInfo
-	for _, ai := range additionalInfoRequest {
-		aiMap := ai.(map[string]interface{})
-		key := aiMap["key"].(string)
-		value := aiMap["value"].(string)
-		mappedai := v4.ConnectionSideAdditionalInfo{Key: key, Value: value}
-		mappedaiArray = append(mappedaiArray, mappedai)
+// fabricPropertyAttributePath returns the attribute path of a Fabric request
+// property, given as a JSON pointer or dot separated, empty if the property is
+// not an attribute of the schema. Blocks are lists configured once, so their
+// index defaults to 0.
+func fabricPropertyAttributePath(resourceSchema map[string]*schema.Schema, property string) string {
+	var steps []string
+	current := resourceSchema
+	for _, name := range strings.FieldsFunc(property, func(r rune) bool { return r == '/' || r == '.' }) {
+		if _, err := strconv.Atoi(name); err == nil && len(steps) != 0 && steps[len(steps)-1] == "0" {
+			steps[len(steps)-1] = name
+			continue
+		}
+		if current == nil {
+			return ""
+		}
+		attribute, ok := current[snakeCaseName(name)]
+		if !ok {
+			return ""
+		}
+		steps = append(steps, snakeCaseName(name))
+		current = nil
+		if block, ok := attribute.Elem.(*schema.Resource); ok {
+			steps = append(steps, "0")
+			current = block.Schema
+		}
+	}
+	if len(steps) != 0 && steps[len(steps)-1] == "0" {
+		steps = steps[:len(steps)-1]
 	}
-	return mappedaiArray
+	return strings.Join(steps, ".")
 }
 
-func accessPointToFabric(accessPointRequest []interface{}) v4.AccessPoint {
-	accessPoint := v4.AccessPoint{}
-	for _, ap := range accessPointRequest {
-		accessPointMap := ap.(map[string]interface{})
-		portList := accessPointMap["port"].(*schema.Set).List()
-		profileList := accessPointMap["profile"].(*schema.Set).List()
-		locationList := accessPointMap["location"].(*schema.Set).List()
-		virtualdeviceList := accessPointMap["virtual_device"].(*schema.Set).List()
-		interfaceList := accessPointMap["interface"].(*schema.Set).List()
-		networkList := accessPointMap["network"].(*schema.Set).List()
-		typeVal := accessPointMap["type"].(string)
-		authenticationKey := accessPointMap["authentication_key"].(string)
-		if authenticationKey != "" {
-			accessPoint.AuthenticationKey = authenticationKey
-		}
-		providerConnectionId := accessPointMap["provider_connection_id"].(string)
-		if providerConnectionId != "" {
-			accessPoint.ProviderConnectionId = providerConnectionId
-		}
-		sellerRegion := accessPointMap["seller_region"].(string)
-		if sellerRegion != "" {
-			accessPoint.SellerRegion = sellerRegion
-		}
-		peeringTypeRaw := accessPointMap["peering_type"].(string)
-		if peeringTypeRaw != "" {
-			peeringType := v4.PeeringType(peeringTypeRaw)
-			accessPoint.PeeringType = &peeringType
-		}
-		cloudRouterRequest := accessPointMap["router"].(*schema.Set).List()
-		if len(cloudRouterRequest) == 0 {
-			log.Print("[DEBUG] The router attribute was not used, attempting to revert to deprecated gateway attribute")
-			cloudRouterRequest = accessPointMap["gateway"].(*schema.Set).List()
-		}
-
-		if len(cloudRouterRequest) != 0 {
-			mappedGWr := cloudRouterToFabric(cloudRouterRequest)
-			if mappedGWr.Uuid != "" {
-				accessPoint.Router = &mappedGWr
-			}
-		}
-		apt := v4.AccessPointType(typeVal)
-		accessPoint.Type_ = &apt
-		if len(portList) != 0 {
-			port := portToFabric(portList)
-			if port.Uuid != "" {
-				accessPoint.Port = &port
+// snakeCaseName returns the snake case form of a camel case Fabric property
+// name, e.g. "access_point" for "accessPoint".
+func snakeCaseName(name string) string {
+	var b strings.Builder
+	for i, r := range name {
+		if unicode.IsUpper(r) {
+			if i != 0 {
+				b.WriteByte('_')
 			}
+			r = unicode.ToLower(r)
 		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
 
-		if len(networkList) != 0 {
-			network := networkToFabric(networkList)
-			if network.Uuid != "" {
-				accessPoint.Network = &network
-			}
-		}
-		linkProtocolList := accessPointMap["link_protocol"].(*schema.Set).List()
+// terraBlock returns the attributes of a block configured at most once. It
+// returns nil if the block is not set, or set without any attribute.
+func terraBlock(blockList []interface{}, block string) (map[string]interface{}, error) {
+	if len(blockList) == 0 || blockList[0] == nil {
+		return nil, nil
+	}
+	if len(blockList) > 1 {
+		return nil, fmt.Errorf("at most one %s block is allowed, got %d", block, len(blockList))
+	}
+	attrs, ok := blockList[0].(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("invalid %s block, expected attributes, got %T", block, blockList[0])
+	}
+	return attrs, nil
+}
 
-		if len(linkProtocolList) != 0 {
-			slp := linkProtocolToFabric(linkProtocolList)
-			if slp.Type_ != nil {
-				accessPoint.LinkProtocol = &slp
-			}
-		}
+// terraStringAttr returns a string attribute of a block, or "" if it is not set.
+func terraStringAttr(attrs map[string]interface{}, block, key string) (string, error) {
+	raw, ok := attrs[key]
+	if !ok || raw == nil {
+		return "", nil
+	}
+	v, ok := raw.(string)
+	if !ok {
+		return "", fmt.Errorf("invalid %s.%s, expected a string, got %T", block, key, raw)
+	}
+	return v, nil
+}
 
-		if len(profileList) != 0 {
-			ssp := simplifiedServiceProfileToFabric(profileList)
-			if ssp.Uuid != "" {
-				accessPoint.Profile = &ssp
-			}
-		}
+// terraIntAttr returns an integer attribute of a block, or 0 if it is not set.
+func terraIntAttr(attrs map[string]interface{}, block, key string) (int, error) {
+	raw, ok := attrs[key]
+	if !ok || raw == nil {
+		return 0, nil
+	}
+	v, ok := raw.(int)
+	if !ok {
+		return 0, fmt.Errorf("invalid %s.%s, expected an integer, got %T", block, key, raw)
+	}
+	return v, nil
+}
+
+// terraBoolAttr returns a boolean attribute of a block, or false if it is not set.
+func terraBoolAttr(attrs map[string]interface{}, block, key string) (bool, error) {
+	raw, ok := attrs[key]
+	if !ok || raw == nil {
+		return false, nil
+	}
+	v, ok := raw.(bool)
+	if !ok {
+		return false, fmt.Errorf("invalid %s.%s, expected a boolean, got %T", block, key, raw)
+	}
+	return v, nil
+}
 
-		if len(locationList) != 0 {
-			sl := equinix_schema.LocationToFabric(locationList)
-			accessPoint.Location = &sl
+// terraListAttr returns a nested block list attribute of a block, or nil if it
+// is not set.
+func terraListAttr(attrs map[string]interface{}, block, key string) ([]interface{}, error) {
+	raw, ok := attrs[key]
+	if !ok || raw == nil {
+		return nil, nil
+	}
+	v, ok := raw.([]interface{})
+	if !ok {
+		return nil, fmt.Errorf("invalid %s.%s, expected a list, got %T", block, key, raw)
+	}
+	return v, nil
+}
+
+// terraUuidBlock returns the uuid of a block referencing another resource, or
+// "" if the block is not set. A block set without uuid is an error, as the
+// resource it references can't be resolved.
+func terraUuidBlock(blockList []interface{}, block string) (map[string]interface{}, string, error) {
+	attrs, err := terraBlock(blockList, block)
+	if err != nil || attrs == nil {
+		return nil, "", err
+	}
+	uuid, err := terraStringAttr(attrs, block, "uuid")
+	if err != nil {
+		return nil, "", err
+	}
+	if uuid == "" {
+		return nil, "", fmt.Errorf("%s.uuid is required when the %s block is set", block, block)
+	}
+	return attrs, uuid, nil
+}
+
+func serviceTokenToFabric(serviceTokenRequest []interface{}) (v4.ServiceToken, error) {
+	stMap, err := terraBlock(serviceTokenRequest, "service_token")
+	if err != nil || stMap == nil {
+		return v4.ServiceToken{}, err
+	}
+	stType, err := terraStringAttr(stMap, "service_token", "type")
+	if err != nil {
+		return v4.ServiceToken{}, err
+	}
+	uuid, err := terraStringAttr(stMap, "service_token", "uuid")
+	if err != nil {
+		return v4.ServiceToken{}, err
+	}
+	if uuid == "" {
+		return v4.ServiceToken{}, fmt.Errorf("service_token.uuid is required when the service_token block is set")
+	}
+	if stType == "" {
+		return v4.ServiceToken{Uuid: uuid}, nil
+	}
+	stTypeObj := v4.ServiceTokenType(strings.ToUpper(stType))
+	if stTypeObj != v4.VC_TOKEN_ServiceTokenType {
+		return v4.ServiceToken{}, fmt.Errorf("invalid service token type in config. Must be: VC_TOKEN; Received: %s", stType)
+	}
+	return v4.ServiceToken{Uuid: uuid, Type_: &stTypeObj}, nil
+}
+
+// connectionSideToFabric returns the a_side or z_side of a connection. A side
+// is either given by a service token, of which Equinix resolves the access
+// point, or by an access point.
+func connectionSideToFabric(sideRequest []interface{}, side string) (v4.ConnectionSide, error) {
+	sideMap, err := terraBlock(sideRequest, side)
+	if err != nil || sideMap == nil {
+		return v4.ConnectionSide{}, err
+	}
+	lists := map[string][]interface{}{}
+	for _, key := range []string{"service_token", "access_point", "additional_info", "invitation"} {
+		if lists[key], err = terraListAttr(sideMap, side, key); err != nil {
+			return v4.ConnectionSide{}, err
 		}
+	}
+	if len(lists["service_token"]) != 0 && len(lists["access_point"]) != 0 {
+		return v4.ConnectionSide{}, fmt.Errorf("%s can't have both a service_token and an access_point, the access point is resolved from the service token", side)
+	}
 
-		if len(virtualdeviceList) != 0 {
-			vd := virtualdeviceToFabric(virtualdeviceList)
-			accessPoint.VirtualDevice = &vd
+	connectionSide := v4.ConnectionSide{}
+	if len(lists["service_token"]) != 0 {
+		serviceToken, err := serviceTokenToFabric(lists["service_token"])
+		if err != nil {
+			return v4.ConnectionSide{}, fmt.Errorf("invalid %s: %s", side, err)
+		}
+		connectionSide.ServiceToken = &serviceToken
+	}
+	if len(lists["access_point"]) != 0 {
+		accessPoint, err := accessPointToFabric(lists["access_point"])
+		if err != nil {
+			return v4.ConnectionSide{}, fmt.Errorf("invalid %s: %s", side, err)
+		}
+		connectionSide.AccessPoint = &accessPoint
+	}
+	if len(lists["additional_info"]) != 0 {
+		if connectionSide.AdditionalInfo, err = additionalInfoTerraToGo(lists["additional_info"]); err != nil {
+			return v4.ConnectionSide{}, fmt.Errorf("invalid %s: %s", side, err)
 		}
+	}
+	if len(lists["invitation"]) != 0 {
+		if connectionSide.Invitation, err = connectionInvitationToFabric(lists["invitation"], side); err != nil {
+			return v4.ConnectionSide{}, err
+		}
+	}
+	return connectionSide, nil
+}
 
-		if len(interfaceList) != 0 {
-			il := interfaceToFabric(interfaceList)
-			accessPoint.Interface_ = &il
+func connectionInvitationToFabric(invitationList []interface{}, side string) (*v4.ConnectionInvitation, error) {
+	block := side + ".invitation"
+	invitationMap, err := terraBlock(invitationList, block)
+	if err != nil || invitationMap == nil {
+		return nil, err
+	}
+	invitation := &v4.ConnectionInvitation{}
+	for attr, value := range map[string]*string{
+		"email":              &invitation.Email,
+		"message":            &invitation.Message,
+		"ctr_draft_order_id": &invitation.CtrDraftOrderId,
+	} {
+		if *value, err = terraStringAttr(invitationMap, block, attr); err != nil {
+			return nil, err
 		}
+	}
+	return invitation, nil
+}
 
+func additionalInfoTerraToGo(additionalInfoRequest []interface{}) ([]v4.ConnectionSideAdditionalInfo, error) {
+	var mappedaiArray []v4.ConnectionSideAdditionalInfo
+	for i, ai := range additionalInfoRequest {
+		aiMap, ok := ai.(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("invalid additional_info.%d, expected attributes, got %T", i, ai)
+		}
+		key, err := terraStringAttr(aiMap, "additional_info", "key")
+		if err != nil {
+			return nil, err
+		}
+		if key == "" {
+			return nil, fmt.Errorf("additional_info.%d.key is required", i)
+		}
+		value, err := terraStringAttr(aiMap, "additional_info", "value")
+		if err != nil {
+			return nil, err
+		}
+		mappedaiArray = append(mappedaiArray, v4.ConnectionSideAdditionalInfo{Key: key, Value: value})
 	}
-	return accessPoint
+	return mappedaiArray, nil
 }
 
-func cloudRouterToFabric(cloudRouterRequest []interface{}) v4.CloudRouter {
-	if cloudRouterRequest == nil {
-		return v4.CloudRouter{}
+func accessPointToFabric(accessPointRequest []interface{}) (v4.AccessPoint, error) {
+	accessPoint := v4.AccessPoint{}
+	accessPointMap, err := terraBlock(accessPointRequest, "access_point")
+	if err != nil || accessPointMap == nil {
+		return accessPoint, err
+	}
+	typeVal, err := terraStringAttr(accessPointMap, "access_point", "type")
+	if err != nil {
+		return accessPoint, err
+	}
+	if typeVal == "" {
+		return accessPoint, fmt.Errorf("access_point.type is required")
+	}
+	apt := v4.AccessPointType(typeVal)
+	accessPoint.Type_ = &apt
+
+	if accessPoint.AuthenticationKey, err = terraStringAttr(accessPointMap, "access_point", "authentication_key"); err != nil {
+		return accessPoint, err
+	}
+	if accessPoint.ProviderConnectionId, err = terraStringAttr(accessPointMap, "access_point", "provider_connection_id"); err != nil {
+		return accessPoint, err
+	}
+	if accessPoint.SellerRegion, err = terraStringAttr(accessPointMap, "access_point", "seller_region"); err != nil {
+		return accessPoint, err
+	}
+	peeringTypeRaw, err := terraStringAttr(accessPointMap, "access_point", "peering_type")
+	if err != nil {
+		return accessPoint, err
+	}
+	if peeringTypeRaw != "" {
+		peeringType := v4.PeeringType(peeringTypeRaw)
+		accessPoint.PeeringType = &peeringType
+	}
+
+	lists := map[string][]interface{}{}
+	for _, key := range []string{"port", "profile", "location", "router", "gateway", "link_protocol", "virtual_device", "interface", "network"} {
+		if lists[key], err = terraListAttr(accessPointMap, "access_point", key); err != nil {
+			return accessPoint, err
+		}
+	}
+
+	cloudRouterRequest := lists["router"]
+	if len(cloudRouterRequest) == 0 {
+		log.Print("[DEBUG] The router attribute was not used, attempting to revert to deprecated gateway attribute")
+		cloudRouterRequest = lists["gateway"]
 	}
-	cloudRouterMapped := v4.CloudRouter{}
-	for _, crr := range cloudRouterRequest {
-		crrMap := crr.(map[string]interface{})
-		cruuid := crrMap["uuid"].(string)
-		cloudRouterMapped = v4.CloudRouter{Uuid: cruuid}
+	if len(cloudRouterRequest) != 0 {
+		mappedGWr, err := cloudRouterToFabric(cloudRouterRequest)
+		if err != nil {
+			return accessPoint, err
+		}
+		accessPoint.Router = &mappedGWr
 	}
-	return cloudRouterMapped
+	if len(lists["port"]) != 0 {
+		port, err := portToFabric(lists["port"])
+		if err != nil {
+			return accessPoint, err
+		}
+		accessPoint.Port = &port
+	}
+	if len(lists["network"]) != 0 {
+		network, err := networkToFabric(lists["network"])
+		if err != nil {
+			return accessPoint, err
+		}
+		accessPoint.Network = &network
+	}
+	if len(lists["link_protocol"]) != 0 {
+		slp, err := linkProtocolToFabric(lists["link_protocol"])
+		if err != nil {
+			return accessPoint, err
+		}
+		accessPoint.LinkProtocol = &slp
+	}
+	if len(lists["profile"]) != 0 {
+		ssp, err := simplifiedServiceProfileToFabric(lists["profile"])
+		if err != nil {
+			return accessPoint, err
+		}
+		accessPoint.Profile = &ssp
+	}
+	if len(lists["location"]) != 0 {
+		sl := equinix_schema.LocationToFabric(lists["location"])
+		accessPoint.Location = &sl
+	}
+	if len(lists["virtual_device"]) != 0 {
+		vd, err := virtualdeviceToFabric(lists["virtual_device"])
+		if err != nil {
+			return accessPoint, err
+		}
+		accessPoint.VirtualDevice = &vd
+	}
+	if len(lists["interface"]) != 0 {
+		il, err := interfaceToFabric(lists["interface"])
+		if err != nil {
+			return accessPoint, err
+		}
+		accessPoint.Interface_ = &il
+	}
+	return accessPoint, nil
 }
 
-func linkProtocolToFabric(linkProtocolList []interface{}) v4.SimplifiedLinkProtocol {
-	slp := v4.SimplifiedLinkProtocol{}
-	for _, lp := range linkProtocolList {
-		lpMap := lp.(map[string]interface{})
-		lpType := lpMap["type"].(string)
-		lpVlanSTag := lpMap["vlan_s_tag"].(int)
-		lpVlanTag := lpMap["vlan_tag"].(int)
-		lpVlanCTag := lpMap["vlan_c_tag"].(int)
-		lpt := v4.LinkProtocolType(lpType)
-		slp = v4.SimplifiedLinkProtocol{Type_: &lpt, VlanSTag: int32(lpVlanSTag), VlanTag: int32(lpVlanTag), VlanCTag: int32(lpVlanCTag)}
+func cloudRouterToFabric(cloudRouterRequest []interface{}) (v4.CloudRouter, error) {
+	_, uuid, err := terraUuidBlock(cloudRouterRequest, "router")
+	if err != nil {
+		return v4.CloudRouter{}, err
 	}
-	return slp
+	return v4.CloudRouter{Uuid: uuid}, nil
 }
 
-func networkToFabric(networkList []interface{}) v4.SimplifiedNetwork {
-	p := v4.SimplifiedNetwork{}
-	for _, pl := range networkList {
-		plMap := pl.(map[string]interface{})
-		uuid := plMap["uuid"].(string)
-		p = v4.SimplifiedNetwork{Uuid: uuid}
+func linkProtocolToFabric(linkProtocolList []interface{}) (v4.SimplifiedLinkProtocol, error) {
+	lpMap, err := terraBlock(linkProtocolList, "link_protocol")
+	if err != nil || lpMap == nil {
+		return v4.SimplifiedLinkProtocol{}, err
+	}
+	lpType, err := terraStringAttr(lpMap, "link_protocol", "type")
+	if err != nil {
+		return v4.SimplifiedLinkProtocol{}, err
+	}
+	if lpType == "" {
+		return v4.SimplifiedLinkProtocol{}, fmt.Errorf("link_protocol.type is required when the link_protocol block is set")
 	}
-	return p
+	tags := map[string]int{}
+	for _, key := range []string{"vlan_tag", "vlan_s_tag", "vlan_c_tag"} {
+		if tags[key], err = terraIntAttr(lpMap, "link_protocol", key); err != nil {
+			return v4.SimplifiedLinkProtocol{}, err
+		}
+	}
+	lpt := v4.LinkProtocolType(lpType)
+	return v4.SimplifiedLinkProtocol{Type_: &lpt, VlanSTag: int32(tags["vlan_s_tag"]), VlanTag: int32(tags["vlan_tag"]), VlanCTag: int32(tags["vlan_c_tag"])}, nil
 }
 
-func simplifiedServiceProfileToFabric(profileList []interface{}) v4.SimplifiedServiceProfile {
-	ssp := v4.SimplifiedServiceProfile{}
-	for _, pl := range profileList {
-		plMap := pl.(map[string]interface{})
-		ptype := plMap["type"].(string)
-		spte := v4.ServiceProfileTypeEnum(ptype)
-		uuid := plMap["uuid"].(string)
-		ssp = v4.SimplifiedServiceProfile{Uuid: uuid, Type_: &spte}
+func networkToFabric(networkList []interface{}) (v4.SimplifiedNetwork, error) {
+	_, uuid, err := terraUuidBlock(networkList, "network")
+	if err != nil {
+		return v4.SimplifiedNetwork{}, err
+	}
+	return v4.SimplifiedNetwork{Uuid: uuid}, nil
+}
 
+func simplifiedServiceProfileToFabric(profileList []interface{}) (v4.SimplifiedServiceProfile, error) {
+	plMap, uuid, err := terraUuidBlock(profileList, "profile")
+	if err != nil || plMap == nil {
+		return v4.SimplifiedServiceProfile{}, err
+	}
+	ptype, err := terraStringAttr(plMap, "profile", "type")
+	if err != nil {
+		return v4.SimplifiedServiceProfile{}, err
 	}
-	return ssp
+	spte := v4.ServiceProfileTypeEnum(ptype)
+	return v4.SimplifiedServiceProfile{Uuid: uuid, Type_: &spte}, nil
 }
 
-func virtualdeviceToFabric(virtualdeviceList []interface{}) v4.VirtualDevice {
-	vd := v4.VirtualDevice{}
-	for _, ll := range virtualdeviceList {
-		llMap := ll.(map[string]interface{})
-		hr := llMap["href"].(string)
-		tp := llMap["type"].(string)
-		ud := llMap["uuid"].(string)
-		na := llMap["name"].(string)
-		vd = v4.VirtualDevice{Href: hr, Type_: tp, Uuid: ud, Name: na}
+func virtualdeviceToFabric(virtualdeviceList []interface{}) (v4.VirtualDevice, error) {
+	llMap, uuid, err := terraUuidBlock(virtualdeviceList, "virtual_device")
+	if err != nil || llMap == nil {
+		return v4.VirtualDevice{}, err
 	}
-	return vd
+	vd := v4.VirtualDevice{Uuid: uuid}
+	if vd.Href, err = terraStringAttr(llMap, "virtual_device", "href"); err != nil {
+		return v4.VirtualDevice{}, err
+	}
+	if vd.Type_, err = terraStringAttr(llMap, "virtual_device", "type"); err != nil {
+		return v4.VirtualDevice{}, err
+	}
+	if vd.Name, err = terraStringAttr(llMap, "virtual_device", "name"); err != nil {
+		return v4.VirtualDevice{}, err
+	}
+	return vd, nil
 }
 
-func interfaceToFabric(interfaceList []interface{}) v4.ModelInterface {
+func interfaceToFabric(interfaceList []interface{}) (v4.ModelInterface, error) {
+	llMap, err := terraBlock(interfaceList, "interface")
+	if err != nil || llMap == nil {
+		return v4.ModelInterface{}, err
+	}
 	il := v4.ModelInterface{}
-	for _, ll := range interfaceList {
-		llMap := ll.(map[string]interface{})
-		ud := llMap["uuid"].(string)
-		tp := llMap["type"].(string)
-		id := llMap["id"].(int)
-		il = v4.ModelInterface{Type_: tp, Uuid: ud, Id: int32(id)}
+	if il.Uuid, err = terraStringAttr(llMap, "interface", "uuid"); err != nil {
+		return v4.ModelInterface{}, err
 	}
-	return il
+	if il.Type_, err = terraStringAttr(llMap, "interface", "type"); err != nil {
+		return v4.ModelInterface{}, err
+	}
+	id, err := terraIntAttr(llMap, "interface", "id")
+	if err != nil {
+		return v4.ModelInterface{}, err
+	}
+	il.Id = int32(id)
+	return il, nil
 }
 
 func operationToTerra(operation *v4.ConnectionOperation) *schema.Set {
 	if operation == nil {
 		return nil
 	}
-	operations := []*v4.ConnectionOperation{operation}
-	mappedOperations := make([]interface{}, len(operations))
-	for _, operation := range operations {
-		mappedOperation := make(map[string]interface{})
-		mappedOperation["provider_status"] = string(*operation.ProviderStatus)
-		mappedOperation["equinix_status"] = string(*operation.EquinixStatus)
-		if operation.Errors != nil {
-			mappedOperation["errors"] = equinix_schema.ErrorToTerra(operation.Errors)
-		}
-		mappedOperations = append(mappedOperations, mappedOperation)
+	mappedOperation := make(map[string]interface{})
+	mappedOperation["provider_status"] = string(*operation.ProviderStatus)
+	mappedOperation["equinix_status"] = string(*operation.EquinixStatus)
+	if operation.Errors != nil {
+		mappedOperation["errors"] = equinix_schema.ErrorToTerra(operation.Errors)
 	}
 	operationSet := schema.NewSet(
 		schema.HashResource(&schema.Resource{Schema: operationSch()}),
-		mappedOperations,
+		[]interface{}{mappedOperation},
 	)
 	return operationSet
 }
 
-func serviceTokenToTerra(serviceToken *v4.ServiceToken) *schema.Set {
+func serviceTokenToTerra(serviceToken *v4.ServiceToken) []interface{} {
 	if serviceToken == nil {
 		return nil
 	}
-	serviceTokens := []*v4.ServiceToken{serviceToken}
-	mappedServiceTokens := make([]interface{}, len(serviceTokens))
-	for _, serviceToken := range serviceTokens {
-		mappedServiceToken := make(map[string]interface{})
-		if serviceToken.Type_ != nil {
-			mappedServiceToken["type"] = string(*serviceToken.Type_)
-		}
-		mappedServiceToken["href"] = serviceToken.Href
-		mappedServiceToken["uuid"] = serviceToken.Uuid
-		mappedServiceTokens = append(mappedServiceTokens, mappedServiceToken)
+	mappedServiceToken := make(map[string]interface{})
+	if serviceToken.Type_ != nil {
+		mappedServiceToken["type"] = string(*serviceToken.Type_)
 	}
-	serviceTokenSet := schema.NewSet(
-		schema.HashResource(&schema.Resource{Schema: serviceTokenSch()}),
-		mappedServiceTokens,
-	)
-	return serviceTokenSet
+	mappedServiceToken["href"] = serviceToken.Href
+	mappedServiceToken["uuid"] = serviceToken.Uuid
+	mappedServiceToken["description"] = serviceToken.Description
+	return []interface{}{mappedServiceToken}
 }
 
-func connectionSideToTerra(connectionSide *v4.ConnectionSide) *schema.Set {
-	connectionSides := []*v4.ConnectionSide{connectionSide}
-	mappedConnectionSides := make([]interface{}, len(connectionSides))
-	for _, connectionSide := range connectionSides {
-		mappedConnectionSide := make(map[string]interface{})
-		serviceTokenSet := serviceTokenToTerra(connectionSide.ServiceToken)
-		if serviceTokenSet != nil {
-			mappedConnectionSide["service_token"] = serviceTokenSet
-		}
-		mappedConnectionSide["access_point"] = accessPointToTerra(connectionSide.AccessPoint)
-		mappedConnectionSides = append(mappedConnectionSides, mappedConnectionSide)
+func connectionSideToTerra(connectionSide *v4.ConnectionSide) []interface{} {
+	if connectionSide == nil {
+		return nil
 	}
-	connectionSideSet := schema.NewSet(
-		schema.HashResource(connectionSideSch()),
-		mappedConnectionSides,
-	)
-	return connectionSideSet
+	mappedConnectionSide := make(map[string]interface{})
+	if serviceToken := serviceTokenToTerra(connectionSide.ServiceToken); serviceToken != nil {
+		mappedConnectionSide["service_token"] = serviceToken
+	}
+	mappedConnectionSide["access_point"] = accessPointToTerra(connectionSide.AccessPoint)
+	if invitation := connectionSide.Invitation; invitation != nil {
+		mappedConnectionSide["invitation"] = []interface{}{map[string]interface{}{
+			"email":              invitation.Email,
+			"message":            invitation.Message,
+			"ctr_draft_order_id": invitation.CtrDraftOrderId,
+		}}
+	}
+	return []interface{}{mappedConnectionSide}
 }
 
 func additionalInfoToTerra(additionalInfol []v4.ConnectionSideAdditionalInfo) []map[string]interface{} {
@@ -283,152 +540,129 @@ func additionalInfoToTerra(additionalInfol []v4.ConnectionSideAdditionalInfo) []
 	return mappedadditionalInfol
 }
 
-func cloudRouterToTerra(cloudRouter *v4.CloudRouter) *schema.Set {
+func cloudRouterToTerra(cloudRouter *v4.CloudRouter) []interface{} {
 	if cloudRouter == nil {
 		return nil
 	}
-	cloudRouters := []*v4.CloudRouter{cloudRouter}
-	mappedCloudRouters := make([]interface{}, len(cloudRouters))
-	for _, cloudRouter := range cloudRouters {
-		mappedCloudRouter := make(map[string]interface{})
-		mappedCloudRouter["uuid"] = cloudRouter.Uuid
-		mappedCloudRouter["href"] = cloudRouter.Href
-		mappedCloudRouters = append(mappedCloudRouters, mappedCloudRouter)
+	mappedCloudRouter := make(map[string]interface{})
+	mappedCloudRouter["uuid"] = cloudRouter.Uuid
+	mappedCloudRouter["href"] = cloudRouter.Href
+	mappedCloudRouter["project"] = equinix_schema.ProjectToTerra(cloudRouter.Project)
+	mappedCloudRouter["name"] = cloudRouter.Name
+	if cloudRouter.State != nil {
+		mappedCloudRouter["state"] = string(*cloudRouter.State)
 	}
-	linkedProtocolSet := schema.NewSet(
-		schema.HashResource(&schema.Resource{Schema: equinix_schema.ProjectSch()}),
-		mappedCloudRouters)
-	return linkedProtocolSet
+	mappedCloudRouter["equinix_asn"] = int(cloudRouter.EquinixAsn)
+	return []interface{}{mappedCloudRouter}
 }
 
-func virtualDeviceToTerra(virtualDevice *v4.VirtualDevice) *schema.Set {
+func virtualDeviceToTerra(virtualDevice *v4.VirtualDevice) []interface{} {
 	if virtualDevice == nil {
 		return nil
 	}
-	virtualDevices := []*v4.VirtualDevice{virtualDevice}
-	mappedVirtualDevices := make([]interface{}, len(virtualDevices))
-	for _, virtualDevice := range virtualDevices {
-		mappedVirtualDevice := make(map[string]interface{})
-		mappedVirtualDevice["name"] = virtualDevice.Name
-		mappedVirtualDevice["href"] = virtualDevice.Href
-		mappedVirtualDevice["type"] = virtualDevice.Type_
-		mappedVirtualDevice["uuid"] = virtualDevice.Uuid
-		mappedVirtualDevices = append(mappedVirtualDevices, mappedVirtualDevice)
-	}
-	virtualDeviceSet := schema.NewSet(
-		schema.HashResource(&schema.Resource{Schema: accessPointVirtualDeviceSch()}),
-		mappedVirtualDevices)
-	return virtualDeviceSet
+	mappedVirtualDevice := make(map[string]interface{})
+	mappedVirtualDevice["name"] = virtualDevice.Name
+	mappedVirtualDevice["href"] = virtualDevice.Href
+	mappedVirtualDevice["type"] = virtualDevice.Type_
+	mappedVirtualDevice["uuid"] = virtualDevice.Uuid
+	return []interface{}{mappedVirtualDevice}
 }
 
-func interfaceToTerra(mInterface *v4.ModelInterface) *schema.Set {
+func interfaceToTerra(mInterface *v4.ModelInterface) []interface{} {
 	if mInterface == nil {
 		return nil
 	}
-	mInterfaces := []*v4.ModelInterface{mInterface}
-	mappedMInterfaces := make([]interface{}, len(mInterfaces))
-	for _, mInterface := range mInterfaces {
-		mappedMInterface := make(map[string]interface{})
-		mappedMInterface["id"] = int(mInterface.Id)
-		mappedMInterface["type"] = mInterface.Type_
-		mappedMInterface["uuid"] = mInterface.Uuid
-		mappedMInterfaces = append(mappedMInterfaces, mappedMInterface)
+	mappedMInterface := make(map[string]interface{})
+	mappedMInterface["id"] = int(mInterface.Id)
+	mappedMInterface["type"] = mInterface.Type_
+	mappedMInterface["uuid"] = mInterface.Uuid
+	return []interface{}{mappedMInterface}
+}
+
+func accessPointToTerra(accessPoint *v4.AccessPoint) []interface{} {
+	if accessPoint == nil {
+		return nil
+	}
+	mappedAccessPoint := make(map[string]interface{})
+	if accessPoint.Type_ != nil {
+		mappedAccessPoint["type"] = string(*accessPoint.Type_)
+	}
+	if accessPoint.Account != nil {
+		mappedAccessPoint["account"] = equinix_schema.AccountToTerra(accessPoint.Account)
+	}
+	if accessPoint.Location != nil {
+		mappedAccessPoint["location"] = equinix_schema.LocationToTerra(accessPoint.Location).List()
+	}
+	if accessPoint.Port != nil {
+		mappedAccessPoint["port"] = portToTerra(accessPoint.Port).List()
+	}
+	if accessPoint.Profile != nil {
+		mappedAccessPoint["profile"] = simplifiedServiceProfileToTerra(accessPoint.Profile)
+	}
+	if accessPoint.Router != nil {
+		mappedAccessPoint["router"] = cloudRouterToTerra(accessPoint.Router)
+		mappedAccessPoint["gateway"] = cloudRouterToTerra(accessPoint.Router)
+	}
+	if accessPoint.LinkProtocol != nil {
+		mappedAccessPoint["link_protocol"] = linkedProtocolToTerra(*accessPoint.LinkProtocol)
+	}
+	if accessPoint.VirtualDevice != nil {
+		mappedAccessPoint["virtual_device"] = virtualDeviceToTerra(accessPoint.VirtualDevice)
+	}
+	if accessPoint.Interface_ != nil {
+		mappedAccessPoint["interface"] = interfaceToTerra(accessPoint.Interface_)
+	}
+	if accessPoint.Network != nil {
+		mappedAccessPoint["network"] = simplifiedNetworkToTerra(accessPoint.Network)
 	}
-	mInterfaceSet := schema.NewSet(
-		schema.HashResource(&schema.Resource{Schema: accessPointInterface()}),
-		mappedMInterfaces)
-	return mInterfaceSet
+	mappedAccessPoint["seller_region"] = accessPoint.SellerRegion
+	if accessPoint.PeeringType != nil {
+		mappedAccessPoint["peering_type"] = string(*accessPoint.PeeringType)
+	}
+	mappedAccessPoint["authentication_key"] = accessPoint.AuthenticationKey
+	mappedAccessPoint["provider_connection_id"] = accessPoint.ProviderConnectionId
+	return []interface{}{mappedAccessPoint}
 }
 
-func accessPointToTerra(accessPoint *v4.AccessPoint) *schema.Set {
-	accessPoints := []*v4.AccessPoint{accessPoint}
-	mappedAccessPoints := make([]interface{}, len(accessPoints))
-	for _, accessPoint := range accessPoints {
-		mappedAccessPoint := make(map[string]interface{})
-		if accessPoint.Type_ != nil {
-			mappedAccessPoint["type"] = string(*accessPoint.Type_)
-		}
-		if accessPoint.Account != nil {
-			mappedAccessPoint["account"] = equinix_schema.AccountToTerra(accessPoint.Account)
-		}
-		if accessPoint.Location != nil {
-			mappedAccessPoint["location"] = equinix_schema.LocationToTerra(accessPoint.Location)
-		}
-		if accessPoint.Port != nil {
-			mappedAccessPoint["port"] = portToTerra(accessPoint.Port)
-		}
-		if accessPoint.Profile != nil {
-			mappedAccessPoint["profile"] = simplifiedServiceProfileToTerra(accessPoint.Profile)
-		}
-		if accessPoint.Router != nil {
-			mappedAccessPoint["router"] = cloudRouterToTerra(accessPoint.Router)
-			mappedAccessPoint["gateway"] = cloudRouterToTerra(accessPoint.Router)
-		}
-		if accessPoint.LinkProtocol != nil {
-			mappedAccessPoint["link_protocol"] = linkedProtocolToTerra(*accessPoint.LinkProtocol)
-		}
-		if accessPoint.VirtualDevice != nil {
-			mappedAccessPoint["virtual_device"] = virtualDeviceToTerra(accessPoint.VirtualDevice)
-		}
-		if accessPoint.Interface_ != nil {
-			mappedAccessPoint["interface"] = interfaceToTerra(accessPoint.Interface_)
-		}
-		mappedAccessPoint["seller_region"] = accessPoint.SellerRegion
-		if accessPoint.PeeringType != nil {
-			mappedAccessPoint["peering_type"] = string(*accessPoint.PeeringType)
-		}
-		mappedAccessPoint["authentication_key"] = accessPoint.AuthenticationKey
-		mappedAccessPoint["provider_connection_id"] = accessPoint.ProviderConnectionId
-		mappedAccessPoints = append(mappedAccessPoints, mappedAccessPoint)
+func simplifiedNetworkToTerra(network *v4.SimplifiedNetwork) []interface{} {
+	if network == nil {
+		return nil
 	}
-	accessPointSet := schema.NewSet(
-		schema.HashResource(accessPointSch()),
-		mappedAccessPoints,
-	)
-	return accessPointSet
+	return []interface{}{map[string]interface{}{
+		"uuid": network.Uuid,
+		"href": network.Href,
+	}}
 }
 
-func linkedProtocolToTerra(linkedProtocol v4.SimplifiedLinkProtocol) *schema.Set {
-	linkedProtocols := []v4.SimplifiedLinkProtocol{linkedProtocol}
-	mappedLinkedProtocols := make([]interface{}, len(linkedProtocols))
-	for _, linkedProtocol := range linkedProtocols {
-		mappedLinkedProtocol := make(map[string]interface{})
+func linkedProtocolToTerra(linkedProtocol v4.SimplifiedLinkProtocol) []interface{} {
+	mappedLinkedProtocol := make(map[string]interface{})
+	if linkedProtocol.Type_ != nil {
 		mappedLinkedProtocol["type"] = string(*linkedProtocol.Type_)
-		mappedLinkedProtocol["vlan_tag"] = int(linkedProtocol.VlanTag)
-		mappedLinkedProtocol["vlan_s_tag"] = int(linkedProtocol.VlanSTag)
-		mappedLinkedProtocol["vlan_c_tag"] = int(linkedProtocol.VlanCTag)
-		mappedLinkedProtocols = append(mappedLinkedProtocols, mappedLinkedProtocol)
-	}
-	linkedProtocolSet := schema.NewSet(
-		schema.HashResource(&schema.Resource{Schema: accessPointLinkProtocolSch()}),
-		mappedLinkedProtocols)
-	return linkedProtocolSet
-}
-
-func simplifiedServiceProfileToTerra(profile *v4.SimplifiedServiceProfile) *schema.Set {
-	profiles := []*v4.SimplifiedServiceProfile{profile}
-	mappedProfiles := make([]interface{}, len(profiles))
-	for _, profile := range profiles {
-		mappedProfile := make(map[string]interface{})
-		mappedProfile["href"] = profile.Href
-		mappedProfile["type"] = string(*profile.Type_)
-		mappedProfile["name"] = profile.Name
-		mappedProfile["uuid"] = profile.Uuid
-		mappedProfile["access_point_type_configs"] = accessPointTypeConfigToTerra(profile.AccessPointTypeConfigs)
-		mappedProfiles = append(mappedProfiles, mappedProfile)
 	}
+	mappedLinkedProtocol["vlan_tag"] = int(linkedProtocol.VlanTag)
+	mappedLinkedProtocol["vlan_s_tag"] = int(linkedProtocol.VlanSTag)
+	mappedLinkedProtocol["vlan_c_tag"] = int(linkedProtocol.VlanCTag)
+	return []interface{}{mappedLinkedProtocol}
+}
 
-	profileSet := schema.NewSet(
-		schema.HashResource(&schema.Resource{Schema: serviceProfileSch()}),
-		mappedProfiles,
-	)
-	return profileSet
+func simplifiedServiceProfileToTerra(profile *v4.SimplifiedServiceProfile) []interface{} {
+	if profile == nil {
+		return nil
+	}
+	mappedProfile := make(map[string]interface{})
+	mappedProfile["href"] = profile.Href
+	if profile.Type_ != nil {
+		mappedProfile["type"] = string(*profile.Type_)
+	}
+	mappedProfile["name"] = profile.Name
+	mappedProfile["uuid"] = profile.Uuid
+	mappedProfile["access_point_type_configs"] = accessPointTypeConfigToTerra(profile.AccessPointTypeConfigs)
+	return []interface{}{mappedProfile}
 }
 
 func accessPointTypeConfigToTerra(spAccessPointTypes []v4.ServiceProfileAccessPointType) []interface{} {
-	mappedSpAccessPointTypes := make([]interface{}, len(spAccessPointTypes))
-	for index, spAccessPointType := range spAccessPointTypes {
-		mappedSpAccessPointTypes[index] = map[string]interface{}{
+	return converters.SliceToIfArr(spAccessPointTypes, func(spAccessPointType v4.ServiceProfileAccessPointType) interface{} {
+		return map[string]interface{}{
 			"type":                             string(*spAccessPointType.Type_),
 			"uuid":                             spAccessPointType.Uuid,
 			"allow_remote_connections":         spAccessPointType.AllowRemoteConnections,
@@ -441,147 +675,154 @@ func accessPointTypeConfigToTerra(spAccessPointTypes []v4.ServiceProfileAccessPo
 			"authentication_key":               authenticationKeyToTerra(spAccessPointType.AuthenticationKey),
 			"supported_bandwidths":             supportedBandwidthsToTerra(spAccessPointType.SupportedBandwidths),
 		}
-	}
-
-	return mappedSpAccessPointTypes
+	})
 }
 
 func apiConfigToTerra(apiConfig *v4.ApiConfig) *schema.Set {
-	apiConfigs := []*v4.ApiConfig{apiConfig}
-	mappedApiConfigs := make([]interface{}, len(apiConfigs))
-	for _, apiConfig := range apiConfigs {
-		mappedApiConfig := make(map[string]interface{})
-		mappedApiConfig["api_available"] = apiConfig.ApiAvailable
-		mappedApiConfig["equinix_managed_vlan"] = apiConfig.EquinixManagedVlan
-		mappedApiConfig["bandwidth_from_api"] = apiConfig.BandwidthFromApi
-		mappedApiConfig["integration_id"] = apiConfig.IntegrationId
-		mappedApiConfig["equinix_managed_port"] = apiConfig.EquinixManagedPort
-		mappedApiConfigs = append(mappedApiConfigs, mappedApiConfig)
+	if apiConfig == nil {
+		return nil
+	}
+	mappedApiConfig := map[string]interface{}{
+		"api_available":        apiConfig.ApiAvailable,
+		"equinix_managed_vlan": apiConfig.EquinixManagedVlan,
+		"bandwidth_from_api":   apiConfig.BandwidthFromApi,
+		"integration_id":       apiConfig.IntegrationId,
+		"equinix_managed_port": apiConfig.EquinixManagedPort,
 	}
 	apiConfigSet := schema.NewSet(
 		schema.HashResource(&schema.Resource{Schema: createApiConfigSch()}),
-		mappedApiConfigs)
+		[]interface{}{mappedApiConfig})
 	return apiConfigSet
 }
 
 func authenticationKeyToTerra(authenticationKey *v4.AuthenticationKey) *schema.Set {
-	authenticationKeys := []*v4.AuthenticationKey{authenticationKey}
-	mappedAuthenticationKeys := make([]interface{}, len(authenticationKeys))
-	for _, authenticationKey := range authenticationKeys {
-		mappedAuthenticationKey := make(map[string]interface{})
-		mappedAuthenticationKey["required"] = authenticationKey.Required
-		mappedAuthenticationKey["label"] = authenticationKey.Label
-		mappedAuthenticationKey["description"] = authenticationKey.Description
-		mappedAuthenticationKeys = append(mappedAuthenticationKeys, mappedAuthenticationKey)
+	if authenticationKey == nil {
+		return nil
 	}
-	apiConfigSet := schema.NewSet(
+	mappedAuthenticationKey := map[string]interface{}{
+		"required":    authenticationKey.Required,
+		"label":       authenticationKey.Label,
+		"description": authenticationKey.Description,
+	}
+	authenticationKeySet := schema.NewSet(
 		schema.HashResource(&schema.Resource{Schema: createAuthenticationKeySch()}),
-		mappedAuthenticationKeys)
-	return apiConfigSet
+		[]interface{}{mappedAuthenticationKey})
+	return authenticationKeySet
 }
 
 func supportedBandwidthsToTerra(supportedBandwidths *[]int32) []interface{} {
 	if supportedBandwidths == nil {
 		return nil
 	}
-	mappedSupportedBandwidths := make([]interface{}, len(*supportedBandwidths))
-	for _, bandwidth := range *supportedBandwidths {
-		mappedSupportedBandwidths = append(mappedSupportedBandwidths, int(bandwidth))
-	}
-	return mappedSupportedBandwidths
+	return converters.SliceToIfArr(*supportedBandwidths, func(bandwidth int32) interface{} {
+		return int(bandwidth)
+	})
 }
 
-func routingProtocolDirectIpv4ToFabric(routingProtocolDirectIpv4Request []interface{}) v4.DirectConnectionIpv4 {
-	mappedRpDirectIpv4 := v4.DirectConnectionIpv4{}
-	for _, str := range routingProtocolDirectIpv4Request {
-		directIpv4Map := str.(map[string]interface{})
-		equinixIfaceIp := directIpv4Map["equinix_iface_ip"].(string)
-
-		mappedRpDirectIpv4 = v4.DirectConnectionIpv4{EquinixIfaceIp: equinixIfaceIp}
+func routingProtocolDirectIpv4ToFabric(routingProtocolDirectIpv4Request []interface{}) (v4.DirectConnectionIpv4, error) {
+	directIpv4Map, err := terraBlock(routingProtocolDirectIpv4Request, "direct_ipv4")
+	if err != nil || directIpv4Map == nil {
+		return v4.DirectConnectionIpv4{}, err
 	}
-	return mappedRpDirectIpv4
+	equinixIfaceIp, err := terraStringAttr(directIpv4Map, "direct_ipv4", "equinix_iface_ip")
+	if err != nil {
+		return v4.DirectConnectionIpv4{}, err
+	}
+	return v4.DirectConnectionIpv4{EquinixIfaceIp: equinixIfaceIp}, nil
 }
 
-func routingProtocolDirectIpv6ToFabric(routingProtocolDirectIpv6Request []interface{}) v4.DirectConnectionIpv6 {
-	mappedRpDirectIpv6 := v4.DirectConnectionIpv6{}
-	for _, str := range routingProtocolDirectIpv6Request {
-		directIpv6Map := str.(map[string]interface{})
-		equinixIfaceIp := directIpv6Map["equinix_iface_ip"].(string)
-
-		mappedRpDirectIpv6 = v4.DirectConnectionIpv6{EquinixIfaceIp: equinixIfaceIp}
+func routingProtocolDirectIpv6ToFabric(routingProtocolDirectIpv6Request []interface{}) (v4.DirectConnectionIpv6, error) {
+	directIpv6Map, err := terraBlock(routingProtocolDirectIpv6Request, "direct_ipv6")
+	if err != nil || directIpv6Map == nil {
+		return v4.DirectConnectionIpv6{}, err
 	}
-	return mappedRpDirectIpv6
+	equinixIfaceIp, err := terraStringAttr(directIpv6Map, "direct_ipv6", "equinix_iface_ip")
+	if err != nil {
+		return v4.DirectConnectionIpv6{}, err
+	}
+	return v4.DirectConnectionIpv6{EquinixIfaceIp: equinixIfaceIp}, nil
 }
 
-func routingProtocolBgpIpv4ToFabric(routingProtocolBgpIpv4Request []interface{}) v4.BgpConnectionIpv4 {
-	mappedRpBgpIpv4 := v4.BgpConnectionIpv4{}
-	for _, str := range routingProtocolBgpIpv4Request {
-		bgpIpv4Map := str.(map[string]interface{})
-		customerPeerIp := bgpIpv4Map["customer_peer_ip"].(string)
-		enabled := bgpIpv4Map["enabled"].(bool)
-
-		mappedRpBgpIpv4 = v4.BgpConnectionIpv4{CustomerPeerIp: customerPeerIp, Enabled: enabled}
+func routingProtocolBgpIpv4ToFabric(routingProtocolBgpIpv4Request []interface{}) (v4.BgpConnectionIpv4, error) {
+	bgpIpv4Map, err := terraBlock(routingProtocolBgpIpv4Request, "bgp_ipv4")
+	if err != nil || bgpIpv4Map == nil {
+		return v4.BgpConnectionIpv4{}, err
 	}
-	return mappedRpBgpIpv4
+	customerPeerIp, err := terraStringAttr(bgpIpv4Map, "bgp_ipv4", "customer_peer_ip")
+	if err != nil {
+		return v4.BgpConnectionIpv4{}, err
+	}
+	enabled, err := terraBoolAttr(bgpIpv4Map, "bgp_ipv4", "enabled")
+	if err != nil {
+		return v4.BgpConnectionIpv4{}, err
+	}
+	return v4.BgpConnectionIpv4{CustomerPeerIp: customerPeerIp, Enabled: enabled}, nil
 }
 
-func routingProtocolBgpIpv6ToFabric(routingProtocolBgpIpv6Request []interface{}) v4.BgpConnectionIpv6 {
-	mappedRpBgpIpv6 := v4.BgpConnectionIpv6{}
-	for _, str := range routingProtocolBgpIpv6Request {
-		bgpIpv6Map := str.(map[string]interface{})
-		customerPeerIp := bgpIpv6Map["customer_peer_ip"].(string)
-		enabled := bgpIpv6Map["enabled"].(bool)
-
-		mappedRpBgpIpv6 = v4.BgpConnectionIpv6{CustomerPeerIp: customerPeerIp, Enabled: enabled}
+func routingProtocolBgpIpv6ToFabric(routingProtocolBgpIpv6Request []interface{}) (v4.BgpConnectionIpv6, error) {
+	bgpIpv6Map, err := terraBlock(routingProtocolBgpIpv6Request, "bgp_ipv6")
+	if err != nil || bgpIpv6Map == nil {
+		return v4.BgpConnectionIpv6{}, err
+	}
+	customerPeerIp, err := terraStringAttr(bgpIpv6Map, "bgp_ipv6", "customer_peer_ip")
+	if err != nil {
+		return v4.BgpConnectionIpv6{}, err
+	}
+	enabled, err := terraBoolAttr(bgpIpv6Map, "bgp_ipv6", "enabled")
+	if err != nil {
+		return v4.BgpConnectionIpv6{}, err
 	}
-	return mappedRpBgpIpv6
+	return v4.BgpConnectionIpv6{CustomerPeerIp: customerPeerIp, Enabled: enabled}, nil
 }
 
-func routingProtocolBfdToFabric(routingProtocolBfdRequest []interface{}) v4.RoutingProtocolBfd {
-	mappedRpBfd := v4.RoutingProtocolBfd{}
-	for _, str := range routingProtocolBfdRequest {
-		rpBfdMap := str.(map[string]interface{})
-		bfdEnabled := rpBfdMap["enabled"].(bool)
-		bfdInterval := rpBfdMap["interval"].(string)
-
-		mappedRpBfd = v4.RoutingProtocolBfd{Enabled: bfdEnabled, Interval: bfdInterval}
+func routingProtocolBfdToFabric(routingProtocolBfdRequest []interface{}) (v4.RoutingProtocolBfd, error) {
+	rpBfdMap, err := terraBlock(routingProtocolBfdRequest, "bfd")
+	if err != nil || rpBfdMap == nil {
+		return v4.RoutingProtocolBfd{}, err
+	}
+	bfdEnabled, err := terraBoolAttr(rpBfdMap, "bfd", "enabled")
+	if err != nil {
+		return v4.RoutingProtocolBfd{}, err
 	}
-	return mappedRpBfd
+	bfdInterval, err := terraStringAttr(rpBfdMap, "bfd", "interval")
+	if err != nil {
+		return v4.RoutingProtocolBfd{}, err
+	}
+	return v4.RoutingProtocolBfd{Enabled: bfdEnabled, Interval: bfdInterval}, nil
 }
 
-func routingProtocolChangeToFabric(routingProtocolChangeRequest []interface{}) v4.RoutingProtocolChange {
-	mappedRpChange := v4.RoutingProtocolChange{}
-	for _, str := range routingProtocolChangeRequest {
-		rpChangeMap := str.(map[string]interface{})
-		uuid := rpChangeMap["uuid"].(string)
-		rpChangeType := rpChangeMap["type"].(string)
-
-		mappedRpChange = v4.RoutingProtocolChange{Uuid: uuid, Type_: rpChangeType}
+func routingProtocolChangeToFabric(routingProtocolChangeRequest []interface{}) (v4.RoutingProtocolChange, error) {
+	rpChangeMap, err := terraBlock(routingProtocolChangeRequest, "change")
+	if err != nil || rpChangeMap == nil {
+		return v4.RoutingProtocolChange{}, err
 	}
-	return mappedRpChange
+	uuid, err := terraStringAttr(rpChangeMap, "change", "uuid")
+	if err != nil {
+		return v4.RoutingProtocolChange{}, err
+	}
+	rpChangeType, err := terraStringAttr(rpChangeMap, "change", "type")
+	if err != nil {
+		return v4.RoutingProtocolChange{}, err
+	}
+	return v4.RoutingProtocolChange{Uuid: uuid, Type_: rpChangeType}, nil
 }
 
 func routingProtocolDirectTypeToTerra(routingProtocolDirect *v4.RoutingProtocolDirectType) *schema.Set {
 	if routingProtocolDirect == nil {
 		return nil
 	}
-	routingProtocolDirects := []*v4.RoutingProtocolDirectType{routingProtocolDirect}
-	mappedDirects := make([]interface{}, len(routingProtocolDirects))
-	for _, routingProtocolDirect := range routingProtocolDirects {
-		mappedDirect := make(map[string]interface{})
-		mappedDirect["type"] = routingProtocolDirect.Type_
-		mappedDirect["name"] = routingProtocolDirect.Name
-		if routingProtocolDirect.DirectIpv4 != nil {
-			mappedDirect["direct_ipv4"] = routingProtocolDirectConnectionIpv4ToTerra(routingProtocolDirect.DirectIpv4)
-		}
-		if routingProtocolDirect.DirectIpv6 != nil {
-			mappedDirect["direct_ipv6"] = routingProtocolDirectConnectionIpv6ToTerra(routingProtocolDirect.DirectIpv6)
-		}
-		mappedDirects = append(mappedDirects, mappedDirect)
+	mappedDirect := make(map[string]interface{})
+	mappedDirect["type"] = routingProtocolDirect.Type_
+	mappedDirect["name"] = routingProtocolDirect.Name
+	if routingProtocolDirect.DirectIpv4 != nil {
+		mappedDirect["direct_ipv4"] = routingProtocolDirectConnectionIpv4ToTerra(routingProtocolDirect.DirectIpv4)
+	}
+	if routingProtocolDirect.DirectIpv6 != nil {
+		mappedDirect["direct_ipv6"] = routingProtocolDirectConnectionIpv6ToTerra(routingProtocolDirect.DirectIpv6)
 	}
 	rpDirectSet := schema.NewSet(
 		schema.HashResource(createRoutingProtocolDirectTypeRes),
-		mappedDirects,
+		[]interface{}{mappedDirect},
 	)
 
 	return rpDirectSet
@@ -627,30 +868,23 @@ func routingProtocolBgpTypeToTerra(routingProtocolBgp *v4.RoutingProtocolBgpType
 	if routingProtocolBgp == nil {
 		return nil
 	}
-	routingProtocolBgps := []*v4.RoutingProtocolBgpType{routingProtocolBgp}
-	mappedBgps := make([]interface{}, len(routingProtocolBgps))
-	for _, routingProtocolBgp := range routingProtocolBgps {
-		mappedBgp := make(map[string]interface{})
-		mappedBgp["type"] = routingProtocolBgp.Type_
-		mappedBgp["name"] = routingProtocolBgp.Name
-		if routingProtocolBgp.BgpIpv4 != nil {
-			mappedBgp["bgp_ipv4"] = routingProtocolBgpConnectionIpv4ToTerra(routingProtocolBgp.BgpIpv4)
-		}
-		if routingProtocolBgp.BgpIpv6 != nil {
-			mappedBgp["bgp_ipv6"] = routingProtocolBgpConnectionIpv6ToTerra(routingProtocolBgp.BgpIpv6)
-		}
-		mappedBgp["customer_asn"] = routingProtocolBgp.CustomerAsn
-		mappedBgp["bgp_auth_key"] = routingProtocolBgp.BgpAuthKey
-		if routingProtocolBgp.Bfd != nil {
-			mappedBgp["bfd"] = routingProtocolBfdToTerra(routingProtocolBgp.Bfd)
-		}
-
-		mappedBgps = append(mappedBgps, mappedBgp)
-
+	mappedBgp := make(map[string]interface{})
+	mappedBgp["type"] = routingProtocolBgp.Type_
+	mappedBgp["name"] = routingProtocolBgp.Name
+	if routingProtocolBgp.BgpIpv4 != nil {
+		mappedBgp["bgp_ipv4"] = routingProtocolBgpConnectionIpv4ToTerra(routingProtocolBgp.BgpIpv4)
+	}
+	if routingProtocolBgp.BgpIpv6 != nil {
+		mappedBgp["bgp_ipv6"] = routingProtocolBgpConnectionIpv6ToTerra(routingProtocolBgp.BgpIpv6)
+	}
+	mappedBgp["customer_asn"] = int(routingProtocolBgp.CustomerAsn)
+	mappedBgp["bgp_auth_key"] = routingProtocolBgp.BgpAuthKey
+	if routingProtocolBgp.Bfd != nil {
+		mappedBgp["bfd"] = routingProtocolBfdToTerra(routingProtocolBgp.Bfd)
 	}
 	rpBgpSet := schema.NewSet(
 		schema.HashResource(createRoutingProtocolBgpTypeRes),
-		mappedBgps,
+		[]interface{}{mappedBgp},
 	)
 
 	return rpBgpSet
@@ -719,18 +953,13 @@ func routingProtocolOperationToTerra(routingProtocolOperation *v4.RoutingProtoco
 	if routingProtocolOperation == nil {
 		return nil
 	}
-	routingProtocolOperations := []*v4.RoutingProtocolOperation{routingProtocolOperation}
-	mappedRpOperations := make([]interface{}, len(routingProtocolOperations))
-	for _, routingProtocolOperation := range routingProtocolOperations {
-		mappedRpOperation := make(map[string]interface{})
-		if routingProtocolOperation.Errors != nil {
-			mappedRpOperation["errors"] = equinix_schema.ErrorToTerra(routingProtocolOperation.Errors)
-		}
-		mappedRpOperations = append(mappedRpOperations, mappedRpOperation)
+	mappedRpOperation := make(map[string]interface{})
+	if routingProtocolOperation.Errors != nil {
+		mappedRpOperation["errors"] = equinix_schema.ErrorToTerra(routingProtocolOperation.Errors)
 	}
 	rpOperationSet := schema.NewSet(
 		schema.HashResource(createRoutingProtocolOperationRes),
-		mappedRpOperations,
+		[]interface{}{mappedRpOperation},
 	)
 	return rpOperationSet
 }
@@ -780,10 +1009,10 @@ func getUpdateRequests(conn v4.Connection, d *schema.ResourceData) ([][]v4.Conne
 	existingName := conn.Name
 	existingBandwidth := int(conn.Bandwidth)
 	updateNameVal := d.Get("name").(string)
-	updateBandwidthVal := d.Get("bandwidth").(int)
+	updateBandwidthVal := equinix_schema.BandwidthToMbps(d.Get("bandwidth").(int), d.Get("bandwidth_unit").(string))
 	additionalInfo := d.Get("additional_info").([]interface{})
 
-	awsSecrets, hasAWSSecrets := additionalInfoContainsAWSSecrets(additionalInfo)
+	acceptanceInfo, seller := connectionAcceptanceAdditionalInfo(additionalInfo)
 
 	if existingName != updateNameVal {
 		changeOps = append(changeOps, []v4.ConnectionChangeOperation{
@@ -805,19 +1034,240 @@ func getUpdateRequests(conn v4.Connection, d *schema.ResourceData) ([][]v4.Conne
 		})
 	}
 
-	if *conn.Operation.ProviderStatus == v4.PENDING_APPROVAL_ProviderStatus && hasAWSSecrets {
+	if *conn.Operation.ProviderStatus == v4.PENDING_APPROVAL_ProviderStatus && seller != "" {
 		changeOps = append(changeOps, []v4.ConnectionChangeOperation{
 			{
 				Op:    "add",
 				Path:  "",
-				Value: map[string]interface{}{"additionalInfo": awsSecrets},
+				Value: map[string]interface{}{"additionalInfo": acceptanceInfo},
+			},
+		})
+	}
+
+	updateNotifications := equinix_schema.NotificationsToFabric(d.Get("notifications").([]interface{}))
+	if !fabricNotificationsEqual(conn.Notifications, updateNotifications) {
+		changeOps = append(changeOps, []v4.ConnectionChangeOperation{
+			{
+				Op:    "replace",
+				Path:  "/notifications",
+				Value: updateNotifications,
 			},
 		})
 	}
 
+	existingPurchaseOrderNumber := ""
+	if conn.Order != nil {
+		existingPurchaseOrderNumber = conn.Order.PurchaseOrderNumber
+	}
+	updatePurchaseOrderNumber := equinix_schema.OrderToFabric(d.Get("order").(*schema.Set).List()).PurchaseOrderNumber
+	if existingPurchaseOrderNumber != updatePurchaseOrderNumber {
+		changeOps = append(changeOps, []v4.ConnectionChangeOperation{
+			{
+				Op:    "replace",
+				Path:  "/order/purchaseOrderNumber",
+				Value: updatePurchaseOrderNumber,
+			},
+		})
+	}
+
+	if conn.Type_ != nil && slices.Contains(fabricConnectionVlanUpdatableTypes, string(*conn.Type_)) {
+		if vlanOps := connectionSideVlanUpdateRequest(conn.ASide, d.Get("a_side").([]interface{}), "/aSide"); len(vlanOps) != 0 {
+			changeOps = append(changeOps, vlanOps)
+		}
+		if vlanOps := connectionSideVlanUpdateRequest(conn.ZSide, d.Get("z_side").([]interface{}), "/zSide"); len(vlanOps) != 0 {
+			changeOps = append(changeOps, vlanOps)
+		}
+	}
+
 	if len(changeOps) == 0 {
 		return changeOps, fmt.Errorf("nothing to update for the connection %s", existingName)
 	}
 
 	return changeOps, nil
 }
+
+// fabricConnectionVlanUpdatableTypes are the connection types of which the
+// access point VLANs can be updated in place.
+var fabricConnectionVlanUpdatableTypes = []string{"EVPL_VC", "IP_VC"}
+
+// fabricConnectionVlanPaths maps the link protocol VLAN attributes to their
+// path in the connection side.
+var fabricConnectionVlanPaths = map[string]string{
+	"vlan_tag":   "/accessPoint/linkProtocol/vlanTag",
+	"vlan_s_tag": "/accessPoint/linkProtocol/vlanSTag",
+	"vlan_c_tag": "/accessPoint/linkProtocol/vlanCTag",
+}
+
+func fabricNotificationsEqual(existing, update []v4.SimplifiedNotification) bool {
+	if len(existing) != len(update) {
+		return false
+	}
+	for i := range existing {
+		if existing[i].Type_ != update[i].Type_ || existing[i].SendInterval != update[i].SendInterval || !slices.Equal(existing[i].Emails, update[i].Emails) {
+			return false
+		}
+	}
+	return true
+}
+
+// connectionSideVlanUpdateRequest returns the operations replacing the VLANs
+// of a connection side that differ from the configured ones. VLANs that are
+// not configured are left unchanged.
+func connectionSideVlanUpdateRequest(side *v4.ConnectionSide, sideRequest []interface{}, sidePath string) []v4.ConnectionChangeOperation {
+	if side == nil || side.AccessPoint == nil || side.AccessPoint.LinkProtocol == nil {
+		return nil
+	}
+	_, _, lpMap := connectionSideAccessPoint(sideRequest)
+	if lpMap == nil {
+		return nil
+	}
+	existing := map[string]int{
+		"vlan_tag":   int(side.AccessPoint.LinkProtocol.VlanTag),
+		"vlan_s_tag": int(side.AccessPoint.LinkProtocol.VlanSTag),
+		"vlan_c_tag": int(side.AccessPoint.LinkProtocol.VlanCTag),
+	}
+	var changeOps []v4.ConnectionChangeOperation
+	for _, key := range []string{"vlan_tag", "vlan_s_tag", "vlan_c_tag"} {
+		update, _ := lpMap[key].(int)
+		if update != 0 && update != existing[key] {
+			changeOps = append(changeOps, v4.ConnectionChangeOperation{
+				Op:    "replace",
+				Path:  sidePath + fabricConnectionVlanPaths[key],
+				Value: update,
+			})
+		}
+	}
+	return changeOps
+}
+
+// connectionSideAccessPoint returns the attributes of a connection side, of its
+// access point and of the access point link protocol, each nil if not set.
+func connectionSideAccessPoint(sideRequest []interface{}) (sideMap, apMap, lpMap map[string]interface{}) {
+	sideMap, _ = terraBlock(sideRequest, "side")
+	if sideMap == nil {
+		return nil, nil, nil
+	}
+	apList, _ := sideMap["access_point"].([]interface{})
+	apMap, _ = terraBlock(apList, "access_point")
+	if apMap == nil {
+		return sideMap, nil, nil
+	}
+	lpList, _ := apMap["link_protocol"].([]interface{})
+	lpMap, _ = terraBlock(lpList, "link_protocol")
+	return sideMap, apMap, lpMap
+}
+
+// getNotUpdatableConnectionChanges returns the changed connection attributes
+// that can't be updated in place.
+func getNotUpdatableConnectionChanges(d *schema.ResourceData) []string {
+	var notUpdatable []string
+	for _, key := range []string{"type", "redundancy", "project", "description", "additional_info"} {
+		if d.HasChange(key) {
+			notUpdatable = append(notUpdatable, key)
+		}
+	}
+	if oldOrder, newOrder := d.GetChange("order"); !terraValuesEqual(
+		withoutPurchaseOrderNumber(oldOrder.(*schema.Set).List()),
+		withoutPurchaseOrderNumber(newOrder.(*schema.Set).List())) {
+		notUpdatable = append(notUpdatable, "order")
+	}
+	vlanUpdatable := slices.Contains(fabricConnectionVlanUpdatableTypes, d.Get("type").(string))
+	for _, side := range []string{"a_side", "z_side"} {
+		oldSide, newSide := d.GetChange(side)
+		if vlanUpdatable {
+			oldSide, newSide = withoutConnectionSideVlans(oldSide.([]interface{})), withoutConnectionSideVlans(newSide.([]interface{}))
+		}
+		if !terraValuesEqual(oldSide, newSide) {
+			notUpdatable = append(notUpdatable, side)
+		}
+	}
+	return notUpdatable
+}
+
+func withoutPurchaseOrderNumber(orderList []interface{}) []interface{} {
+	mapped := make([]interface{}, 0, len(orderList))
+	for _, o := range orderList {
+		orderMap, ok := o.(map[string]interface{})
+		if !ok {
+			mapped = append(mapped, o)
+			continue
+		}
+		withoutNumber := map[string]interface{}{}
+		for k, v := range orderMap {
+			if k != "purchase_order_number" {
+				withoutNumber[k] = v
+			}
+		}
+		mapped = append(mapped, withoutNumber)
+	}
+	return mapped
+}
+
+// withoutConnectionSideVlans returns a copy of a connection side without the
+// VLANs of its access point link protocol.
+func withoutConnectionSideVlans(sideList []interface{}) []interface{} {
+	sideMap, apMap, lpMap := connectionSideAccessPoint(sideList)
+	if lpMap == nil {
+		return sideList
+	}
+	withoutVlans := map[string]interface{}{}
+	for k, v := range lpMap {
+		if _, ok := fabricConnectionVlanPaths[k]; !ok {
+			withoutVlans[k] = v
+		}
+	}
+	return []interface{}{copyTerraMap(sideMap, "access_point", []interface{}{copyTerraMap(apMap, "link_protocol", []interface{}{withoutVlans})})}
+}
+
+// terraValuesEqual compares two terra values, with sets compared by their
+// elements.
+func terraValuesEqual(a, b interface{}) bool {
+	if set, ok := a.(*schema.Set); ok {
+		a = terraSetList(set)
+	}
+	if set, ok := b.(*schema.Set); ok {
+		b = terraSetList(set)
+	}
+	switch a := a.(type) {
+	case []interface{}:
+		bList, ok := b.([]interface{})
+		if !ok || len(a) != len(bList) {
+			return false
+		}
+		for i := range a {
+			if !terraValuesEqual(a[i], bList[i]) {
+				return false
+			}
+		}
+		return true
+	case map[string]interface{}:
+		bMap, ok := b.(map[string]interface{})
+		if !ok || len(a) != len(bMap) {
+			return false
+		}
+		for k, v := range a {
+			if !terraValuesEqual(v, bMap[k]) {
+				return false
+			}
+		}
+		return true
+	}
+	return reflect.DeepEqual(a, b)
+}
+
+func terraSetList(set *schema.Set) []interface{} {
+	if set == nil {
+		return []interface{}{}
+	}
+	return set.List()
+}
+
+// copyTerraMap returns a copy of a map with the given key replaced.
+func copyTerraMap(m map[string]interface{}, key string, value interface{}) map[string]interface{} {
+	mapped := make(map[string]interface{}, len(m))
+	for k, v := range m {
+		mapped[k] = v
+	}
+	mapped[key] = value
+	return mapped
+}
@@ -0,0 +1,596 @@
+package equinix
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+
+	v4 "github.com/equinix-labs/fabric-go/fabric/v4"
+	equinix_schema "github.com/equinix/terraform-provider-equinix/internal/fabric/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fabricMappingRoundTrip is a terra -> fabric -> terra mapping pair. The terra
+// value is generated from the fuzzer inputs, and every attribute of it must
+// survive the round trip.
+type fabricMappingRoundTrip struct {
+	name      string
+	terra     func(text string, number int32, flag bool) []interface{}
+	roundTrip func(terra []interface{}) (interface{}, error)
+}
+
+var fabricMappingRoundTrips = []fabricMappingRoundTrip{
+	{
+		name: "service token",
+		terra: func(text string, _ int32, flag bool) []interface{} {
+			tokenType := ""
+			if flag {
+				tokenType = "VC_TOKEN"
+			}
+			return testUuidBlockTerra(text, map[string]interface{}{"type": tokenType, "uuid": text})
+		},
+		roundTrip: func(terra []interface{}) (interface{}, error) {
+			serviceToken, err := serviceTokenToFabric(terra)
+			return serviceTokenToTerra(&serviceToken), err
+		},
+	},
+	{
+		name: "additional info",
+		terra: func(text string, _ int32, _ bool) []interface{} {
+			additionalInfo := []interface{}{map[string]interface{}{"key": "other", "value": text}}
+			if text != "" {
+				additionalInfo = append(additionalInfo, map[string]interface{}{"key": text, "value": text + "-value"})
+			}
+			return additionalInfo
+		},
+		roundTrip: func(terra []interface{}) (interface{}, error) {
+			additionalInfo, err := additionalInfoTerraToGo(terra)
+			return additionalInfoToTerra(additionalInfo), err
+		},
+	},
+	{
+		name: "access point",
+		terra: func(text string, number int32, flag bool) []interface{} {
+			peeringType := ""
+			if flag {
+				peeringType = string(v4.PRIVATE_PeeringType)
+			}
+			return []interface{}{map[string]interface{}{
+				"type":                   string(v4.COLO_AccessPointType),
+				"authentication_key":     text,
+				"provider_connection_id": text,
+				"seller_region":          text,
+				"peering_type":           peeringType,
+				"port":                   testUuidBlockTerra(text, map[string]interface{}{"uuid": text}),
+				"profile":                testServiceProfileTerra(text),
+				"location":               []interface{}{map[string]interface{}{"metro_code": text, "metro_name": text, "region": text, "ibx": text}},
+				"router":                 testCloudRouterTerra(text),
+				"link_protocol":          testLinkProtocolTerra(text, number),
+				"virtual_device":         testVirtualDeviceTerra(text),
+				"interface":              testInterfaceTerra(text, number),
+				"network":                testUuidBlockTerra(text, map[string]interface{}{"uuid": text}),
+			}}
+		},
+		roundTrip: func(terra []interface{}) (interface{}, error) {
+			accessPoint, err := accessPointToFabric(terra)
+			return accessPointToTerra(&accessPoint), err
+		},
+	},
+	{
+		name: "cloud router",
+		terra: func(text string, _ int32, _ bool) []interface{} {
+			return testCloudRouterTerra(text)
+		},
+		roundTrip: func(terra []interface{}) (interface{}, error) {
+			cloudRouter, err := cloudRouterToFabric(terra)
+			return cloudRouterToTerra(&cloudRouter), err
+		},
+	},
+	{
+		name: "network",
+		terra: func(text string, _ int32, _ bool) []interface{} {
+			return testUuidBlockTerra(text, map[string]interface{}{"uuid": text})
+		},
+		roundTrip: func(terra []interface{}) (interface{}, error) {
+			network, err := networkToFabric(terra)
+			return simplifiedNetworkToTerra(&network), err
+		},
+	},
+	{
+		name: "link protocol",
+		terra: func(text string, number int32, _ bool) []interface{} {
+			return testLinkProtocolTerra(text, number)
+		},
+		roundTrip: func(terra []interface{}) (interface{}, error) {
+			linkProtocol, err := linkProtocolToFabric(terra)
+			return linkedProtocolToTerra(linkProtocol), err
+		},
+	},
+	{
+		name: "service profile",
+		terra: func(text string, _ int32, _ bool) []interface{} {
+			return testServiceProfileTerra(text)
+		},
+		roundTrip: func(terra []interface{}) (interface{}, error) {
+			profile, err := simplifiedServiceProfileToFabric(terra)
+			return simplifiedServiceProfileToTerra(&profile), err
+		},
+	},
+	{
+		name: "virtual device",
+		terra: func(text string, _ int32, _ bool) []interface{} {
+			return testVirtualDeviceTerra(text)
+		},
+		roundTrip: func(terra []interface{}) (interface{}, error) {
+			virtualDevice, err := virtualdeviceToFabric(terra)
+			return virtualDeviceToTerra(&virtualDevice), err
+		},
+	},
+	{
+		name: "interface",
+		terra: func(text string, number int32, _ bool) []interface{} {
+			return testInterfaceTerra(text, number)
+		},
+		roundTrip: func(terra []interface{}) (interface{}, error) {
+			mInterface, err := interfaceToFabric(terra)
+			return interfaceToTerra(&mInterface), err
+		},
+	},
+	{
+		name: "routing protocol direct ipv4",
+		terra: func(text string, _ int32, _ bool) []interface{} {
+			return []interface{}{map[string]interface{}{"equinix_iface_ip": text}}
+		},
+		roundTrip: func(terra []interface{}) (interface{}, error) {
+			directIpv4, err := routingProtocolDirectIpv4ToFabric(terra)
+			return routingProtocolDirectConnectionIpv4ToTerra(&directIpv4), err
+		},
+	},
+	{
+		name: "routing protocol direct ipv6",
+		terra: func(text string, _ int32, _ bool) []interface{} {
+			return []interface{}{map[string]interface{}{"equinix_iface_ip": text}}
+		},
+		roundTrip: func(terra []interface{}) (interface{}, error) {
+			directIpv6, err := routingProtocolDirectIpv6ToFabric(terra)
+			return routingProtocolDirectConnectionIpv6ToTerra(&directIpv6), err
+		},
+	},
+	{
+		name: "routing protocol bgp ipv4",
+		terra: func(text string, _ int32, flag bool) []interface{} {
+			return []interface{}{map[string]interface{}{"customer_peer_ip": text, "enabled": flag}}
+		},
+		roundTrip: func(terra []interface{}) (interface{}, error) {
+			bgpIpv4, err := routingProtocolBgpIpv4ToFabric(terra)
+			return routingProtocolBgpConnectionIpv4ToTerra(&bgpIpv4), err
+		},
+	},
+	{
+		name: "routing protocol bgp ipv6",
+		terra: func(text string, _ int32, flag bool) []interface{} {
+			return []interface{}{map[string]interface{}{"customer_peer_ip": text, "enabled": flag}}
+		},
+		roundTrip: func(terra []interface{}) (interface{}, error) {
+			bgpIpv6, err := routingProtocolBgpIpv6ToFabric(terra)
+			return routingProtocolBgpConnectionIpv6ToTerra(&bgpIpv6), err
+		},
+	},
+	{
+		name: "routing protocol bfd",
+		terra: func(text string, _ int32, flag bool) []interface{} {
+			return []interface{}{map[string]interface{}{"enabled": flag, "interval": text}}
+		},
+		roundTrip: func(terra []interface{}) (interface{}, error) {
+			bfd, err := routingProtocolBfdToFabric(terra)
+			return routingProtocolBfdToTerra(&bfd), err
+		},
+	},
+	{
+		name: "routing protocol change",
+		terra: func(text string, _ int32, _ bool) []interface{} {
+			return []interface{}{map[string]interface{}{"uuid": text, "type": text}}
+		},
+		roundTrip: func(terra []interface{}) (interface{}, error) {
+			change, err := routingProtocolChangeToFabric(terra)
+			return routingProtocolChangeToTerra(&change), err
+		},
+	},
+}
+
+// testUuidBlockTerra returns a block identified by the given text, or no block
+// if the text is empty, as such blocks are rejected by the converters.
+func testUuidBlockTerra(text string, attrs map[string]interface{}) []interface{} {
+	if text == "" {
+		return []interface{}{}
+	}
+	return []interface{}{attrs}
+}
+
+func testCloudRouterTerra(text string) []interface{} {
+	return testUuidBlockTerra(text, map[string]interface{}{"uuid": text})
+}
+
+func testLinkProtocolTerra(text string, number int32) []interface{} {
+	return testUuidBlockTerra(text, map[string]interface{}{
+		"type":       text,
+		"vlan_tag":   int(number),
+		"vlan_s_tag": int(number / 2),
+		"vlan_c_tag": int(number / 3),
+	})
+}
+
+func testServiceProfileTerra(text string) []interface{} {
+	return testUuidBlockTerra(text, map[string]interface{}{"type": text, "uuid": text})
+}
+
+func testVirtualDeviceTerra(text string) []interface{} {
+	return testUuidBlockTerra(text, map[string]interface{}{"href": text, "type": text, "uuid": text, "name": text})
+}
+
+func testInterfaceTerra(text string, number int32) []interface{} {
+	return []interface{}{map[string]interface{}{"uuid": text, "type": text, "id": int(number)}}
+}
+
+func FuzzFabricMappingRoundTrip(f *testing.F) {
+	f.Add("", int32(0), false)
+	f.Add("3a58dd05-f46d-4b1d-a154-2e85c396ea62", int32(100), true)
+	f.Fuzz(func(t *testing.T, text string, number int32, flag bool) {
+		for _, rt := range fabricMappingRoundTrips {
+			terra := rt.terra(text, number, flag)
+			result, err := rt.roundTrip(terra)
+			require.NoError(t, err, rt.name)
+			assertTerraRoundTrip(t, rt.name, terra, result)
+		}
+	})
+}
+
+func TestFabricMappingToTerra_singleElement(t *testing.T) {
+	// given
+	providerStatus, equinixStatus := v4.AVAILABLE_ProviderStatus, v4.PROVISIONED_EquinixStatus
+	sets := map[string]*schema.Set{
+		"operation":                operationToTerra(&v4.ConnectionOperation{ProviderStatus: &providerStatus, EquinixStatus: &equinixStatus}),
+		"api config":               apiConfigToTerra(&v4.ApiConfig{ApiAvailable: true}),
+		"authentication key":       authenticationKeyToTerra(&v4.AuthenticationKey{Label: "key"}),
+		"routing protocol direct":  routingProtocolDirectTypeToTerra(&v4.RoutingProtocolDirectType{Type_: "DIRECT", Name: "direct"}),
+		"routing protocol bgp":     routingProtocolBgpTypeToTerra(&v4.RoutingProtocolBgpType{Type_: "BGP", Name: "bgp"}),
+		"routing protocol op":      routingProtocolOperationToTerra(&v4.RoutingProtocolOperation{}),
+		"routing protocol change":  routingProtocolChangeToTerra(&v4.RoutingProtocolChange{Uuid: "change"}),
+		"location":                 equinix_schema.LocationToTerra(&v4.SimplifiedLocation{MetroCode: "SV"}),
+		"routing protocol bfd":     routingProtocolBfdToTerra(&v4.RoutingProtocolBfd{Enabled: true}),
+		"routing protocol bgp ip4": routingProtocolBgpConnectionIpv4ToTerra(&v4.BgpConnectionIpv4{Enabled: true}),
+		"routing protocol bgp ip6": routingProtocolBgpConnectionIpv6ToTerra(&v4.BgpConnectionIpv6{Enabled: true}),
+		"routing protocol dir ip4": routingProtocolDirectConnectionIpv4ToTerra(&v4.DirectConnectionIpv4{EquinixIfaceIp: "190.1.1.1/30"}),
+		"routing protocol dir ip6": routingProtocolDirectConnectionIpv6ToTerra(&v4.DirectConnectionIpv6{EquinixIfaceIp: "190::1:1/126"}),
+		"order":                    equinix_schema.OrderToTerra(&v4.Order{PurchaseOrderNumber: "1-129105284100"}),
+		"project":                  equinix_schema.ProjectToTerra(&v4.Project{ProjectId: "project"}),
+		"location without ibx":     equinix_schema.LocationWithoutIBXToTerra(&v4.SimplifiedLocationWithoutIbx{MetroCode: "SV"}),
+	}
+	// when / then
+	for name, set := range sets {
+		require.NotNil(t, set, name)
+		assert.Equal(t, 1, set.Len(), "%s maps to a single element", name)
+		assert.NotContains(t, set.List(), nil, "%s has no empty element", name)
+	}
+	assert.Equal(t, []interface{}{100, 1000}, supportedBandwidthsToTerra(&[]int32{100, 1000}), "Supported bandwidths are mapped in order")
+}
+
+func TestFabricMappingToTerra_list(t *testing.T) {
+	// given
+	apType := v4.COLO_ServiceProfileAccessPointTypeEnum
+	accessPointTypes := []v4.ServiceProfileAccessPointType{
+		{Type_: &apType, Uuid: "first", SupportedBandwidths: &[]int32{50}},
+		{Type_: &apType, Uuid: "second"},
+	}
+	additionalInfo := []v4.ConnectionSideAdditionalInfo{{Key: "first"}, {Key: "second"}}
+	// when
+	mappedAccessPointTypes := accessPointTypeConfigToTerra(accessPointTypes)
+	mappedAdditionalInfo := additionalInfoToTerra(additionalInfo)
+	// then
+	require.Len(t, mappedAccessPointTypes, 2, "Access point types are not padded")
+	assert.Equal(t, "first", mappedAccessPointTypes[0].(map[string]interface{})["uuid"])
+	assert.Equal(t, []interface{}{50}, mappedAccessPointTypes[0].(map[string]interface{})["supported_bandwidths"])
+	assert.Equal(t, "second", mappedAccessPointTypes[1].(map[string]interface{})["uuid"])
+	require.Len(t, mappedAdditionalInfo, 2, "Additional info is not padded")
+	assert.Equal(t, "first", mappedAdditionalInfo[0]["key"])
+	assert.Nil(t, supportedBandwidthsToTerra(nil), "Unset supported bandwidths map to nil")
+	assert.Empty(t, supportedBandwidthsToTerra(&[]int32{}))
+}
+
+func TestFabricMappingToFabric_invalidInput(t *testing.T) {
+	// given
+	uuid := "3a58dd05-f46d-4b1d-a154-2e85c396ea62"
+	block := func(attrs map[string]interface{}) []interface{} {
+		return []interface{}{attrs}
+	}
+	accessPoint := func(key string, value interface{}) []interface{} {
+		return block(map[string]interface{}{"type": string(v4.COLO_AccessPointType), key: value})
+	}
+	converters := map[string]struct {
+		toFabric func([]interface{}) error
+		terra    []interface{}
+		err      string
+	}{
+		"service token type": {
+			toFabric: func(terra []interface{}) error { _, err := serviceTokenToFabric(terra); return err },
+			terra:    block(map[string]interface{}{"type": "OTHER", "uuid": uuid}),
+			err:      "invalid service token type",
+		},
+		"service token uuid": {
+			toFabric: func(terra []interface{}) error { _, err := serviceTokenToFabric(terra); return err },
+			terra:    block(map[string]interface{}{"uuid": 1}),
+			err:      "invalid service_token.uuid",
+		},
+		"service token without uuid": {
+			toFabric: func(terra []interface{}) error { _, err := serviceTokenToFabric(terra); return err },
+			terra:    block(map[string]interface{}{"type": "VC_TOKEN"}),
+			err:      "service_token.uuid is required",
+		},
+		"additional info element": {
+			toFabric: func(terra []interface{}) error { _, err := additionalInfoTerraToGo(terra); return err },
+			terra:    []interface{}{"key"},
+			err:      "invalid additional_info.0",
+		},
+		"additional info key": {
+			toFabric: func(terra []interface{}) error { _, err := additionalInfoTerraToGo(terra); return err },
+			terra:    block(map[string]interface{}{"value": "value"}),
+			err:      "additional_info.0.key is required",
+		},
+		"access point blocks": {
+			toFabric: func(terra []interface{}) error { _, err := accessPointToFabric(terra); return err },
+			terra:    []interface{}{map[string]interface{}{}, map[string]interface{}{}},
+			err:      "at most one access_point block",
+		},
+		"access point type": {
+			toFabric: func(terra []interface{}) error { _, err := accessPointToFabric(terra); return err },
+			terra:    block(map[string]interface{}{"authentication_key": "key"}),
+			err:      "access_point.type is required",
+		},
+		"access point port": {
+			toFabric: func(terra []interface{}) error { _, err := accessPointToFabric(terra); return err },
+			terra:    accessPoint("port", "port"),
+			err:      "invalid access_point.port",
+		},
+		"access point port uuid": {
+			toFabric: func(terra []interface{}) error { _, err := accessPointToFabric(terra); return err },
+			terra:    accessPoint("port", block(map[string]interface{}{"uuid": ""})),
+			err:      "port.uuid is required",
+		},
+		"access point network uuid": {
+			toFabric: func(terra []interface{}) error { _, err := accessPointToFabric(terra); return err },
+			terra:    accessPoint("network", block(map[string]interface{}{})),
+			err:      "network.uuid is required",
+		},
+		"access point gateway uuid": {
+			toFabric: func(terra []interface{}) error { _, err := accessPointToFabric(terra); return err },
+			terra:    accessPoint("gateway", block(map[string]interface{}{"uuid": ""})),
+			err:      "router.uuid is required",
+		},
+		"port redundancy": {
+			toFabric: func(terra []interface{}) error { _, err := portToFabric(terra); return err },
+			terra:    block(map[string]interface{}{"uuid": uuid, "redundancy": block(map[string]interface{}{"priority": true})}),
+			err:      "invalid port.redundancy.priority",
+		},
+		"cloud router uuid": {
+			toFabric: func(terra []interface{}) error { _, err := cloudRouterToFabric(terra); return err },
+			terra:    block(map[string]interface{}{"uuid": ""}),
+			err:      "router.uuid is required",
+		},
+		"link protocol type": {
+			toFabric: func(terra []interface{}) error { _, err := linkProtocolToFabric(terra); return err },
+			terra:    block(map[string]interface{}{"vlan_tag": 100}),
+			err:      "link_protocol.type is required",
+		},
+		"link protocol vlan tag": {
+			toFabric: func(terra []interface{}) error { _, err := linkProtocolToFabric(terra); return err },
+			terra:    block(map[string]interface{}{"type": "DOT1Q", "vlan_tag": "100"}),
+			err:      "invalid link_protocol.vlan_tag",
+		},
+		"network uuid": {
+			toFabric: func(terra []interface{}) error { _, err := networkToFabric(terra); return err },
+			terra:    block(map[string]interface{}{"uuid": ""}),
+			err:      "network.uuid is required",
+		},
+		"service profile uuid": {
+			toFabric: func(terra []interface{}) error { _, err := simplifiedServiceProfileToFabric(terra); return err },
+			terra:    block(map[string]interface{}{"type": "L2_PROFILE"}),
+			err:      "profile.uuid is required",
+		},
+		"virtual device uuid": {
+			toFabric: func(terra []interface{}) error { _, err := virtualdeviceToFabric(terra); return err },
+			terra:    block(map[string]interface{}{"name": "device"}),
+			err:      "virtual_device.uuid is required",
+		},
+		"interface id": {
+			toFabric: func(terra []interface{}) error { _, err := interfaceToFabric(terra); return err },
+			terra:    block(map[string]interface{}{"type": "NETWORK", "id": "7"}),
+			err:      "invalid interface.id",
+		},
+		"routing protocol direct ipv4": {
+			toFabric: func(terra []interface{}) error { _, err := routingProtocolDirectIpv4ToFabric(terra); return err },
+			terra:    block(map[string]interface{}{"equinix_iface_ip": 1}),
+			err:      "invalid direct_ipv4.equinix_iface_ip",
+		},
+		"routing protocol direct ipv6": {
+			toFabric: func(terra []interface{}) error { _, err := routingProtocolDirectIpv6ToFabric(terra); return err },
+			terra:    []interface{}{"2001:db8::1"},
+			err:      "invalid direct_ipv6 block",
+		},
+		"routing protocol bgp ipv4": {
+			toFabric: func(terra []interface{}) error { _, err := routingProtocolBgpIpv4ToFabric(terra); return err },
+			terra:    block(map[string]interface{}{"customer_peer_ip": "192.168.100.2", "enabled": "true"}),
+			err:      "invalid bgp_ipv4.enabled",
+		},
+		"routing protocol bgp ipv6": {
+			toFabric: func(terra []interface{}) error { _, err := routingProtocolBgpIpv6ToFabric(terra); return err },
+			terra:    block(map[string]interface{}{"customer_peer_ip": []string{"2001:db8::2"}}),
+			err:      "invalid bgp_ipv6.customer_peer_ip",
+		},
+		"routing protocol bfd": {
+			toFabric: func(terra []interface{}) error { _, err := routingProtocolBfdToFabric(terra); return err },
+			terra:    block(map[string]interface{}{"enabled": true, "interval": 100}),
+			err:      "invalid bfd.interval",
+		},
+		"routing protocol change": {
+			toFabric: func(terra []interface{}) error { _, err := routingProtocolChangeToFabric(terra); return err },
+			terra:    []interface{}{map[string]interface{}{"uuid": uuid}, map[string]interface{}{"uuid": uuid}},
+			err:      "at most one change block",
+		},
+	}
+	for name, c := range converters {
+		// when
+		err := c.toFabric(c.terra)
+		// then
+		if assert.Error(t, err, "%s is rejected", name) {
+			assert.Contains(t, err.Error(), c.err, "%s error names the invalid input", name)
+		}
+	}
+}
+
+func TestFabricMappingToFabric_unsetBlock(t *testing.T) {
+	// given
+	unset := [][]interface{}{nil, {}, {nil}}
+	for _, terra := range unset {
+		// when
+		accessPoint, apErr := accessPointToFabric(terra)
+		port, portErr := portToFabric(terra)
+		linkProtocol, lpErr := linkProtocolToFabric(terra)
+		bgpIpv4, bgpErr := routingProtocolBgpIpv4ToFabric(terra)
+		// then
+		assert.NoError(t, apErr, "Unset access point is accepted")
+		assert.Nil(t, accessPoint.Type_, "Unset access point is empty")
+		assert.NoError(t, portErr, "Unset port is accepted")
+		assert.Empty(t, port.Uuid, "Unset port is empty")
+		assert.NoError(t, lpErr, "Unset link protocol is accepted")
+		assert.Nil(t, linkProtocol.Type_, "Unset link protocol is empty")
+		assert.NoError(t, bgpErr, "Unset bgp ipv4 is accepted")
+		assert.Empty(t, bgpIpv4.CustomerPeerIp, "Unset bgp ipv4 is empty")
+	}
+}
+
+func TestFabricMappingToFabric_accessPoint(t *testing.T) {
+	// given
+	terra := []interface{}{map[string]interface{}{
+		"type":          string(v4.COLO_AccessPointType),
+		"peering_type":  string(v4.PRIVATE_PeeringType),
+		"port":          []interface{}{map[string]interface{}{"uuid": "port-uuid"}},
+		"gateway":       []interface{}{map[string]interface{}{"uuid": "gateway-uuid"}},
+		"link_protocol": []interface{}{map[string]interface{}{"type": "DOT1Q", "vlan_tag": 1001}},
+		"network":       []interface{}{},
+	}}
+	// when
+	accessPoint, err := accessPointToFabric(terra)
+	// then
+	require.NoError(t, err, "Access point is converted")
+	assert.Equal(t, v4.COLO_AccessPointType, *accessPoint.Type_, "Access point type is converted")
+	assert.Equal(t, v4.PRIVATE_PeeringType, *accessPoint.PeeringType, "Peering type is converted")
+	require.NotNil(t, accessPoint.Port, "Port is converted")
+	assert.Equal(t, "port-uuid", accessPoint.Port.Uuid, "Port uuid is converted")
+	require.NotNil(t, accessPoint.Router, "Deprecated gateway is converted to a router")
+	assert.Equal(t, "gateway-uuid", accessPoint.Router.Uuid, "Gateway uuid is converted")
+	require.NotNil(t, accessPoint.LinkProtocol, "Link protocol is converted")
+	assert.Equal(t, int32(1001), accessPoint.LinkProtocol.VlanTag, "Vlan tag is converted")
+	assert.Nil(t, accessPoint.Network, "Unset network is not sent")
+	assert.Nil(t, accessPoint.Profile, "Unset profile is not sent")
+}
+
+// assertTerraRoundTrip asserts that every attribute of the expected terra value
+// is in the actual one. Attributes that are missing from the actual value must
+// have a zero value, as Terraform doesn't tell them apart, and attributes that
+// are only in the actual value, like computed ones, are ignored.
+func assertTerraRoundTrip(t *testing.T, path string, expected, actual interface{}) {
+	t.Helper()
+	switch expected := expected.(type) {
+	case []interface{}:
+		actualList := terraList(actual)
+		if len(expected) == 0 && isZeroTerraValue(actualList) {
+			// An unset block may come back as a block of which no attribute is set
+			return
+		}
+		if !assert.Len(t, actualList, len(expected), "%s has the same number of elements", path) {
+			return
+		}
+		for i := range expected {
+			assertTerraRoundTrip(t, fmt.Sprintf("%s.%d", path, i), expected[i], actualList[i])
+		}
+	case map[string]interface{}:
+		actualMap, ok := actual.(map[string]interface{})
+		if !assert.True(t, ok, "%s is a map, got %#v", path, actual) {
+			return
+		}
+		for k, v := range expected {
+			actualValue, ok := actualMap[k]
+			if !ok {
+				assert.True(t, isZeroTerraValue(v), "%s.%s is missing", path, k)
+				continue
+			}
+			assertTerraRoundTrip(t, path+"."+k, v, actualValue)
+		}
+	default:
+		assert.Equal(t, expected, actual, path)
+	}
+}
+
+func terraList(v interface{}) []interface{} {
+	switch v := v.(type) {
+	case *schema.Set:
+		if v == nil {
+			return nil
+		}
+		return v.List()
+	case []map[string]interface{}:
+		list := make([]interface{}, 0, len(v))
+		for _, m := range v {
+			list = append(list, m)
+		}
+		return list
+	case []interface{}:
+		return v
+	}
+	return nil
+}
+
+// isZeroTerraValue returns true for zero values, and for lists and maps that
+// only hold zero values, like a block of which no attribute is set.
+func isZeroTerraValue(v interface{}) bool {
+	switch v := v.(type) {
+	case []interface{}:
+		for _, e := range v {
+			if !isZeroTerraValue(e) {
+				return false
+			}
+		}
+		return true
+	case map[string]interface{}:
+		for _, e := range v {
+			if !isZeroTerraValue(e) {
+				return false
+			}
+		}
+		return true
+	}
+	value := reflect.ValueOf(v)
+	return !value.IsValid() || value.IsZero()
+}
+
+func TestFabricMapping_propertyAttributePath(t *testing.T) {
+	// given
+	connectionSchema := fabricConnectionResourceSchema()
+	properties := map[string]string{
+		"/zSide/accessPoint/profile/uuid": "z_side.0.access_point.0.profile.0.uuid",
+		"aSide.accessPoint.linkProtocol":  "a_side.0.access_point.0.link_protocol",
+		"/notifications/1/emails":         "notifications.1.emails",
+		"/bandwidth":                      "bandwidth",
+		"/zSide/accessPoint/unknownField": "",
+		"/bandwidth/value":                "",
+		"":                                "",
+	}
+	for property, want := range properties {
+		// when
+		attributePath := fabricPropertyAttributePath(connectionSchema, property)
+		// then
+		assert.Equal(t, want, attributePath, "Attribute path of %q", property)
+	}
+}
@@ -352,19 +352,21 @@ func createFabricRoutingProtocolResourceSchema() map[string]*schema.Schema {
 			},
 		},
 		"customer_asn": {
-			Type:        schema.TypeInt,
-			Optional:    true,
-			Description: "Customer-provided ASN",
+			Type:         schema.TypeInt,
+			Optional:     true,
+			ValidateFunc: validateCustomerAsn,
+			Description:  "Customer-provided ASN. Both 2-byte and 4-byte ASNs are supported, reserved and documentation ASNs are rejected",
 		},
 		"equinix_asn": {
 			Type:        schema.TypeInt,
 			Computed:    true,
-			Description: "Equinix ASN",
+			Description: "Equinix ASN. Set automatically from the Cloud Router for Cloud Router connections",
 		},
 		"bgp_auth_key": {
 			Type:        schema.TypeString,
 			Optional:    true,
-			Description: "BGP authorization key",
+			Sensitive:   true,
+			Description: "BGP authorization key. Changing it rotates the key in place",
 		},
 		"bfd": {
 			Type:        schema.TypeSet,
@@ -374,6 +376,18 @@ func createFabricRoutingProtocolResourceSchema() map[string]*schema.Schema {
 				Schema: createRoutingProtocolBfdSch(),
 			},
 		},
+		"ipv4_advertised_prefix_count": {
+			Type:         schema.TypeInt,
+			Optional:     true,
+			ValidateFunc: validation.IntAtLeast(0),
+			Description:  "Number of IPv4 prefixes expected to be advertised over the BGP session. Checked against the Fabric Cloud Router package limit when planning and before creation",
+		},
+		"ipv6_advertised_prefix_count": {
+			Type:         schema.TypeInt,
+			Optional:     true,
+			ValidateFunc: validation.IntAtLeast(0),
+			Description:  "Number of IPv6 prefixes expected to be advertised over the BGP session. Checked against the Fabric Cloud Router package limit when planning and before creation",
+		},
 		"change_log": {
 			Type:        schema.TypeSet,
 			Computed:    true,
@@ -0,0 +1,147 @@
+package equinix
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/antihax/optional"
+	v4 "github.com/equinix-labs/fabric-go/fabric/v4"
+	"github.com/equinix/terraform-provider-equinix/internal/config"
+	"github.com/equinix/terraform-provider-equinix/internal/sweep"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+const fabricSweepPageSize = 100
+
+func init() {
+	resource.AddTestSweepers("equinix_fabric_cloud_router_PFCR", &resource.Sweeper{
+		Name:         "equinix_fabric_cloud_router",
+		Dependencies: []string{"equinix_fabric_connection_PNFV"},
+		F:            testSweepCloudRouters,
+	})
+	resource.AddTestSweepers("equinix_fabric_service_token_PFCR", &resource.Sweeper{
+		Name: "equinix_fabric_service_token",
+		F:    testSweepServiceTokens,
+	})
+}
+
+// sharedFabricConfigForSweep returns the loaded configuration and the options
+// of the Fabric sweepers.
+func sharedFabricConfigForSweep(region string) (context.Context, *config.Config, sweep.Options, error) {
+	opts, err := sweep.OptionsFromEnv()
+	if err != nil {
+		return nil, nil, opts, err
+	}
+	meta, err := sharedConfigForRegion(region)
+	if err != nil {
+		return nil, nil, opts, err
+	}
+	if err := meta.Load(context.Background()); err != nil {
+		return nil, nil, opts, err
+	}
+	ctx := context.WithValue(context.Background(), v4.ContextAccessToken, meta.FabricAuthToken)
+	return ctx, meta, opts, nil
+}
+
+func testSweepCloudRouters(region string) error {
+	log.Printf("[DEBUG] Sweeping Fabric cloud routers")
+	ctx, meta, opts, err := sharedFabricConfigForSweep(region)
+	if err != nil {
+		return fmt.Errorf("[INFO][SWEEPER_LOG] Error getting configuration for sweeping Fabric cloud routers: %s", err)
+	}
+	client := meta.FabricClient
+
+	var cloudRouters []v4.CloudRouter
+	for {
+		resp, _, err := client.CloudRoutersApi.SearchCloudRouters(ctx, v4.CloudRouterSearchRequest{
+			Pagination: &v4.PaginationRequest{Offset: int32(len(cloudRouters)), Limit: fabricSweepPageSize},
+		})
+		if err != nil {
+			return fmt.Errorf("[INFO][SWEEPER_LOG] Error listing Fabric cloud routers for sweeping: %s", err)
+		}
+		cloudRouters = append(cloudRouters, resp.Data...)
+		if len(resp.Data) == 0 || resp.Pagination == nil || len(cloudRouters) >= int(resp.Pagination.Total) {
+			break
+		}
+	}
+
+	now := time.Now()
+	var errs []error
+	for _, fcr := range cloudRouters {
+		if fcr.State != nil && *fcr.State == v4.DEPROVISIONED_CloudRouterAccessPointState {
+			continue
+		}
+		var created time.Time
+		if fcr.ChangeLog != nil {
+			created = fcr.ChangeLog.CreatedDateTime
+		}
+		if !opts.IsOrphaned(fcr.Name, created, now) {
+			continue
+		}
+		uuid := fcr.Uuid
+		err := opts.Delete("Fabric cloud router", fcr.Name, uuid, func() error {
+			_, err := client.CloudRoutersApi.DeleteCloudRouterByUuid(ctx, uuid)
+			return err
+		})
+		if err != nil {
+			errs = append(errs, err)
+		}
+	}
+	if len(errs) != 0 {
+		return fmt.Errorf("[INFO][SWEEPER_LOG] Error sweeping Fabric cloud routers: %v", errs)
+	}
+	return nil
+}
+
+func testSweepServiceTokens(region string) error {
+	log.Printf("[DEBUG] Sweeping Fabric service tokens")
+	ctx, meta, opts, err := sharedFabricConfigForSweep(region)
+	if err != nil {
+		return fmt.Errorf("[INFO][SWEEPER_LOG] Error getting configuration for sweeping Fabric service tokens: %s", err)
+	}
+	client := meta.FabricClient
+
+	var serviceTokens []v4.ServiceToken
+	for {
+		resp, _, err := client.ServiceTokensApi.GetServiceTokens(ctx, &v4.ServiceTokensApiGetServiceTokensOpts{
+			Offset: optional.NewFloat64(float64(len(serviceTokens))),
+			Limit:  optional.NewFloat64(fabricSweepPageSize),
+		})
+		if err != nil {
+			return fmt.Errorf("[INFO][SWEEPER_LOG] Error listing Fabric service tokens for sweeping: %s", err)
+		}
+		serviceTokens = append(serviceTokens, resp.Data...)
+		if len(resp.Data) == 0 || resp.Pagination == nil || len(serviceTokens) >= int(resp.Pagination.Total) {
+			break
+		}
+	}
+
+	now := time.Now()
+	var errs []error
+	for _, token := range serviceTokens {
+		if token.State != nil && *token.State == v4.DELETED_ServiceTokenState {
+			continue
+		}
+		var created time.Time
+		if token.Changelog != nil {
+			created = token.Changelog.CreatedDateTime
+		}
+		if !opts.IsOrphaned(token.Name, created, now) {
+			continue
+		}
+		uuid := token.Uuid
+		err := opts.Delete("Fabric service token", token.Name, uuid, func() error {
+			_, err := client.ServiceTokensApi.DeleteServiceTokenByUuid(ctx, uuid)
+			return err
+		})
+		if err != nil {
+			errs = append(errs, err)
+		}
+	}
+	if len(errs) != 0 {
+		return fmt.Errorf("[INFO][SWEEPER_LOG] Error sweeping Fabric service tokens: %v", errs)
+	}
+	return nil
+}
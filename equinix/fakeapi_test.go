@@ -0,0 +1,29 @@
+package equinix
+
+import (
+	"context"
+	"testing"
+
+	"github.com/equinix/terraform-provider-equinix/internal/config"
+	"github.com/equinix/terraform-provider-equinix/internal/fakeapi"
+	"github.com/stretchr/testify/require"
+)
+
+// newFakeAPIConfig returns a provider configuration loaded against a fake API
+// server, closed when the test completes. The options update the configuration
+// before it is loaded.
+func newFakeAPIConfig(t *testing.T, options ...func(*config.Config)) *config.Config {
+	t.Helper()
+	_, srv := fakeapi.NewTestServer()
+	t.Cleanup(srv.Close)
+	c := &config.Config{
+		BaseURL:   srv.URL,
+		Token:     "fabric-token",
+		AuthToken: "metal-token",
+	}
+	for _, option := range options {
+		option(c)
+	}
+	require.NoError(t, c.Load(context.Background()))
+	return c
+}
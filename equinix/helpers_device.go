@@ -4,9 +4,11 @@ import (
 	"context"
 	"encoding/json"
 	"errors"
+	"fmt"
 	"log"
 	"path"
 	"sort"
+	"strconv"
 	"sync"
 	"time"
 
@@ -25,6 +27,16 @@ const (
 	provisionable  = "provisionable"
 	reprovisioned  = "reprovisioned"
 	errstate       = "error"
+
+	deviceReconcileReplace   = "replace"
+	deviceReconcileReinstall = "reinstall"
+
+	customImageRepoKey = "image_repo"
+	customImageTagKey  = "image_tag"
+
+	// deviceRootPasswordValidity is how long after the device creation its
+	// root password can be used.
+	deviceRootPasswordValidity = 24 * time.Hour
 )
 
 var (
@@ -41,6 +53,29 @@ type NetworkInfo struct {
 	PrivateIPv4    string
 }
 
+// addCustomImageToCustomdata adds the repository and tag of a custom image to the device
+// customdata, where the Equinix Metal API expects them. Setting the same keys through
+// custom_data and the custom image arguments at once is an error.
+func addCustomImageToCustomdata(customdata map[string]interface{}, imageURL, imageTag string) (map[string]interface{}, error) {
+	if imageURL == "" {
+		return customdata, nil
+	}
+	for _, key := range []string{customImageRepoKey, customImageTagKey} {
+		if _, ok := customdata[key]; ok {
+			return nil, fmt.Errorf("custom_data can not set %q when custom_image_url is specified", key)
+		}
+	}
+	merged := make(map[string]interface{}, len(customdata)+2)
+	for k, v := range customdata {
+		merged[k] = v
+	}
+	merged[customImageRepoKey] = imageURL
+	if imageTag != "" {
+		merged[customImageTagKey] = imageTag
+	}
+	return merged, nil
+}
+
 func getNetworkInfo(ips []metalv1.IPAssignment) NetworkInfo {
 	ni := NetworkInfo{Networks: make([]map[string]interface{}, 0, 1)}
 	for _, ip := range ips {
@@ -68,6 +103,10 @@ func getNetworkInfo(ips []metalv1.IPAssignment) NetworkInfo {
 			}
 		}
 	}
+	// IPv6 only devices can only be reached on their public IPv6 address
+	if ni.Host == "" {
+		ni.Host = ni.PublicIPv6
+	}
 	return ni
 }
 
@@ -182,6 +221,38 @@ func waitForDeviceAttribute(ctx context.Context, d *schema.ResourceData, stateCo
 	return "", err
 }
 
+// deviceSpotPriceMax returns the maximum bid price of a spot instance. The API
+// returns it as a float32, which is converted through its shortest decimal
+// representation so that e.g. 0.15 is not stored as 0.15000000596.
+func deviceSpotPriceMax(device metalv1.Device) float64 {
+	price, _ := strconv.ParseFloat(strconv.FormatFloat(float64(device.GetSpotPriceMax()), 'f', -1, 32), 64)
+	return price
+}
+
+// deviceSosURL returns the SSH URL of the Serial over SSH console of a device,
+// on which the device ID is the user name. It is empty if the API doesn't
+// report the SOS hostname.
+func deviceSosURL(device metalv1.Device) string {
+	if device.GetSos() == "" || device.GetId() == "" {
+		return ""
+	}
+	return fmt.Sprintf("ssh://%s@%s", device.GetId(), device.GetSos())
+}
+
+// deviceRootPassword returns the root password of a device and when it
+// expires. An expired password is not returned, so that it is removed from the
+// state on the next read.
+func deviceRootPassword(device metalv1.Device, now time.Time) (string, string) {
+	if device.CreatedAt == nil {
+		return device.GetRootPassword(), ""
+	}
+	expiresAt := device.GetCreatedAt().Add(deviceRootPasswordValidity)
+	if !now.Before(expiresAt) {
+		return "", expiresAt.Format(time.RFC3339)
+	}
+	return device.GetRootPassword(), expiresAt.Format(time.RFC3339)
+}
+
 func getDeviceMap(device metalv1.Device) map[string]interface{} {
 	networkInfo := getNetworkInfo(device.IpAddresses)
 	sort.SliceStable(networkInfo.Networks, func(i, j int) bool {
@@ -196,28 +267,33 @@ func getDeviceMap(device metalv1.Device) map[string]interface{} {
 		keyIDs = append(keyIDs, path.Base(k.GetHref()))
 	}
 	ports := getPorts(device.NetworkPorts)
+	rootPassword, rootPasswordExpiresAt := deviceRootPassword(device, time.Now())
 
 	return map[string]interface{}{
-		"hostname":            device.GetHostname(),
-		"project_id":          device.Project.GetId(),
-		"description":         device.GetDescription(),
-		"device_id":           device.GetId(),
-		"facility":            device.Facility.GetCode(),
-		"metro":               device.Metro.GetCode(),
-		"plan":                device.Plan.GetSlug(),
-		"operating_system":    device.OperatingSystem.GetSlug(),
-		"state":               device.GetState(),
-		"billing_cycle":       device.GetBillingCycle(),
-		"ipxe_script_url":     device.GetIpxeScriptUrl(),
-		"always_pxe":          device.GetAlwaysPxe(),
-		"root_password":       device.GetRootPassword(),
-		"tags":                converters.StringArrToIfArr(device.GetTags()),
-		"access_public_ipv6":  networkInfo.PublicIPv6,
-		"access_public_ipv4":  networkInfo.PublicIPv4,
-		"access_private_ipv4": networkInfo.PrivateIPv4,
-		"network":             networkInfo.Networks,
-		"ssh_key_ids":         keyIDs,
-		"ports":               ports,
-		"sos_hostname":        device.GetSos(),
+		"hostname":                 device.GetHostname(),
+		"project_id":               device.Project.GetId(),
+		"description":              device.GetDescription(),
+		"device_id":                device.GetId(),
+		"facility":                 device.Facility.GetCode(),
+		"metro":                    device.Metro.GetCode(),
+		"plan":                     device.Plan.GetSlug(),
+		"operating_system":         device.OperatingSystem.GetSlug(),
+		"state":                    device.GetState(),
+		"billing_cycle":            device.GetBillingCycle(),
+		"ipxe_script_url":          device.GetIpxeScriptUrl(),
+		"always_pxe":               device.GetAlwaysPxe(),
+		"root_password":            rootPassword,
+		"root_password_expires_at": rootPasswordExpiresAt,
+		"spot_instance":            device.GetSpotInstance(),
+		"spot_price_max":           deviceSpotPriceMax(device),
+		"tags":                     converters.StringArrToIfArr(device.GetTags()),
+		"access_public_ipv6":       networkInfo.PublicIPv6,
+		"access_public_ipv4":       networkInfo.PublicIPv4,
+		"access_private_ipv4":      networkInfo.PrivateIPv4,
+		"network":                  networkInfo.Networks,
+		"ssh_key_ids":              keyIDs,
+		"ports":                    ports,
+		"sos_hostname":             device.GetSos(),
+		"sos_url":                  deviceSosURL(device),
 	}
 }
@@ -4,12 +4,14 @@ import (
 	"context"
 	"net/http"
 	"net/http/httptest"
+	"reflect"
 	"strings"
 	"testing"
 	"time"
 
 	"github.com/equinix/equinix-sdk-go/services/metalv1"
 	"github.com/equinix/terraform-provider-equinix/internal/config"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 )
 
 func Test_waitUntilReservationProvisionable(t *testing.T) {
@@ -169,3 +171,191 @@ func Test_waitUntilReservationProvisionable(t *testing.T) {
 		})
 	}
 }
+
+func Test_addCustomImageToCustomdata(t *testing.T) {
+	// given
+	customdata := map[string]interface{}{"foo": "bar"}
+	// when
+	merged, err := addCustomImageToCustomdata(customdata, "https://github.com/example/images", "abc123")
+	// then
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	expected := map[string]interface{}{
+		"foo":        "bar",
+		"image_repo": "https://github.com/example/images",
+		"image_tag":  "abc123",
+	}
+	if !reflect.DeepEqual(expected, merged) {
+		t.Errorf("expected %v, got %v", expected, merged)
+	}
+	if _, ok := customdata["image_repo"]; ok {
+		t.Errorf("input customdata must not be modified")
+	}
+}
+
+func Test_addCustomImageToCustomdata_conflict(t *testing.T) {
+	// given
+	customdata := map[string]interface{}{"image_repo": "https://github.com/example/other"}
+	// when
+	_, err := addCustomImageToCustomdata(customdata, "https://github.com/example/images", "")
+	// then
+	if err == nil {
+		t.Errorf("expected an error when custom_data already sets image_repo")
+	}
+}
+
+func Test_addCustomImageToCustomdata_noImage(t *testing.T) {
+	// when
+	merged, err := addCustomImageToCustomdata(nil, "", "")
+	// then
+	if err != nil || merged != nil {
+		t.Errorf("expected nil customdata and no error, got %v, %v", merged, err)
+	}
+}
+
+func Test_getNetworkInfo_ipv6Only(t *testing.T) {
+	// given
+	ip := metalv1.IPAssignment{}
+	ip.SetAddress("2604:1380::1")
+	ip.SetAddressFamily(6)
+	ip.SetCidr(127)
+	ip.SetPublic(true)
+	ip.SetManagement(true)
+	// when
+	ni := getNetworkInfo([]metalv1.IPAssignment{ip})
+	// then
+	if ni.PublicIPv6 != "2604:1380::1" || ni.Host != ni.PublicIPv6 {
+		t.Errorf("expected public IPv6 to be used as host, got %+v", ni)
+	}
+	if ni.PublicIPv4 != "" || ni.PrivateIPv4 != "" {
+		t.Errorf("expected no IPv4 addresses, got %+v", ni)
+	}
+}
+
+func Test_validateDeviceIPAddresses(t *testing.T) {
+	ipAddress := func(ipType string, cidr int, reservations ...interface{}) interface{} {
+		return map[string]interface{}{"type": ipType, "cidr": cidr, "reservation_ids": reservations}
+	}
+	tests := []struct {
+		name    string
+		input   []interface{}
+		wantErr bool
+	}{
+		{"ipv6 only", []interface{}{ipAddress("public_ipv6", 127)}, false},
+		{"dual stack", []interface{}{ipAddress("private_ipv4", 30), ipAddress("public_ipv4", 31, "reservation"), ipAddress("public_ipv6", 0)}, false},
+		{"duplicate type", []interface{}{ipAddress("public_ipv6", 0), ipAddress("public_ipv6", 0)}, true},
+		{"public ipv4 without private", []interface{}{ipAddress("public_ipv4", 31), ipAddress("public_ipv6", 0)}, true},
+		{"ipv4 cidr out of range", []interface{}{ipAddress("private_ipv4", 64)}, true},
+		{"ipv6 reservation", []interface{}{ipAddress("public_ipv6", 0, "reservation")}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := validateDeviceIPAddresses(context.Background(), tt.input, nil); (err != nil) != tt.wantErr {
+				t.Errorf("validateDeviceIPAddresses() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func Test_validateDeviceHostname(t *testing.T) {
+	tests := []struct {
+		hostname string
+		wantErr  bool
+	}{
+		{"web-1", false},
+		{"Web01.example.com", false},
+		{strings.Repeat("a", 63), false},
+		{strings.Repeat("a", 64), true},
+		{"-web", true},
+		{"web-", true},
+		{"web_1", true},
+		{"web..example", true},
+		{"", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.hostname, func(t *testing.T) {
+			if _, errs := validateDeviceHostname(tt.hostname, "hostname"); (len(errs) > 0) != tt.wantErr {
+				t.Errorf("validateDeviceHostname() errors = %v, wantErr %v", errs, tt.wantErr)
+			}
+		})
+	}
+}
+
+func Test_validateDeviceHostnamePrefix(t *testing.T) {
+	if _, errs := validateDeviceHostnamePrefix("web-", "hostname_prefix"); len(errs) > 0 {
+		t.Errorf("validateDeviceHostnamePrefix() unexpected errors = %v", errs)
+	}
+	if _, errs := validateDeviceHostnamePrefix(strings.Repeat("a", 60), "hostname_prefix"); len(errs) == 0 {
+		t.Errorf("validateDeviceHostnamePrefix() expected an error for a prefix leaving no room for the suffix")
+	}
+}
+
+func Test_generateDeviceHostname(t *testing.T) {
+	r := resourceMetalDevice()
+	cfg := terraform.NewResourceConfigRaw(map[string]interface{}{
+		"project_id":       "project",
+		"metro":            "sv",
+		"plan":             "c3.small.x86",
+		"operating_system": "ubuntu_22_04",
+		"billing_cycle":    "hourly",
+		"hostname_prefix":  "web-",
+	})
+	diff, err := r.Diff(context.Background(), nil, cfg, nil)
+	if err != nil {
+		t.Fatalf("Diff() error = %v", err)
+	}
+	hostname := diff.Attributes["hostname"].New
+	if !strings.HasPrefix(hostname, "web-") || len(hostname) != len("web-")+deviceHostnameSuffixLength {
+		t.Errorf("generated hostname = %q, want web- followed by a %d characters suffix", hostname, deviceHostnameSuffixLength)
+	}
+	if _, errs := validateDeviceHostname(hostname, "hostname"); len(errs) > 0 {
+		t.Errorf("generated hostname is invalid: %v", errs)
+	}
+}
+
+func Test_deviceRootPassword(t *testing.T) {
+	created := time.Date(2024, 1, 10, 8, 0, 0, 0, time.UTC)
+	device := metalv1.Device{CreatedAt: &created}
+	device.SetRootPassword("secret")
+
+	password, expiresAt := deviceRootPassword(device, created.Add(time.Hour))
+	if password != "secret" || expiresAt != "2024-01-11T08:00:00Z" {
+		t.Errorf("deviceRootPassword() = %q, %q, want the password expiring 24 hours after creation", password, expiresAt)
+	}
+
+	password, expiresAt = deviceRootPassword(device, created.Add(deviceRootPasswordValidity))
+	if password != "" || expiresAt != "2024-01-11T08:00:00Z" {
+		t.Errorf("deviceRootPassword() = %q, %q, want no password once expired", password, expiresAt)
+	}
+
+	password, expiresAt = deviceRootPassword(metalv1.Device{RootPassword: device.RootPassword}, created)
+	if password != "secret" || expiresAt != "" {
+		t.Errorf("deviceRootPassword() = %q, %q, want the password without expiry for an unknown creation time", password, expiresAt)
+	}
+}
+
+func Test_deviceSpotPriceMax(t *testing.T) {
+	device := metalv1.Device{}
+	device.SetSpotPriceMax(0.15)
+
+	if price := deviceSpotPriceMax(device); price != 0.15 {
+		t.Errorf("deviceSpotPriceMax() = %v, want 0.15", price)
+	}
+	if price := deviceSpotPriceMax(metalv1.Device{}); price != 0 {
+		t.Errorf("deviceSpotPriceMax() = %v, want 0 for on-demand devices", price)
+	}
+}
+
+func Test_deviceSosURL(t *testing.T) {
+	device := metalv1.Device{}
+	device.SetId("4c641195-25e5-4c3c-b2b7-4cd7a42c7b40")
+	device.SetSos("sos.da11.platformequinix.com")
+
+	if url := deviceSosURL(device); url != "ssh://4c641195-25e5-4c3c-b2b7-4cd7a42c7b40@sos.da11.platformequinix.com" {
+		t.Errorf("deviceSosURL() = %q, want the device ID as user of the SOS hostname", url)
+	}
+	if url := deviceSosURL(metalv1.Device{Id: device.Id}); url != "" {
+		t.Errorf("deviceSosURL() = %q, want no URL without SOS hostname", url)
+	}
+}
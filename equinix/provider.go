@@ -7,8 +7,11 @@ import (
 	"time"
 
 	"github.com/equinix/terraform-provider-equinix/internal/config"
+	"github.com/equinix/terraform-provider-equinix/internal/resources/metal/device_network_type"
 	"github.com/equinix/terraform-provider-equinix/internal/resources/metal/metal_connection"
+	"github.com/equinix/terraform-provider-equinix/internal/resources/metal/organization_member"
 	metal_project "github.com/equinix/terraform-provider-equinix/internal/resources/metal/project"
+	"github.com/equinix/terraform-provider-equinix/internal/resources/metal/project_backend_transfer"
 	"github.com/equinix/terraform-provider-equinix/internal/resources/metal/vrf"
 
 	"github.com/equinix/ecx-go/v2"
@@ -17,6 +20,38 @@ import (
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
+// internalResources are the resources of the packages under
+// internal/resources, each package listing its resources by type name.
+var internalResources = []func() map[string]*schema.Resource{
+	device_network_type.Resources,
+	metal_connection.Resources,
+	organization_member.Resources,
+	metal_project.Resources,
+	project_backend_transfer.Resources,
+	vrf.Resources,
+}
+
+// internalDataSources are the data sources of the packages under
+// internal/resources, see internalResources.
+var internalDataSources = []func() map[string]*schema.Resource{
+	metal_connection.DataSources,
+	metal_project.DataSources,
+	vrf.DataSources,
+}
+
+// registerResources adds the resources listed by the packages to the provider
+// resources or data sources. Type names can only be registered once.
+func registerResources(registry map[string]*schema.Resource, packages ...func() map[string]*schema.Resource) {
+	for _, resources := range packages {
+		for typeName, r := range resources() {
+			if _, ok := registry[typeName]; ok {
+				panic(fmt.Sprintf("%s is registered more than once", typeName))
+			}
+			registry[typeName] = r
+		}
+	}
+}
+
 // Provider returns Equinix terraform *schema.Provider
 func Provider() *schema.Provider {
 	provider := &schema.Provider{
@@ -77,44 +112,102 @@ func Provider() *schema.Provider {
 				Default:     30,
 				Description: "Maximum number of seconds to wait before retrying a request.",
 			},
+			"additional_headers": {
+				Type:        schema.TypeMap,
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "Map of additional HTTP headers to include in every Equinix Fabric and Network Edge API request. Authentication headers can not be overridden.",
+			},
+			"fabric_deprovisioned_as_error": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Report an error instead of removing an Equinix Fabric connection from state when it is found DEPROVISIONED outside of Terraform. Defaults to false",
+			},
+			"fabric_correlation_prefix": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Prefix prepended to the random correlation ID sent with every Equinix Fabric API request, e.g. a CI job ID, to map Equinix support logs back to pipeline runs",
+				ValidateFunc: validation.All(
+					validation.StringLenBetween(0, config.CorrelationPrefixMaxLength),
+					validation.StringMatch(config.CorrelationPrefixRe, "must only contain letters, digits and the characters #$&@._-"),
+				),
+			},
+			"dry_run": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Validate Equinix Fabric orders, i.e. the creation and bandwidth updates of connections and the creation and package changes of cloud routers, against the Fabric price API instead of placing them. The plan reports the validation result and fails without ordering anything. Defaults to false",
+			},
+			"enable_metal": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     true,
+				Description: "Construct the Equinix Metal clients and require their credentials. Metal resources and data sources can't be used when false. Defaults to true",
+			},
+			"enable_fabric": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     true,
+				Description: "Construct the Equinix Fabric clients and require their credentials. Fabric and ECX resources and data sources can't be used when false. Defaults to true",
+			},
+			"enable_network_edge": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     true,
+				Description: "Construct the Network Edge client and require its credentials. Network Edge resources and data sources can't be used when false. Defaults to true",
+			},
 		},
 		DataSourcesMap: map[string]*schema.Resource{
-			"equinix_ecx_port":                   dataSourceECXPort(),
-			"equinix_ecx_l2_sellerprofile":       dataSourceECXL2SellerProfile(),
-			"equinix_ecx_l2_sellerprofiles":      dataSourceECXL2SellerProfiles(),
-			"equinix_fabric_routing_protocol":    dataSourceRoutingProtocol(),
-			"equinix_fabric_connection":          dataSourceFabricConnection(),
-			"equinix_fabric_cloud_router":        dataSourceFabricCloudRouter(),
-			"equinix_fabric_network":             dataSourceFabricNetwork(),
-			"equinix_fabric_port":                dataSourceFabricPort(),
-			"equinix_fabric_ports":               dataSourceFabricGetPortsByName(),
-			"equinix_fabric_service_profile":     dataSourceFabricServiceProfileReadByUuid(),
-			"equinix_fabric_service_profiles":    dataSourceFabricSearchServiceProfilesByName(),
-			"equinix_network_account":            dataSourceNetworkAccount(),
-			"equinix_network_device":             dataSourceNetworkDevice(),
-			"equinix_network_device_type":        dataSourceNetworkDeviceType(),
-			"equinix_network_device_software":    dataSourceNetworkDeviceSoftware(),
-			"equinix_network_device_platform":    dataSourceNetworkDevicePlatform(),
-			"equinix_metal_hardware_reservation": dataSourceMetalHardwareReservation(),
-			"equinix_metal_metro":                dataSourceMetalMetro(),
-			"equinix_metal_facility":             dataSourceMetalFacility(),
-			"equinix_metal_connection":           metal_connection.DataSource(),
-			"equinix_metal_ip_block_ranges":      dataSourceMetalIPBlockRanges(),
-			"equinix_metal_precreated_ip_block":  dataSourceMetalPreCreatedIPBlock(),
-			"equinix_metal_operating_system":     dataSourceOperatingSystem(),
-			"equinix_metal_organization":         dataSourceMetalOrganization(),
-			"equinix_metal_spot_market_price":    dataSourceSpotMarketPrice(),
-			"equinix_metal_device":               dataSourceMetalDevice(),
-			"equinix_metal_devices":              dataSourceMetalDevices(),
-			"equinix_metal_device_bgp_neighbors": dataSourceMetalDeviceBGPNeighbors(),
-			"equinix_metal_plans":                dataSourceMetalPlans(),
-			"equinix_metal_port":                 dataSourceMetalPort(),
-			"equinix_metal_project":              metal_project.DataSource(),
-			"equinix_metal_reserved_ip_block":    dataSourceMetalReservedIPBlock(),
-			"equinix_metal_spot_market_request":  dataSourceMetalSpotMarketRequest(),
-			"equinix_metal_virtual_circuit":      dataSourceMetalVirtualCircuit(),
-			"equinix_metal_vlan":                 dataSourceMetalVlan(),
-			"equinix_metal_vrf":                  vrf.DataSource(),
+			"equinix_ecx_port":                        dataSourceECXPort(),
+			"equinix_ecx_l2_sellerprofile":            dataSourceECXL2SellerProfile(),
+			"equinix_ecx_l2_sellerprofiles":           dataSourceECXL2SellerProfiles(),
+			"equinix_fabric_routing_protocol":         dataSourceRoutingProtocol(),
+			"equinix_fabric_routing_protocols":        dataSourceFabricRoutingProtocols(),
+			"equinix_fabric_routing_protocol_state":   dataSourceFabricRoutingProtocolState(),
+			"equinix_fabric_connection_statistics":    dataSourceFabricConnectionStatistics(),
+			"equinix_fabric_connection":               dataSourceFabricConnection(),
+			"equinix_fabric_connection_price":         dataSourceFabricConnectionPrice(),
+			"equinix_fabric_connections":              dataSourceFabricConnections(),
+			"equinix_fabric_cloud_router":             dataSourceFabricCloudRouter(),
+			"equinix_fabric_cloud_routers":            dataSourceFabricCloudRouters(),
+			"equinix_fabric_cloud_router_routes":      dataSourceFabricCloudRouterRoutes(),
+			"equinix_fabric_metros":                   dataSourceFabricMetros(),
+			"equinix_fabric_network":                  dataSourceFabricNetwork(),
+			"equinix_fabric_networks":                 dataSourceFabricNetworks(),
+			"equinix_fabric_port":                     dataSourceFabricPort(),
+			"equinix_fabric_ports":                    dataSourceFabricGetPortsByName(),
+			"equinix_fabric_service_profile":          dataSourceFabricServiceProfileReadByUuid(),
+			"equinix_fabric_service_profiles":         dataSourceFabricSearchServiceProfilesByName(),
+			"equinix_fabric_service_token":            dataSourceFabricServiceToken(),
+			"equinix_network_account":                 dataSourceNetworkAccount(),
+			"equinix_network_device":                  dataSourceNetworkDevice(),
+			"equinix_network_device_type":             dataSourceNetworkDeviceType(),
+			"equinix_network_device_software":         dataSourceNetworkDeviceSoftware(),
+			"equinix_network_device_platform":         dataSourceNetworkDevicePlatform(),
+			"equinix_network_bgp":                     dataSourceNetworkBGP(),
+			"equinix_network_ssh_users":               dataSourceNetworkSSHUsers(),
+			"equinix_metal_hardware_reservation":      dataSourceMetalHardwareReservation(),
+			"equinix_metal_metro":                     dataSourceMetalMetro(),
+			"equinix_metal_facility":                  dataSourceMetalFacility(),
+			"equinix_metal_ip_block_ranges":           dataSourceMetalIPBlockRanges(),
+			"equinix_metal_precreated_ip_block":       dataSourceMetalPreCreatedIPBlock(),
+			"equinix_metal_operating_system":          dataSourceOperatingSystem(),
+			"equinix_metal_organization":              dataSourceMetalOrganization(),
+			"equinix_metal_payment_method":            dataSourceMetalPaymentMethod(),
+			"equinix_metal_payment_methods":           dataSourceMetalPaymentMethods(),
+			"equinix_metal_spot_market_price":         dataSourceSpotMarketPrice(),
+			"equinix_metal_device":                    dataSourceMetalDevice(),
+			"equinix_metal_devices":                   dataSourceMetalDevices(),
+			"equinix_metal_device_bgp_neighbors":      dataSourceMetalDeviceBGPNeighbors(),
+			"equinix_metal_plans":                     dataSourceMetalPlans(),
+			"equinix_metal_port":                      dataSourceMetalPort(),
+			"equinix_metal_reserved_ip_block":         dataSourceMetalReservedIPBlock(),
+			"equinix_metal_reserved_ip_block_subnets": dataSourceMetalReservedIPBlockSubnets(),
+			"equinix_metal_spot_market_request":       dataSourceMetalSpotMarketRequest(),
+			"equinix_metal_virtual_circuit":           dataSourceMetalVirtualCircuit(),
+			"equinix_metal_virtual_circuits":          dataSourceMetalVirtualCircuits(),
+			"equinix_metal_vlan":                      dataSourceMetalVlan(),
 		},
 		ResourcesMap: map[string]*schema.Resource{
 			"equinix_ecx_l2_connection":          resourceECXL2Connection(),
@@ -123,8 +216,11 @@ func Provider() *schema.Provider {
 			"equinix_fabric_network":             resourceFabricNetwork(),
 			"equinix_fabric_cloud_router":        resourceFabricCloudRouter(),
 			"equinix_fabric_connection":          resourceFabricConnection(),
+			"equinix_fabric_connection_action":   resourceFabricConnectionAction(),
 			"equinix_fabric_routing_protocol":    resourceFabricRoutingProtocol(),
 			"equinix_fabric_service_profile":     resourceFabricServiceProfile(),
+			"equinix_fabric_service_token":       resourceFabricServiceToken(),
+			"equinix_fabric_precision_time":      resourceFabricPrecisionTime(),
 			"equinix_network_device":             resourceNetworkDevice(),
 			"equinix_network_ssh_user":           resourceNetworkSSHUser(),
 			"equinix_network_bgp":                resourceNetworkBGP(),
@@ -134,19 +230,15 @@ func Provider() *schema.Provider {
 			"equinix_network_file":               resourceNetworkFile(),
 			"equinix_metal_user_api_key":         resourceMetalUserAPIKey(),
 			"equinix_metal_project_api_key":      resourceMetalProjectAPIKey(),
-			"equinix_metal_connection":           metal_connection.Resource(),
 			"equinix_metal_device":               resourceMetalDevice(),
-			"equinix_metal_device_network_type":  resourceMetalDeviceNetworkType(),
-			"equinix_metal_organization_member":  resourceMetalOrganizationMember(),
+			"equinix_metal_device_power":         resourceMetalDevicePower(),
 			"equinix_metal_port":                 resourceMetalPort(),
-			"equinix_metal_project":              metal_project.Resource(),
 			"equinix_metal_organization":         resourceMetalOrganization(),
 			"equinix_metal_reserved_ip_block":    resourceMetalReservedIPBlock(),
 			"equinix_metal_ip_attachment":        resourceMetalIPAttachment(),
 			"equinix_metal_spot_market_request":  resourceMetalSpotMarketRequest(),
 			"equinix_metal_vlan":                 resourceMetalVlan(),
 			"equinix_metal_virtual_circuit":      resourceMetalVirtualCircuit(),
-			"equinix_metal_vrf":                  vrf.Resource(),
 			"equinix_metal_bgp_session":          resourceMetalBGPSession(),
 			"equinix_metal_port_vlan_attachment": resourceMetalPortVlanAttachment(),
 		},
@@ -158,6 +250,16 @@ func Provider() *schema.Provider {
 		},
 	}
 
+	registerResources(provider.ResourcesMap, internalResources...)
+	registerResources(provider.DataSourcesMap, internalDataSources...)
+
+	for typeName, r := range provider.ResourcesMap {
+		withServiceCheck(typeName, r)
+	}
+	for typeName, r := range provider.DataSourcesMap {
+		withServiceCheck(typeName, r)
+	}
+
 	provider.ConfigureContextFunc = func(ctx context.Context, d *schema.ResourceData) (interface{}, diag.Diagnostics) {
 		return configureProvider(ctx, d, provider)
 	}
@@ -171,6 +273,10 @@ type providerMeta struct {
 func configureProvider(ctx context.Context, d *schema.ResourceData, p *schema.Provider) (interface{}, diag.Diagnostics) {
 	mrws := d.Get("max_retry_wait_seconds").(int)
 	rt := d.Get("request_timeout").(int)
+	headers := make(map[string]string)
+	for k, v := range d.Get("additional_headers").(map[string]interface{}) {
+		headers[k] = v.(string)
+	}
 
 	config := config.Config{
 		AuthToken:      d.Get("auth_token").(string),
@@ -182,6 +288,14 @@ func configureProvider(ctx context.Context, d *schema.ResourceData, p *schema.Pr
 		PageSize:       d.Get("response_max_page_size").(int),
 		MaxRetries:     d.Get("max_retries").(int),
 		MaxRetryWait:   time.Duration(mrws) * time.Second,
+
+		AdditionalHeaders:          headers,
+		FabricDeprovisionedAsError: d.Get("fabric_deprovisioned_as_error").(bool),
+		FabricCorrelationPrefix:    d.Get("fabric_correlation_prefix").(string),
+		FabricDryRun:               d.Get("dry_run").(bool),
+		DisableMetal:               !d.Get("enable_metal").(bool),
+		DisableFabric:              !d.Get("enable_fabric").(bool),
+		DisableNetworkEdge:         !d.Get("enable_network_edge").(bool),
 	}
 	meta := providerMeta{}
 
@@ -205,6 +319,62 @@ func configureProvider(ctx context.Context, d *schema.ResourceData, p *schema.Pr
 	return &config, nil
 }
 
+// withServiceCheck wraps the CRUD and CustomizeDiff functions of the resource
+// or data source of the given type, so they fail instead of using the nil
+// clients of a service disabled in the provider configuration.
+func withServiceCheck(typeName string, r *schema.Resource) {
+	check := func(meta interface{}) error {
+		if c, ok := meta.(*config.Config); ok {
+			return c.DisabledServiceError(typeName)
+		}
+		return nil
+	}
+	wrapContext := func(f func(context.Context, *schema.ResourceData, interface{}) diag.Diagnostics) func(context.Context, *schema.ResourceData, interface{}) diag.Diagnostics {
+		if f == nil {
+			return nil
+		}
+		return func(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+			if err := check(meta); err != nil {
+				return diag.FromErr(err)
+			}
+			return f(ctx, d, meta)
+		}
+	}
+	wrap := func(f func(*schema.ResourceData, interface{}) error) func(*schema.ResourceData, interface{}) error {
+		if f == nil {
+			return nil
+		}
+		return func(d *schema.ResourceData, meta interface{}) error {
+			if err := check(meta); err != nil {
+				return err
+			}
+			return f(d, meta)
+		}
+	}
+
+	r.CreateContext = wrapContext(r.CreateContext)
+	r.ReadContext = wrapContext(r.ReadContext)
+	r.UpdateContext = wrapContext(r.UpdateContext)
+	r.DeleteContext = wrapContext(r.DeleteContext)
+	r.CreateWithoutTimeout = wrapContext(r.CreateWithoutTimeout)
+	r.ReadWithoutTimeout = wrapContext(r.ReadWithoutTimeout)
+	r.UpdateWithoutTimeout = wrapContext(r.UpdateWithoutTimeout)
+	r.DeleteWithoutTimeout = wrapContext(r.DeleteWithoutTimeout)
+	// the legacy functions are still used by some resources
+	r.Create = wrap(r.Create)
+	r.Read = wrap(r.Read)
+	r.Update = wrap(r.Update)
+	r.Delete = wrap(r.Delete)
+	if customizeDiff := r.CustomizeDiff; customizeDiff != nil {
+		r.CustomizeDiff = func(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+			if err := check(meta); err != nil {
+				return err
+			}
+			return customizeDiff(ctx, d, meta)
+		}
+	}
+}
+
 func stringsFound(source []string, target []string) bool {
 	for i := range source {
 		if !isStringInSlice(source[i], target) {
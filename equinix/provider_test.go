@@ -14,6 +14,7 @@ import (
 
 	"github.com/equinix/ecx-go/v2"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
 	"github.com/stretchr/testify/assert"
 
@@ -150,6 +151,55 @@ func TestProvider(t *testing.T) {
 	}
 }
 
+func TestProvider_withServiceCheck(t *testing.T) {
+	// given
+	p := Provider()
+	meta := &config.Config{DisableMetal: true}
+	metalDevice := p.DataSourcesMap["equinix_metal_device"]
+	d := metalDevice.TestResourceData()
+	// when
+	diags := metalDevice.ReadWithoutTimeout(context.Background(), d, meta)
+	// then
+	assert.True(t, diags.HasError(), "Data source of a disabled service fails")
+	assert.Contains(t, diags[0].Summary, "enable_metal = false")
+}
+
+func TestProvider_withServiceCheckCustomizeDiff(t *testing.T) {
+	// given
+	p := Provider()
+	meta := &config.Config{DisableMetal: true}
+	metalDevice := p.ResourcesMap["equinix_metal_device"]
+	// when
+	_, err := metalDevice.Diff(context.Background(), nil, terraform.NewResourceConfigRaw(map[string]interface{}{
+		"plan":             "c3.small.x86",
+		"operating_system": "ubuntu_22_04",
+		"metro":            "sv",
+		"project_id":       "project",
+	}), meta)
+	// then
+	assert.ErrorContains(t, err, "enable_metal = false", "Plan of a disabled service fails instead of panicking")
+}
+
+func TestProvider_registerResources(t *testing.T) {
+	// given
+	p := Provider()
+	duplicate := func() map[string]*schema.Resource {
+		return map[string]*schema.Resource{"equinix_metal_vrf": {}}
+	}
+	// then
+	for _, resources := range internalResources {
+		for typeName, r := range resources() {
+			assert.Equal(t, r.Description, p.ResourcesMap[typeName].Description, "Resource %s is registered", typeName)
+		}
+	}
+	for _, dataSources := range internalDataSources {
+		for typeName := range dataSources() {
+			assert.Contains(t, p.DataSourcesMap, typeName, "Data source %s is registered", typeName)
+		}
+	}
+	assert.Panics(t, func() { registerResources(p.ResourcesMap, duplicate) }, "Type names are registered once")
+}
+
 func TestProvider_stringsFound(t *testing.T) {
 	// given
 	needles := []string{"key1", "key5"}
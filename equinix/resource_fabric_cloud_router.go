@@ -10,6 +10,7 @@ import (
 	"strings"
 	"time"
 
+	equinix_diagnostics "github.com/equinix/terraform-provider-equinix/internal/diagnostics"
 	equinix_errors "github.com/equinix/terraform-provider-equinix/internal/errors"
 	equinix_fabric_schema "github.com/equinix/terraform-provider-equinix/internal/fabric/schema"
 	equinix_schema "github.com/equinix/terraform-provider-equinix/internal/schema"
@@ -201,7 +202,8 @@ func resourceFabricCloudRouter() *schema.Resource {
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
 		},
-		Schema: fabricCloudRouterResourceSchema(),
+		Schema:        fabricCloudRouterResourceSchema(),
+		CustomizeDiff: validateCloudRouterDryRun,
 
 		Description: "Fabric V4 API compatible resource allows creation and management of Equinix Fabric Cloud Router",
 	}
@@ -278,13 +280,17 @@ func resourceFabricCloudRouterCreate(ctx context.Context, d *schema.ResourceData
 		createRequest.Order = &order
 	}
 
-	fcr, _, err := client.CloudRoutersApi.CreateCloudRouter(ctx, createRequest)
+	if meta.(*config.Config).FabricDryRun {
+		return validateFabricOrder(ctx, client, "cloud router", createRequest.Name, cloudRouterPriceFilter(createRequest))
+	}
+
+	fcr, resp, err := client.CloudRoutersApi.CreateCloudRouter(ctx, createRequest)
 	if err != nil {
-		return diag.FromErr(equinix_errors.FormatFabricError(err))
+		return equinix_diagnostics.SDKError("creating Fabric Cloud Router", fabricErrorAttributePath(fabricCloudRouterResourceSchema(), err), resp, err)
 	}
 	d.SetId(fcr.Uuid)
 
-	if _, err = waitUntilCloudRouterIsProvisioned(d.Id(), meta, ctx); err != nil {
+	if _, err = waitUntilCloudRouterIsProvisioned(d.Id(), meta, ctx, d.Timeout(schema.TimeoutCreate)); err != nil {
 		return diag.Errorf("error waiting for Cloud Router (%s) to be created: %s", d.Id(), err)
 	}
 
@@ -294,13 +300,14 @@ func resourceFabricCloudRouterCreate(ctx context.Context, d *schema.ResourceData
 func resourceFabricCloudRouterRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	client := meta.(*config.Config).FabricClient
 	ctx = context.WithValue(ctx, v4.ContextAccessToken, meta.(*config.Config).FabricAuthToken)
-	CloudRouter, _, err := client.CloudRoutersApi.GetCloudRouterByUuid(ctx, d.Id())
+	CloudRouter, resp, err := client.CloudRoutersApi.GetCloudRouterByUuid(ctx, d.Id())
 	if err != nil {
-		log.Printf("[WARN] Fabric Cloud Router %s not found , error %s", d.Id(), err)
-		if !strings.Contains(err.Error(), "500") {
+		if !d.IsNewResource() && equinix_errors.IsGone(resp, err) {
+			log.Printf("[WARN] Fabric Cloud Router %s not found, removing from state", d.Id())
 			d.SetId("")
+			return nil
 		}
-		return diag.FromErr(equinix_errors.FormatFabricError(err))
+		return equinix_diagnostics.SDKError(fmt.Sprintf("reading Fabric Cloud Router %s", d.Id()), "", resp, err)
 	}
 	d.SetId(CloudRouter.Uuid)
 	return setCloudRouterMap(d, CloudRouter)
@@ -308,8 +315,17 @@ func resourceFabricCloudRouterRead(ctx context.Context, d *schema.ResourceData,
 
 func setCloudRouterMap(d *schema.ResourceData, fcr v4.CloudRouter) diag.Diagnostics {
 	diags := diag.Diagnostics{}
-	err := equinix_schema.SetMap(d, map[string]interface{}{
+	err := equinix_schema.SetMap(d, fabricCloudRouterToTerra(fcr))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	return diags
+}
+
+func fabricCloudRouterToTerra(fcr v4.CloudRouter) map[string]interface{} {
+	return map[string]interface{}{
 		"name":                         fcr.Name,
+		"uuid":                         fcr.Uuid,
 		"href":                         fcr.Href,
 		"type":                         fcr.Type_,
 		"state":                        fcr.State,
@@ -326,12 +342,9 @@ func setCloudRouterMap(d *schema.ResourceData, fcr v4.CloudRouter) diag.Diagnost
 		"distinct_ipv6_prefixes_count": fcr.DistinctIpv6PrefixesCount,
 		"connections_count":            fcr.ConnectionsCount,
 		"order":                        equinix_fabric_schema.OrderToTerra(fcr.Order),
-	})
-	if err != nil {
-		return diag.FromErr(err)
 	}
-	return diags
 }
+
 func accountCloudRouterToTerra(account *v4.SimplifiedAccount) *schema.Set {
 	if account == nil {
 		return nil
@@ -351,6 +364,9 @@ func accountCloudRouterToTerra(account *v4.SimplifiedAccount) *schema.Set {
 	return accountSet
 }
 func packageCloudRouterGoToTerra(packageType *v4.CloudRouterPackageType) *schema.Set {
+	if packageType == nil {
+		return nil
+	}
 	packageTypes := []*v4.CloudRouterPackageType{packageType}
 	mappedPackages := make([]interface{}, len(packageTypes))
 	for i, packageType := range packageTypes {
@@ -364,22 +380,31 @@ func packageCloudRouterGoToTerra(packageType *v4.CloudRouterPackageType) *schema
 	)
 	return packageSet
 }
-func getCloudRouterUpdateRequest(conn v4.CloudRouter, d *schema.ResourceData) (v4.CloudRouterChangeOperation, error) {
-	changeOps := v4.CloudRouterChangeOperation{}
-	existingName := conn.Name
-	existingPackage := conn.Package_.Code
-	updateNameVal := d.Get("name")
-	updatePackageVal := d.Get("conn.Package_.Code")
+
+// getCloudRouterUpdateRequest returns the change operations to patch the name
+// and the package of the cloud router to their configured values. The package
+// is changed in place, so an upgrade keeps the attached connections.
+func getCloudRouterUpdateRequest(fcr v4.CloudRouter, d *schema.ResourceData) ([]v4.CloudRouterChangeOperation, error) {
+	changeOps := []v4.CloudRouterChangeOperation{}
+	existingName := fcr.Name
+	existingPackage := ""
+	if fcr.Package_ != nil {
+		existingPackage = fcr.Package_.Code
+	}
+	var updateNameVal interface{} = d.Get("name").(string)
+	var updatePackageVal interface{} = packageCloudRouterTerraToGo(d.Get("package").(*schema.Set).List()).Code
 
 	log.Printf("existing name %s, existing Package %s, Update Name Request %s, Update Package Request %s ",
 		existingName, existingPackage, updateNameVal, updatePackageVal)
 
 	if existingName != updateNameVal {
-		changeOps = v4.CloudRouterChangeOperation{Op: "replace", Path: "/name", Value: &updateNameVal}
-	} else if existingPackage != updatePackageVal {
-		changeOps = v4.CloudRouterChangeOperation{Op: "replace", Path: "/package", Value: &updatePackageVal}
-	} else {
-		return changeOps, fmt.Errorf("nothing to update for the connection %s", existingName)
+		changeOps = append(changeOps, v4.CloudRouterChangeOperation{Op: "replace", Path: "/name", Value: &updateNameVal})
+	}
+	if existingPackage != updatePackageVal {
+		changeOps = append(changeOps, v4.CloudRouterChangeOperation{Op: "replace", Path: "/package/code", Value: &updatePackageVal})
+	}
+	if len(changeOps) == 0 {
+		return changeOps, fmt.Errorf("nothing to update for the Fabric Cloud Router %s", existingName)
 	}
 	return changeOps, nil
 }
@@ -387,25 +412,29 @@ func getCloudRouterUpdateRequest(conn v4.CloudRouter, d *schema.ResourceData) (v
 func resourceFabricCloudRouterUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	client := meta.(*config.Config).FabricClient
 	ctx = context.WithValue(ctx, v4.ContextAccessToken, meta.(*config.Config).FabricAuthToken)
-	dbConn, err := waitUntilCloudRouterIsProvisioned(d.Id(), meta, ctx)
+	dbConn, err := waitUntilCloudRouterIsProvisioned(d.Id(), meta, ctx, d.Timeout(schema.TimeoutUpdate))
 	if err != nil {
 		if !strings.Contains(err.Error(), "500") {
 			d.SetId("")
 		}
 		return diag.Errorf("either timed out or errored out while fetching Fabric Cloud Router for uuid %s and error %v", d.Id(), err)
 	}
-	// TO-DO
-	update, err := getCloudRouterUpdateRequest(dbConn, d)
+	if meta.(*config.Config).FabricDryRun && d.HasChange("package") {
+		// Keep the previous package in state since it is not changed
+		d.Partial(true)
+		packages := packageCloudRouterTerraToGo(d.Get("package").(*schema.Set).List())
+		return validateFabricOrder(ctx, client, "cloud router", d.Get("name").(string), cloudRouterPriceFilter(v4.CloudRouterPostRequest{Location: dbConn.Location, Package_: &packages}))
+	}
+	updates, err := getCloudRouterUpdateRequest(dbConn, d)
 	if err != nil {
 		return diag.FromErr(err)
 	}
-	updates := []v4.CloudRouterChangeOperation{update}
-	_, _, err = client.CloudRoutersApi.UpdateCloudRouterByUuid(ctx, updates, d.Id())
+	_, resp, err := client.CloudRoutersApi.UpdateCloudRouterByUuid(ctx, updates, d.Id())
 	if err != nil {
-		return diag.FromErr(equinix_errors.FormatFabricError(err))
+		return equinix_diagnostics.SDKError(fmt.Sprintf("updating Fabric Cloud Router %s", d.Id()), fabricErrorAttributePath(fabricCloudRouterResourceSchema(), err), resp, err)
 	}
 	updateFg := v4.CloudRouter{}
-	updateFg, err = waitForCloudRouterUpdateCompletion(d.Id(), meta, ctx)
+	updateFg, err = waitForCloudRouterUpdateCompletion(d.Id(), meta, ctx, d.Timeout(schema.TimeoutUpdate))
 
 	if err != nil {
 		if !strings.Contains(err.Error(), "500") {
@@ -418,7 +447,7 @@ func resourceFabricCloudRouterUpdate(ctx context.Context, d *schema.ResourceData
 	return setCloudRouterMap(d, updateFg)
 }
 
-func waitForCloudRouterUpdateCompletion(uuid string, meta interface{}, ctx context.Context) (v4.CloudRouter, error) {
+func waitForCloudRouterUpdateCompletion(uuid string, meta interface{}, ctx context.Context, timeout time.Duration) (v4.CloudRouter, error) {
 	log.Printf("Waiting for Cloud Router update to complete, uuid %s", uuid)
 	stateConf := &retry.StateChangeConf{
 		Target: []string{string(v4.PROVISIONED_CloudRouterAccessPointState)},
@@ -430,7 +459,7 @@ func waitForCloudRouterUpdateCompletion(uuid string, meta interface{}, ctx conte
 			}
 			return dbConn, string(*dbConn.State), nil
 		},
-		Timeout:    2 * time.Minute,
+		Timeout:    timeout,
 		Delay:      30 * time.Second,
 		MinTimeout: 30 * time.Second,
 	}
@@ -444,7 +473,7 @@ func waitForCloudRouterUpdateCompletion(uuid string, meta interface{}, ctx conte
 	return dbConn, err
 }
 
-func waitUntilCloudRouterIsProvisioned(uuid string, meta interface{}, ctx context.Context) (v4.CloudRouter, error) {
+func waitUntilCloudRouterIsProvisioned(uuid string, meta interface{}, ctx context.Context, timeout time.Duration) (v4.CloudRouter, error) {
 	log.Printf("Waiting for Cloud Router to be provisioned, uuid %s", uuid)
 	stateConf := &retry.StateChangeConf{
 		Pending: []string{
@@ -461,7 +490,7 @@ func waitUntilCloudRouterIsProvisioned(uuid string, meta interface{}, ctx contex
 			}
 			return dbConn, string(*dbConn.State), nil
 		},
-		Timeout:    5 * time.Minute,
+		Timeout:    timeout,
 		Delay:      30 * time.Second,
 		MinTimeout: 30 * time.Second,
 	}
@@ -479,7 +508,7 @@ func resourceFabricCloudRouterDelete(ctx context.Context, d *schema.ResourceData
 	diags := diag.Diagnostics{}
 	client := meta.(*config.Config).FabricClient
 	ctx = context.WithValue(ctx, v4.ContextAccessToken, meta.(*config.Config).FabricAuthToken)
-	_, err := client.CloudRoutersApi.DeleteCloudRouterByUuid(ctx, d.Id())
+	resp, err := client.CloudRoutersApi.DeleteCloudRouterByUuid(ctx, d.Id())
 	if err != nil {
 		errors, ok := err.(v4.GenericSwaggerError).Model().([]v4.ModelError)
 		if ok {
@@ -488,17 +517,17 @@ func resourceFabricCloudRouterDelete(ctx context.Context, d *schema.ResourceData
 				return diags
 			}
 		}
-		return diag.FromErr(equinix_errors.FormatFabricError(err))
+		return equinix_diagnostics.SDKError(fmt.Sprintf("deleting Fabric Cloud Router %s", d.Id()), "", resp, err)
 	}
 
-	err = WaitUntilCloudRouterDeprovisioned(d.Id(), meta, ctx)
+	err = WaitUntilCloudRouterDeprovisioned(d.Id(), meta, ctx, d.Timeout(schema.TimeoutDelete))
 	if err != nil {
 		return diag.FromErr(fmt.Errorf("API call failed while waiting for resource deletion. Error %v", err))
 	}
 	return diags
 }
 
-func WaitUntilCloudRouterDeprovisioned(uuid string, meta interface{}, ctx context.Context) error {
+func WaitUntilCloudRouterDeprovisioned(uuid string, meta interface{}, ctx context.Context, timeout time.Duration) error {
 	log.Printf("Waiting for Fabric Cloud Router to be deprovisioned, uuid %s", uuid)
 	stateConf := &retry.StateChangeConf{
 		Pending: []string{
@@ -515,7 +544,7 @@ func WaitUntilCloudRouterDeprovisioned(uuid string, meta interface{}, ctx contex
 			}
 			return dbConn, string(*dbConn.State), nil
 		},
-		Timeout:    5 * time.Minute,
+		Timeout:    timeout,
 		Delay:      30 * time.Second,
 		MinTimeout: 30 * time.Second,
 	}
@@ -0,0 +1,60 @@
+package equinix
+
+import (
+	"testing"
+
+	v4 "github.com/equinix-labs/fabric-go/fabric/v4"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFabricCloudRouter_updateRequest(t *testing.T) {
+	// given
+	fcr := v4.CloudRouter{Name: "fcr", Package_: &v4.CloudRouterPackageType{Code: "STANDARD"}}
+	rawData := map[string]interface{}{
+		"name":    "fcr",
+		"package": []interface{}{map[string]interface{}{"code": "PREMIUM"}},
+	}
+	d := schema.TestResourceDataRaw(t, fabricCloudRouterResourceSchema(), rawData)
+	// when
+	updates, err := getCloudRouterUpdateRequest(fcr, d)
+	// then
+	require.NoError(t, err)
+	require.Len(t, updates, 1, "Only the package is patched")
+	assert.Equal(t, "replace", updates[0].Op)
+	assert.Equal(t, "/package/code", updates[0].Path)
+	assert.Equal(t, "PREMIUM", *updates[0].Value)
+}
+
+func TestFabricCloudRouter_updateRequestNameAndPackage(t *testing.T) {
+	// given
+	fcr := v4.CloudRouter{Name: "fcr", Package_: &v4.CloudRouterPackageType{Code: "STANDARD"}}
+	rawData := map[string]interface{}{
+		"name":    "fcr-renamed",
+		"package": []interface{}{map[string]interface{}{"code": "PREMIUM"}},
+	}
+	d := schema.TestResourceDataRaw(t, fabricCloudRouterResourceSchema(), rawData)
+	// when
+	updates, err := getCloudRouterUpdateRequest(fcr, d)
+	// then
+	require.NoError(t, err)
+	require.Len(t, updates, 2, "Both changes are patched at once")
+	assert.Equal(t, "/name", updates[0].Path)
+	assert.Equal(t, "fcr-renamed", *updates[0].Value)
+	assert.Equal(t, "/package/code", updates[1].Path)
+}
+
+func TestFabricCloudRouter_updateRequestNoChange(t *testing.T) {
+	// given
+	fcr := v4.CloudRouter{Name: "fcr", Package_: &v4.CloudRouterPackageType{Code: "STANDARD"}}
+	rawData := map[string]interface{}{
+		"name":    "fcr",
+		"package": []interface{}{map[string]interface{}{"code": "STANDARD"}},
+	}
+	d := schema.TestResourceDataRaw(t, fabricCloudRouterResourceSchema(), rawData)
+	// when
+	_, err := getCloudRouterUpdateRequest(fcr, d)
+	// then
+	assert.Error(t, err)
+}
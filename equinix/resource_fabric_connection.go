@@ -5,9 +5,12 @@ import (
 	"fmt"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 	"log"
+	"regexp"
+	"strconv"
 	"strings"
 	"time"
 
+	equinix_diagnostics "github.com/equinix/terraform-provider-equinix/internal/diagnostics"
 	equinix_errors "github.com/equinix/terraform-provider-equinix/internal/errors"
 	equinix_fabric_schema "github.com/equinix/terraform-provider-equinix/internal/fabric/schema"
 	equinix_schema "github.com/equinix/terraform-provider-equinix/internal/schema"
@@ -15,9 +18,13 @@ import (
 	"github.com/equinix/terraform-provider-equinix/internal/config"
 
 	v4 "github.com/equinix-labs/fabric-go/fabric/v4"
+	"github.com/hashicorp/go-cty/cty"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/retry"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/packethost/packngo"
+	"golang.org/x/exp/slices"
 )
 
 func fabricConnectionResourceSchema() map[string]*schema.Schema {
@@ -52,10 +59,12 @@ func fabricConnectionResourceSchema() map[string]*schema.Schema {
 			},
 		},
 		"bandwidth": {
-			Type:        schema.TypeInt,
-			Required:    true,
-			Description: "Connection bandwidth in Mbps",
+			Type:             schema.TypeInt,
+			Required:         true,
+			DiffSuppressFunc: equinix_fabric_schema.SuppressEquivalentBandwidth("bandwidth"),
+			Description:      "Connection bandwidth in the unit set by bandwidth_unit, Mbps by default",
 		},
+		"bandwidth_unit": equinix_fabric_schema.BandwidthUnitSch("bandwidth"),
 		//"geo_scope": {
 		//	Type:         schema.TypeString,
 		//	Optional:     true,
@@ -63,8 +72,9 @@ func fabricConnectionResourceSchema() map[string]*schema.Schema {
 		//	Description:  "Geographic boundary types",
 		//},
 		"redundancy": {
-			Type:        schema.TypeSet,
+			Type:        schema.TypeList,
 			Optional:    true,
+			Computed:    true,
 			Description: "Connection Redundancy Configuration",
 			MaxItems:    1,
 			Elem: &schema.Resource{
@@ -72,24 +82,69 @@ func fabricConnectionResourceSchema() map[string]*schema.Schema {
 			},
 		},
 		"a_side": {
-			Type:        schema.TypeSet,
-			Required:    true,
-			Description: "Requester or Customer side connection configuration object of the multi-segment connection",
-			MaxItems:    1,
-			Elem:        connectionSideSch(),
-			Set:         schema.HashResource(accessPointSch()),
+			Type:         schema.TypeList,
+			Optional:     true,
+			Computed:     true,
+			Description:  "Requester or Customer side connection configuration object of the multi-segment connection. Required unless it is resolved from metal_connection_id",
+			MaxItems:     1,
+			Elem:         connectionSideSch(),
+			AtLeastOneOf: []string{"a_side", "metal_connection_id"},
 		},
 		"z_side": {
-			Type:        schema.TypeSet,
-			Required:    true,
-			Description: "Destination or Provider side connection configuration object of the multi-segment connection",
-			MaxItems:    1,
-			Elem:        connectionSideSch(),
-			Set:         schema.HashResource(accessPointSch()),
+			Type:         schema.TypeList,
+			Optional:     true,
+			Computed:     true,
+			Description:  "Destination or Provider side connection configuration object of the multi-segment connection. Required unless it is resolved from metal_connection_id",
+			MaxItems:     1,
+			Elem:         connectionSideSch(),
+			AtLeastOneOf: []string{"z_side", "metal_connection_id"},
+		},
+		"metal_connection_id": {
+			Type:         schema.TypeString,
+			Optional:     true,
+			ForceNew:     true,
+			ValidateFunc: validation.IsUUID,
+			Description:  "ID of an Equinix Metal connection whose service token is used for the connection side matching the Metal connection service_token_type. The primary or secondary token is selected according to the redundancy priority",
+		},
+		"allow_bandwidth_downgrade": {
+			Type:        schema.TypeBool,
+			Optional:    true,
+			Default:     false,
+			Description: "Whether to allow updates reducing the bandwidth of the connection. Bandwidth reductions can be service affecting, so they fail the plan unless allowed",
+		},
+		"adopt_existing": {
+			Type:        schema.TypeBool,
+			Optional:    true,
+			Default:     false,
+			Description: "Whether to adopt, on create, an existing connection with the same name, a_side port and z_side service profile instead of ordering a new one. Requires an a_side port or a z_side service profile. Deprovisioned, cancelled and failed connections are not adopted",
+		},
+		"wait_for_provider_status": {
+			Type:         schema.TypeString,
+			Optional:     true,
+			ValidateFunc: validation.StringInSlice([]string{string(v4.PROVISIONED_ProviderStatus)}, false),
+			Description:  "Provider status to wait for on create, e.g. PROVISIONED to return only once the remote side, such as AWS Direct Connect, accepted and provisioned the connection. Only the Equinix side provisioning is waited for if not set",
+		},
+		"wait_for_invitation_acceptance": {
+			Type:        schema.TypeBool,
+			Optional:    true,
+			Default:     false,
+			Description: "Whether to wait on create, within the create timeout, for the recipient of the z_side invitation to accept the connection. Create fails if the invitation is rejected",
+		},
+		"invitation_status": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "Status of the z_side invitation of the connection, one of PENDING, ACCEPTED, REJECTED or CANCELLED. Empty for connections without invitation",
+		},
+		"auto_vlan_tag": {
+			Type:        schema.TypeBool,
+			Optional:    true,
+			Default:     false,
+			Description: "Whether to select, on create, the lowest VLAN tag not in use on the port of the DOT1Q access points without a vlan_tag",
 		},
 		"project": {
 			Type:        schema.TypeSet,
 			Optional:    true,
+			Computed:    true,
 			Description: "Project information",
 			MaxItems:    1,
 			Elem: &schema.Resource{
@@ -158,6 +213,22 @@ func fabricConnectionResourceSchema() map[string]*schema.Schema {
 			Computed:    true,
 			Description: "Connection directionality from the requester point of view",
 		},
+		"path": {
+			Type:        schema.TypeList,
+			Computed:    true,
+			Description: "Network path characteristics of the connection, derived from the metros of its access points",
+			Elem: &schema.Resource{
+				Schema: connectionPathSch(),
+			},
+		},
+		"routing_protocols": {
+			Type:        schema.TypeList,
+			Computed:    true,
+			Description: "Routing protocols attached to the connection, with their peering details. Only populated for Fabric Cloud Router connections",
+			Elem: &schema.Resource{
+				Schema: connectionRoutingProtocolSch(),
+			},
+		},
 	}
 }
 
@@ -165,7 +236,7 @@ func connectionSideSch() *schema.Resource {
 	return &schema.Resource{
 		Schema: map[string]*schema.Schema{
 			"service_token": {
-				Type:        schema.TypeSet,
+				Type:        schema.TypeList,
 				Optional:    true,
 				Description: "For service token based connections, Service tokens authorize users to access protected resources and services. Resource owners can distribute the tokens to trusted partners and vendors, allowing selected third parties to work directly with Equinix network assets",
 				MaxItems:    1,
@@ -174,7 +245,7 @@ func connectionSideSch() *schema.Resource {
 				},
 			},
 			"access_point": {
-				Type:        schema.TypeSet,
+				Type:        schema.TypeList,
 				Optional:    true,
 				Description: "Point of access details",
 				MaxItems:    1,
@@ -188,6 +259,36 @@ func connectionSideSch() *schema.Resource {
 					Schema: additionalInfoSch(),
 				},
 			},
+			"invitation": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				Description: "Invitation sent by email to the recipient of the connection, who accepts it to complete the z_side of the connection",
+				MaxItems:    1,
+				Elem: &schema.Resource{
+					Schema: connectionInvitationSch(),
+				},
+			},
+		},
+	}
+}
+
+func connectionInvitationSch() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"email": {
+			Type:         schema.TypeString,
+			Required:     true,
+			ValidateFunc: validation.StringMatch(regexp.MustCompile(`^[^@\s]+@[^@\s]+$`), "must be an email address"),
+			Description:  "Email of the invitation recipient",
+		},
+		"message": {
+			Type:        schema.TypeString,
+			Optional:    true,
+			Description: "Message of the invitation",
+		},
+		"ctr_draft_order_id": {
+			Type:        schema.TypeString,
+			Optional:    true,
+			Description: "Identifier of the draft order of the invitation",
 		},
 	}
 }
@@ -224,8 +325,8 @@ func accessPointSch() *schema.Resource {
 			"type": {
 				Type:         schema.TypeString,
 				Optional:     true,
-				ValidateFunc: validation.StringInSlice([]string{"COLO", "VD", "VG", "SP", "IGW", "SUBNET", "CLOUD_ROUTER", "NETWORK"}, true),
-				Description:  "Access point type - COLO, VD, VG, SP, IGW, SUBNET, CLOUD_ROUTER, NETWORK",
+				ValidateFunc: validation.StringInSlice([]string{"COLO", "VD", "VG", "SP", "IGW", "SUBNET", "CLOUD_ROUTER", "NETWORK", "METAL_NETWORK"}, true),
+				Description:  "Access point type - COLO, VD, VG, SP, IGW, SUBNET, CLOUD_ROUTER, NETWORK, METAL_NETWORK",
 			},
 			"account": {
 				Type:        schema.TypeSet,
@@ -237,7 +338,7 @@ func accessPointSch() *schema.Resource {
 				},
 			},
 			"location": {
-				Type:        schema.TypeSet,
+				Type:        schema.TypeList,
 				Optional:    true,
 				Computed:    true,
 				Description: "Access point location",
@@ -247,7 +348,7 @@ func accessPointSch() *schema.Resource {
 				},
 			},
 			"port": {
-				Type:        schema.TypeSet,
+				Type:        schema.TypeList,
 				Optional:    true,
 				Description: "Port access point information",
 				MaxItems:    1,
@@ -256,7 +357,7 @@ func accessPointSch() *schema.Resource {
 				},
 			},
 			"profile": {
-				Type:        schema.TypeSet,
+				Type:        schema.TypeList,
 				Optional:    true,
 				Description: "Service Profile",
 				MaxItems:    1,
@@ -265,7 +366,7 @@ func accessPointSch() *schema.Resource {
 				},
 			},
 			"gateway": {
-				Type:        schema.TypeSet,
+				Type:        schema.TypeList,
 				Optional:    true,
 				Deprecated:  "use router attribute instead; gateway is no longer a part of the supported backend",
 				Description: "**Deprecated** `gateway` Use `router` attribute instead",
@@ -275,7 +376,7 @@ func accessPointSch() *schema.Resource {
 				},
 			},
 			"router": {
-				Type:        schema.TypeSet,
+				Type:        schema.TypeList,
 				Optional:    true,
 				Description: "Cloud Router access point information that replaces `gateway`",
 				MaxItems:    1,
@@ -284,7 +385,7 @@ func accessPointSch() *schema.Resource {
 				},
 			},
 			"link_protocol": {
-				Type:        schema.TypeSet,
+				Type:        schema.TypeList,
 				Optional:    true,
 				Description: "Connection link protocol",
 				MaxItems:    1,
@@ -293,7 +394,7 @@ func accessPointSch() *schema.Resource {
 				},
 			},
 			"virtual_device": {
-				Type:        schema.TypeSet,
+				Type:        schema.TypeList,
 				Optional:    true,
 				Description: "Virtual device",
 				MaxItems:    1,
@@ -302,7 +403,7 @@ func accessPointSch() *schema.Resource {
 				},
 			},
 			"interface": {
-				Type:        schema.TypeSet,
+				Type:        schema.TypeList,
 				Optional:    true,
 				Description: "Virtual device interface",
 				MaxItems:    1,
@@ -311,7 +412,7 @@ func accessPointSch() *schema.Resource {
 				},
 			},
 			"network": {
-				Type:        schema.TypeSet,
+				Type:        schema.TypeList,
 				Optional:    true,
 				Description: "network access point information",
 				MaxItems:    1,
@@ -333,7 +434,7 @@ func accessPointSch() *schema.Resource {
 			"authentication_key": {
 				Type:        schema.TypeString,
 				Optional:    true,
-				Description: "Authentication key for provider based connections",
+				Description: "Authentication key for provider based connections. For METAL_NETWORK access points, the authorization code of the Equinix Metal connection",
 			},
 			"provider_connection_id": {
 				Type:        schema.TypeString,
@@ -395,6 +496,29 @@ func cloudRouterSch() map[string]*schema.Schema {
 			Computed:    true,
 			Description: "Unique Resource Identifier",
 		},
+		"project": {
+			Type:        schema.TypeSet,
+			Computed:    true,
+			Description: "Project information of the Cloud Router",
+			Elem: &schema.Resource{
+				Schema: equinix_fabric_schema.ProjectSch(),
+			},
+		},
+		"name": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "Cloud Router name",
+		},
+		"state": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "Cloud Router access point state",
+		},
+		"equinix_asn": {
+			Type:        schema.TypeInt,
+			Computed:    true,
+			Description: "Equinix ASN of the Cloud Router",
+		},
 	}
 }
 
@@ -507,16 +631,40 @@ func portSch() map[string]*schema.Schema {
 			Description: "Port name",
 		},
 		"redundancy": {
-			Type:        schema.TypeSet,
+			Type:        schema.TypeList,
+			Optional:    true,
 			Computed:    true,
+			MaxItems:    1,
 			Description: "Redundancy Information",
 			Elem: &schema.Resource{
-				Schema: PortRedundancySch(),
+				Schema: accessPointPortRedundancySch(),
 			},
 		},
 	}
 }
 
+func accessPointPortRedundancySch() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"enabled": {
+			Type:        schema.TypeBool,
+			Computed:    true,
+			Description: "Access point redundancy",
+		},
+		"group": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "Port redundancy group",
+		},
+		"priority": {
+			Type:         schema.TypeString,
+			Optional:     true,
+			Computed:     true,
+			ValidateFunc: validation.StringInSlice([]string{"PRIMARY", "SECONDARY"}, false),
+			Description:  "Port redundancy priority, used to select the primary or secondary port of a redundant pair. One of PRIMARY, SECONDARY",
+		},
+	}
+}
+
 func connectionAccessPointTypeConfigSch() map[string]*schema.Schema {
 	return map[string]*schema.Schema{
 		"type": {
@@ -576,18 +724,23 @@ func connectionRedundancySch() map[string]*schema.Schema {
 			Type:        schema.TypeString,
 			Computed:    true,
 			Optional:    true,
-			Description: "Redundancy group identifier (Use the redundancy.0.group UUID of primary connection; e.g. one(equinix_fabric_connection.primary_port_connection.redundancy).group or equinix_fabric_connection.primary_port_connection.redundancy.0.group)",
+			Description: "Redundancy group identifier. Required for a SECONDARY connection, use the group of the primary connection, e.g. equinix_fabric_connection.primary_port_connection.redundancy.0.group",
 		},
 		"priority": {
 			Type:         schema.TypeString,
 			Computed:     true,
 			Optional:     true,
 			ValidateFunc: validation.StringInSlice([]string{"PRIMARY", "SECONDARY"}, true),
+			StateFunc:    func(v interface{}) string { return strings.ToUpper(v.(string)) },
 			Description:  "Connection priority in redundancy group - PRIMARY, SECONDARY",
 		},
 	}
 }
 
+// fabricConnectionLocalAttributes are the arguments of the connection resource
+// that only change the behaviour of the provider and are not sent to the API.
+var fabricConnectionLocalAttributes = []string{"adopt_existing", "allow_bandwidth_downgrade", "wait_for_provider_status", "wait_for_invitation_acceptance", "auto_vlan_tag"}
+
 func resourceFabricConnection() *schema.Resource {
 	return &schema.Resource{
 		Timeouts: &schema.ResourceTimeout{
@@ -603,7 +756,8 @@ func resourceFabricConnection() *schema.Resource {
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
 		},
-		Schema: fabricConnectionResourceSchema(),
+		Schema:        fabricConnectionResourceSchema(),
+		CustomizeDiff: customdiff.All(validateConnectionRedundancy, validateConnectionSides, validateConnectionLinkProtocols, validateConnectionAccessPoints, validateConnectionAdditionalInfo, validateConnectionProfileBandwidth, validateConnectionMetalSpeed, validateConnectionBandwidthDowngrade, validateConnectionDryRun),
 
 		Description: "Fabric V4 API compatible resource allows creation and management of Equinix Fabric connection",
 	}
@@ -615,69 +769,66 @@ func resourceFabricConnectionCreate(ctx context.Context, d *schema.ResourceData,
 	conType := v4.ConnectionType(d.Get("type").(string))
 	schemaNotifications := d.Get("notifications").([]interface{})
 	notifications := equinix_fabric_schema.NotificationsToFabric(schemaNotifications)
-	schemaRedundancy := d.Get("redundancy").(*schema.Set).List()
+	schemaRedundancy := d.Get("redundancy").([]interface{})
 	red := connectionRedundancyToFabric(schemaRedundancy)
 	schemaOrder := d.Get("order").(*schema.Set).List()
 	order := equinix_fabric_schema.OrderToFabric(schemaOrder)
-	aside := d.Get("a_side").(*schema.Set).List()
+	aside := d.Get("a_side").([]interface{})
 	projectReq := d.Get("project").(*schema.Set).List()
 	project := equinix_fabric_schema.ProjectToFabric(projectReq)
 	additionalInfoTerraConfig := d.Get("additional_info").([]interface{})
-	additionalInfo := additionalInfoTerraToGo(additionalInfoTerraConfig)
-	connectionASide := v4.ConnectionSide{}
-	for _, as := range aside {
-		asideMap := as.(map[string]interface{})
-		accessPoint := asideMap["access_point"].(*schema.Set).List()
-		serviceTokenRequest := asideMap["service_token"].(*schema.Set).List()
-		additionalInfoRequest := asideMap["additional_info"].([]interface{})
-
-		if len(accessPoint) != 0 {
-			ap := accessPointToFabric(accessPoint)
-			connectionASide = v4.ConnectionSide{AccessPoint: &ap}
-		}
-		if len(serviceTokenRequest) != 0 {
-			mappedServiceToken, err := serviceTokenToFabric(serviceTokenRequest)
-			if err != nil {
-				return diag.FromErr(err)
-			}
-			connectionASide = v4.ConnectionSide{ServiceToken: &mappedServiceToken}
-		}
-		if len(additionalInfoRequest) != 0 {
-			mappedAdditionalInfo := additionalInfoTerraToGo(additionalInfoRequest)
-			connectionASide = v4.ConnectionSide{AdditionalInfo: mappedAdditionalInfo}
-		}
+	additionalInfo, err := additionalInfoTerraToGo(additionalInfoTerraConfig)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	connectionASide, err := connectionSideToFabric(aside, "a_side")
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	zside := d.Get("z_side").([]interface{})
+	connectionZSide, err := connectionSideToFabric(zside, "z_side")
+	if err != nil {
+		return diag.FromErr(err)
 	}
 
-	zside := d.Get("z_side").(*schema.Set).List()
-	connectionZSide := v4.ConnectionSide{}
-	for _, as := range zside {
-		zsideMap := as.(map[string]interface{})
-		accessPoint := zsideMap["access_point"].(*schema.Set).List()
-		serviceTokenRequest := zsideMap["service_token"].(*schema.Set).List()
-		additionalInfoRequest := zsideMap["additional_info"].([]interface{})
-		if len(accessPoint) != 0 {
-			ap := accessPointToFabric(accessPoint)
-			connectionZSide = v4.ConnectionSide{AccessPoint: &ap}
+	if metalConnID, ok := d.GetOk("metal_connection_id"); ok {
+		if err := meta.(*config.Config).DisabledServiceError("equinix_metal_connection"); err != nil {
+			return diag.Errorf("metal_connection_id can't be resolved: %s", err)
 		}
-		if len(serviceTokenRequest) != 0 {
-			mappedServiceToken, err := serviceTokenToFabric(serviceTokenRequest)
-			if err != nil {
-				return diag.FromErr(err)
-			}
-			connectionZSide = v4.ConnectionSide{ServiceToken: &mappedServiceToken}
+		priority := ""
+		if red.Priority != nil {
+			priority = string(*red.Priority)
+		}
+		side, token, err := getMetalConnectionServiceToken(meta.(*config.Config).Metal, metalConnID.(string), priority)
+		if err != nil {
+			return diag.FromErr(err)
 		}
-		if len(additionalInfoRequest) != 0 {
-			mappedAdditionalInfo := additionalInfoTerraToGo(additionalInfoRequest)
-			connectionZSide = v4.ConnectionSide{AdditionalInfo: mappedAdditionalInfo}
+		switch side {
+		case packngo.FabricServiceTokenASide:
+			if len(aside) != 0 {
+				return diag.Errorf("a_side can not be set when it is resolved from the a_side service token of Metal connection %s", metalConnID)
+			}
+			connectionASide = v4.ConnectionSide{ServiceToken: &token}
+		case packngo.FabricServiceTokenZSide:
+			if len(zside) != 0 {
+				return diag.Errorf("z_side can not be set when it is resolved from the z_side service token of Metal connection %s", metalConnID)
+			}
+			connectionZSide = v4.ConnectionSide{ServiceToken: &token}
 		}
 	}
+	if len(aside) == 0 && connectionASide.ServiceToken == nil {
+		return diag.Errorf("a_side must be set unless it is resolved from metal_connection_id")
+	}
+	if len(zside) == 0 && connectionZSide.ServiceToken == nil {
+		return diag.Errorf("z_side must be set unless it is resolved from metal_connection_id")
+	}
 
 	createRequest := v4.ConnectionPostRequest{
 		Name:           d.Get("name").(string),
 		Type_:          &conType,
 		Order:          &order,
 		Notifications:  notifications,
-		Bandwidth:      int32(d.Get("bandwidth").(int)),
+		Bandwidth:      int32(equinix_fabric_schema.BandwidthToMbps(d.Get("bandwidth").(int), d.Get("bandwidth_unit").(string))),
 		AdditionalInfo: additionalInfo,
 		Redundancy:     &red,
 		ASide:          &connectionASide,
@@ -685,23 +836,52 @@ func resourceFabricConnectionCreate(ctx context.Context, d *schema.ResourceData,
 		Project:        &project,
 	}
 
-	conn, _, err := client.ConnectionsApi.CreateConnection(ctx, createRequest)
+	if d.Get("adopt_existing").(bool) {
+		conn, err := findAdoptableConnection(ctx, client, createRequest)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		if conn != nil {
+			log.Printf("[INFO] Adopting existing connection %s (%s)", conn.Name, conn.Uuid)
+			d.SetId(conn.Uuid)
+			return resourceFabricConnectionRead(ctx, d, meta)
+		}
+	}
+
+	if d.Get("auto_vlan_tag").(bool) {
+		sides := map[string]*v4.ConnectionSide{"a_side": createRequest.ASide, "z_side": createRequest.ZSide}
+		for _, side := range []string{"a_side", "z_side"} {
+			if err := setFreeVlanTag(ctx, client, sides[side]); err != nil {
+				return equinix_diagnostics.SDKError("selecting a free VLAN tag", side+".0.access_point.0.port.0.uuid", nil, err)
+			}
+		}
+	}
+
+	if diags := validateConnectionServiceTokens(ctx, client, createRequest); diags.HasError() {
+		return diags
+	}
+
+	if meta.(*config.Config).FabricDryRun {
+		return validateFabricOrder(ctx, client, "connection", createRequest.Name, connectionPriceFilter(createRequest))
+	}
+
+	conn, resp, err := client.ConnectionsApi.CreateConnection(ctx, createRequest)
 	if err != nil {
-		return diag.FromErr(equinix_errors.FormatFabricError(err))
+		return equinix_diagnostics.SDKError("creating Fabric connection", fabricErrorAttributePath(fabricConnectionResourceSchema(), err), resp, err)
 	}
 	d.SetId(conn.Uuid)
 
-	if err = waitUntilConnectionIsCreated(d.Id(), meta, ctx); err != nil {
+	if err = waitUntilConnectionIsCreated(d.Id(), meta, ctx, d.Timeout(schema.TimeoutCreate)); err != nil {
 		return diag.Errorf("error waiting for connection (%s) to be created: %s", d.Id(), err)
 	}
 
-	awsSecrets, hasAWSSecrets := additionalInfoContainsAWSSecrets(additionalInfoTerraConfig)
-	if hasAWSSecrets {
+	acceptanceInfo, seller := connectionAcceptanceAdditionalInfo(additionalInfoTerraConfig)
+	if seller != "" {
 		patchChangeOperation := []v4.ConnectionChangeOperation{
 			{
 				Op:    "add",
 				Path:  "",
-				Value: map[string]interface{}{"additionalInfo": awsSecrets},
+				Value: map[string]interface{}{"additionalInfo": acceptanceInfo},
 			},
 		}
 
@@ -710,14 +890,161 @@ func resourceFabricConnectionCreate(ctx context.Context, d *schema.ResourceData,
 			return diag.FromErr(equinix_errors.FormatFabricError(patchErr))
 		}
 
-		if _, statusChangeErr := waitForConnectionProviderStatusChange(d.Id(), meta, ctx); statusChangeErr != nil {
-			return diag.Errorf("error waiting for AWS Approval for connection %s: %v", d.Id(), statusChangeErr)
+		if _, statusChangeErr := waitForConnectionProviderStatusChange(d.Id(), meta, ctx, d.Timeout(schema.TimeoutCreate)); statusChangeErr != nil {
+			return diag.Errorf("error waiting for %s approval for connection %s: %v", seller, d.Id(), statusChangeErr)
+		}
+	} else if d.Get("wait_for_provider_status").(string) != "" {
+		if _, statusChangeErr := waitForConnectionProviderStatusChange(d.Id(), meta, ctx, d.Timeout(schema.TimeoutCreate)); statusChangeErr != nil {
+			return diag.Errorf("error waiting for the provider side of connection %s to be %s: %v", d.Id(), d.Get("wait_for_provider_status"), statusChangeErr)
+		}
+	}
+
+	if d.Get("wait_for_invitation_acceptance").(bool) && connectionZSide.Invitation != nil {
+		if _, err := waitForConnectionInvitationAcceptance(d.Id(), meta, ctx, d.Timeout(schema.TimeoutCreate)); err != nil {
+			return diag.Errorf("error waiting for %s to accept the invitation to connection %s: %v", connectionZSide.Invitation.Email, d.Id(), err)
 		}
 	}
 
 	return resourceFabricConnectionRead(ctx, d, meta)
 }
 
+// findAdoptableConnection returns the existing connection matching the name,
+// the a_side port and the z_side service profile of a connection to create, or
+// nil if there is none. Connections are not adopted on the name alone.
+func findAdoptableConnection(ctx context.Context, client *v4.APIClient, request v4.ConnectionPostRequest) (*v4.Connection, error) {
+	if adoptableConnectionPortUuid(request.ASide) == "" && adoptableConnectionProfileUuid(request.ZSide) == "" {
+		return nil, fmt.Errorf("adopt_existing needs an a_side port or a z_side service profile to identify the existing connection %s", request.Name)
+	}
+	search := v4.SearchRequest{Filter: adoptableConnectionSearchExpression(request)}
+	var conns []v4.Connection
+	for offset := 0; ; {
+		search.Pagination = &v4.PaginationRequest{
+			Offset: int32(offset),
+			Limit:  fabricConnectionsPageSize,
+		}
+		resp, _, err := client.ConnectionsApi.SearchConnections(ctx, search)
+		if err != nil {
+			return nil, equinix_errors.FormatFabricError(err)
+		}
+		for _, conn := range activeServiceProfileConnections(resp.Data) {
+			if connectionMatchesAdoption(conn, request) {
+				conns = append(conns, conn)
+			}
+		}
+		offset += len(resp.Data)
+		if len(resp.Data) == 0 || resp.Pagination == nil || offset >= int(resp.Pagination.Total) {
+			break
+		}
+	}
+	switch len(conns) {
+	case 0:
+		return nil, nil
+	case 1:
+		return &conns[0], nil
+	}
+	uuids := make([]string, 0, len(conns))
+	for _, conn := range conns {
+		uuids = append(uuids, conn.Uuid)
+	}
+	return nil, fmt.Errorf("can't adopt a connection named %s, %d connections match: %s", request.Name, len(conns), strings.Join(uuids, ", "))
+}
+
+// adoptableConnectionSearchExpression returns the search expression matching
+// the name, the a_side port and the z_side service profile of a connection.
+func adoptableConnectionSearchExpression(request v4.ConnectionPostRequest) *v4.Expression {
+	expression := func(property v4.SearchFieldName, value string) v4.Expression {
+		return v4.Expression{Property: &property, Operator: "=", Values: []string{value}}
+	}
+	and := []v4.Expression{expression(v4.NAME_SearchFieldName, request.Name)}
+	if port := adoptableConnectionPortUuid(request.ASide); port != "" {
+		and = append(and, expression(v4.A_SIDEACCESS_POINTPORTUUID_SearchFieldName, port))
+	}
+	if profile := adoptableConnectionProfileUuid(request.ZSide); profile != "" {
+		and = append(and, expression(v4.Z_SIDEACCESS_POINTPROFILEUUID_SearchFieldName, profile))
+	}
+	return &v4.Expression{And: &and}
+}
+
+// connectionMatchesAdoption reports whether an existing connection has the
+// name, the a_side port and the z_side service profile of a connection.
+func connectionMatchesAdoption(conn v4.Connection, request v4.ConnectionPostRequest) bool {
+	if conn.Name != request.Name {
+		return false
+	}
+	if port := adoptableConnectionPortUuid(request.ASide); port != "" && adoptableConnectionPortUuid(conn.ASide) != port {
+		return false
+	}
+	if profile := adoptableConnectionProfileUuid(request.ZSide); profile != "" && adoptableConnectionProfileUuid(conn.ZSide) != profile {
+		return false
+	}
+	return true
+}
+
+func adoptableConnectionPortUuid(side *v4.ConnectionSide) string {
+	if side == nil || side.AccessPoint == nil || side.AccessPoint.Port == nil {
+		return ""
+	}
+	return side.AccessPoint.Port.Uuid
+}
+
+func adoptableConnectionProfileUuid(side *v4.ConnectionSide) string {
+	if side == nil || side.AccessPoint == nil || side.AccessPoint.Profile == nil {
+		return ""
+	}
+	return side.AccessPoint.Profile.Uuid
+}
+
+// Range of the VLAN tags selected for DOT1Q access points.
+const (
+	fabricVlanTagMin = 2
+	fabricVlanTagMax = 4092
+)
+
+// setFreeVlanTag sets the VLAN tag of a DOT1Q port access point without one to
+// the lowest tag not in use on the port.
+func setFreeVlanTag(ctx context.Context, client *v4.APIClient, side *v4.ConnectionSide) error {
+	port := adoptableConnectionPortUuid(side)
+	if port == "" || side.AccessPoint.LinkProtocol == nil || side.AccessPoint.LinkProtocol.Type_ == nil ||
+		!strings.EqualFold(string(*side.AccessPoint.LinkProtocol.Type_), string(v4.DOT1_Q_LinkProtocolType)) ||
+		side.AccessPoint.LinkProtocol.VlanTag != 0 {
+		return nil
+	}
+	vlans, _, err := client.PortsApi.GetVlans(ctx, port)
+	if err != nil {
+		return equinix_errors.FormatFabricError(err)
+	}
+	tag, err := freeVlanTag(vlans.Data)
+	if err != nil {
+		return fmt.Errorf("can't select a VLAN tag on port %s: %v", port, err)
+	}
+	log.Printf("[DEBUG] Selected free VLAN tag %d on port %s", tag, port)
+	side.AccessPoint.LinkProtocol.VlanTag = int32(tag)
+	return nil
+}
+
+// freeVlanTag returns the lowest VLAN tag not used by the given link
+// protocols of a port. Released link protocols don't use their tags anymore.
+func freeVlanTag(linkProtocols []v4.LinkProtocolResponse) (int, error) {
+	used := map[int]bool{}
+	for _, lp := range linkProtocols {
+		if lp.State != nil && *lp.State == v4.RELEASED_LinkProtocolState {
+			continue
+		}
+		for _, tag := range []int32{lp.VlanTag, lp.VlanSTag} {
+			used[int(tag)] = true
+		}
+		for tag := lp.VlanTagMin; lp.VlanTagMin != 0 && tag <= lp.VlanTagMax; tag++ {
+			used[int(tag)] = true
+		}
+	}
+	for tag := fabricVlanTagMin; tag <= fabricVlanTagMax; tag++ {
+		if !used[tag] {
+			return tag, nil
+		}
+	}
+	return 0, fmt.Errorf("all VLAN tags from %d to %d are in use", fabricVlanTagMin, fabricVlanTagMax)
+}
+
 func additionalInfoContainsAWSSecrets(info []interface{}) ([]interface{}, bool) {
 	var awsSecrets []interface{}
 
@@ -734,87 +1061,450 @@ func additionalInfoContainsAWSSecrets(info []interface{}) ([]interface{}, bool)
 	return awsSecrets, len(awsSecrets) == 2
 }
 
-func resourceFabricConnectionRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*config.Config).FabricClient
-	ctx = context.WithValue(ctx, v4.ContextAccessToken, meta.(*config.Config).FabricAuthToken)
-	conn, _, err := client.ConnectionsApi.GetConnectionByUuid(ctx, d.Id(), nil)
-	if err != nil {
-		log.Printf("[WARN] Connection %s not found , error %s", d.Id(), err)
-		if !strings.Contains(err.Error(), "500") {
-			d.SetId("")
+// Keys of the additional_info of connections to IBM Cloud Direct Link, the
+// BGP ASN of the customer and whether the Direct Link gateway is global.
+const (
+	ibmAdditionalInfoASN    = "ASN"
+	ibmAdditionalInfoGlobal = "Global"
+)
+
+// additionalInfoContainsIBMAcceptance returns the additional_info items IBM
+// Cloud Direct Link accepts connections with, and whether both are set.
+func additionalInfoContainsIBMAcceptance(info []interface{}) ([]interface{}, bool) {
+	var ibmInfo []interface{}
+	for _, item := range info {
+		key, _ := item.(map[string]interface{})["key"].(string)
+		if strings.EqualFold(key, ibmAdditionalInfoASN) || strings.EqualFold(key, ibmAdditionalInfoGlobal) {
+			ibmInfo = append(ibmInfo, item)
 		}
-		return diag.FromErr(equinix_errors.FormatFabricError(err))
 	}
-	d.SetId(conn.Uuid)
-	return setFabricMap(d, conn)
+	return ibmInfo, len(ibmInfo) == 2
 }
 
-func setFabricMap(d *schema.ResourceData, conn v4.Connection) diag.Diagnostics {
-	diags := diag.Diagnostics{}
-	err := equinix_schema.SetMap(d, map[string]interface{}{
-		"name":      conn.Name,
-		"bandwidth": conn.Bandwidth,
-		"href":      conn.Href,
-		// TODO v4.ConnectionPostRequest doesn't have a "description" field,
-		// so it always returns empty because it was never in the API, that produces an inconsistency
-		// "description":     conn.Description,
-		"is_remote":       conn.IsRemote,
-		"type":            conn.Type_,
-		"state":           conn.State,
-		"direction":       conn.Direction,
-		"operation":       operationToTerra(conn.Operation),
-		"order":           equinix_fabric_schema.OrderToTerra(conn.Order),
-		"change_log":      equinix_fabric_schema.ChangeLogToTerra(conn.ChangeLog),
-		"redundancy":      connectionRedundancyToTerra(conn.Redundancy),
-		"notifications":   equinix_fabric_schema.NotificationsToTerra(conn.Notifications),
-		"account":         equinix_fabric_schema.AccountToTerra(conn.Account),
-		"a_side":          connectionSideToTerra(conn.ASide),
-		"z_side":          connectionSideToTerra(conn.ZSide),
-		"additional_info": additionalInfoToTerra(conn.AdditionalInfo),
-		"project":         equinix_fabric_schema.ProjectToTerra(conn.Project),
-	})
-	if err != nil {
-		return diag.FromErr(err)
+// connectionAcceptanceAdditionalInfo returns the additional_info items sent
+// to the seller once the connection is created for it to accept the
+// connection, along with the name of the seller, or no seller if the
+// connection doesn't need such acceptance.
+func connectionAcceptanceAdditionalInfo(info []interface{}) ([]interface{}, string) {
+	if awsSecrets, ok := additionalInfoContainsAWSSecrets(info); ok {
+		return awsSecrets, "AWS"
 	}
-	return diags
+	if ibmInfo, ok := additionalInfoContainsIBMAcceptance(info); ok {
+		return ibmInfo, "IBM Cloud"
+	}
+	return nil, ""
 }
 
-func resourceFabricConnectionUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+func resourceFabricConnectionRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	client := meta.(*config.Config).FabricClient
 	ctx = context.WithValue(ctx, v4.ContextAccessToken, meta.(*config.Config).FabricAuthToken)
-	dbConn, err := verifyConnectionCreated(d.Id(), meta, ctx)
+	conn, resp, err := client.ConnectionsApi.GetConnectionByUuid(ctx, d.Id(), nil)
 	if err != nil {
-		if !strings.Contains(err.Error(), "500") {
+		if !d.IsNewResource() && equinix_errors.IsGone(resp, err) {
+			log.Printf("[WARN] Connection %s not found, removing from state", d.Id())
 			d.SetId("")
+			return nil
 		}
-		return diag.Errorf("either timed out or errored out while fetching connection for uuid %s: error -> %v", d.Id(), err)
+		return equinix_diagnostics.SDKError(fmt.Sprintf("reading Fabric connection %s", d.Id()), "", resp, err)
 	}
-
-	diags := diag.Diagnostics{}
-	updateRequests, err := getUpdateRequests(dbConn, d)
-	if err != nil {
-		diags = append(diags, diag.Diagnostic{Severity: 1, Summary: err.Error()})
+	if conn.State != nil && *conn.State == v4.DEPROVISIONED_ConnectionState {
+		if meta.(*config.Config).FabricDeprovisionedAsError {
+			return diag.Errorf("connection %s is %s, it was deprovisioned outside of Terraform", d.Id(), *conn.State)
+		}
+		log.Printf("[WARN] Connection %s is %s, removing from state", d.Id(), *conn.State)
+		id := d.Id()
+		d.SetId("")
+		return diag.Diagnostics{
+			{
+				Severity: diag.Warning,
+				Summary:  "Equinix Fabric connection deprovisioned outside of Terraform",
+				Detail:   fmt.Sprintf("[WARN] Connection (%s) is %s, removing from state", id, *conn.State),
+			},
+		}
+	}
+	d.SetId(conn.Uuid)
+	diags := setFabricMap(d, conn)
+	if diags.HasError() {
 		return diags
 	}
-	updatedConn := dbConn
-
-	for _, update := range updateRequests {
-		_, _, err := client.ConnectionsApi.UpdateConnectionByUuid(ctx, update, d.Id())
+	aMetro := getConnectionSideMetro(ctx, meta.(*config.Config), conn.ASide, nil)
+	zMetro := getConnectionSideMetro(ctx, meta.(*config.Config), conn.ZSide, aMetro)
+	if err := d.Set("path", connectionPathToTerra(conn, aMetro, zMetro)); err != nil {
+		return diag.FromErr(err)
+	}
+	if isCloudRouterConnection(conn) {
+		// Routing protocols are informational, a failure to fetch them keeps
+		// the ones of the previous read rather than failing the refresh
+		routingProtocols, err := getFabricConnectionRoutingProtocols(ctx, client, conn.Uuid)
 		if err != nil {
-			diags = append(diags, diag.Diagnostic{Severity: 0, Summary: fmt.Sprintf("connection property update request error: %v [update payload: %v] (other updates will be successful if the payload is not shown)", equinix_errors.FormatFabricError(err), update)})
-			continue
+			log.Printf("[WARN] Routing protocols of connection %s not retrieved, error %s", conn.Uuid, err)
+		} else if err := d.Set("routing_protocols", connectionRoutingProtocolsToTerra(routingProtocols)); err != nil {
+			return diag.FromErr(err)
 		}
-
-		var waitFunction func(uuid string, meta interface{}, ctx context.Context) (v4.Connection, error)
-		if update[0].Op == "replace" {
+	}
+	return diags
+}
+
+func connectionRoutingProtocolSch() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"type": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "Routing protocol type - DIRECT or BGP",
+		},
+		"uuid": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "Routing protocol identifier",
+		},
+		"name": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "Routing protocol name",
+		},
+		"state": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "Routing protocol state",
+		},
+		"equinix_iface_ipv4": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "Equinix side interface IPv4 address of a DIRECT routing protocol",
+		},
+		"equinix_iface_ipv6": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "Equinix side interface IPv6 address of a DIRECT routing protocol",
+		},
+		"customer_peer_ipv4": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "Customer side BGP peering IPv4 address",
+		},
+		"customer_peer_ipv6": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "Customer side BGP peering IPv6 address",
+		},
+		"equinix_peer_ipv4": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "Equinix side BGP peering IPv4 address",
+		},
+		"equinix_peer_ipv6": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "Equinix side BGP peering IPv6 address",
+		},
+		"customer_asn": {
+			Type:        schema.TypeInt,
+			Computed:    true,
+			Description: "Customer ASN of a BGP routing protocol",
+		},
+		"equinix_asn": {
+			Type:        schema.TypeInt,
+			Computed:    true,
+			Description: "Equinix ASN of a BGP routing protocol",
+		},
+	}
+}
+
+func isCloudRouterConnection(conn v4.Connection) bool {
+	return conn.ASide != nil && conn.ASide.AccessPoint != nil && conn.ASide.AccessPoint.Type_ != nil &&
+		*conn.ASide.AccessPoint.Type_ == v4.CLOUD_ROUTER_AccessPointType
+}
+
+func connectionRoutingProtocolsToTerra(rps []v4.RoutingProtocolData) []interface{} {
+	mappedRps := make([]interface{}, 0, len(rps))
+	for _, rp := range rps {
+		switch rp.Type_ {
+		case "DIRECT":
+			direct := rp.RoutingProtocolDirectData
+			mappedRp := map[string]interface{}{
+				"type":  rp.Type_,
+				"uuid":  direct.Uuid,
+				"name":  direct.Name,
+				"state": direct.State,
+			}
+			if direct.DirectIpv4 != nil {
+				mappedRp["equinix_iface_ipv4"] = direct.DirectIpv4.EquinixIfaceIp
+			}
+			if direct.DirectIpv6 != nil {
+				mappedRp["equinix_iface_ipv6"] = direct.DirectIpv6.EquinixIfaceIp
+			}
+			mappedRps = append(mappedRps, mappedRp)
+		case "BGP":
+			bgp := rp.RoutingProtocolBgpData
+			mappedRp := map[string]interface{}{
+				"type":         rp.Type_,
+				"uuid":         bgp.Uuid,
+				"name":         bgp.Name,
+				"state":        bgp.State,
+				"customer_asn": int(bgp.CustomerAsn),
+				"equinix_asn":  int(bgp.EquinixAsn),
+			}
+			if bgp.BgpIpv4 != nil {
+				mappedRp["customer_peer_ipv4"] = bgp.BgpIpv4.CustomerPeerIp
+				mappedRp["equinix_peer_ipv4"] = bgp.BgpIpv4.EquinixPeerIp
+			}
+			if bgp.BgpIpv6 != nil {
+				mappedRp["customer_peer_ipv6"] = bgp.BgpIpv6.CustomerPeerIp
+				mappedRp["equinix_peer_ipv6"] = bgp.BgpIpv6.EquinixPeerIp
+			}
+			mappedRps = append(mappedRps, mappedRp)
+		}
+	}
+	return mappedRps
+}
+
+func connectionPathSch() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"local_metro_code": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "Metro code of the A side access point",
+		},
+		"remote_metro_code": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "Metro code of the Z side access point",
+		},
+		"latency_class": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "Latency class of the path - LOCAL within a metro, REGIONAL between metros of the same region, GLOBAL between regions",
+		},
+		"avg_latency": {
+			Type:        schema.TypeFloat,
+			Computed:    true,
+			Description: "Average latency in milliseconds between the metros of the connection",
+		},
+		"max_bandwidth": {
+			Type:        schema.TypeInt,
+			Computed:    true,
+			Description: "Maximum connection speed in Mbps supported between the metros of the connection",
+		},
+		"geo_scope": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "Geographic boundary of the connection",
+		},
+	}
+}
+
+func connectionSideMetroCode(side *v4.ConnectionSide) string {
+	if side == nil || side.AccessPoint == nil || side.AccessPoint.Location == nil {
+		return ""
+	}
+	return side.AccessPoint.Location.MetroCode
+}
+
+// getConnectionSideMetro looks up the metro of a connection side in the catalog
+// cache, reusing known when it is the same metro. A metro that can not be
+// retrieved only carries its code, so that the path of the connection is still
+// partially known.
+func getConnectionSideMetro(ctx context.Context, c *config.Config, side *v4.ConnectionSide, known *v4.Metro) *v4.Metro {
+	code := connectionSideMetroCode(side)
+	if code == "" {
+		return nil
+	}
+	if known != nil && known.Code == code {
+		return known
+	}
+	metro, err := cachedFabricMetroByCode(ctx, c, code)
+	if err != nil {
+		log.Printf("[WARN] Fabric metro %s not retrieved, error %s", code, err)
+		return &v4.Metro{Code: code}
+	}
+	return &metro
+}
+
+func connectionPathToTerra(conn v4.Connection, aMetro, zMetro *v4.Metro) []interface{} {
+	path := map[string]interface{}{}
+	if conn.GeoScope != nil {
+		path["geo_scope"] = string(*conn.GeoScope)
+	}
+	if aMetro != nil {
+		path["local_metro_code"] = aMetro.Code
+	}
+	if zMetro != nil {
+		path["remote_metro_code"] = zMetro.Code
+	}
+	if aMetro == nil || zMetro == nil {
+		return []interface{}{path}
+	}
+	if aMetro.Code == zMetro.Code {
+		path["latency_class"] = "LOCAL"
+		path["max_bandwidth"] = int(aMetro.LocalVCBandwidthMax)
+		return []interface{}{path}
+	}
+	switch {
+	case aMetro.Region == "" || zMetro.Region == "":
+	case aMetro.Region == zMetro.Region:
+		path["latency_class"] = "REGIONAL"
+	default:
+		path["latency_class"] = "GLOBAL"
+	}
+	for _, connected := range aMetro.ConnectedMetros {
+		if connected.Code == zMetro.Code {
+			path["avg_latency"] = connected.AvgLatency
+			path["max_bandwidth"] = int(connected.RemoteVCBandwidthMax)
+			break
+		}
+	}
+	return []interface{}{path}
+}
+
+func setFabricMap(d *schema.ResourceData, conn v4.Connection) diag.Diagnostics {
+	diags := diag.Diagnostics{}
+	err := equinix_schema.SetMap(d, fabricConnectionToTerra(conn, d.Get("bandwidth_unit").(string)))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	return diags
+}
+
+// fabricConnectionToTerra maps the connection to its schema attributes, with
+// the bandwidth in the preferred unit when it can be expressed in it.
+func fabricConnectionToTerra(conn v4.Connection, preferredBandwidthUnit string) map[string]interface{} {
+	bandwidth, bandwidthUnit := equinix_fabric_schema.BandwidthFromMbps(int(conn.Bandwidth), preferredBandwidthUnit)
+	return map[string]interface{}{
+		"name":           conn.Name,
+		"uuid":           conn.Uuid,
+		"bandwidth":      bandwidth,
+		"bandwidth_unit": bandwidthUnit,
+		"href":           conn.Href,
+		// TODO v4.ConnectionPostRequest doesn't have a "description" field,
+		// so it always returns empty because it was never in the API, that produces an inconsistency
+		// "description":     conn.Description,
+		"is_remote":         conn.IsRemote,
+		"type":              conn.Type_,
+		"state":             conn.State,
+		"direction":         conn.Direction,
+		"operation":         operationToTerra(conn.Operation),
+		"order":             equinix_fabric_schema.OrderToTerra(conn.Order),
+		"change_log":        equinix_fabric_schema.ChangeLogToTerra(conn.ChangeLog),
+		"redundancy":        connectionRedundancyToTerra(conn.Redundancy),
+		"notifications":     equinix_fabric_schema.NotificationsToTerra(conn.Notifications),
+		"account":           equinix_fabric_schema.AccountToTerra(conn.Account),
+		"a_side":            connectionSideToTerra(conn.ASide),
+		"z_side":            connectionSideToTerra(conn.ZSide),
+		"additional_info":   additionalInfoToTerra(conn.AdditionalInfo),
+		"project":           equinix_fabric_schema.ProjectToTerra(conn.Project),
+		"invitation_status": connectionInvitationStatus(conn),
+	}
+}
+
+// Statuses of the z_side invitation of a connection.
+const (
+	connectionInvitationPending   = "PENDING"
+	connectionInvitationAccepted  = "ACCEPTED"
+	connectionInvitationRejected  = "REJECTED"
+	connectionInvitationCancelled = "CANCELLED"
+)
+
+// connectionInvitationStatus derives the status of the z_side invitation of a
+// connection from its Equinix status, the API doesn't report it otherwise.
+func connectionInvitationStatus(conn v4.Connection) string {
+	if conn.ZSide == nil || conn.ZSide.Invitation == nil {
+		return ""
+	}
+	if conn.Operation == nil || conn.Operation.EquinixStatus == nil {
+		return connectionInvitationPending
+	}
+	switch *conn.Operation.EquinixStatus {
+	case v4.DRAFT_EquinixStatus, v4.CREATED_EquinixStatus, v4.ORDERING_EquinixStatus,
+		v4.PENDING_APPROVAL_EquinixStatus, v4.PENDING_AUTO_APPROVAL_EquinixStatus:
+		return connectionInvitationPending
+	case v4.REJECTED_EquinixStatus, v4.REJECTED_ACK_EquinixStatus:
+		return connectionInvitationRejected
+	case v4.CANCELLED_EquinixStatus, v4.DEPROVISIONING_EquinixStatus, v4.DEPROVISIONED_EquinixStatus,
+		v4.DELETED_EquinixStatus, v4.DELETED_API_EquinixStatus:
+		return connectionInvitationCancelled
+	}
+	return connectionInvitationAccepted
+}
+
+// waitForConnectionInvitationAcceptance waits for the recipient of the z_side
+// invitation of a connection to accept it.
+func waitForConnectionInvitationAcceptance(uuid string, meta interface{}, ctx context.Context, timeout time.Duration) (v4.Connection, error) {
+	log.Printf("[DEBUG] Waiting for the invitation to connection %s to be accepted", uuid)
+	stateConf := &retry.StateChangeConf{
+		Pending: []string{connectionInvitationPending},
+		Target:  []string{connectionInvitationAccepted},
+		Refresh: func() (interface{}, string, error) {
+			client := meta.(*config.Config).FabricClient
+			dbConn, _, err := client.ConnectionsApi.GetConnectionByUuid(ctx, uuid, nil)
+			if err != nil {
+				return "", "", equinix_errors.FormatFabricError(err)
+			}
+			return dbConn, connectionInvitationStatus(dbConn), nil
+		},
+		Timeout:    timeout,
+		MinTimeout: 30 * time.Second,
+	}
+
+	inter, err := stateConf.WaitForStateContext(ctx)
+	if err != nil {
+		return v4.Connection{}, err
+	}
+	return inter.(v4.Connection), nil
+}
+
+func resourceFabricConnectionUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	if !d.HasChangesExcept(fabricConnectionLocalAttributes...) {
+		return nil
+	}
+	client := meta.(*config.Config).FabricClient
+	ctx = context.WithValue(ctx, v4.ContextAccessToken, meta.(*config.Config).FabricAuthToken)
+	dbConn, err := verifyConnectionCreated(d.Id(), meta, ctx, d.Timeout(schema.TimeoutUpdate))
+	if err != nil {
+		if !strings.Contains(err.Error(), "500") {
+			d.SetId("")
+		}
+		return diag.Errorf("either timed out or errored out while fetching connection for uuid %s: error -> %v", d.Id(), err)
+	}
+
+	if meta.(*config.Config).FabricDryRun && d.HasChanges("bandwidth", "bandwidth_unit") {
+		// Keep the previous bandwidth in state since it is not updated
+		d.Partial(true)
+		bandwidth := equinix_fabric_schema.BandwidthToMbps(d.Get("bandwidth").(int), d.Get("bandwidth_unit").(string))
+		return validateFabricOrder(ctx, client, "connection", d.Get("name").(string), connectionBandwidthPriceFilter(dbConn, int32(bandwidth)))
+	}
+
+	diags := diag.Diagnostics{}
+	notUpdatable := getNotUpdatableConnectionChanges(d)
+	updateRequests, err := getUpdateRequests(dbConn, d)
+	if err != nil {
+		if len(notUpdatable) != 0 {
+			return diag.Errorf("the connection %s can't be updated in place, these changed attributes are not updatable: %s", d.Id(), strings.Join(notUpdatable, ", "))
+		}
+		diags = append(diags, diag.Diagnostic{Severity: 1, Summary: err.Error()})
+		return diags
+	}
+	if len(notUpdatable) != 0 {
+		diags = append(diags, diag.Diagnostic{Severity: 1, Summary: fmt.Sprintf("these changed attributes of the connection %s are not updatable and are left unchanged: %s", d.Id(), strings.Join(notUpdatable, ", "))})
+	}
+	updatedConn := dbConn
+
+	for _, update := range updateRequests {
+		_, _, err := client.ConnectionsApi.UpdateConnectionByUuid(ctx, update, d.Id())
+		if err != nil {
+			diags = append(diags, diag.Diagnostic{Severity: 0, Summary: fmt.Sprintf("connection property update request error: %v [update payload: %v] (other updates will be successful if the payload is not shown)", equinix_errors.FormatFabricError(err), update)})
+			continue
+		}
+
+		var waitFunction func(uuid string, meta interface{}, ctx context.Context, timeout time.Duration) (v4.Connection, error)
+		if update[0].Op == "replace" {
 			// Update type is either name or bandwidth
 			waitFunction = waitForConnectionUpdateCompletion
 		} else if update[0].Op == "add" {
-			// Update type is aws secret additionalInfo
+			// Update type is the acceptance additionalInfo of the seller
 			waitFunction = waitForConnectionProviderStatusChange
 		}
 
-		conn, err := waitFunction(d.Id(), meta, ctx)
+		conn, err := waitFunction(d.Id(), meta, ctx, d.Timeout(schema.TimeoutUpdate))
 
 		if err != nil {
 			diags = append(diags, diag.Diagnostic{Severity: 0, Summary: fmt.Sprintf("connection property update completion timeout error: %v [update payload: %v] (other updates will be successful if the payload is not shown)", err, update)})
@@ -827,7 +1517,7 @@ func resourceFabricConnectionUpdate(ctx context.Context, d *schema.ResourceData,
 	return append(diags, setFabricMap(d, updatedConn)...)
 }
 
-func waitForConnectionUpdateCompletion(uuid string, meta interface{}, ctx context.Context) (v4.Connection, error) {
+func waitForConnectionUpdateCompletion(uuid string, meta interface{}, ctx context.Context, timeout time.Duration) (v4.Connection, error) {
 	log.Printf("[DEBUG] Waiting for connection update to complete, uuid %s", uuid)
 	stateConf := &retry.StateChangeConf{
 		Target: []string{"COMPLETED"},
@@ -843,7 +1533,7 @@ func waitForConnectionUpdateCompletion(uuid string, meta interface{}, ctx contex
 			}
 			return dbConn, updatableState, nil
 		},
-		Timeout:    3 * time.Minute,
+		Timeout:    timeout,
 		Delay:      30 * time.Second,
 		MinTimeout: 30 * time.Second,
 	}
@@ -857,7 +1547,7 @@ func waitForConnectionUpdateCompletion(uuid string, meta interface{}, ctx contex
 	return dbConn, err
 }
 
-func waitUntilConnectionIsCreated(uuid string, meta interface{}, ctx context.Context) error {
+func waitUntilConnectionIsCreated(uuid string, meta interface{}, ctx context.Context, timeout time.Duration) error {
 	log.Printf("Waiting for connection to be created, uuid %s", uuid)
 	stateConf := &retry.StateChangeConf{
 		Pending: []string{
@@ -876,7 +1566,7 @@ func waitUntilConnectionIsCreated(uuid string, meta interface{}, ctx context.Con
 			}
 			return dbConn, string(*dbConn.State), nil
 		},
-		Timeout:    5 * time.Minute,
+		Timeout:    timeout,
 		Delay:      30 * time.Second,
 		MinTimeout: 30 * time.Second,
 	}
@@ -886,10 +1576,12 @@ func waitUntilConnectionIsCreated(uuid string, meta interface{}, ctx context.Con
 	return err
 }
 
-func waitForConnectionProviderStatusChange(uuid string, meta interface{}, ctx context.Context) (v4.Connection, error) {
+func waitForConnectionProviderStatusChange(uuid string, meta interface{}, ctx context.Context, timeout time.Duration) (v4.Connection, error) {
 	log.Printf("DEBUG: wating for provider status to update. Connection uuid: %s", uuid)
 	stateConf := &retry.StateChangeConf{
 		Pending: []string{
+			// Provider status not reported yet
+			"",
 			string(v4.PENDING_APPROVAL_ProviderStatus),
 			string(v4.PROVISIONING_ProviderStatus),
 		},
@@ -902,9 +1594,13 @@ func waitForConnectionProviderStatusChange(uuid string, meta interface{}, ctx co
 			if err != nil {
 				return "", "", equinix_errors.FormatFabricError(err)
 			}
-			return dbConn, string(*dbConn.Operation.ProviderStatus), nil
+			providerStatus := ""
+			if dbConn.Operation != nil && dbConn.Operation.ProviderStatus != nil {
+				providerStatus = string(*dbConn.Operation.ProviderStatus)
+			}
+			return dbConn, providerStatus, nil
 		},
-		Timeout:    5 * time.Minute,
+		Timeout:    timeout,
 		Delay:      30 * time.Second,
 		MinTimeout: 30 * time.Second,
 	}
@@ -918,7 +1614,7 @@ func waitForConnectionProviderStatusChange(uuid string, meta interface{}, ctx co
 	return dbConn, err
 }
 
-func verifyConnectionCreated(uuid string, meta interface{}, ctx context.Context) (v4.Connection, error) {
+func verifyConnectionCreated(uuid string, meta interface{}, ctx context.Context, timeout time.Duration) (v4.Connection, error) {
 	log.Printf("Waiting for connection to be in created state, uuid %s", uuid)
 	stateConf := &retry.StateChangeConf{
 		Target: []string{
@@ -934,7 +1630,7 @@ func verifyConnectionCreated(uuid string, meta interface{}, ctx context.Context)
 			}
 			return dbConn, string(*dbConn.State), nil
 		},
-		Timeout:    5 * time.Minute,
+		Timeout:    timeout,
 		Delay:      30 * time.Second,
 		MinTimeout: 30 * time.Second,
 	}
@@ -952,7 +1648,10 @@ func resourceFabricConnectionDelete(ctx context.Context, d *schema.ResourceData,
 	diags := diag.Diagnostics{}
 	client := meta.(*config.Config).FabricClient
 	ctx = context.WithValue(ctx, v4.ContextAccessToken, meta.(*config.Config).FabricAuthToken)
-	_, _, err := client.ConnectionsApi.DeleteConnectionByUuid(ctx, d.Id())
+	if err := cancelPendingConnectionChange(ctx, d.Id(), meta, d.Timeout(schema.TimeoutDelete)); err != nil {
+		return diag.FromErr(err)
+	}
+	_, resp, err := client.ConnectionsApi.DeleteConnectionByUuid(ctx, d.Id())
 	if err != nil {
 		errors, ok := err.(v4.GenericSwaggerError).Model().([]v4.ModelError)
 		if ok {
@@ -961,17 +1660,82 @@ func resourceFabricConnectionDelete(ctx context.Context, d *schema.ResourceData,
 				return diags
 			}
 		}
-		return diag.FromErr(equinix_errors.FormatFabricError(err))
+		return equinix_diagnostics.SDKError(fmt.Sprintf("deleting Fabric connection %s", d.Id()), "", resp, err)
 	}
 
-	err = WaitUntilConnectionDeprovisioned(d.Id(), meta, ctx)
+	err = WaitUntilConnectionDeprovisioned(d.Id(), meta, ctx, d.Timeout(schema.TimeoutDelete))
 	if err != nil {
 		return diag.FromErr(fmt.Errorf("API call failed while waiting for resource deletion. Error %v", err))
 	}
 	return diags
 }
 
-func WaitUntilConnectionDeprovisioned(uuid string, meta interface{}, ctx context.Context) error {
+// connectionChangeFinalStatuses are the statuses of connection changes that
+// are no longer in flight
+var connectionChangeFinalStatuses = []string{"COMPLETED", "FAILED", "REJECTED", "CANCELLED"}
+
+// connectionChangeInProgress reports whether the latest change of the
+// connection is still in flight, in which case the API rejects the deletion
+// of the connection with a conflict.
+func connectionChangeInProgress(conn v4.Connection) bool {
+	if conn.Change == nil || conn.Change.Status == "" {
+		return false
+	}
+	return !slices.Contains(connectionChangeFinalStatuses, strings.ToUpper(conn.Change.Status))
+}
+
+// cancelPendingConnectionChange cancels the in-flight change of a connection
+// that is waiting for approval, e.g. a bandwidth update shortly followed by a
+// destroy, and waits for the change to settle so the connection can be
+// deleted.
+func cancelPendingConnectionChange(ctx context.Context, uuid string, meta interface{}, timeout time.Duration) error {
+	client := meta.(*config.Config).FabricClient
+	conn, _, err := client.ConnectionsApi.GetConnectionByUuid(ctx, uuid, nil)
+	if err != nil {
+		// Let the deletion report missing or already deleted connections
+		return nil
+	}
+	if !connectionChangeInProgress(conn) {
+		return nil
+	}
+
+	if strings.EqualFold(conn.Change.Status, "REQUESTED") {
+		log.Printf("[DEBUG] Cancelling %s change of connection %s before deletion", conn.Change.Type_, uuid)
+		rejection := v4.CONNECTION_UPDATE_REJECTION_Actions
+		_, _, err := client.ConnectionsApi.CreateConnectionAction(ctx, v4.ConnectionActionRequest{
+			Type_:       &rejection,
+			Description: "Cancelled to delete the connection",
+		}, uuid)
+		if err != nil {
+			log.Printf("[WARN] Could not cancel %s change of connection %s, waiting for it instead: %s", conn.Change.Type_, uuid, equinix_errors.FormatFabricError(err))
+		}
+	}
+
+	log.Printf("[DEBUG] Waiting for %s change of connection %s to settle before deletion", conn.Change.Type_, uuid)
+	stateConf := &retry.StateChangeConf{
+		Pending: []string{"IN_PROGRESS"},
+		Target:  []string{"SETTLED"},
+		Refresh: func() (interface{}, string, error) {
+			dbConn, _, err := client.ConnectionsApi.GetConnectionByUuid(ctx, uuid, nil)
+			if err != nil {
+				return "", "", equinix_errors.FormatFabricError(err)
+			}
+			if connectionChangeInProgress(dbConn) {
+				return dbConn, "IN_PROGRESS", nil
+			}
+			return dbConn, "SETTLED", nil
+		},
+		Timeout:    timeout,
+		Delay:      10 * time.Second,
+		MinTimeout: 10 * time.Second,
+	}
+	if _, err := stateConf.WaitForStateContext(ctx); err != nil {
+		return fmt.Errorf("error waiting for pending change of connection %s to settle before deletion: %v", uuid, err)
+	}
+	return nil
+}
+
+func WaitUntilConnectionDeprovisioned(uuid string, meta interface{}, ctx context.Context, timeout time.Duration) error {
 	log.Printf("Waiting for connection to be deprovisioned, uuid %s", uuid)
 	stateConf := &retry.StateChangeConf{
 		Pending: []string{
@@ -988,7 +1752,7 @@ func WaitUntilConnectionDeprovisioned(uuid string, meta interface{}, ctx context
 			}
 			return dbConn, string(*dbConn.State), nil
 		},
-		Timeout:    6 * time.Minute,
+		Timeout:    timeout,
 		Delay:      30 * time.Second,
 		MinTimeout: 30 * time.Second,
 	}
@@ -997,38 +1761,579 @@ func WaitUntilConnectionDeprovisioned(uuid string, meta interface{}, ctx context
 	return err
 }
 
-func connectionRedundancyToFabric(schemaRedundancy []interface{}) v4.ConnectionRedundancy {
-	if schemaRedundancy == nil {
-		return v4.ConnectionRedundancy{}
+// getMetalConnectionServiceToken fetches an Equinix Metal connection and
+// returns the side and the Fabric service token matching the given priority
+func getMetalConnectionServiceToken(client *packngo.Client, metalConnID, priority string) (packngo.FabricServiceTokenType, v4.ServiceToken, error) {
+	conn, _, err := client.Connections.Get(metalConnID, &packngo.GetOptions{Includes: []string{"service_tokens"}})
+	if err != nil {
+		return "", v4.ServiceToken{}, equinix_errors.FriendlyError(err)
+	}
+	token, err := selectMetalConnectionServiceToken(conn.Tokens, priority)
+	if err != nil {
+		return "", v4.ServiceToken{}, fmt.Errorf("metal connection %s: %s", metalConnID, err)
+	}
+	tokenType := v4.VC_TOKEN_ServiceTokenType
+	return token.ServiceTokenType, v4.ServiceToken{Uuid: token.ID, Type_: &tokenType}, nil
+}
+
+// selectMetalConnectionServiceToken picks the secondary token for SECONDARY
+// connections and the primary token otherwise
+func selectMetalConnectionServiceToken(tokens []packngo.FabricServiceToken, priority string) (packngo.FabricServiceToken, error) {
+	role := packngo.ConnectionPortPrimary
+	if strings.EqualFold(priority, string(v4.SECONDARY_ConnectionPriority)) {
+		role = packngo.ConnectionPortSecondary
+	}
+	for _, token := range tokens {
+		if token.Role == role {
+			return token, nil
+		}
+	}
+	if len(tokens) == 1 && role == packngo.ConnectionPortPrimary {
+		return tokens[0], nil
+	}
+	return packngo.FabricServiceToken{}, fmt.Errorf("no %s service token found, make sure the connection was created with service_token_type", role)
+}
+
+// validateConnectionServiceTokens reads the service tokens used by the sides
+// of the connection request and checks them against the requested connection
+// before it is ordered. Tokens that can't be read, e.g. tokens shared by
+// another organization, are left to the validation of the order.
+func validateConnectionServiceTokens(ctx context.Context, client *v4.APIClient, request v4.ConnectionPostRequest) diag.Diagnostics {
+	sides := map[string]*v4.ConnectionSide{"a_side": request.ASide, "z_side": request.ZSide}
+	for _, side := range []string{"a_side", "z_side"} {
+		if sides[side] == nil || sides[side].ServiceToken == nil || sides[side].ServiceToken.Uuid == "" {
+			continue
+		}
+		uuid := sides[side].ServiceToken.Uuid
+		token, _, err := client.ServiceTokensApi.GetServiceTokenByUuid(ctx, uuid)
+		if err != nil {
+			log.Printf("[WARN] Service token %s of %s can't be read, it is not validated: %s", uuid, side, equinix_errors.FormatFabricError(err))
+			continue
+		}
+		if err := serviceTokenConnectionError(token, side, request.Bandwidth, time.Now()); err != nil {
+			return equinix_diagnostics.SDKError("validating Fabric service token", side+".0.service_token.0.uuid", nil, err)
+		}
+	}
+	return nil
+}
+
+// serviceTokenConnectionError returns an error if the service token can't be
+// used for a connection of the given bandwidth on the given side.
+func serviceTokenConnectionError(token v4.ServiceToken, side string, bandwidth int32, now time.Time) error {
+	if token.State != nil && (*token.State == v4.EXPIRED_ServiceTokenState || *token.State == v4.DELETED_ServiceTokenState) {
+		return fmt.Errorf("service token %s of %s is %s, it can't be used to order a connection", token.Uuid, side, *token.State)
+	}
+	if !token.ExpirationDateTime.IsZero() && token.ExpirationDateTime.Before(now) {
+		return fmt.Errorf("service token %s of %s expired on %s", token.Uuid, side, token.ExpirationDateTime.Format(time.RFC3339))
+	}
+	conn := token.Connection
+	if conn == nil {
+		return nil
 	}
+	tokenSide := ""
+	switch {
+	case conn.ASide != nil && conn.ZSide == nil:
+		tokenSide = "a_side"
+	case conn.ZSide != nil && conn.ASide == nil:
+		tokenSide = "z_side"
+	}
+	if tokenSide != "" && tokenSide != side {
+		return fmt.Errorf("service token %s is a %s service token, it can't be used in %s; move it to the %s block", token.Uuid, tokenSide, side, tokenSide)
+	}
+	if len(conn.SupportedBandwidths) != 0 && !slices.Contains(conn.SupportedBandwidths, bandwidth) {
+		return fmt.Errorf("service token %s of %s doesn't allow a bandwidth of %d Mbps, supported bandwidths are %v Mbps", token.Uuid, side, bandwidth, conn.SupportedBandwidths)
+	}
+	if conn.BandwidthLimit > 0 && bandwidth > conn.BandwidthLimit {
+		return fmt.Errorf("service token %s of %s allows a bandwidth of at most %d Mbps, %d Mbps were requested", token.Uuid, side, conn.BandwidthLimit, bandwidth)
+	}
+	return nil
+}
+
+func connectionRedundancyToFabric(schemaRedundancy []interface{}) v4.ConnectionRedundancy {
 	red := v4.ConnectionRedundancy{}
 	for _, r := range schemaRedundancy {
+		if r == nil {
+			continue
+		}
 		redundancyMap := r.(map[string]interface{})
-		connectionPriority := v4.ConnectionPriority(redundancyMap["priority"].(string))
-		redundancyGroup := redundancyMap["group"].(string)
-		red = v4.ConnectionRedundancy{
-			Priority: &connectionPriority,
-			Group:    redundancyGroup,
+		red = v4.ConnectionRedundancy{Group: redundancyMap["group"].(string)}
+		if priority := redundancyMap["priority"].(string); priority != "" {
+			connectionPriority := v4.ConnectionPriority(strings.ToUpper(priority))
+			red.Priority = &connectionPriority
 		}
 	}
 	return red
 }
 
-func connectionRedundancyToTerra(redundancy *v4.ConnectionRedundancy) *schema.Set {
+func connectionRedundancyToTerra(redundancy *v4.ConnectionRedundancy) []interface{} {
 	if redundancy == nil {
 		return nil
 	}
-	redundancies := []*v4.ConnectionRedundancy{redundancy}
-	mappedRedundancys := make([]interface{}, len(redundancies))
-	for _, redundancy := range redundancies {
-		mappedRedundancy := make(map[string]interface{})
-		mappedRedundancy["group"] = redundancy.Group
+	mappedRedundancy := map[string]interface{}{
+		"group": redundancy.Group,
+	}
+	if redundancy.Priority != nil {
 		mappedRedundancy["priority"] = string(*redundancy.Priority)
-		mappedRedundancys = append(mappedRedundancys, mappedRedundancy)
 	}
-	redundancySet := schema.NewSet(
-		schema.HashResource(&schema.Resource{Schema: connectionRedundancySch()}),
-		mappedRedundancys,
+	return []interface{}{mappedRedundancy}
+}
+
+// validateConnectionRedundancy checks that a new SECONDARY connection is
+// pinned to the redundancy group of its primary connection. The group may be
+// unknown at plan time when it references a primary connection created in
+// the same apply.
+func validateConnectionRedundancy(_ context.Context, d *schema.ResourceDiff, _ interface{}) error {
+	if d.Id() != "" || !strings.EqualFold(d.Get("redundancy.0.priority").(string), string(v4.SECONDARY_ConnectionPriority)) {
+		return nil
+	}
+	if rawConfig := d.GetRawConfig(); !rawConfig.IsNull() && redundancyGroupMissing(rawConfig.GetAttr("redundancy")) {
+		return fmt.Errorf("redundancy.0.group is required for a SECONDARY connection, set it to the group of the primary connection, e.g. equinix_fabric_connection.primary.redundancy.0.group")
+	}
+	return nil
+}
+
+// validateConnectionSides rejects connection sides configured with both a
+// service token and an access point.
+func validateConnectionSides(_ context.Context, d *schema.ResourceDiff, _ interface{}) error {
+	rawConfig := d.GetRawConfig()
+	if rawConfig.IsNull() {
+		return nil
+	}
+	for _, side := range []string{"a_side", "z_side"} {
+		if connectionSideTokenConflict(rawConfig.GetAttr(side)) {
+			return fmt.Errorf("%s can't have both a service_token and an access_point, the access point is resolved from the service token", side)
+		}
+	}
+	return nil
+}
+
+// connectionSideTokenConflict reports whether the configured connection side
+// has both a service token and an access point.
+func connectionSideTokenConflict(side cty.Value) bool {
+	if side.IsNull() || !side.IsKnown() || side.LengthInt() == 0 {
+		return false
+	}
+	sideVal := side.Index(cty.NumberIntVal(0))
+	if sideVal.IsNull() || !sideVal.IsKnown() {
+		return false
+	}
+	configured := func(block cty.Value) bool {
+		return !block.IsNull() && (!block.IsKnown() || block.LengthInt() != 0)
+	}
+	return configured(sideVal.GetAttr("service_token")) && configured(sideVal.GetAttr("access_point"))
+}
+
+// redundancyGroupMissing reports whether the configured redundancy block has
+// no group, neither set nor known after apply.
+func redundancyGroupMissing(redundancy cty.Value) bool {
+	if redundancy.IsNull() || !redundancy.IsKnown() || redundancy.LengthInt() == 0 {
+		return false
+	}
+	group := redundancy.Index(cty.NumberIntVal(0)).GetAttr("group")
+	return group.IsNull() || (group.IsKnown() && group.AsString() == "")
+}
+
+// validateConnectionLinkProtocols checks that the VLAN tags configured on the
+// link protocol of each connection side match the link protocol type.
+func validateConnectionLinkProtocols(_ context.Context, d *schema.ResourceDiff, _ interface{}) error {
+	rawConfig := d.GetRawConfig()
+	if rawConfig.IsNull() {
+		return nil
+	}
+	for _, side := range []string{"a_side", "z_side"} {
+		if err := linkProtocolVlansError(connectionSideLinkProtocol(rawConfig.GetAttr(side))); err != nil {
+			return fmt.Errorf("%s.access_point.link_protocol: %v", side, err)
+		}
+	}
+	return nil
+}
+
+// connectionSideLinkProtocol returns the configured link protocol of the
+// access point of a connection side, null if it is not configured or known.
+func connectionSideLinkProtocol(side cty.Value) cty.Value {
+	first := func(list cty.Value) cty.Value {
+		if list.IsNull() || !list.IsKnown() || list.LengthInt() == 0 {
+			return cty.NullVal(cty.DynamicPseudoType)
+		}
+		block := list.Index(cty.NumberIntVal(0))
+		if !block.IsKnown() {
+			return cty.NullVal(cty.DynamicPseudoType)
+		}
+		return block
+	}
+	block := first(side)
+	for _, attr := range []string{"access_point", "link_protocol"} {
+		if block.IsNull() {
+			return block
+		}
+		block = first(block.GetAttr(attr))
+	}
+	return block
+}
+
+// linkProtocolVlansError returns an error if the VLAN tags of a configured
+// link protocol don't match its type. Tags known after apply count as set.
+func linkProtocolVlansError(linkProtocol cty.Value) error {
+	if linkProtocol.IsNull() {
+		return nil
+	}
+	lpType := linkProtocol.GetAttr("type")
+	if lpType.IsNull() || !lpType.IsKnown() {
+		return nil
+	}
+	isSet := func(key string) bool {
+		tag := linkProtocol.GetAttr(key)
+		if tag.IsNull() {
+			return false
+		}
+		return !tag.IsKnown() || tag.AsBigFloat().Sign() != 0
+	}
+	var unexpected []string
+	switch strings.ToUpper(lpType.AsString()) {
+	case string(v4.UNTAGGED_LinkProtocolType):
+		unexpected = []string{"vlan_tag", "vlan_s_tag", "vlan_c_tag"}
+	case string(v4.DOT1_Q_LinkProtocolType):
+		unexpected = []string{"vlan_s_tag", "vlan_c_tag"}
+	case string(v4.QINQ_LinkProtocolType):
+		unexpected = []string{"vlan_tag"}
+		if isSet("vlan_c_tag") && !isSet("vlan_s_tag") {
+			return fmt.Errorf("vlan_c_tag requires the vlan_s_tag outer tag of QINQ link protocols")
+		}
+	}
+	for _, key := range unexpected {
+		if isSet(key) {
+			return fmt.Errorf("%s can't be set for %s link protocols", key, strings.ToUpper(lpType.AsString()))
+		}
+	}
+	return nil
+}
+
+// accessPointTypeBlocks lists, per access point type, the blocks of the access
+// point identifying its asset. The blocks of the other types can't be set.
+var accessPointTypeBlocks = map[string][]string{
+	"COLO":         {"port"},
+	"VD":           {"virtual_device", "interface"},
+	"SP":           {"profile"},
+	"CLOUD_ROUTER": {"router", "gateway"},
+	"VG":           {"router", "gateway"},
+	"NETWORK":      {"network"},
+	// Equinix Metal networks are identified by the authentication_key
+	"METAL_NETWORK": {},
+}
+
+// validateConnectionAccessPoints rejects access points combining blocks of
+// different access point types, Cloud Routers on both sides of connections
+// other than IP_VC, and COLO access points missing the VLAN tags of their
+// link protocol, all of which the API only rejects on apply.
+func validateConnectionAccessPoints(_ context.Context, d *schema.ResourceDiff, _ interface{}) error {
+	return connectionAccessPointsError(d.GetRawConfig())
+}
+
+func connectionAccessPointsError(rawConfig cty.Value) error {
+	if rawConfig.IsNull() {
+		return nil
+	}
+	autoVlanTag := rawConfig.GetAttr("auto_vlan_tag")
+	autoVlanTagSet := !autoVlanTag.IsKnown() || (!autoVlanTag.IsNull() && autoVlanTag.True())
+	routers := 0
+	for _, side := range []string{"a_side", "z_side"} {
+		accessPoint := configuredAccessPoint(rawConfig.GetAttr(side))
+		if accessPoint.IsNull() {
+			continue
+		}
+		apType := ""
+		if t := accessPoint.GetAttr("type"); !t.IsNull() && t.IsKnown() {
+			apType = strings.ToUpper(t.AsString())
+		}
+		if err := accessPointBlocksError(accessPoint, apType); err != nil {
+			return fmt.Errorf("%s.access_point: %v", side, err)
+		}
+		if apType == "CLOUD_ROUTER" {
+			routers++
+		}
+		if apType == "METAL_NETWORK" && accessPoint.GetAttr("authentication_key").IsNull() {
+			return fmt.Errorf("%s.access_point: authentication_key must be set for METAL_NETWORK access points, to the authorization code of the Equinix Metal connection", side)
+		}
+		if apType == "COLO" {
+			if err := linkProtocolMissingVlansError(connectionSideLinkProtocol(rawConfig.GetAttr(side)), autoVlanTagSet); err != nil {
+				return fmt.Errorf("%s.access_point.link_protocol: %v", side, err)
+			}
+		}
+	}
+	connType := rawConfig.GetAttr("type")
+	if routers == 2 && !connType.IsNull() && connType.IsKnown() && connType.AsString() != string(v4.IP_VC_ConnectionType) {
+		return fmt.Errorf("connections between two Cloud Routers must be of type %s", v4.IP_VC_ConnectionType)
+	}
+	return nil
+}
+
+// configuredAccessPoint returns the configured access point of a connection
+// side, null if it is not configured or known.
+func configuredAccessPoint(side cty.Value) cty.Value {
+	first := func(list cty.Value) cty.Value {
+		if list.IsNull() || !list.IsKnown() || list.LengthInt() == 0 {
+			return cty.NullVal(cty.DynamicPseudoType)
+		}
+		block := list.Index(cty.NumberIntVal(0))
+		if !block.IsKnown() {
+			return cty.NullVal(cty.DynamicPseudoType)
+		}
+		return block
+	}
+	block := first(side)
+	if block.IsNull() {
+		return block
+	}
+	return first(block.GetAttr("access_point"))
+}
+
+// accessPointBlocksError returns an error if the access point has blocks of
+// another access point type configured. Access point types not known before
+// apply or without specific blocks aren't checked.
+func accessPointBlocksError(accessPoint cty.Value, apType string) error {
+	allowed, ok := accessPointTypeBlocks[apType]
+	if !ok {
+		return nil
+	}
+	for _, block := range []string{"port", "profile", "router", "gateway", "virtual_device", "interface", "network"} {
+		if isStringInSlice(block, allowed) {
+			continue
+		}
+		value := accessPoint.GetAttr(block)
+		if !value.IsNull() && (!value.IsKnown() || value.LengthInt() != 0) {
+			return fmt.Errorf("%s can't be set for %s access points", block, apType)
+		}
+	}
+	return nil
+}
+
+// linkProtocolMissingVlansError returns an error if a configured link protocol
+// misses the VLAN tags required by its type. The VLAN tag of DOT1Q link
+// protocols may be selected on create with auto_vlan_tag.
+func linkProtocolMissingVlansError(linkProtocol cty.Value, autoVlanTag bool) error {
+	if linkProtocol.IsNull() {
+		return nil
+	}
+	lpType := linkProtocol.GetAttr("type")
+	if lpType.IsNull() || !lpType.IsKnown() {
+		return nil
+	}
+	isSet := func(key string) bool {
+		tag := linkProtocol.GetAttr(key)
+		if tag.IsNull() {
+			return false
+		}
+		return !tag.IsKnown() || tag.AsBigFloat().Sign() != 0
+	}
+	switch strings.ToUpper(lpType.AsString()) {
+	case string(v4.DOT1_Q_LinkProtocolType):
+		if !isSet("vlan_tag") && !autoVlanTag {
+			return fmt.Errorf("vlan_tag must be set for DOT1Q link protocols, or selected with auto_vlan_tag")
+		}
+	case string(v4.QINQ_LinkProtocolType):
+		if !isSet("vlan_s_tag") {
+			return fmt.Errorf("vlan_s_tag must be set for QINQ link protocols")
+		}
+	}
+	return nil
+}
+
+// validateConnectionAdditionalInfo checks the additional_info of connections
+// to IBM Cloud Direct Link: the API requires both the ASN and the Global keys
+// and only rejects invalid values once the connection is created.
+func validateConnectionAdditionalInfo(_ context.Context, d *schema.ResourceDiff, _ interface{}) error {
+	rawConfig := d.GetRawConfig()
+	if rawConfig.IsNull() {
+		return nil
+	}
+	return ibmAdditionalInfoError(rawConfig.GetAttr("additional_info"))
+}
+
+func ibmAdditionalInfoError(additionalInfo cty.Value) error {
+	if additionalInfo.IsNull() || !additionalInfo.IsKnown() {
+		return nil
+	}
+	values := map[string]cty.Value{}
+	for it := additionalInfo.ElementIterator(); it.Next(); {
+		_, item := it.Element()
+		if item.IsNull() || !item.IsKnown() || !item.Type().IsMapType() || !item.HasIndex(cty.StringVal("key")).True() {
+			continue
+		}
+		key := item.Index(cty.StringVal("key"))
+		if key.IsNull() || !key.IsKnown() {
+			continue
+		}
+		value := cty.UnknownVal(cty.String)
+		if item.HasIndex(cty.StringVal("value")).True() {
+			value = item.Index(cty.StringVal("value"))
+		}
+		for _, ibmKey := range []string{ibmAdditionalInfoASN, ibmAdditionalInfoGlobal} {
+			if strings.EqualFold(key.AsString(), ibmKey) {
+				values[ibmKey] = value
+			}
+		}
+	}
+	if len(values) == 0 {
+		return nil
+	}
+	for _, ibmKey := range []string{ibmAdditionalInfoASN, ibmAdditionalInfoGlobal} {
+		if _, ok := values[ibmKey]; !ok {
+			return fmt.Errorf("additional_info of IBM Cloud connections must have both the %s and %s keys, %s is missing", ibmAdditionalInfoASN, ibmAdditionalInfoGlobal, ibmKey)
+		}
+	}
+	if asn := values[ibmAdditionalInfoASN]; !asn.IsNull() && asn.IsKnown() {
+		if n, err := strconv.ParseUint(asn.AsString(), 10, 32); err != nil || n == 0 {
+			return fmt.Errorf("additional_info %s must be a BGP ASN between 1 and 4294967295, got %q", ibmAdditionalInfoASN, asn.AsString())
+		}
+	}
+	if global := values[ibmAdditionalInfoGlobal]; !global.IsNull() && global.IsKnown() {
+		if _, err := strconv.ParseBool(global.AsString()); err != nil {
+			return fmt.Errorf("additional_info %s must be true or false, got %q", ibmAdditionalInfoGlobal, global.AsString())
+		}
+	}
+	return nil
+}
+
+// validateConnectionProfileBandwidth checks the bandwidth of connections to a
+// service profile against the bandwidths supported by the profile, the same
+// as the supported_bandwidths of the equinix_fabric_service_profile data
+// source, so that unsupported bandwidths are caught on plan rather than
+// rejected by the API. Profiles that can't be read are left to the API.
+func validateConnectionProfileBandwidth(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	if d.Id() != "" && !d.HasChanges("bandwidth", "bandwidth_unit") {
+		return nil
+	}
+	if !d.NewValueKnown("bandwidth") || !d.NewValueKnown("bandwidth_unit") {
+		return nil
+	}
+	profileUuid := connectionProfileUuid(d.GetRawConfig())
+	if profileUuid == "" {
+		return nil
+	}
+	c, ok := meta.(*config.Config)
+	if !ok || c.FabricClient == nil {
+		// Without a configured provider the API can't be queried
+		return nil
+	}
+	ctx = context.WithValue(ctx, v4.ContextAccessToken, c.FabricAuthToken)
+	profile, err := cachedFabricServiceProfile(ctx, c, profileUuid)
+	if err != nil {
+		log.Printf("[WARN] Service profile %s can't be read, the connection bandwidth is not validated: %s", profileUuid, equinix_errors.FormatFabricError(err))
+		return nil
+	}
+	bandwidth := equinix_fabric_schema.BandwidthToMbps(d.Get("bandwidth").(int), d.Get("bandwidth_unit").(string))
+	return profileBandwidthError(profile, int32(bandwidth))
+}
+
+// connectionProfileUuid returns the UUID of the service profile configured on
+// the Z side of a connection, empty if it is not configured or known.
+func connectionProfileUuid(rawConfig cty.Value) string {
+	if rawConfig.IsNull() {
+		return ""
+	}
+	accessPoint := configuredAccessPoint(rawConfig.GetAttr("z_side"))
+	if accessPoint.IsNull() {
+		return ""
+	}
+	profiles := accessPoint.GetAttr("profile")
+	if profiles.IsNull() || !profiles.IsKnown() || profiles.LengthInt() == 0 {
+		return ""
+	}
+	profile := profiles.Index(cty.NumberIntVal(0))
+	if profile.IsNull() || !profile.IsKnown() {
+		return ""
+	}
+	uuid := profile.GetAttr("uuid")
+	if uuid.IsNull() || !uuid.IsKnown() {
+		return ""
+	}
+	return uuid.AsString()
+}
+
+// profileBandwidthError returns an error if no access point type of the
+// service profile supports the bandwidth, in Mbps. Profiles allowing custom
+// bandwidths or without supported bandwidths accept any bandwidth.
+func profileBandwidthError(profile v4.ServiceProfile, bandwidth int32) error {
+	var supported []int32
+	for _, apType := range profile.AccessPointTypeConfigs {
+		if apType.AllowCustomBandwidth {
+			return nil
+		}
+		if apType.SupportedBandwidths != nil {
+			supported = append(supported, *apType.SupportedBandwidths...)
+		}
+	}
+	if len(supported) == 0 || slices.Contains(supported, bandwidth) {
+		return nil
+	}
+	slices.Sort(supported)
+	supported = slices.Compact(supported)
+	return fmt.Errorf("service profile %s doesn't support a bandwidth of %d Mbps, supported bandwidths are %v Mbps", profile.Uuid, bandwidth, supported)
+}
+
+// validateConnectionMetalSpeed checks that the bandwidth of a connection
+// using the service token of an Equinix Metal connection doesn't exceed the
+// speed of the Metal connection, which the order only rejects once the Metal
+// side is provisioned. Metal connections that can't be read are left to the
+// API.
+func validateConnectionMetalSpeed(_ context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	if d.Id() != "" && !d.HasChanges("bandwidth", "bandwidth_unit") {
+		return nil
+	}
+	if !d.NewValueKnown("metal_connection_id") || !d.NewValueKnown("bandwidth") || !d.NewValueKnown("bandwidth_unit") {
+		return nil
+	}
+	metalConnID := d.Get("metal_connection_id").(string)
+	if metalConnID == "" {
+		return nil
+	}
+	c, ok := meta.(*config.Config)
+	if !ok || c.Metal == nil || c.DisabledServiceError("equinix_metal_connection") != nil {
+		return nil
+	}
+	conn, _, err := c.Metal.Connections.Get(metalConnID, nil)
+	if err != nil {
+		log.Printf("[WARN] Metal connection %s can't be read, the connection bandwidth is not validated: %s", metalConnID, equinix_errors.FriendlyError(err))
+		return nil
+	}
+	bandwidth := equinix_fabric_schema.BandwidthToMbps(d.Get("bandwidth").(int), d.Get("bandwidth_unit").(string))
+	return metalConnectionSpeedError(*conn, bandwidth)
+}
+
+// metalConnectionSpeedError returns an error if the bandwidth, in Mbps,
+// exceeds the speed of the Metal connection, in bps.
+func metalConnectionSpeedError(conn packngo.Connection, bandwidth int) error {
+	speed := conn.Speed / (1000 * 1000)
+	if speed == 0 || uint64(bandwidth) <= speed {
+		return nil
+	}
+	return fmt.Errorf("bandwidth of %d Mbps exceeds the speed of %d Mbps of Metal connection %s", bandwidth, speed, conn.ID)
+}
+
+// validateConnectionBandwidthDowngrade fails the plan of updates reducing the
+// bandwidth of a connection unless allow_bandwidth_downgrade is set, since
+// reductions can be service affecting.
+func validateConnectionBandwidthDowngrade(_ context.Context, d *schema.ResourceDiff, _ interface{}) error {
+	if d.Id() == "" || !d.HasChanges("bandwidth", "bandwidth_unit") {
+		return nil
+	}
+	if !d.NewValueKnown("bandwidth") || !d.NewValueKnown("bandwidth_unit") {
+		return nil
+	}
+	oldBandwidth, newBandwidth := d.GetChange("bandwidth")
+	oldUnit, newUnit := d.GetChange("bandwidth_unit")
+	return bandwidthDowngradeError(
+		equinix_fabric_schema.BandwidthToMbps(oldBandwidth.(int), oldUnit.(string)),
+		equinix_fabric_schema.BandwidthToMbps(newBandwidth.(int), newUnit.(string)),
+		d.Get("allow_bandwidth_downgrade").(bool),
 	)
-	return redundancySet
+}
+
+// bandwidthDowngradeError returns an error if the bandwidth, in Mbps, is
+// reduced without being allowed.
+func bandwidthDowngradeError(oldBandwidth, newBandwidth int, allowDowngrade bool) error {
+	if newBandwidth >= oldBandwidth || allowDowngrade {
+		return nil
+	}
+	return fmt.Errorf("bandwidth is reduced from %d Mbps to %d Mbps, which can be service affecting; set allow_bandwidth_downgrade to apply the reduction", oldBandwidth, newBandwidth)
 }
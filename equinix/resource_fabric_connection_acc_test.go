@@ -8,6 +8,7 @@ import (
 	"github.com/equinix/terraform-provider-equinix/internal/acceptance"
 	"os"
 	"testing"
+	"time"
 
 	"github.com/equinix/terraform-provider-equinix/internal/config"
 
@@ -465,7 +466,7 @@ func CheckConnectionDelete(s *terraform.State) error {
 		if rs.Type != "equinix_fabric_connection" {
 			continue
 		}
-		err := equinix.WaitUntilConnectionDeprovisioned(rs.Primary.ID, acceptance.TestAccProvider.Meta(), ctx)
+		err := equinix.WaitUntilConnectionDeprovisioned(rs.Primary.ID, acceptance.TestAccProvider.Meta(), ctx, 6*time.Minute)
 		if err != nil {
 			return fmt.Errorf("API call failed while waiting for resource deletion")
 		}
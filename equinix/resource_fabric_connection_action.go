@@ -0,0 +1,219 @@
+package equinix
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	v4 "github.com/equinix-labs/fabric-go/fabric/v4"
+	"github.com/equinix/terraform-provider-equinix/internal/config"
+	equinix_errors "github.com/equinix/terraform-provider-equinix/internal/errors"
+	equinix_schema "github.com/equinix/terraform-provider-equinix/internal/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/retry"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// connectionActionSettleStatuses lists, per connection action type, the
+// Equinix statuses of the connection while the action is processed and once
+// it settled.
+var connectionActionSettleStatuses = map[string]struct {
+	pending []string
+	target  []string
+}{
+	string(v4.CONNECTION_CREATION_ACCEPTANCE_Actions): {
+		pending: []string{
+			string(v4.PENDING_APPROVAL_EquinixStatus),
+			string(v4.APPROVED_EquinixStatus),
+			string(v4.PROVISIONING_EquinixStatus),
+			string(v4.BEING_PROVISIONED_EquinixStatus),
+		},
+		target: []string{
+			string(v4.PROVISIONED_EquinixStatus),
+			string(v4.PENDING_BGP_PEERING_EquinixStatus),
+		},
+	},
+	string(v4.CONNECTION_CREATION_REJECTION_Actions): {
+		pending: []string{
+			string(v4.PENDING_APPROVAL_EquinixStatus),
+		},
+		target: []string{
+			string(v4.REJECTED_EquinixStatus),
+			string(v4.REJECTED_ACK_EquinixStatus),
+		},
+	},
+}
+
+func fabricConnectionActionResourceSchema() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"connection_id": {
+			Type:         schema.TypeString,
+			Required:     true,
+			ForceNew:     true,
+			ValidateFunc: validation.StringIsNotEmpty,
+			Description:  "Identifier of the pending connection the action is taken on",
+		},
+		"type": {
+			Type:         schema.TypeString,
+			Required:     true,
+			ForceNew:     true,
+			ValidateFunc: validation.StringInSlice([]string{string(v4.CONNECTION_CREATION_ACCEPTANCE_Actions), string(v4.CONNECTION_CREATION_REJECTION_Actions)}, false),
+			Description:  "Type of the action, one of CONNECTION_CREATION_ACCEPTANCE or CONNECTION_CREATION_REJECTION",
+		},
+		"description": {
+			Type:         schema.TypeString,
+			Optional:     true,
+			ForceNew:     true,
+			ValidateFunc: validation.StringIsNotEmpty,
+			Description:  "Reason of the action, shown to the connection requester on rejections",
+		},
+		"z_side": {
+			Type:        schema.TypeList,
+			Optional:    true,
+			ForceNew:    true,
+			MaxItems:    1,
+			Description: "Z side of the connection set on acceptance, e.g. the port and VLAN the connection of a service profile is delivered to",
+			Elem:        connectionSideSch(),
+		},
+		"href": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "Connection action URI",
+		},
+		"equinix_status": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "Equinix status of the connection once the action settled",
+		},
+		"provider_status": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "Provider status of the connection once the action settled",
+		},
+	}
+}
+
+func resourceFabricConnectionAction() *schema.Resource {
+	return &schema.Resource{
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(10 * time.Minute),
+		},
+		CreateContext: resourceFabricConnectionActionCreate,
+		ReadContext:   resourceFabricConnectionActionRead,
+		DeleteContext: resourceFabricConnectionActionDelete,
+		Schema:        fabricConnectionActionResourceSchema(),
+
+		Description: "Fabric V4 API compatible resource allows accepting or rejecting the creation of Equinix Fabric connections pending approval on the Z side",
+	}
+}
+
+func resourceFabricConnectionActionCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*config.Config).FabricClient
+	ctx = context.WithValue(ctx, v4.ContextAccessToken, meta.(*config.Config).FabricAuthToken)
+	connectionId := d.Get("connection_id").(string)
+	actionType := v4.Actions(d.Get("type").(string))
+
+	request := v4.ConnectionActionRequest{
+		Type_:       &actionType,
+		Description: d.Get("description").(string),
+	}
+	if zSide, ok := d.GetOk("z_side"); ok {
+		side, err := connectionSideToFabric(zSide.([]interface{}), "z_side")
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		request.Data = &v4.ConnectionAcceptanceData{ZSide: &side}
+	}
+
+	action, _, err := client.ConnectionsApi.CreateConnectionAction(ctx, request, connectionId)
+	if err != nil {
+		return diag.FromErr(equinix_errors.FormatFabricError(err))
+	}
+	d.SetId(connectionId)
+	if err := d.Set("href", action.Href); err != nil {
+		return diag.FromErr(err)
+	}
+
+	if _, err := waitForConnectionActionToSettle(ctx, connectionId, string(actionType), meta, d.Timeout(schema.TimeoutCreate)); err != nil {
+		return diag.FromErr(err)
+	}
+	return resourceFabricConnectionActionRead(ctx, d, meta)
+}
+
+func resourceFabricConnectionActionRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*config.Config).FabricClient
+	ctx = context.WithValue(ctx, v4.ContextAccessToken, meta.(*config.Config).FabricAuthToken)
+	conn, resp, err := client.ConnectionsApi.GetConnectionByUuid(ctx, d.Id(), nil)
+	if err != nil {
+		if !d.IsNewResource() && equinix_errors.IsGone(resp, err) {
+			log.Printf("[WARN] Connection %s not found, removing its action from state", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return diag.FromErr(equinix_errors.FormatFabricError(err))
+	}
+	if conn.State != nil && *conn.State == v4.DEPROVISIONED_ConnectionState {
+		log.Printf("[WARN] Connection %s is %s, removing its action from state", d.Id(), *conn.State)
+		d.SetId("")
+		return nil
+	}
+	err = equinix_schema.SetMap(d, connectionActionStatusesToTerra(conn))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	return nil
+}
+
+// resourceFabricConnectionActionDelete only removes the action from state,
+// accepted or rejected connections can't be brought back to pending approval.
+func resourceFabricConnectionActionDelete(_ context.Context, d *schema.ResourceData, _ interface{}) diag.Diagnostics {
+	log.Printf("[DEBUG] Removing action of connection %s from state, the connection is left as is", d.Id())
+	d.SetId("")
+	return nil
+}
+
+func connectionActionStatusesToTerra(conn v4.Connection) map[string]interface{} {
+	statuses := map[string]interface{}{
+		"equinix_status":  "",
+		"provider_status": "",
+	}
+	if conn.Operation == nil {
+		return statuses
+	}
+	if conn.Operation.EquinixStatus != nil {
+		statuses["equinix_status"] = string(*conn.Operation.EquinixStatus)
+	}
+	if conn.Operation.ProviderStatus != nil {
+		statuses["provider_status"] = string(*conn.Operation.ProviderStatus)
+	}
+	return statuses
+}
+
+// waitForConnectionActionToSettle waits for the Equinix status of the
+// connection to reflect the outcome of the given action.
+func waitForConnectionActionToSettle(ctx context.Context, uuid, actionType string, meta interface{}, timeout time.Duration) (v4.Connection, error) {
+	log.Printf("[DEBUG] Waiting for %s of connection %s to settle", actionType, uuid)
+	statuses := connectionActionSettleStatuses[actionType]
+	stateConf := &retry.StateChangeConf{
+		Pending: statuses.pending,
+		Target:  statuses.target,
+		Refresh: func() (interface{}, string, error) {
+			client := meta.(*config.Config).FabricClient
+			dbConn, _, err := client.ConnectionsApi.GetConnectionByUuid(ctx, uuid, nil)
+			if err != nil {
+				return "", "", equinix_errors.FormatFabricError(err)
+			}
+			return dbConn, connectionActionStatusesToTerra(dbConn)["equinix_status"].(string), nil
+		},
+		Timeout:    timeout,
+		MinTimeout: 10 * time.Second,
+	}
+
+	inter, err := stateConf.WaitForStateContext(ctx)
+	if err != nil {
+		return v4.Connection{}, fmt.Errorf("error waiting for %s of connection %s to settle: %v", actionType, uuid, err)
+	}
+	return inter.(v4.Connection), nil
+}
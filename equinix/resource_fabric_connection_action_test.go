@@ -0,0 +1,96 @@
+package equinix
+
+import (
+	"context"
+	"testing"
+
+	v4 "github.com/equinix-labs/fabric-go/fabric/v4"
+	"github.com/equinix/terraform-provider-equinix/internal/config"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// testPendingApprovalConnection creates a connection pending approval on the
+// fake API and returns its identifier.
+func testPendingApprovalConnection(t *testing.T, c *config.Config) string {
+	ctx := context.WithValue(context.Background(), v4.ContextAccessToken, c.FabricAuthToken)
+	connType := v4.EVPL_VC_ConnectionType
+	conn, _, err := c.FabricClient.ConnectionsApi.CreateConnection(ctx, v4.ConnectionPostRequest{
+		Type_:     &connType,
+		Name:      "pending-connection",
+		Bandwidth: 50,
+	})
+	require.NoError(t, err)
+	_, _, err = c.FabricClient.ConnectionsApi.UpdateConnectionByUuid(ctx, []v4.ConnectionChangeOperation{
+		{Op: "replace", Path: "/operation", Value: map[string]interface{}{
+			"equinixStatus":  "PENDING_APPROVAL",
+			"providerStatus": "PENDING_APPROVAL",
+		}},
+	}, conn.Uuid)
+	require.NoError(t, err)
+	return conn.Uuid
+}
+
+func TestFabricConnectionAction_accept(t *testing.T) {
+	// given
+	c := newFakeAPIConfig(t)
+	connectionId := testPendingApprovalConnection(t, c)
+	d := schema.TestResourceDataRaw(t, resourceFabricConnectionAction().Schema, map[string]interface{}{
+		"connection_id": connectionId,
+		"type":          "CONNECTION_CREATION_ACCEPTANCE",
+		"z_side": []interface{}{
+			map[string]interface{}{
+				"access_point": []interface{}{
+					map[string]interface{}{
+						"type": "COLO",
+						"port": []interface{}{
+							map[string]interface{}{"uuid": "c4d9350e-783c-83cd-1ce0-306a5c00a600"},
+						},
+					},
+				},
+			},
+		},
+	})
+	// when
+	diags := resourceFabricConnectionActionCreate(context.Background(), d, c)
+	// then
+	require.False(t, diags.HasError(), "Create does not fail: %v", diags)
+	assert.Equal(t, connectionId, d.Id(), "Action is identified by the connection")
+	assert.Equal(t, "PROVISIONED", d.Get("equinix_status"))
+	assert.Equal(t, "PROVISIONED", d.Get("provider_status"))
+	assert.NotEmpty(t, d.Get("href"))
+}
+
+func TestFabricConnectionAction_reject(t *testing.T) {
+	// given
+	c := newFakeAPIConfig(t)
+	connectionId := testPendingApprovalConnection(t, c)
+	d := schema.TestResourceDataRaw(t, resourceFabricConnectionAction().Schema, map[string]interface{}{
+		"connection_id": connectionId,
+		"type":          "CONNECTION_CREATION_REJECTION",
+		"description":   "Unknown requester",
+	})
+	// when
+	diags := resourceFabricConnectionActionCreate(context.Background(), d, c)
+	deleteDiags := resourceFabricConnectionActionDelete(context.Background(), d, c)
+	// then
+	require.False(t, diags.HasError(), "Create does not fail: %v", diags)
+	assert.Equal(t, "REJECTED", d.Get("equinix_status"))
+	assert.False(t, deleteDiags.HasError(), "Delete does not fail")
+	assert.Empty(t, d.Id(), "Action is removed from state")
+}
+
+func TestFabricConnectionAction_unknownConnection(t *testing.T) {
+	// given
+	c := newFakeAPIConfig(t)
+	d := schema.TestResourceDataRaw(t, resourceFabricConnectionAction().Schema, map[string]interface{}{
+		"connection_id": "missing-connection",
+		"type":          "CONNECTION_CREATION_ACCEPTANCE",
+	})
+	// when
+	diags := resourceFabricConnectionActionCreate(context.Background(), d, c)
+	// then
+	assert.True(t, diags.HasError(), "Action on an unknown connection fails")
+	assert.Empty(t, d.Id())
+}
@@ -0,0 +1,919 @@
+package equinix
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	v4 "github.com/equinix-labs/fabric-go/fabric/v4"
+	"github.com/equinix/terraform-provider-equinix/internal/config"
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/packethost/packngo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func deprovisionedFabricConnection(t *testing.T, strict bool) (*config.Config, string) {
+	c := newFakeAPIConfig(t, func(c *config.Config) {
+		c.FabricDeprovisionedAsError = strict
+	})
+
+	ctx := context.WithValue(context.Background(), v4.ContextAccessToken, c.FabricAuthToken)
+	connType := v4.EVPL_VC_ConnectionType
+	conn, _, err := c.FabricClient.ConnectionsApi.CreateConnection(ctx, v4.ConnectionPostRequest{
+		Type_:     &connType,
+		Name:      "test-connection",
+		Bandwidth: 50,
+	})
+	require.NoError(t, err)
+	_, _, err = c.FabricClient.ConnectionsApi.DeleteConnectionByUuid(ctx, conn.Uuid)
+	require.NoError(t, err)
+	return c, conn.Uuid
+}
+
+func TestFabricConnectionRead_deprovisioned(t *testing.T) {
+	// given
+	c, uuid := deprovisionedFabricConnection(t, false)
+	d := schema.TestResourceDataRaw(t, resourceFabricConnection().Schema, map[string]interface{}{})
+	d.SetId(uuid)
+	// when
+	diags := resourceFabricConnectionRead(context.Background(), d, c)
+	// then
+	assert.False(t, diags.HasError(), "Read does not fail")
+	require.Len(t, diags, 1, "Read returns a single diagnostic")
+	assert.Equal(t, diag.Warning, diags[0].Severity, "Read returns a warning")
+	assert.Empty(t, d.Id(), "Connection is removed from state")
+}
+
+func TestFabricConnectionRead_deprovisionedAsError(t *testing.T) {
+	// given
+	c, uuid := deprovisionedFabricConnection(t, true)
+	d := schema.TestResourceDataRaw(t, resourceFabricConnection().Schema, map[string]interface{}{})
+	d.SetId(uuid)
+	// when
+	diags := resourceFabricConnectionRead(context.Background(), d, c)
+	// then
+	assert.True(t, diags.HasError(), "Read fails")
+	assert.Equal(t, uuid, d.Id(), "Connection is kept in state")
+}
+
+func TestFabricConnectionSideToTerra(t *testing.T) {
+	// given
+	apType := v4.COLO_AccessPointType
+	lpType := v4.DOT1_Q_LinkProtocolType
+	side := &v4.ConnectionSide{
+		AccessPoint: &v4.AccessPoint{
+			Type_:        &apType,
+			Port:         &v4.SimplifiedPort{Uuid: "port-uuid"},
+			LinkProtocol: &v4.SimplifiedLinkProtocol{Type_: &lpType, VlanTag: 100},
+			Router:       &v4.CloudRouter{Uuid: "router-uuid"},
+		},
+	}
+	d := schema.TestResourceDataRaw(t, resourceFabricConnection().Schema, map[string]interface{}{})
+	// when
+	err := d.Set("a_side", connectionSideToTerra(side))
+	// then
+	require.NoError(t, err)
+	assert.Equal(t, 1, d.Get("a_side.#"), "Connection side is a single element list")
+	assert.Equal(t, "COLO", d.Get("a_side.0.access_point.0.type"))
+	assert.Equal(t, "port-uuid", d.Get("a_side.0.access_point.0.port.0.uuid"))
+	assert.Equal(t, "DOT1Q", d.Get("a_side.0.access_point.0.link_protocol.0.type"))
+	assert.Equal(t, 100, d.Get("a_side.0.access_point.0.link_protocol.0.vlan_tag"))
+	assert.Equal(t, "router-uuid", d.Get("a_side.0.access_point.0.router.0.uuid"))
+}
+
+func TestFabricConnection_selectMetalConnectionServiceToken(t *testing.T) {
+	// given
+	primary := packngo.FabricServiceToken{ID: "primary-token", Role: packngo.ConnectionPortPrimary, ServiceTokenType: packngo.FabricServiceTokenZSide}
+	secondary := packngo.FabricServiceToken{ID: "secondary-token", Role: packngo.ConnectionPortSecondary, ServiceTokenType: packngo.FabricServiceTokenZSide}
+	tokens := []packngo.FabricServiceToken{secondary, primary}
+	// when
+	defaultToken, defaultErr := selectMetalConnectionServiceToken(tokens, "")
+	secondaryToken, secondaryErr := selectMetalConnectionServiceToken(tokens, "SECONDARY")
+	_, missingErr := selectMetalConnectionServiceToken([]packngo.FabricServiceToken{primary}, "SECONDARY")
+	_, noTokensErr := selectMetalConnectionServiceToken(nil, "PRIMARY")
+	// then
+	assert.NoError(t, defaultErr)
+	assert.Equal(t, primary, defaultToken, "Primary token is selected by default")
+	assert.NoError(t, secondaryErr)
+	assert.Equal(t, secondary, secondaryToken, "Secondary token is selected for SECONDARY connections")
+	assert.Error(t, missingErr, "Missing secondary token is reported")
+	assert.Error(t, noTokensErr, "Connection without tokens is reported")
+}
+
+func TestFabricConnectionPathToTerra(t *testing.T) {
+	// given
+	sv := &v4.Metro{
+		Code:                "SV",
+		Region:              "AMER",
+		LocalVCBandwidthMax: 50000,
+		ConnectedMetros: []v4.ConnectedMetro{
+			{Code: "DC", AvgLatency: 62.5, RemoteVCBandwidthMax: 10000},
+			{Code: "AM", AvgLatency: 140.1, RemoteVCBandwidthMax: 5000},
+		},
+	}
+	dc := &v4.Metro{Code: "DC", Region: "AMER"}
+	am := &v4.Metro{Code: "AM", Region: "EMEA"}
+	// when
+	local := connectionPathToTerra(v4.Connection{}, sv, sv)[0].(map[string]interface{})
+	regional := connectionPathToTerra(v4.Connection{}, sv, dc)[0].(map[string]interface{})
+	global := connectionPathToTerra(v4.Connection{}, sv, am)[0].(map[string]interface{})
+	unknown := connectionPathToTerra(v4.Connection{}, sv, nil)[0].(map[string]interface{})
+	// then
+	assert.Equal(t, "LOCAL", local["latency_class"], "Connection within a metro is local")
+	assert.Equal(t, 50000, local["max_bandwidth"], "Local connection is limited by the metro bandwidth")
+	assert.Equal(t, "REGIONAL", regional["latency_class"], "Connection within a region is regional")
+	assert.Equal(t, 62.5, regional["avg_latency"], "Average latency is taken from the connected metros")
+	assert.Equal(t, "GLOBAL", global["latency_class"], "Connection across regions is global")
+	assert.Equal(t, 5000, global["max_bandwidth"], "Remote connection is limited by the connected metro bandwidth")
+	assert.Equal(t, map[string]interface{}{"local_metro_code": "SV"}, unknown, "Path without a remote metro only has the local metro")
+}
+
+func TestFabricConnectionRoutingProtocolsToTerra(t *testing.T) {
+	// given
+	rps := []v4.RoutingProtocolData{
+		{
+			Type_: "DIRECT",
+			OneOfRoutingProtocolData: v4.OneOfRoutingProtocolData{
+				RoutingProtocolDirectData: v4.RoutingProtocolDirectData{
+					Uuid:       "direct-uuid",
+					State:      "PROVISIONED",
+					DirectIpv4: &v4.DirectConnectionIpv4{EquinixIfaceIp: "192.168.100.1/30"},
+				},
+			},
+		},
+		{
+			Type_: "BGP",
+			OneOfRoutingProtocolData: v4.OneOfRoutingProtocolData{
+				RoutingProtocolBgpData: v4.RoutingProtocolBgpData{
+					Uuid:        "bgp-uuid",
+					State:       "PROVISIONED",
+					BgpIpv4:     &v4.BgpConnectionIpv4{CustomerPeerIp: "192.168.100.2", EquinixPeerIp: "192.168.100.1"},
+					CustomerAsn: 65001,
+					EquinixAsn:  30000,
+				},
+			},
+		},
+		{Type_: "UNKNOWN"},
+	}
+	// when
+	result := connectionRoutingProtocolsToTerra(rps)
+	// then
+	require.Len(t, result, 2, "Routing protocols of unknown type are skipped")
+	direct := result[0].(map[string]interface{})
+	assert.Equal(t, "direct-uuid", direct["uuid"])
+	assert.Equal(t, "192.168.100.1/30", direct["equinix_iface_ipv4"], "DIRECT interface IP is mapped")
+	assert.NotContains(t, direct, "equinix_iface_ipv6", "Missing IPv6 is not mapped")
+	bgp := result[1].(map[string]interface{})
+	assert.Equal(t, "BGP", bgp["type"])
+	assert.Equal(t, "192.168.100.2", bgp["customer_peer_ipv4"], "BGP customer peer IP is mapped")
+	assert.Equal(t, "192.168.100.1", bgp["equinix_peer_ipv4"], "BGP Equinix peer IP is mapped")
+	assert.Equal(t, 65001, bgp["customer_asn"])
+	assert.Equal(t, 30000, bgp["equinix_asn"])
+}
+
+func TestFabricConnection_portRedundancy(t *testing.T) {
+	// given
+	portList := []interface{}{
+		map[string]interface{}{
+			"uuid": "c4d9350e-783c-83cd-1ce0-306a5c00a600",
+			"redundancy": []interface{}{
+				map[string]interface{}{"priority": "SECONDARY"},
+			},
+		},
+	}
+	// when
+	port, err := portToFabric(portList)
+	mapped := portToTerra(&port).List()
+	// then
+	require.NoError(t, err, "Port is converted")
+	require.NotNil(t, port.Redundancy, "Port redundancy is mapped")
+	assert.Equal(t, v4.SECONDARY_PortPriority, *port.Redundancy.Priority, "Port redundancy priority is mapped")
+	require.Len(t, mapped, 1, "Port is mapped back")
+	redundancy := mapped[0].(map[string]interface{})["redundancy"].([]interface{})
+	require.Len(t, redundancy, 1, "Port redundancy is mapped back")
+	assert.Equal(t, "SECONDARY", redundancy[0].(map[string]interface{})["priority"], "Port redundancy priority is mapped back")
+}
+
+func TestFabricConnection_portWithoutRedundancy(t *testing.T) {
+	// given
+	portList := []interface{}{
+		map[string]interface{}{
+			"uuid":       "c4d9350e-783c-83cd-1ce0-306a5c00a600",
+			"redundancy": []interface{}{},
+		},
+	}
+	// when
+	port, err := portToFabric(portList)
+	redundancy := PortRedundancyToTerra(&v4.PortRedundancy{Enabled: true}).List()
+	// then
+	require.NoError(t, err, "Port is converted")
+	assert.Nil(t, port.Redundancy, "Port redundancy is not sent without priority")
+	require.Len(t, redundancy, 1, "Port redundancy without priority is mapped")
+	assert.Equal(t, true, redundancy[0].(map[string]interface{})["enabled"], "Port redundancy is mapped without priority")
+}
+
+func TestFabricConnection_redundancyGroupMissing(t *testing.T) {
+	redundancy := func(priority string, group cty.Value) cty.Value {
+		return cty.ListVal([]cty.Value{cty.ObjectVal(map[string]cty.Value{
+			"priority": cty.StringVal(priority),
+			"group":    group,
+		})})
+	}
+	assert.False(t, redundancyGroupMissing(redundancy("SECONDARY", cty.StringVal("group-uuid"))), "Group is set")
+	assert.False(t, redundancyGroupMissing(redundancy("SECONDARY", cty.UnknownVal(cty.String))), "Group is known after apply")
+	assert.False(t, redundancyGroupMissing(cty.NullVal(cty.List(cty.DynamicPseudoType))), "Redundancy is not configured")
+	assert.True(t, redundancyGroupMissing(redundancy("SECONDARY", cty.NullVal(cty.String))), "Group is not set")
+	assert.True(t, redundancyGroupMissing(redundancy("SECONDARY", cty.StringVal(""))), "Group is empty")
+}
+
+func TestFabricConnection_redundancyMapping(t *testing.T) {
+	// given
+	schemaRedundancy := []interface{}{map[string]interface{}{"priority": "secondary", "group": "group-uuid"}}
+	// when
+	red := connectionRedundancyToFabric(schemaRedundancy)
+	mapped := connectionRedundancyToTerra(&v4.ConnectionRedundancy{Group: "group-uuid"})
+	// then
+	require.NotNil(t, red.Priority)
+	assert.Equal(t, v4.SECONDARY_ConnectionPriority, *red.Priority, "Priority is sent in upper case")
+	assert.Equal(t, "group-uuid", red.Group)
+	assert.Nil(t, connectionRedundancyToFabric([]interface{}{map[string]interface{}{"priority": "", "group": ""}}).Priority, "Empty priority is not sent")
+	assert.Equal(t, []interface{}{map[string]interface{}{"group": "group-uuid"}}, mapped, "Redundancy without priority is mapped")
+}
+
+func TestFabricConnection_changeInProgress(t *testing.T) {
+	connection := func(status string) v4.Connection {
+		return v4.Connection{Change: &v4.Change{Type_: "CONNECTION_UPDATE", Status: status}}
+	}
+	assert.False(t, connectionChangeInProgress(v4.Connection{}), "Connection without change")
+	assert.False(t, connectionChangeInProgress(connection("COMPLETED")), "Completed change")
+	assert.False(t, connectionChangeInProgress(connection("failed")), "Failed change")
+	assert.True(t, connectionChangeInProgress(connection("REQUESTED")), "Change waiting for approval")
+	assert.True(t, connectionChangeInProgress(connection("APPROVED")), "Change being provisioned")
+}
+
+func testFabricConnectionUpdateData(purchaseOrderNumber string, vlanTag int) map[string]interface{} {
+	return map[string]interface{}{
+		"type":      "EVPL_VC",
+		"name":      "conn",
+		"bandwidth": 50,
+		"order":     []interface{}{map[string]interface{}{"purchase_order_number": purchaseOrderNumber}},
+		"notifications": []interface{}{map[string]interface{}{
+			"type":   "ALL",
+			"emails": []interface{}{"test@equinix.com"},
+		}},
+		"a_side": []interface{}{map[string]interface{}{
+			"access_point": []interface{}{map[string]interface{}{
+				"type":          "COLO",
+				"link_protocol": []interface{}{map[string]interface{}{"type": "DOT1Q", "vlan_tag": vlanTag}},
+			}},
+		}},
+	}
+}
+
+func TestFabricConnection_updateRequests(t *testing.T) {
+	// given
+	connType, providerStatus := v4.EVPL_VC_ConnectionType, v4.AVAILABLE_ProviderStatus
+	conn := v4.Connection{
+		Type_:         &connType,
+		Name:          "conn",
+		Bandwidth:     50,
+		Operation:     &v4.ConnectionOperation{ProviderStatus: &providerStatus},
+		Order:         &v4.Order{PurchaseOrderNumber: "1-129105284100"},
+		Notifications: []v4.SimplifiedNotification{{Type_: "ALL", Emails: []string{"old@equinix.com"}}},
+		ASide: &v4.ConnectionSide{AccessPoint: &v4.AccessPoint{
+			LinkProtocol: &v4.SimplifiedLinkProtocol{VlanTag: 1001},
+		}},
+	}
+	d := schema.TestResourceDataRaw(t, fabricConnectionResourceSchema(), testFabricConnectionUpdateData("1-129105284200", 1002))
+	// when
+	updates, err := getUpdateRequests(conn, d)
+	// then
+	require.NoError(t, err)
+	paths := []string{}
+	for _, update := range updates {
+		for _, op := range update {
+			assert.Equal(t, "replace", op.Op)
+			paths = append(paths, op.Path)
+		}
+	}
+	assert.Equal(t, []string{"/notifications", "/order/purchaseOrderNumber", "/aSide/accessPoint/linkProtocol/vlanTag"}, paths)
+}
+
+func TestFabricConnection_updateRequestsVlanNotUpdatable(t *testing.T) {
+	// given
+	connType, providerStatus := v4.EPL_VC_ConnectionType, v4.AVAILABLE_ProviderStatus
+	conn := v4.Connection{
+		Type_:         &connType,
+		Name:          "conn",
+		Bandwidth:     50,
+		Operation:     &v4.ConnectionOperation{ProviderStatus: &providerStatus},
+		Order:         &v4.Order{PurchaseOrderNumber: "1-129105284100"},
+		Notifications: []v4.SimplifiedNotification{{Type_: "ALL", Emails: []string{"test@equinix.com"}}},
+		ASide: &v4.ConnectionSide{AccessPoint: &v4.AccessPoint{
+			LinkProtocol: &v4.SimplifiedLinkProtocol{VlanTag: 1001},
+		}},
+	}
+	d := schema.TestResourceDataRaw(t, fabricConnectionResourceSchema(), testFabricConnectionUpdateData("1-129105284100", 1002))
+	// when
+	_, err := getUpdateRequests(conn, d)
+	// then
+	assert.Error(t, err, "VLANs of EPL_VC connections are not updated")
+}
+
+// testFabricConnectionUpdate returns the resource data of a connection updated
+// from the old configuration to the new one.
+func testFabricConnectionUpdate(t *testing.T, oldRaw, newRaw map[string]interface{}) *schema.ResourceData {
+	sch := fabricConnectionResourceSchema()
+	old := schema.TestResourceDataRaw(t, sch, oldRaw)
+	old.SetId("connection-uuid")
+	state := old.State()
+	diff, err := (&schema.Resource{Schema: sch}).Diff(context.Background(), state, terraform.NewResourceConfigRaw(newRaw), nil)
+	require.NoError(t, err)
+	d, err := schema.InternalMap(sch).Data(state, diff)
+	require.NoError(t, err)
+	return d
+}
+
+func TestFabricConnection_notUpdatableChanges(t *testing.T) {
+	// given
+	oldRaw := testFabricConnectionUpdateData("1-129105284100", 1001)
+	updatableRaw := testFabricConnectionUpdateData("1-129105284200", 1002)
+	notUpdatableRaw := testFabricConnectionUpdateData("1-129105284100", 1001)
+	notUpdatableRaw["description"] = "changed"
+	notUpdatableRaw["a_side"] = []interface{}{map[string]interface{}{
+		"access_point": []interface{}{map[string]interface{}{"type": "SP"}},
+	}}
+	// when
+	updatable := getNotUpdatableConnectionChanges(testFabricConnectionUpdate(t, oldRaw, updatableRaw))
+	notUpdatable := getNotUpdatableConnectionChanges(testFabricConnectionUpdate(t, oldRaw, notUpdatableRaw))
+	// then
+	assert.Empty(t, updatable, "Purchase order number and VLANs are updatable")
+	assert.Equal(t, []string{"description", "a_side"}, notUpdatable)
+}
+
+func TestFabricConnection_zSideServiceToken(t *testing.T) {
+	// given
+	zSide := []interface{}{map[string]interface{}{
+		"service_token":   []interface{}{map[string]interface{}{"type": "vc_token", "uuid": "token-uuid"}},
+		"access_point":    []interface{}{},
+		"additional_info": []interface{}{map[string]interface{}{"key": "ASN", "value": "1111"}},
+	}}
+	// when
+	side, err := connectionSideToFabric(zSide, "z_side")
+	mapped := connectionSideToTerra(&v4.ConnectionSide{ServiceToken: side.ServiceToken})
+	// then
+	require.NoError(t, err)
+	require.NotNil(t, side.ServiceToken, "Z side service token is mapped")
+	assert.Equal(t, "token-uuid", side.ServiceToken.Uuid)
+	assert.Equal(t, v4.VC_TOKEN_ServiceTokenType, *side.ServiceToken.Type_, "Service token type is sent in upper case")
+	assert.Nil(t, side.AccessPoint, "Access point is resolved from the service token")
+	assert.Len(t, side.AdditionalInfo, 1, "Additional info is kept along the service token")
+	token := mapped[0].(map[string]interface{})["service_token"].([]interface{})[0].(map[string]interface{})
+	assert.Equal(t, "token-uuid", token["uuid"], "Z side service token is mapped back")
+	assert.Equal(t, "VC_TOKEN", token["type"])
+}
+
+func TestFabricConnection_sideServiceTokenConflict(t *testing.T) {
+	// given
+	aSide := []interface{}{map[string]interface{}{
+		"service_token": []interface{}{map[string]interface{}{"uuid": "token-uuid"}},
+		"access_point":  []interface{}{map[string]interface{}{"type": "COLO"}},
+	}}
+	side := func(serviceToken, accessPoint cty.Value) cty.Value {
+		return cty.ListVal([]cty.Value{cty.ObjectVal(map[string]cty.Value{
+			"service_token": serviceToken,
+			"access_point":  accessPoint,
+		})})
+	}
+	block := cty.ListVal([]cty.Value{cty.ObjectVal(map[string]cty.Value{"uuid": cty.StringVal("uuid")})})
+	noBlock := cty.ListValEmpty(block.Type().ElementType())
+	// when
+	_, err := connectionSideToFabric(aSide, "a_side")
+	// then
+	assert.ErrorContains(t, err, "a_side can't have both a service_token and an access_point")
+	assert.True(t, connectionSideTokenConflict(side(block, block)), "Service token and access point")
+	assert.True(t, connectionSideTokenConflict(side(block, cty.UnknownVal(block.Type()))), "Service token and access point known after apply")
+	assert.False(t, connectionSideTokenConflict(side(block, noBlock)), "Service token only")
+	assert.False(t, connectionSideTokenConflict(side(cty.NullVal(block.Type()), block)), "Access point only")
+	assert.False(t, connectionSideTokenConflict(cty.NullVal(cty.List(cty.DynamicPseudoType))), "Side is not configured")
+}
+
+func TestFabricConnection_adoption(t *testing.T) {
+	// given
+	side := func(port, profile string) *v4.ConnectionSide {
+		ap := &v4.AccessPoint{}
+		if port != "" {
+			ap.Port = &v4.SimplifiedPort{Uuid: port}
+		}
+		if profile != "" {
+			ap.Profile = &v4.SimplifiedServiceProfile{Uuid: profile}
+		}
+		return &v4.ConnectionSide{AccessPoint: ap}
+	}
+	request := v4.ConnectionPostRequest{Name: "port2sp", ASide: side("port-uuid", ""), ZSide: side("", "profile-uuid")}
+	matching := v4.Connection{Name: "port2sp", ASide: side("port-uuid", ""), ZSide: side("", "profile-uuid")}
+	// when
+	search := adoptableConnectionSearchExpression(request)
+	// then
+	require.NotNil(t, search.And)
+	require.Len(t, *search.And, 3, "Name, port and profile are searched")
+	assert.Equal(t, v4.A_SIDEACCESS_POINTPORTUUID_SearchFieldName, *(*search.And)[1].Property)
+	assert.Equal(t, []string{"profile-uuid"}, (*search.And)[2].Values)
+	assert.True(t, connectionMatchesAdoption(matching, request), "Connection with the same name, port and profile")
+	assert.False(t, connectionMatchesAdoption(v4.Connection{Name: "port2sp", ASide: side("other-port", ""), ZSide: side("", "profile-uuid")}, request), "Connection from another port")
+	assert.False(t, connectionMatchesAdoption(v4.Connection{Name: "port2sp-2", ASide: side("port-uuid", ""), ZSide: side("", "profile-uuid")}, request), "Connection of another name")
+}
+
+func TestFabricConnection_adoptionByNameOnly(t *testing.T) {
+	// given
+	c := newFakeAPIConfig(t)
+	ctx := context.WithValue(context.Background(), v4.ContextAccessToken, c.FabricAuthToken)
+	request := v4.ConnectionPostRequest{Name: "port2sp", ASide: &v4.ConnectionSide{}, ZSide: &v4.ConnectionSide{}}
+	// when
+	conn, err := findAdoptableConnection(ctx, c.FabricClient, request)
+	// then
+	assert.ErrorContains(t, err, "needs an a_side port or a z_side service profile", "Connections are not adopted on the name alone")
+	assert.Nil(t, conn)
+}
+
+func TestFabricConnection_waitTimeout(t *testing.T) {
+	// given
+	c := newFakeAPIConfig(t)
+	ctx := context.WithValue(context.Background(), v4.ContextAccessToken, c.FabricAuthToken)
+	connType := v4.EVPL_VC_ConnectionType
+	conn, _, err := c.FabricClient.ConnectionsApi.CreateConnection(ctx, v4.ConnectionPostRequest{
+		Type_:     &connType,
+		Name:      "slow-connection",
+		Bandwidth: 50,
+	})
+	require.NoError(t, err)
+	_, _, err = c.FabricClient.ConnectionsApi.UpdateConnectionByUuid(ctx, []v4.ConnectionChangeOperation{
+		{Op: "replace", Path: "/state", Value: "PROVISIONING"},
+	}, conn.Uuid)
+	require.NoError(t, err)
+	// when
+	start := time.Now()
+	err = waitUntilConnectionIsCreated(conn.Uuid, c, ctx, 100*time.Millisecond)
+	// then
+	assert.ErrorContains(t, err, "timeout", "Wait gives up after the given timeout")
+	assert.Less(t, time.Since(start), 20*time.Second, "Wait doesn't use its former hardcoded timeout")
+}
+
+func TestFabricConnection_localAttributesUpdate(t *testing.T) {
+	// given
+	oldRaw := testFabricConnectionUpdateData("1-129105284100", 1001)
+	newRaw := testFabricConnectionUpdateData("1-129105284100", 1001)
+	newRaw["wait_for_provider_status"] = "PROVISIONED"
+	newRaw["adopt_existing"] = true
+	d := testFabricConnectionUpdate(t, oldRaw, newRaw)
+	// when
+	diags := resourceFabricConnectionUpdate(context.Background(), d, &config.Config{})
+	// then
+	assert.Empty(t, diags, "Changes of provider only arguments don't update the connection")
+	assert.Equal(t, "PROVISIONED", d.Get("wait_for_provider_status"))
+}
+
+func TestFabricConnection_linkProtocolVlans(t *testing.T) {
+	// given
+	linkProtocol := func(lpType string, vlanTag, vlanSTag, vlanCTag cty.Value) cty.Value {
+		return cty.ObjectVal(map[string]cty.Value{
+			"type":       cty.StringVal(lpType),
+			"vlan_tag":   vlanTag,
+			"vlan_s_tag": vlanSTag,
+			"vlan_c_tag": vlanCTag,
+		})
+	}
+	side := func(lp cty.Value) cty.Value {
+		return cty.ListVal([]cty.Value{cty.ObjectVal(map[string]cty.Value{
+			"access_point": cty.ListVal([]cty.Value{cty.ObjectVal(map[string]cty.Value{
+				"link_protocol": cty.ListVal([]cty.Value{lp}),
+			})}),
+		})})
+	}
+	none := cty.NullVal(cty.Number)
+	// when / then
+	assert.NoError(t, linkProtocolVlansError(connectionSideLinkProtocol(side(linkProtocol("DOT1Q", cty.NumberIntVal(1001), none, none)))))
+	assert.NoError(t, linkProtocolVlansError(connectionSideLinkProtocol(side(linkProtocol("DOT1Q", none, none, none)))), "VLAN tag may be selected on create")
+	assert.NoError(t, linkProtocolVlansError(connectionSideLinkProtocol(side(linkProtocol("qinq", none, cty.NumberIntVal(100), cty.NumberIntVal(200))))))
+	assert.NoError(t, linkProtocolVlansError(connectionSideLinkProtocol(side(linkProtocol("DOT1Q", cty.NumberIntVal(1001), cty.NumberIntVal(0), none)))), "Zero tags are not set")
+	assert.NoError(t, linkProtocolVlansError(connectionSideLinkProtocol(cty.NullVal(cty.List(cty.DynamicPseudoType)))), "Side is not configured")
+	assert.ErrorContains(t, linkProtocolVlansError(connectionSideLinkProtocol(side(linkProtocol("DOT1Q", cty.NumberIntVal(1001), cty.NumberIntVal(100), none)))), "vlan_s_tag can't be set for DOT1Q")
+	assert.ErrorContains(t, linkProtocolVlansError(connectionSideLinkProtocol(side(linkProtocol("QINQ", cty.UnknownVal(cty.Number), cty.NumberIntVal(100), none)))), "vlan_tag can't be set for QINQ")
+	assert.ErrorContains(t, linkProtocolVlansError(connectionSideLinkProtocol(side(linkProtocol("QINQ", none, none, cty.NumberIntVal(200))))), "vlan_c_tag requires the vlan_s_tag")
+	assert.ErrorContains(t, linkProtocolVlansError(connectionSideLinkProtocol(side(linkProtocol("UNTAGGED", cty.NumberIntVal(1001), none, none)))), "vlan_tag can't be set for UNTAGGED")
+}
+
+func TestFabricConnection_freeVlanTag(t *testing.T) {
+	// given
+	released := v4.RELEASED_LinkProtocolState
+	linkProtocols := []v4.LinkProtocolResponse{
+		{VlanTag: 2},
+		{VlanTagMin: 3, VlanTagMax: 5},
+		{VlanSTag: 6, VlanCTag: 7},
+		{VlanTag: 7, State: &released},
+	}
+	// when
+	tag, err := freeVlanTag(linkProtocols)
+	all, allErr := freeVlanTag([]v4.LinkProtocolResponse{{VlanTagMin: fabricVlanTagMin, VlanTagMax: fabricVlanTagMax}})
+	// then
+	require.NoError(t, err)
+	assert.Equal(t, 7, tag, "Lowest tag not in use, released tags are free")
+	assert.Zero(t, all)
+	assert.ErrorContains(t, allErr, "are in use")
+}
+
+func TestFabricConnection_setFreeVlanTag(t *testing.T) {
+	// given
+	c := newFakeAPIConfig(t)
+	ctx := context.WithValue(context.Background(), v4.ContextAccessToken, c.FabricAuthToken)
+	side := func(lpType v4.LinkProtocolType, vlanTag int32) *v4.ConnectionSide {
+		return &v4.ConnectionSide{AccessPoint: &v4.AccessPoint{
+			Port:         &v4.SimplifiedPort{Uuid: "port-uuid"},
+			LinkProtocol: &v4.SimplifiedLinkProtocol{Type_: &lpType, VlanTag: vlanTag},
+		}}
+	}
+	connType := v4.EVPL_VC_ConnectionType
+	_, _, err := c.FabricClient.ConnectionsApi.CreateConnection(ctx, v4.ConnectionPostRequest{
+		Type_: &connType, Name: "existing", Bandwidth: 50, ASide: side(v4.DOT1_Q_LinkProtocolType, 2),
+	})
+	require.NoError(t, err)
+	untagged, configured, selected := side(v4.UNTAGGED_LinkProtocolType, 0), side(v4.DOT1_Q_LinkProtocolType, 1001), side(v4.DOT1_Q_LinkProtocolType, 0)
+	// when
+	for _, s := range []*v4.ConnectionSide{untagged, configured, selected, {}} {
+		require.NoError(t, setFreeVlanTag(ctx, c.FabricClient, s))
+	}
+	// then
+	assert.Zero(t, untagged.AccessPoint.LinkProtocol.VlanTag, "Only DOT1Q access points get a VLAN tag")
+	assert.Equal(t, int32(1001), configured.AccessPoint.LinkProtocol.VlanTag, "Configured VLAN tag is kept")
+	assert.Equal(t, int32(3), selected.AccessPoint.LinkProtocol.VlanTag, "First free VLAN tag of the port")
+}
+
+// testAccessPointConfig returns the raw configuration of an access point of
+// the given type with the given asset blocks set.
+func testAccessPointConfig(apType string, blocks ...string) cty.Value {
+	attrs := map[string]cty.Value{"type": cty.StringVal(apType), "authentication_key": cty.NullVal(cty.String)}
+	for _, block := range []string{"port", "profile", "router", "gateway", "virtual_device", "interface", "network"} {
+		attrs[block] = cty.ListValEmpty(cty.Map(cty.String))
+	}
+	for _, block := range blocks {
+		attrs[block] = cty.ListVal([]cty.Value{cty.MapVal(map[string]cty.Value{"uuid": cty.StringVal("uuid")})})
+	}
+	return cty.ObjectVal(attrs)
+}
+
+func TestFabricConnection_accessPointCombinations(t *testing.T) {
+	// given
+	accessPoint := testAccessPointConfig
+	side := func(ap cty.Value) cty.Value {
+		return cty.ListVal([]cty.Value{cty.ObjectVal(map[string]cty.Value{
+			"access_point": cty.ListVal([]cty.Value{ap}),
+		})})
+	}
+	// when / then
+	assert.NoError(t, accessPointBlocksError(configuredAccessPoint(side(accessPoint("COLO", "port"))), "COLO"))
+	assert.NoError(t, accessPointBlocksError(configuredAccessPoint(side(accessPoint("VD", "virtual_device", "interface"))), "VD"))
+	assert.NoError(t, accessPointBlocksError(configuredAccessPoint(side(accessPoint("IGW", "port"))), "IGW"), "Types without specific blocks are not checked")
+	assert.ErrorContains(t, accessPointBlocksError(configuredAccessPoint(side(accessPoint("COLO", "port", "virtual_device"))), "COLO"), "virtual_device can't be set for COLO access points")
+	assert.ErrorContains(t, accessPointBlocksError(configuredAccessPoint(side(accessPoint("SP", "profile", "network"))), "SP"), "network can't be set for SP access points")
+	assert.True(t, configuredAccessPoint(cty.NullVal(cty.List(cty.DynamicPseudoType))).IsNull(), "Side is not configured")
+}
+
+func TestFabricConnection_linkProtocolMissingVlans(t *testing.T) {
+	// given
+	linkProtocol := func(lpType string, vlanTag, vlanSTag cty.Value) cty.Value {
+		return cty.ObjectVal(map[string]cty.Value{
+			"type":       cty.StringVal(lpType),
+			"vlan_tag":   vlanTag,
+			"vlan_s_tag": vlanSTag,
+		})
+	}
+	none := cty.NullVal(cty.Number)
+	// when / then
+	assert.NoError(t, linkProtocolMissingVlansError(linkProtocol("DOT1Q", cty.NumberIntVal(1001), none), false))
+	assert.NoError(t, linkProtocolMissingVlansError(linkProtocol("DOT1Q", cty.UnknownVal(cty.Number), none), false), "Tag known after apply is set")
+	assert.NoError(t, linkProtocolMissingVlansError(linkProtocol("DOT1Q", none, none), true), "Tag is selected on create")
+	assert.NoError(t, linkProtocolMissingVlansError(linkProtocol("QINQ", none, cty.NumberIntVal(100)), false))
+	assert.NoError(t, linkProtocolMissingVlansError(linkProtocol("UNTAGGED", none, none), false))
+	assert.ErrorContains(t, linkProtocolMissingVlansError(linkProtocol("DOT1Q", none, none), false), "vlan_tag must be set for DOT1Q")
+	assert.ErrorContains(t, linkProtocolMissingVlansError(linkProtocol("qinq", none, cty.NumberIntVal(0)), false), "vlan_s_tag must be set for QINQ")
+}
+
+func TestFabricConnection_routersOnBothSides(t *testing.T) {
+	// given
+	routerSide := cty.ListVal([]cty.Value{cty.ObjectVal(map[string]cty.Value{
+		"access_point": cty.ListVal([]cty.Value{testAccessPointConfig("CLOUD_ROUTER", "router")}),
+	})})
+	rawConfig := func(connType string) cty.Value {
+		return cty.ObjectVal(map[string]cty.Value{
+			"type":          cty.StringVal(connType),
+			"auto_vlan_tag": cty.NullVal(cty.Bool),
+			"a_side":        routerSide,
+			"z_side":        routerSide,
+		})
+	}
+	// when
+	ipErr := connectionAccessPointsError(rawConfig("IP_VC"))
+	evplErr := connectionAccessPointsError(rawConfig("EVPL_VC"))
+	// then
+	assert.NoError(t, ipErr)
+	assert.ErrorContains(t, evplErr, "connections between two Cloud Routers must be of type IP_VC")
+}
+
+func TestFabricConnection_acceptanceAdditionalInfo(t *testing.T) {
+	// given
+	item := func(key, value string) interface{} {
+		return map[string]interface{}{"key": key, "value": value}
+	}
+	aws := []interface{}{item("accessKey", "AKIA"), item("secretKey", "secret")}
+	ibm := []interface{}{item("ASN", "64512"), item("Global", "false"), item("description", "dl")}
+	// when
+	awsInfo, awsSeller := connectionAcceptanceAdditionalInfo(aws)
+	ibmInfo, ibmSeller := connectionAcceptanceAdditionalInfo(ibm)
+	_, partialSeller := connectionAcceptanceAdditionalInfo([]interface{}{item("ASN", "64512")})
+	// then
+	assert.Equal(t, "AWS", awsSeller)
+	assert.Equal(t, aws, awsInfo)
+	assert.Equal(t, "IBM Cloud", ibmSeller)
+	assert.Equal(t, ibm[:2], ibmInfo, "Only the IBM keys are sent on acceptance")
+	assert.Empty(t, partialSeller, "Connections missing acceptance keys are not accepted")
+}
+
+func TestFabricConnection_updateRequestsIBMAcceptance(t *testing.T) {
+	// given
+	connType, providerStatus := v4.EVPL_VC_ConnectionType, v4.PENDING_APPROVAL_ProviderStatus
+	conn := v4.Connection{
+		Type_:         &connType,
+		Name:          "conn",
+		Bandwidth:     50,
+		Operation:     &v4.ConnectionOperation{ProviderStatus: &providerStatus},
+		Order:         &v4.Order{PurchaseOrderNumber: "1-129105284100"},
+		Notifications: []v4.SimplifiedNotification{{Type_: "ALL", Emails: []string{"test@equinix.com"}}},
+		ASide: &v4.ConnectionSide{AccessPoint: &v4.AccessPoint{
+			LinkProtocol: &v4.SimplifiedLinkProtocol{VlanTag: 1001},
+		}},
+	}
+	data := testFabricConnectionUpdateData("1-129105284100", 1001)
+	data["additional_info"] = []interface{}{
+		map[string]interface{}{"key": "ASN", "value": "64512"},
+		map[string]interface{}{"key": "Global", "value": "true"},
+	}
+	d := schema.TestResourceDataRaw(t, fabricConnectionResourceSchema(), data)
+	// when
+	updates, err := getUpdateRequests(conn, d)
+	// then
+	require.NoError(t, err)
+	require.Len(t, updates, 1)
+	assert.Equal(t, "add", updates[0][0].Op, "Pending IBM Cloud connection is accepted")
+	assert.Len(t, updates[0][0].Value.(map[string]interface{})["additionalInfo"], 2)
+}
+
+func TestFabricConnection_ibmAdditionalInfo(t *testing.T) {
+	// given
+	additionalInfo := func(items ...map[string]string) cty.Value {
+		values := []cty.Value{}
+		for _, item := range items {
+			values = append(values, cty.MapVal(map[string]cty.Value{
+				"key":   cty.StringVal(item["key"]),
+				"value": cty.StringVal(item["value"]),
+			}))
+		}
+		return cty.ListVal(values)
+	}
+	asn := func(value string) map[string]string { return map[string]string{"key": "ASN", "value": value} }
+	global := func(value string) map[string]string { return map[string]string{"key": "Global", "value": value} }
+	// when / then
+	assert.NoError(t, ibmAdditionalInfoError(cty.NullVal(cty.List(cty.Map(cty.String)))))
+	assert.NoError(t, ibmAdditionalInfoError(additionalInfo(map[string]string{"key": "accessKey", "value": "AKIA"})), "Other sellers are not checked")
+	assert.NoError(t, ibmAdditionalInfoError(additionalInfo(asn("64512"), global("false"))))
+	assert.ErrorContains(t, ibmAdditionalInfoError(additionalInfo(asn("64512"))), "Global is missing")
+	assert.ErrorContains(t, ibmAdditionalInfoError(additionalInfo(global("true"))), "ASN is missing")
+	assert.ErrorContains(t, ibmAdditionalInfoError(additionalInfo(asn("AS64512"), global("true"))), "must be a BGP ASN")
+	assert.ErrorContains(t, ibmAdditionalInfoError(additionalInfo(asn("64512"), global("yes"))), "must be true or false")
+}
+
+func TestFabricConnection_invitationStatus(t *testing.T) {
+	// given
+	invited := func(status v4.EquinixStatus) v4.Connection {
+		return v4.Connection{
+			ZSide:     &v4.ConnectionSide{Invitation: &v4.ConnectionInvitation{Email: "recipient@example.com"}},
+			Operation: &v4.ConnectionOperation{EquinixStatus: &status},
+		}
+	}
+	// when / then
+	assert.Empty(t, connectionInvitationStatus(v4.Connection{ZSide: &v4.ConnectionSide{}}), "Connection without invitation")
+	assert.Equal(t, "PENDING", connectionInvitationStatus(v4.Connection{ZSide: invited(v4.PENDING_APPROVAL_EquinixStatus).ZSide}), "Status not reported yet")
+	assert.Equal(t, "PENDING", connectionInvitationStatus(invited(v4.PENDING_APPROVAL_EquinixStatus)))
+	assert.Equal(t, "ACCEPTED", connectionInvitationStatus(invited(v4.PROVISIONING_EquinixStatus)))
+	assert.Equal(t, "ACCEPTED", connectionInvitationStatus(invited(v4.PROVISIONED_EquinixStatus)))
+	assert.Equal(t, "REJECTED", connectionInvitationStatus(invited(v4.REJECTED_EquinixStatus)))
+	assert.Equal(t, "CANCELLED", connectionInvitationStatus(invited(v4.CANCELLED_EquinixStatus)))
+}
+
+func TestFabricConnection_invitationSide(t *testing.T) {
+	// given
+	zSide := []interface{}{map[string]interface{}{
+		"invitation": []interface{}{map[string]interface{}{
+			"email":              "recipient@example.com",
+			"message":            "Please accept",
+			"ctr_draft_order_id": "",
+		}},
+	}}
+	// when
+	side, err := connectionSideToFabric(zSide, "z_side")
+	mapped := connectionSideToTerra(&side)
+	// then
+	require.NoError(t, err)
+	assert.Equal(t, &v4.ConnectionInvitation{Email: "recipient@example.com", Message: "Please accept"}, side.Invitation)
+	assert.Equal(t, zSide[0].(map[string]interface{})["invitation"], mapped[0].(map[string]interface{})["invitation"], "Invitation is read back")
+}
+
+func TestFabricConnection_waitForInvitationAcceptance(t *testing.T) {
+	// given
+	c := newFakeAPIConfig(t)
+	ctx := context.WithValue(context.Background(), v4.ContextAccessToken, c.FabricAuthToken)
+	createInvitation := func() string {
+		connType := v4.EVPL_VC_ConnectionType
+		conn, _, err := c.FabricClient.ConnectionsApi.CreateConnection(ctx, v4.ConnectionPostRequest{
+			Type_:     &connType,
+			Name:      "invitation",
+			Bandwidth: 50,
+			ZSide:     &v4.ConnectionSide{Invitation: &v4.ConnectionInvitation{Email: "recipient@example.com"}},
+		})
+		require.NoError(t, err)
+		return conn.Uuid
+	}
+	accepted := createInvitation()
+	rejected := createInvitation()
+	_, _, err := c.FabricClient.ConnectionsApi.UpdateConnectionByUuid(ctx, []v4.ConnectionChangeOperation{
+		{Op: "replace", Path: "/operation", Value: map[string]interface{}{"equinixStatus": "REJECTED"}},
+	}, rejected)
+	require.NoError(t, err)
+	// when
+	conn, acceptedErr := waitForConnectionInvitationAcceptance(accepted, c, ctx, time.Minute)
+	_, rejectedErr := waitForConnectionInvitationAcceptance(rejected, c, ctx, time.Minute)
+	// then
+	require.NoError(t, acceptedErr)
+	assert.Equal(t, accepted, conn.Uuid)
+	assert.ErrorContains(t, rejectedErr, "REJECTED", "Rejected invitation is not waited for")
+}
+
+func TestFabricConnection_serviceTokenConnectionError(t *testing.T) {
+	// given
+	now := time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC)
+	active, expired := v4.ACTIVE_ServiceTokenState, v4.EXPIRED_ServiceTokenState
+	aSideToken := v4.ServiceToken{
+		Uuid:               "a-token",
+		State:              &active,
+		ExpirationDateTime: now.Add(24 * time.Hour),
+		Connection: &v4.ServiceTokenConnection{
+			Type_:               "EVPL_VC",
+			SupportedBandwidths: []int32{50, 100, 500},
+			ASide:               &v4.ServiceTokenSide{},
+		},
+	}
+	zSideToken := v4.ServiceToken{
+		Uuid:       "z-token",
+		State:      &active,
+		Connection: &v4.ServiceTokenConnection{Type_: "EVPL_VC", BandwidthLimit: 200, ZSide: &v4.ServiceTokenSide{}},
+	}
+	expiredToken := v4.ServiceToken{Uuid: "expired-token", State: &expired}
+	// when
+	valid := serviceTokenConnectionError(aSideToken, "a_side", 100, now)
+	wrongSide := serviceTokenConnectionError(aSideToken, "z_side", 100, now)
+	unsupportedBandwidth := serviceTokenConnectionError(aSideToken, "a_side", 200, now)
+	overLimit := serviceTokenConnectionError(zSideToken, "z_side", 1000, now)
+	notActive := serviceTokenConnectionError(expiredToken, "a_side", 100, now)
+	pastExpiration := serviceTokenConnectionError(aSideToken, "a_side", 100, now.Add(48*time.Hour))
+	// then
+	assert.NoError(t, valid)
+	assert.ErrorContains(t, wrongSide, "service token a-token is a a_side service token, it can't be used in z_side")
+	assert.ErrorContains(t, unsupportedBandwidth, "doesn't allow a bandwidth of 200 Mbps, supported bandwidths are [50 100 500] Mbps")
+	assert.ErrorContains(t, overLimit, "allows a bandwidth of at most 200 Mbps, 1000 Mbps were requested")
+	assert.ErrorContains(t, notActive, "is EXPIRED, it can't be used to order a connection")
+	assert.ErrorContains(t, pastExpiration, "expired on")
+}
+
+func TestFabricConnection_validateConnectionServiceTokens(t *testing.T) {
+	// given
+	c := newFakeAPIConfig(t)
+	ctx := context.WithValue(context.Background(), v4.ContextAccessToken, c.FabricAuthToken)
+	tokenType := v4.VC_TOKEN_ServiceTokenType
+	token, _, err := c.FabricClient.ServiceTokensApi.CreateServiceToken(ctx, v4.ServiceToken{
+		Type_: &tokenType,
+		Connection: &v4.ServiceTokenConnection{
+			Type_:          "EVPL_VC",
+			BandwidthLimit: 100,
+			ZSide:          &v4.ServiceTokenSide{},
+		},
+	})
+	require.NoError(t, err)
+	request := func(side string, bandwidth int32) v4.ConnectionPostRequest {
+		tokenSide := &v4.ConnectionSide{ServiceToken: &v4.ServiceToken{Uuid: token.Uuid}}
+		if side == "a_side" {
+			return v4.ConnectionPostRequest{Bandwidth: bandwidth, ASide: tokenSide, ZSide: &v4.ConnectionSide{}}
+		}
+		return v4.ConnectionPostRequest{Bandwidth: bandwidth, ASide: &v4.ConnectionSide{}, ZSide: tokenSide}
+	}
+	unknownToken := v4.ConnectionPostRequest{Bandwidth: 50, ASide: &v4.ConnectionSide{ServiceToken: &v4.ServiceToken{Uuid: "shared-token"}}}
+	// when
+	valid := validateConnectionServiceTokens(ctx, c.FabricClient, request("z_side", 50))
+	wrongSide := validateConnectionServiceTokens(ctx, c.FabricClient, request("a_side", 50))
+	overLimit := validateConnectionServiceTokens(ctx, c.FabricClient, request("z_side", 500))
+	unreadable := validateConnectionServiceTokens(ctx, c.FabricClient, unknownToken)
+	// then
+	assert.False(t, valid.HasError())
+	require.Len(t, wrongSide, 1)
+	assert.Contains(t, wrongSide[0].Detail, "move it to the z_side block")
+	assert.Equal(t, cty.GetAttrPath("a_side").IndexInt(0).GetAttr("service_token").IndexInt(0).GetAttr("uuid"), wrongSide[0].AttributePath, "Error points at the service token")
+	require.Len(t, overLimit, 1)
+	assert.Contains(t, overLimit[0].Detail, "at most 100 Mbps")
+	assert.False(t, unreadable.HasError(), "Tokens that can't be read are left to the order validation")
+}
+
+func TestFabricConnection_profileBandwidth(t *testing.T) {
+	// given
+	bandwidths := func(values ...int32) *[]int32 { return &values }
+	profile := v4.ServiceProfile{Uuid: "profile", AccessPointTypeConfigs: []v4.ServiceProfileAccessPointType{
+		{SupportedBandwidths: bandwidths(50, 100)},
+		{SupportedBandwidths: bandwidths(1000, 100)},
+	}}
+	custom := v4.ServiceProfile{AccessPointTypeConfigs: []v4.ServiceProfileAccessPointType{
+		{SupportedBandwidths: bandwidths(50), AllowCustomBandwidth: true},
+	}}
+	// when / then
+	assert.NoError(t, profileBandwidthError(profile, 1000), "Bandwidths of any access point type are supported")
+	assert.NoError(t, profileBandwidthError(custom, 75), "Custom bandwidths are allowed")
+	assert.NoError(t, profileBandwidthError(v4.ServiceProfile{}, 75), "Profiles without supported bandwidths are not checked")
+	assert.ErrorContains(t, profileBandwidthError(profile, 200), "supported bandwidths are [50 100 1000] Mbps")
+}
+
+func TestFabricConnection_profileUuid(t *testing.T) {
+	// given
+	rawConfig := func(uuid cty.Value) cty.Value {
+		profile := cty.ObjectVal(map[string]cty.Value{"uuid": uuid})
+		return cty.ObjectVal(map[string]cty.Value{
+			"z_side": cty.ListVal([]cty.Value{cty.ObjectVal(map[string]cty.Value{
+				"access_point": cty.ListVal([]cty.Value{cty.ObjectVal(map[string]cty.Value{
+					"type":    cty.StringVal("SP"),
+					"profile": cty.ListVal([]cty.Value{profile}),
+				})}),
+			})}),
+		})
+	}
+	// when / then
+	assert.Equal(t, "profile-uuid", connectionProfileUuid(rawConfig(cty.StringVal("profile-uuid"))))
+	assert.Empty(t, connectionProfileUuid(rawConfig(cty.UnknownVal(cty.String))), "Profile known after apply is not checked")
+	assert.Empty(t, connectionProfileUuid(cty.NullVal(cty.DynamicPseudoType)))
+}
+
+func TestFabricConnection_metalNetworkAccessPoint(t *testing.T) {
+	// given
+	rawConfig := func(metalAccessPoint cty.Value) cty.Value {
+		side := func(ap cty.Value) cty.Value {
+			return cty.ListVal([]cty.Value{cty.ObjectVal(map[string]cty.Value{"access_point": cty.ListVal([]cty.Value{ap})})})
+		}
+		return cty.ObjectVal(map[string]cty.Value{
+			"type":          cty.StringVal("EVPL_VC"),
+			"auto_vlan_tag": cty.NullVal(cty.Bool),
+			"a_side":        side(testAccessPointConfig("VD", "virtual_device")),
+			"z_side":        side(metalAccessPoint),
+		})
+	}
+	withKey := func(ap cty.Value, key cty.Value) cty.Value {
+		attrs := ap.AsValueMap()
+		attrs["authentication_key"] = key
+		return cty.ObjectVal(attrs)
+	}
+	metalNetwork := testAccessPointConfig("METAL_NETWORK")
+	// when
+	keyErr := connectionAccessPointsError(rawConfig(withKey(metalNetwork, cty.StringVal("authorization-code"))))
+	unknownKeyErr := connectionAccessPointsError(rawConfig(withKey(metalNetwork, cty.UnknownVal(cty.String))))
+	missingKeyErr := connectionAccessPointsError(rawConfig(metalNetwork))
+	portErr := connectionAccessPointsError(rawConfig(withKey(testAccessPointConfig("METAL_NETWORK", "port"), cty.StringVal("authorization-code"))))
+	// then
+	assert.NoError(t, keyErr)
+	assert.NoError(t, unknownKeyErr, "Key known after apply is set")
+	assert.ErrorContains(t, missingKeyErr, "z_side.access_point: authentication_key must be set for METAL_NETWORK access points")
+	assert.ErrorContains(t, portErr, "port can't be set for METAL_NETWORK access points")
+}
+
+func TestFabricConnection_metalConnectionSpeed(t *testing.T) {
+	// given
+	conn := packngo.Connection{ID: "metal-conn", Speed: 200 * 1000 * 1000}
+	// when / then
+	assert.NoError(t, metalConnectionSpeedError(conn, 200))
+	assert.NoError(t, metalConnectionSpeedError(packngo.Connection{}, 1000), "Metal connections without speed are not checked")
+	assert.ErrorContains(t, metalConnectionSpeedError(conn, 500), "bandwidth of 500 Mbps exceeds the speed of 200 Mbps of Metal connection metal-conn")
+}
+
+func TestFabricConnection_bandwidthDowngrade(t *testing.T) {
+	// when / then
+	assert.NoError(t, bandwidthDowngradeError(50, 100, false))
+	assert.NoError(t, bandwidthDowngradeError(1000, 1000, false), "Equivalent bandwidths are not a reduction")
+	assert.NoError(t, bandwidthDowngradeError(1000, 500, true), "Allowed reductions are applied")
+	assert.ErrorContains(t, bandwidthDowngradeError(1000, 500, false), "bandwidth is reduced from 1000 Mbps to 500 Mbps")
+}
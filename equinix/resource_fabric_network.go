@@ -5,6 +5,7 @@ import (
 	"fmt"
 	v4 "github.com/equinix-labs/fabric-go/fabric/v4"
 	"github.com/equinix/terraform-provider-equinix/internal/config"
+	equinix_diagnostics "github.com/equinix/terraform-provider-equinix/internal/diagnostics"
 	equinix_errors "github.com/equinix/terraform-provider-equinix/internal/errors"
 	equinix_fabric_schema "github.com/equinix/terraform-provider-equinix/internal/fabric/schema"
 	equinix_schema "github.com/equinix/terraform-provider-equinix/internal/schema"
@@ -36,6 +37,69 @@ func fabricNetworkChangeSch() map[string]*schema.Schema {
 		},
 	}
 }
+func fabricNetworkChangeHistorySch() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"uuid": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "Uniquely identifies a change",
+		},
+		"href": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "Network change URI",
+		},
+		"type": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "Type of change: NETWORK_CREATION, NETWORK_UPDATE, NETWORK_DELETION",
+		},
+		"status": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "Current outcome of the change flow",
+		},
+		"created_date_time": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "Date and time of the change request",
+		},
+		"updated_date_time": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "Date and time of the last update of the change request",
+		},
+	}
+}
+func fabricNetworkConnectionSch() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"uuid": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "Equinix-assigned connection identifier",
+		},
+		"href": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "Connection URI information",
+		},
+		"name": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "Connection name",
+		},
+		"type": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "Connection type",
+		},
+		"state": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "Connection overall state",
+		},
+	}
+}
 func fabricNetworkOperationSch() map[string]*schema.Schema {
 	return map[string]*schema.Schema{
 		"equinix_status": {
@@ -78,13 +142,16 @@ func fabricNetworkResourceSchema() map[string]*schema.Schema {
 			Description: "Fabric Network overall state",
 		},
 		"scope": {
-			Type:        schema.TypeString,
-			Required:    true,
-			Description: "Fabric Network scope",
+			Type:         schema.TypeString,
+			Required:     true,
+			ForceNew:     true,
+			ValidateFunc: validation.StringInSlice([]string{"LOCAL", "REGIONAL", "GLOBAL"}, true),
+			Description:  "Fabric Network scope - LOCAL, REGIONAL, GLOBAL",
 		},
 		"type": {
 			Type:         schema.TypeString,
 			Required:     true,
+			ForceNew:     true,
 			ValidateFunc: validation.StringInSlice([]string{"IPWAN", "EPLAN", "EVPLAN"}, true),
 			Description:  "Supported Network types - EVPLAN, EPLAN, IPWAN",
 		},
@@ -92,6 +159,7 @@ func fabricNetworkResourceSchema() map[string]*schema.Schema {
 			Type:        schema.TypeSet,
 			Computed:    true,
 			Optional:    true,
+			ForceNew:    true,
 			Description: "Fabric Network location",
 			MaxItems:    1,
 			Elem: &schema.Resource{
@@ -101,11 +169,20 @@ func fabricNetworkResourceSchema() map[string]*schema.Schema {
 		"project": {
 			Type:        schema.TypeSet,
 			Required:    true,
+			ForceNew:    true,
 			Description: "Fabric Network project",
 			Elem: &schema.Resource{
 				Schema: fabricNetworkProjectSch(),
 			},
 		},
+		"account": {
+			Type:        schema.TypeSet,
+			Computed:    true,
+			Description: "Customer account information that is associated with this Fabric Network",
+			Elem: &schema.Resource{
+				Schema: equinix_fabric_schema.AccountSch(),
+			},
+		},
 		"operation": {
 			Type:        schema.TypeSet,
 			Computed:    true,
@@ -143,6 +220,22 @@ func fabricNetworkResourceSchema() map[string]*schema.Schema {
 			Computed:    true,
 			Description: "Number of connections associated with this network",
 		},
+		"connections": {
+			Type:        schema.TypeList,
+			Computed:    true,
+			Description: "Connections attached to this network",
+			Elem: &schema.Resource{
+				Schema: fabricNetworkConnectionSch(),
+			},
+		},
+		"changes": {
+			Type:        schema.TypeList,
+			Computed:    true,
+			Description: "History of the changes of this network, like its creation and updates",
+			Elem: &schema.Resource{
+				Schema: fabricNetworkChangeHistorySch(),
+			},
+		},
 	}
 }
 func resourceFabricNetwork() *schema.Resource {
@@ -190,9 +283,9 @@ func resourceFabricNetworkCreate(ctx context.Context, d *schema.ResourceData, me
 		Project:       &project,
 	}
 
-	fabricNetwork, _, err := client.NetworksApi.CreateNetwork(ctx, createRequest)
+	fabricNetwork, resp, err := client.NetworksApi.CreateNetwork(ctx, createRequest)
 	if err != nil {
-		return diag.FromErr(equinix_errors.FormatFabricError(err))
+		return equinix_diagnostics.SDKError("creating Fabric Network", fabricErrorAttributePath(fabricNetworkResourceSchema(), err), resp, err)
 	}
 	d.SetId(fabricNetwork.Uuid)
 
@@ -206,19 +299,79 @@ func resourceFabricNetworkCreate(ctx context.Context, d *schema.ResourceData, me
 func resourceFabricNetworkRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	client := meta.(*config.Config).FabricClient
 	ctx = context.WithValue(ctx, v4.ContextAccessToken, meta.(*config.Config).FabricAuthToken)
-	fabricNetwork, _, err := client.NetworksApi.GetNetworkByUuid(ctx, d.Id())
+	fabricNetwork, resp, err := client.NetworksApi.GetNetworkByUuid(ctx, d.Id())
 	if err != nil {
-		return diag.FromErr(equinix_errors.FormatFabricError(err))
+		if !d.IsNewResource() && equinix_errors.IsGone(resp, err) {
+			log.Printf("[WARN] Fabric Network %s not found, removing from state", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return equinix_diagnostics.SDKError(fmt.Sprintf("reading Fabric Network %s", d.Id()), "", resp, err)
 	}
 	d.SetId(fabricNetwork.Uuid)
-	return setFabricNetworkMap(d, fabricNetwork)
+	if diags := setFabricNetworkMap(d, fabricNetwork); diags.HasError() {
+		return diags
+	}
+	// The attached connections and the change history are informational, a
+	// failure to fetch them keeps the ones of the previous read rather than
+	// failing the refresh
+	connections, _, err := client.NetworksApi.GetConnectionsByNetworkUuid(ctx, d.Id())
+	if err != nil {
+		log.Printf("[WARN] Connections of Fabric Network %s not retrieved, error %s", d.Id(), equinix_errors.FormatFabricError(err))
+	} else if err := d.Set("connections", fabricNetworkConnectionsToTerra(connections.Data)); err != nil {
+		return diag.FromErr(err)
+	}
+	changes, _, err := client.NetworksApi.GetNetworkChanges(ctx, d.Id())
+	if err != nil {
+		log.Printf("[WARN] Changes of Fabric Network %s not retrieved, error %s", d.Id(), equinix_errors.FormatFabricError(err))
+	} else if err := d.Set("changes", fabricNetworkChangesToTerra(changes.Data)); err != nil {
+		return diag.FromErr(err)
+	}
+	return nil
+}
+func fabricNetworkConnectionsToTerra(connections []v4.Connection) []interface{} {
+	mappedConnections := make([]interface{}, 0, len(connections))
+	for _, conn := range connections {
+		mappedConnection := map[string]interface{}{
+			"uuid": conn.Uuid,
+			"href": conn.Href,
+			"name": conn.Name,
+		}
+		if conn.Type_ != nil {
+			mappedConnection["type"] = string(*conn.Type_)
+		}
+		if conn.State != nil {
+			mappedConnection["state"] = string(*conn.State)
+		}
+		mappedConnections = append(mappedConnections, mappedConnection)
+	}
+	return mappedConnections
+}
+func fabricNetworkChangesToTerra(changes []v4.NetworkChange) []interface{} {
+	mappedChanges := make([]interface{}, 0, len(changes))
+	for _, change := range changes {
+		mappedChange := map[string]interface{}{
+			"uuid":              change.Uuid,
+			"href":              change.Href,
+			"created_date_time": change.CreatedDateTime.String(),
+			"updated_date_time": change.UpdatedDateTime.String(),
+		}
+		if change.Type_ != nil {
+			mappedChange["type"] = string(*change.Type_)
+		}
+		if change.Status != nil {
+			mappedChange["status"] = string(*change.Status)
+		}
+		mappedChanges = append(mappedChanges, mappedChange)
+	}
+	return mappedChanges
 }
 func fabricNetworkOperationToTerra(operation *v4.NetworkOperation) *schema.Set {
 	if operation == nil {
 		return nil
 	}
 	operations := []*v4.NetworkOperation{operation}
-	mappedOperations := make([]interface{}, len(operations))
+	mappedOperations := make([]interface{}, 0, len(operations))
 	for _, operation := range operations {
 		mappedOperation := make(map[string]interface{})
 		mappedOperation["equinix_status"] = string(*operation.EquinixStatus)
@@ -232,8 +385,11 @@ func fabricNetworkOperationToTerra(operation *v4.NetworkOperation) *schema.Set {
 	return operationSet
 }
 func simplifiedFabricNetworkChangeToTerra(networkChange *v4.SimplifiedNetworkChange) *schema.Set {
+	if networkChange == nil {
+		return nil
+	}
 	changes := []*v4.SimplifiedNetworkChange{networkChange}
-	mappedChanges := make([]interface{}, len(changes))
+	mappedChanges := make([]interface{}, 0, len(changes))
 	for _, change := range changes {
 		mappedChange := make(map[string]interface{})
 		mappedChange["href"] = change.Href
@@ -251,36 +407,59 @@ func simplifiedFabricNetworkChangeToTerra(networkChange *v4.SimplifiedNetworkCha
 
 func setFabricNetworkMap(d *schema.ResourceData, nt v4.Network) diag.Diagnostics {
 	diags := diag.Diagnostics{}
-	err := equinix_schema.SetMap(d, map[string]interface{}{
+	err := equinix_schema.SetMap(d, fabricNetworkMap(nt))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	return diags
+}
+
+func fabricNetworkMap(nt v4.Network) map[string]interface{} {
+	var netType, netScope, netState string
+	if nt.Type_ != nil {
+		netType = string(*nt.Type_)
+	}
+	if nt.Scope != nil {
+		netScope = string(*nt.Scope)
+	}
+	if nt.State != nil {
+		netState = string(*nt.State)
+	}
+	return map[string]interface{}{
 		"name":              nt.Name,
 		"href":              nt.Href,
 		"uuid":              nt.Uuid,
-		"type":              nt.Type_,
-		"scope":             nt.Scope,
-		"state":             nt.State,
+		"type":              netType,
+		"scope":             netScope,
+		"state":             netState,
 		"operation":         fabricNetworkOperationToTerra(nt.Operation),
 		"change":            simplifiedFabricNetworkChangeToTerra(nt.Change),
 		"location":          equinix_fabric_schema.LocationToTerra(nt.Location),
 		"notifications":     equinix_fabric_schema.NotificationsToTerra(nt.Notifications),
 		"project":           equinix_fabric_schema.ProjectToTerra(nt.Project),
+		"account":           equinix_fabric_schema.AccountToTerra(nt.Account),
 		"change_log":        equinix_fabric_schema.ChangeLogToTerra(nt.ChangeLog),
 		"connections_count": nt.ConnectionsCount,
-	})
-	if err != nil {
-		return diag.FromErr(err)
 	}
-	return diags
 }
-func getFabricNetworkUpdateRequest(network v4.Network, d *schema.ResourceData) (v4.NetworkChangeOperation, error) {
-	changeOps := v4.NetworkChangeOperation{}
+
+// getFabricNetworkUpdateRequest returns the operations updating the name and
+// the notifications of the network, the only attributes the API can update.
+func getFabricNetworkUpdateRequest(network v4.Network, d *schema.ResourceData) ([]v4.NetworkChangeOperation, error) {
+	changeOps := []v4.NetworkChangeOperation{}
 	existingName := network.Name
 	updateNameVal := d.Get("name")
 
 	log.Printf("existing name %s, Update Name Request %s ", existingName, updateNameVal)
 
 	if existingName != updateNameVal {
-		changeOps = v4.NetworkChangeOperation{Op: "replace", Path: "/name", Value: &updateNameVal}
-	} else {
+		changeOps = append(changeOps, v4.NetworkChangeOperation{Op: "replace", Path: "/name", Value: &updateNameVal})
+	}
+	if d.HasChange("notifications") {
+		var notifications interface{} = equinix_fabric_schema.NotificationsToFabric(d.Get("notifications").([]interface{}))
+		changeOps = append(changeOps, v4.NetworkChangeOperation{Op: "replace", Path: "/notifications", Value: &notifications})
+	}
+	if len(changeOps) == 0 {
 		return changeOps, fmt.Errorf("nothing to update for the Fabric Network: %s", existingName)
 	}
 	return changeOps, nil
@@ -292,14 +471,13 @@ func resourceFabricNetworkUpdate(ctx context.Context, d *schema.ResourceData, me
 	if err != nil {
 		return diag.Errorf("either timed out or errored out while fetching Fabric Network for uuid %s and error %v", d.Id(), err)
 	}
-	update, err := getFabricNetworkUpdateRequest(dbConn, d)
+	updates, err := getFabricNetworkUpdateRequest(dbConn, d)
 	if err != nil {
 		return diag.Errorf("error retrieving intended updates from network config: %v", err)
 	}
-	updates := []v4.NetworkChangeOperation{update}
 	_, res, err := client.NetworksApi.UpdateNetworkByUuid(ctx, updates, d.Id())
 	if err != nil {
-		return diag.FromErr(equinix_errors.FormatFabricError(err))
+		return equinix_diagnostics.SDKError(fmt.Sprintf("updating Fabric Network %s", d.Id()), fabricErrorAttributePath(fabricNetworkResourceSchema(), err), res, err)
 	}
 	updateFg := v4.Network{}
 	updateFg, err = waitForFabricNetworkUpdateCompletion(d.Id(), meta, ctx)
@@ -373,7 +551,7 @@ func resourceFabricNetworkDelete(ctx context.Context, d *schema.ResourceData, me
 	diags := diag.Diagnostics{}
 	client := meta.(*config.Config).FabricClient
 	ctx = context.WithValue(ctx, v4.ContextAccessToken, meta.(*config.Config).FabricAuthToken)
-	_, _, err := client.NetworksApi.DeleteNetworkByUuid(ctx, d.Id())
+	_, resp, err := client.NetworksApi.DeleteNetworkByUuid(ctx, d.Id())
 	if err != nil {
 		errors, ok := err.(v4.GenericSwaggerError).Model().([]v4.ModelError)
 		if ok {
@@ -382,7 +560,7 @@ func resourceFabricNetworkDelete(ctx context.Context, d *schema.ResourceData, me
 				return diags
 			}
 		}
-		return diag.FromErr(equinix_errors.FormatFabricError(err))
+		return equinix_diagnostics.SDKError(fmt.Sprintf("deleting Fabric Network %s", d.Id()), "", resp, err)
 	}
 
 	err = WaitUntilFabricNetworkDeprovisioned(d.Id(), meta, ctx)
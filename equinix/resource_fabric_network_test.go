@@ -0,0 +1,68 @@
+package equinix
+
+import (
+	"testing"
+	"time"
+
+	v4 "github.com/equinix-labs/fabric-go/fabric/v4"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFabricNetwork_connectionsAndChangesToTerra(t *testing.T) {
+	// given
+	connType, connState := v4.EVPLAN_VC_ConnectionType, v4.ACTIVE_ConnectionState
+	connections := []v4.Connection{
+		{Uuid: "conn-uuid", Name: "conn-1", Type_: &connType, State: &connState},
+		{Uuid: "other-uuid", Name: "conn-2"},
+	}
+	changeType, changeStatus := v4.CREATION_NetworkChangeType, v4.COMPLETED_NetworkChangeStatus
+	created := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	changes := []v4.NetworkChange{
+		{Uuid: "change-uuid", Type_: &changeType, Status: &changeStatus, CreatedDateTime: created},
+	}
+	d := schema.TestResourceDataRaw(t, fabricNetworkResourceSchema(), map[string]interface{}{})
+	// when
+	connErr := d.Set("connections", fabricNetworkConnectionsToTerra(connections))
+	changeErr := d.Set("changes", fabricNetworkChangesToTerra(changes))
+	// then
+	require.NoError(t, connErr)
+	require.NoError(t, changeErr)
+	assert.Equal(t, 2, d.Get("connections.#"))
+	assert.Equal(t, "conn-uuid", d.Get("connections.0.uuid"))
+	assert.Equal(t, "EVPLAN_VC", d.Get("connections.0.type"))
+	assert.Equal(t, "ACTIVE", d.Get("connections.0.state"))
+	assert.Equal(t, "", d.Get("connections.1.state"), "State is empty if not reported")
+	assert.Equal(t, 1, d.Get("changes.#"))
+	assert.Equal(t, "NETWORK_CREATION", d.Get("changes.0.type"))
+	assert.Equal(t, "COMPLETED", d.Get("changes.0.status"))
+	assert.Equal(t, created.String(), d.Get("changes.0.created_date_time"))
+}
+
+func TestFabricNetwork_updateRequest(t *testing.T) {
+	// given
+	network := v4.Network{Name: "network"}
+	renamed := schema.TestResourceDataRaw(t, fabricNetworkResourceSchema(), map[string]interface{}{
+		"name": "renamed",
+		"notifications": []interface{}{
+			map[string]interface{}{"type": "ALL", "emails": []interface{}{"test@equinix.com"}},
+		},
+	})
+	unchanged := schema.TestResourceDataRaw(t, fabricNetworkResourceSchema(), map[string]interface{}{
+		"name": "network",
+	})
+	// when
+	ops, err := getFabricNetworkUpdateRequest(network, renamed)
+	_, unchangedErr := getFabricNetworkUpdateRequest(network, unchanged)
+	// then
+	require.NoError(t, err)
+	require.Len(t, ops, 2)
+	assert.Equal(t, "/name", ops[0].Path)
+	assert.Equal(t, "renamed", *ops[0].Value)
+	assert.Equal(t, "/notifications", ops[1].Path)
+	notifications, ok := (*ops[1].Value).([]v4.SimplifiedNotification)
+	require.True(t, ok, "Notifications are converted to the API model")
+	assert.Equal(t, []string{"test@equinix.com"}, notifications[0].Emails)
+	assert.Error(t, unchangedErr, "Nothing to update")
+}
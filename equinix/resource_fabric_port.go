@@ -2,12 +2,14 @@ package equinix
 
 import (
 	"context"
+	"crypto/sha256"
 	"errors"
 	"fmt"
 	"log"
 	"runtime/debug"
 	"strings"
 
+	"github.com/equinix/terraform-provider-equinix/internal/converters"
 	equinix_errors "github.com/equinix/terraform-provider-equinix/internal/errors"
 	equinix_fabric_schema "github.com/equinix/terraform-provider-equinix/internal/fabric/schema"
 	equinix_schema "github.com/equinix/terraform-provider-equinix/internal/schema"
@@ -18,6 +20,7 @@ import (
 	v4 "github.com/equinix-labs/fabric-go/fabric/v4"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func portDeviceSch() map[string]*schema.Schema {
@@ -73,6 +76,157 @@ func portOperationSch() map[string]*schema.Schema {
 	}
 }
 
+func portLagSch() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"id": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "Link aggregation group identifier",
+		},
+		"enabled": {
+			Type:        schema.TypeBool,
+			Computed:    true,
+			Description: "Whether link aggregation is enabled on the port",
+		},
+		"name": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "Link aggregation group name",
+		},
+		"member_status": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "Status of the port as member of the link aggregation group",
+		},
+	}
+}
+
+func portTetherSch() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"cross_connect_id": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "Cross connect identifier",
+		},
+		"cabinet_number": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "Cabinet number",
+		},
+		"system_name": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "System name",
+		},
+		"patch_panel": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "Patch panel",
+		},
+		"patch_panel_port_a": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "Patch panel port A",
+		},
+		"patch_panel_port_b": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "Patch panel port B",
+		},
+		"ibx": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "Equinix IBX of the Z side",
+		},
+	}
+}
+
+func portDemarcationPointSch() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"cabinet_unique_space_id": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "Cabinet unique space identifier",
+		},
+		"cage_unique_space_id": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "Cage unique space identifier",
+		},
+		"patch_panel": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "Patch panel",
+		},
+		"patch_panel_name": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "Patch panel name",
+		},
+		"patch_panel_port_a": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "Patch panel port A",
+		},
+		"patch_panel_port_b": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "Patch panel port B",
+		},
+		"connector_type": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "Connector type",
+		},
+		"ibx": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "Equinix IBX of the A side",
+		},
+	}
+}
+
+func physicalPortSch() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"type": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "Physical port type",
+		},
+		"state": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "Physical port state",
+		},
+		"interface_speed": {
+			Type:        schema.TypeInt,
+			Computed:    true,
+			Description: "Physical port speed in Mbps",
+		},
+		"interface_type": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "Physical port interface type",
+		},
+		"tether": {
+			Type:        schema.TypeList,
+			Computed:    true,
+			Description: "Physical connection of the port",
+			Elem: &schema.Resource{
+				Schema: portTetherSch(),
+			},
+		},
+		"demarcation_point": {
+			Type:        schema.TypeList,
+			Computed:    true,
+			Description: "Customer side of the physical connection",
+			Elem: &schema.Resource{
+				Schema: portDemarcationPointSch(),
+			},
+		},
+	}
+}
+
 func PortRedundancySch() map[string]*schema.Schema {
 	return map[string]*schema.Schema{
 		"enabled": {
@@ -206,6 +360,47 @@ func FabricPortResourceSchema() map[string]*schema.Schema {
 			Computed:    true,
 			Description: "Port Lag",
 		},
+		"lag": {
+			Type:        schema.TypeList,
+			Computed:    true,
+			Description: "Link aggregation group of the port",
+			Elem: &schema.Resource{
+				Schema: portLagSch(),
+			},
+		},
+		"physical_ports_speed": {
+			Type:        schema.TypeInt,
+			Computed:    true,
+			Description: "Speed of the physical ports in Mbps",
+		},
+		"physical_ports_type": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "Type of the physical ports, e.g. 10GBASE_LR",
+		},
+		"physical_ports_count": {
+			Type:        schema.TypeInt,
+			Computed:    true,
+			Description: "Number of physical ports",
+		},
+		"demarcation_point_ibx": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "Equinix IBX of the A side",
+		},
+		"tether_ibx": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "Equinix IBX of the Z side",
+		},
+		"physical_ports": {
+			Type:        schema.TypeList,
+			Computed:    true,
+			Description: "Physical ports that implement the port",
+			Elem: &schema.Resource{
+				Schema: physicalPortSch(),
+			},
+		},
 	}
 }
 
@@ -243,32 +438,139 @@ func readGetPortsByNameQueryParamSch() map[string]*schema.Schema {
 	return map[string]*schema.Schema{
 		"name": {
 			Type:        schema.TypeString,
-			Required:    true,
+			Optional:    true,
 			Description: "Query Parameter to Get Ports By Name",
 		},
+		"name_match": {
+			Type:         schema.TypeString,
+			Optional:     true,
+			Default:      portNameMatchPrefix,
+			ValidateFunc: validation.StringInSlice([]string{portNameMatchExact, portNameMatchPrefix}, false),
+			Description:  "How ports are matched by name, EXACT for ports named name only or PREFIX for ports whose name starts with name. Defaults to PREFIX",
+		},
+		"encapsulation_type": {
+			Type:         schema.TypeString,
+			Optional:     true,
+			ValidateFunc: validation.StringInSlice([]string{"DOT1Q", "QINQ", "UNTAGGED"}, true),
+			Description:  "Port encapsulation protocol type, one of DOT1Q, QINQ or UNTAGGED",
+		},
+		"state": {
+			Type:        schema.TypeString,
+			Optional:    true,
+			Description: "Port state, e.g. ACTIVE",
+		},
+	}
+}
+
+// Name matches of the ports data source filters.
+const (
+	portNameMatchExact  = "EXACT"
+	portNameMatchPrefix = "PREFIX"
+)
+
+// fabricPortsFilter selects ports among the ones the API returns, which only
+// filters them by name.
+type fabricPortsFilter struct {
+	name              string
+	nameMatch         string
+	encapsulationType string
+	state             string
+}
+
+func fabricPortsFilterFromTerra(filters []interface{}) fabricPortsFilter {
+	filter := fabricPortsFilter{nameMatch: portNameMatchPrefix}
+	if len(filters) == 0 || filters[0] == nil {
+		return filter
 	}
+	filterMap := filters[0].(map[string]interface{})
+	filter.name, _ = filterMap["name"].(string)
+	if nameMatch, _ := filterMap["name_match"].(string); nameMatch != "" {
+		filter.nameMatch = nameMatch
+	}
+	filter.encapsulationType, _ = filterMap["encapsulation_type"].(string)
+	filter.state, _ = filterMap["state"].(string)
+	return filter
 }
 
-func portToFabric(portList []interface{}) v4.SimplifiedPort {
-	p := v4.SimplifiedPort{}
-	for _, pl := range portList {
-		plMap := pl.(map[string]interface{})
-		uuid := plMap["uuid"].(string)
-		p = v4.SimplifiedPort{Uuid: uuid}
+// matches reports whether a port matches the filter.
+func (f fabricPortsFilter) matches(port v4.Port) bool {
+	if f.nameMatch == portNameMatchExact && port.Name != f.name {
+		return false
+	}
+	if !strings.HasPrefix(port.Name, f.name) {
+		return false
+	}
+	if f.encapsulationType != "" && (port.Encapsulation == nil || !strings.EqualFold(port.Encapsulation.Type_, f.encapsulationType)) {
+		return false
 	}
-	return p
+	if f.state != "" && (port.State == nil || !strings.EqualFold(string(*port.State), f.state)) {
+		return false
+	}
+	return true
+}
+
+func (f fabricPortsFilter) id() string {
+	return fmt.Sprintf("%x", sha256.Sum256([]byte(strings.Join([]string{f.name, f.nameMatch, f.encapsulationType, f.state}, ","))))
+}
+
+// getFabricPorts returns the ports matching the filter.
+func getFabricPorts(ctx context.Context, client *v4.APIClient, filter fabricPortsFilter) ([]v4.Port, error) {
+	opts := v4.PortsApiGetPortsOpts{}
+	if filter.name != "" {
+		opts.Name = optional.NewString(filter.name)
+	}
+	ports, _, err := client.PortsApi.GetPorts(ctx, &opts)
+	if err != nil {
+		return nil, equinix_errors.FormatFabricError(err)
+	}
+	matching := []v4.Port{}
+	for _, port := range ports.Data {
+		if filter.matches(port) {
+			matching = append(matching, port)
+		}
+	}
+	return matching, nil
+}
+
+func portToFabric(portList []interface{}) (v4.SimplifiedPort, error) {
+	plMap, uuid, err := terraUuidBlock(portList, "port")
+	if err != nil || plMap == nil {
+		return v4.SimplifiedPort{}, err
+	}
+	p := v4.SimplifiedPort{Uuid: uuid}
+	redundancyList, err := terraListAttr(plMap, "port", "redundancy")
+	if err != nil {
+		return v4.SimplifiedPort{}, err
+	}
+	if p.Redundancy, err = portRedundancyToFabric(redundancyList); err != nil {
+		return v4.SimplifiedPort{}, err
+	}
+	return p, nil
+}
+
+func portRedundancyToFabric(redundancyList []interface{}) (*v4.PortRedundancy, error) {
+	redundancyMap, err := terraBlock(redundancyList, "port.redundancy")
+	if err != nil || redundancyMap == nil {
+		return nil, err
+	}
+	priority, err := terraStringAttr(redundancyMap, "port.redundancy", "priority")
+	if err != nil || priority == "" {
+		return nil, err
+	}
+	portPriority := v4.PortPriority(priority)
+	return &v4.PortRedundancy{Priority: &portPriority}, nil
 }
 
 func portToTerra(port *v4.SimplifiedPort) *schema.Set {
 	ports := []*v4.SimplifiedPort{port}
-	mappedPorts := make([]interface{}, len(ports))
+	mappedPorts := make([]interface{}, 0, len(ports))
 	for _, port := range ports {
 		mappedPort := make(map[string]interface{})
 		mappedPort["href"] = port.Href
 		mappedPort["name"] = port.Name
 		mappedPort["uuid"] = port.Uuid
 		if port.Redundancy != nil {
-			mappedPort["redundancy"] = PortRedundancyToTerra(port.Redundancy)
+			mappedPort["redundancy"] = PortRedundancyToTerra(port.Redundancy).List()
 		}
 		mappedPorts = append(mappedPorts, mappedPort)
 	}
@@ -289,7 +591,9 @@ func PortRedundancyToTerra(redundancy *v4.PortRedundancy) *schema.Set {
 		mappedRedundancy := make(map[string]interface{})
 		mappedRedundancy["enabled"] = redundancy.Enabled
 		mappedRedundancy["group"] = redundancy.Group
-		mappedRedundancy["priority"] = string(*redundancy.Priority)
+		if redundancy.Priority != nil {
+			mappedRedundancy["priority"] = string(*redundancy.Priority)
+		}
 		mappedRedundancies = append(mappedRedundancies, mappedRedundancy)
 	}
 	redundancySet := schema.NewSet(
@@ -385,38 +689,111 @@ func fabricPortsListToTerra(ports v4.AllPortsResponse) []map[string]interface{}
 	}
 	mappedPortsl := make([]map[string]interface{}, len(portsl))
 	for index, port := range portsl {
-		mappedPortsl[index] = map[string]interface{}{
-			"uuid":                port.Uuid,
-			"name":                port.Name,
-			"bandwidth":           port.Bandwidth,
-			"available_bandwidth": port.AvailableBandwidth,
-			"used_bandwidth":      port.UsedBandwidth,
-			"href":                port.Href,
-			"description":         port.Description,
-			"type":                port.Type_,
-			"state":               port.State,
-			"service_type":        port.ServiceType,
-			"operation":           portOperationToTerra(port.Operation),
-			"redundancy":          PortRedundancyToTerra(port.Redundancy),
-			"account":             equinix_fabric_schema.AccountToTerra(port.Account),
-			"change_log":          equinix_fabric_schema.ChangeLogToTerra(port.Changelog),
-			"location":            equinix_fabric_schema.LocationToTerra(port.Location),
-			"device":              portDeviceToTerra(port.Device),
-			"encapsulation":       portEncapsulationToTerra(port.Encapsulation),
-			"lag_enabled":         port.LagEnabled,
-		}
+		mappedPortsl[index] = fabricPortToTerra(port)
+		mappedPortsl[index]["uuid"] = port.Uuid
 	}
 	return mappedPortsl
 }
 
+func fabricPortToTerra(port v4.Port) map[string]interface{} {
+	return map[string]interface{}{
+		"name":                  port.Name,
+		"bandwidth":             port.Bandwidth,
+		"available_bandwidth":   port.AvailableBandwidth,
+		"used_bandwidth":        port.UsedBandwidth,
+		"href":                  port.Href,
+		"description":           port.Description,
+		"type":                  port.Type_,
+		"state":                 port.State,
+		"service_type":          port.ServiceType,
+		"operation":             portOperationToTerra(port.Operation),
+		"redundancy":            PortRedundancyToTerra(port.Redundancy),
+		"account":               equinix_fabric_schema.AccountToTerra(port.Account),
+		"change_log":            equinix_fabric_schema.ChangeLogToTerra(port.Changelog),
+		"location":              equinix_fabric_schema.LocationToTerra(port.Location),
+		"device":                portDeviceToTerra(port.Device),
+		"encapsulation":         portEncapsulationToTerra(port.Encapsulation),
+		"lag_enabled":           port.LagEnabled,
+		"lag":                   portLagToTerra(port.Lag),
+		"physical_ports_speed":  int(port.PhysicalPortsSpeed),
+		"physical_ports_type":   port.PhysicalPortsType,
+		"physical_ports_count":  int(port.PhysicalPortsCount),
+		"demarcation_point_ibx": port.DemarcationPointIbx,
+		"tether_ibx":            port.TetherIbx,
+		"physical_ports":        physicalPortsToTerra(port.PhysicalPorts),
+	}
+}
+
+func portLagToTerra(lag *v4.PortLag) []interface{} {
+	if lag == nil {
+		return nil
+	}
+	return []interface{}{map[string]interface{}{
+		"id":            lag.Id,
+		"enabled":       lag.Enabled,
+		"name":          lag.Name,
+		"member_status": lag.MemberStatus,
+	}}
+}
+
+func physicalPortsToTerra(physicalPorts []v4.PhysicalPort) []interface{} {
+	return converters.SliceToIfArr(physicalPorts, func(physicalPort v4.PhysicalPort) interface{} {
+		mappedPhysicalPort := map[string]interface{}{
+			"interface_speed":   int(physicalPort.InterfaceSpeed),
+			"interface_type":    physicalPort.InterfaceType,
+			"tether":            portTetherToTerra(physicalPort.Tether),
+			"demarcation_point": portDemarcationPointToTerra(physicalPort.DemarcationPoint),
+		}
+		if physicalPort.Type_ != nil {
+			mappedPhysicalPort["type"] = string(*physicalPort.Type_)
+		}
+		if physicalPort.State != nil {
+			mappedPhysicalPort["state"] = string(*physicalPort.State)
+		}
+		return mappedPhysicalPort
+	})
+}
+
+func portTetherToTerra(tether *v4.PortTether) []interface{} {
+	if tether == nil {
+		return nil
+	}
+	return []interface{}{map[string]interface{}{
+		"cross_connect_id":   tether.CrossConnectId,
+		"cabinet_number":     tether.CabinetNumber,
+		"system_name":        tether.SystemName,
+		"patch_panel":        tether.PatchPanel,
+		"patch_panel_port_a": tether.PatchPanelPortA,
+		"patch_panel_port_b": tether.PatchPanelPortB,
+		"ibx":                tether.Ibx,
+	}}
+}
+
+func portDemarcationPointToTerra(demarcationPoint *v4.PortDemarcationPoint) []interface{} {
+	if demarcationPoint == nil {
+		return nil
+	}
+	return []interface{}{map[string]interface{}{
+		"cabinet_unique_space_id": demarcationPoint.CabinetUniqueSpaceId,
+		"cage_unique_space_id":    demarcationPoint.CageUniqueSpaceId,
+		"patch_panel":             demarcationPoint.PatchPanel,
+		"patch_panel_name":        demarcationPoint.PatchPanelName,
+		"patch_panel_port_a":      demarcationPoint.PatchPanelPortA,
+		"patch_panel_port_b":      demarcationPoint.PatchPanelPortB,
+		"connector_type":          demarcationPoint.ConnectorType,
+		"ibx":                     demarcationPoint.Ibx,
+	}}
+}
+
 func resourceFabricPortRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	client := meta.(*config.Config).FabricClient
 	ctx = context.WithValue(ctx, v4.ContextAccessToken, meta.(*config.Config).FabricAuthToken)
-	port, _, err := client.PortsApi.GetPortByUuid(ctx, d.Id())
+	port, resp, err := client.PortsApi.GetPortByUuid(ctx, d.Id())
 	if err != nil {
-		log.Printf("[WARN] Port %s not found , error %s", d.Id(), err)
-		if !strings.Contains(err.Error(), "500") {
+		if !d.IsNewResource() && equinix_errors.IsGone(resp, err) {
+			log.Printf("[WARN] Port %s not found, removing from state", d.Id())
 			d.SetId("")
+			return nil
 		}
 		return diag.FromErr(equinix_errors.FormatFabricError(err))
 	}
@@ -426,25 +803,7 @@ func resourceFabricPortRead(ctx context.Context, d *schema.ResourceData, meta in
 
 func setFabricPortMap(d *schema.ResourceData, port v4.Port) diag.Diagnostics {
 	diags := diag.Diagnostics{}
-	err := equinix_schema.SetMap(d, map[string]interface{}{
-		"name":                port.Name,
-		"bandwidth":           port.Bandwidth,
-		"available_bandwidth": port.AvailableBandwidth,
-		"used_bandwidth":      port.UsedBandwidth,
-		"href":                port.Href,
-		"description":         port.Description,
-		"type":                port.Type_,
-		"state":               port.State,
-		"service_type":        port.ServiceType,
-		"operation":           portOperationToTerra(port.Operation),
-		"redundancy":          PortRedundancyToTerra(port.Redundancy),
-		"account":             equinix_fabric_schema.AccountToTerra(port.Account),
-		"change_log":          equinix_fabric_schema.ChangeLogToTerra(port.Changelog),
-		"location":            equinix_fabric_schema.LocationToTerra(port.Location),
-		"device":              portDeviceToTerra(port.Device),
-		"encapsulation":       portEncapsulationToTerra(port.Encapsulation),
-		"lag_enabled":         port.LagEnabled,
-	})
+	err := equinix_schema.SetMap(d, fabricPortToTerra(port))
 	if err != nil {
 		return diag.FromErr(err)
 	}
@@ -480,36 +839,13 @@ func resourceFabricPortGetByPortName(ctx context.Context, d *schema.ResourceData
 
 	client := meta.(*config.Config).FabricClient
 	ctx = context.WithValue(ctx, v4.ContextAccessToken, meta.(*config.Config).FabricAuthToken)
-	portNameParam := d.Get("filters").(*schema.Set).List()
-	portName := portNameQueryParamToFabric(portNameParam)
-	ports, _, err := client.PortsApi.GetPorts(ctx, &portName)
+	filter := fabricPortsFilterFromTerra(d.Get("filters").(*schema.Set).List())
+	ports, err := getFabricPorts(ctx, client, filter)
 	if err != nil {
 		log.Printf("[WARN] Ports not found , error %s", err)
-		if !strings.Contains(err.Error(), "500") {
-			d.SetId("")
-		}
-		return diag.FromErr(equinix_errors.FormatFabricError(err))
-	}
-	if len(ports.Data) != 1 {
-		error := fmt.Errorf("incorrect # of records are found for the port name parameter criteria - %d , please change the criteria", len(ports.Data))
-		d.SetId("")
-		return diag.FromErr(error)
+		return diag.FromErr(err)
 	}
 
-	d.SetId(ports.Data[0].Uuid)
-	return setPortsListMap(d, ports)
-}
-
-func portNameQueryParamToFabric(portNameParam []interface{}) v4.PortsApiGetPortsOpts {
-	if len(portNameParam) == 0 {
-		return v4.PortsApiGetPortsOpts{}
-	}
-	mappedPn := v4.PortsApiGetPortsOpts{}
-	for _, pn := range portNameParam {
-		pnMap := pn.(map[string]interface{})
-		portName := pnMap["name"].(string)
-		pName := optional.NewString(portName)
-		mappedPn = v4.PortsApiGetPortsOpts{Name: pName}
-	}
-	return mappedPn
+	d.SetId(filter.id())
+	return setPortsListMap(d, v4.AllPortsResponse{Data: ports})
 }
@@ -0,0 +1,628 @@
+package equinix
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	v4 "github.com/equinix-labs/fabric-go/fabric/v4"
+	"github.com/equinix/terraform-provider-equinix/internal/config"
+	equinix_errors "github.com/equinix/terraform-provider-equinix/internal/errors"
+	equinix_fabric_schema "github.com/equinix/terraform-provider-equinix/internal/fabric/schema"
+	equinix_schema "github.com/equinix/terraform-provider-equinix/internal/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/retry"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func precisionTimePackageSch() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"code": {
+			Type:         schema.TypeString,
+			Required:     true,
+			ValidateFunc: validation.StringInSlice([]string{"NTP_STANDARD", "NTP_ENTERPRISE", "PTP_STANDARD", "PTP_ENTERPRISE"}, false),
+			Description:  "Precision Time package code - NTP_STANDARD, NTP_ENTERPRISE, PTP_STANDARD or PTP_ENTERPRISE",
+		},
+	}
+}
+
+func precisionTimeConnectionSch() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"uuid": {
+			Type:         schema.TypeString,
+			Required:     true,
+			ValidateFunc: validation.IsUUID,
+			Description:  "Equinix-assigned identifier of the Fabric connection",
+		},
+		"href": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "Fabric connection URI",
+		},
+		"type": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "Fabric connection type",
+		},
+	}
+}
+
+func precisionTimeIpv4Sch() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"primary": {
+			Type:         schema.TypeString,
+			Required:     true,
+			ValidateFunc: validation.IsIPv4Address,
+			Description:  "IPv4 address of the primary timing server",
+		},
+		"secondary": {
+			Type:         schema.TypeString,
+			Required:     true,
+			ValidateFunc: validation.IsIPv4Address,
+			Description:  "IPv4 address of the secondary timing server",
+		},
+		"network_mask": {
+			Type:         schema.TypeString,
+			Required:     true,
+			ValidateFunc: validation.IsIPv4Address,
+			Description:  "Network mask of the timing servers, e.g. 255.255.255.240",
+		},
+		"default_gateway": {
+			Type:         schema.TypeString,
+			Optional:     true,
+			ValidateFunc: validation.IsIPv4Address,
+			Description:  "IPv4 address of the default gateway",
+		},
+	}
+}
+
+func precisionTimeNtpAdvancedConfigurationSch() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"type": {
+			Type:         schema.TypeString,
+			Required:     true,
+			ValidateFunc: validation.StringInSlice([]string{"ASCII", "HEX"}, false),
+			Description:  "MD5 key type - ASCII or HEX",
+		},
+		"key_number": {
+			Type:        schema.TypeString,
+			Required:    true,
+			Description: "MD5 key number, between 1 and 65534",
+		},
+		"key": {
+			Type:        schema.TypeString,
+			Required:    true,
+			Sensitive:   true,
+			Description: "MD5 key, up to 20 ASCII characters or 40 hexadecimal characters",
+		},
+	}
+}
+
+func precisionTimePtpAdvancedConfigurationSch() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"time_scale": {
+			Type:         schema.TypeString,
+			Optional:     true,
+			Computed:     true,
+			ValidateFunc: validation.StringInSlice([]string{"ARB", "PTP"}, false),
+			Description:  "Time scale - ARB for arbitrary or PTP",
+		},
+		"domain": {
+			Type:         schema.TypeInt,
+			Optional:     true,
+			Computed:     true,
+			ValidateFunc: validation.IntBetween(0, 127),
+			Description:  "PTP domain number",
+		},
+		"priority_1": {
+			Type:         schema.TypeInt,
+			Optional:     true,
+			Computed:     true,
+			ValidateFunc: validation.IntBetween(0, 248),
+			Description:  "PTP priority 1 of the grandmaster clock",
+		},
+		"priority_2": {
+			Type:         schema.TypeInt,
+			Optional:     true,
+			Computed:     true,
+			ValidateFunc: validation.IntBetween(0, 248),
+			Description:  "PTP priority 2 of the grandmaster clock",
+		},
+		"log_announce_interval": {
+			Type:        schema.TypeInt,
+			Optional:    true,
+			Computed:    true,
+			Description: "Mean interval between Announce messages, as a power of two in seconds",
+		},
+		"log_sync_interval": {
+			Type:        schema.TypeInt,
+			Optional:    true,
+			Computed:    true,
+			Description: "Mean interval between Sync messages, as a power of two in seconds",
+		},
+		"log_delay_req_interval": {
+			Type:        schema.TypeInt,
+			Optional:    true,
+			Computed:    true,
+			Description: "Mean interval between Delay_Req messages, as a power of two in seconds",
+		},
+		"transport_mode": {
+			Type:         schema.TypeString,
+			Optional:     true,
+			Computed:     true,
+			ValidateFunc: validation.StringInSlice([]string{"Multicast", "Unicast", "Hybrid"}, false),
+			Description:  "PTP transport mode - Multicast, Unicast or Hybrid",
+		},
+		"grant_time": {
+			Type:         schema.TypeInt,
+			Optional:     true,
+			Computed:     true,
+			ValidateFunc: validation.IntBetween(30, 7200),
+			Description:  "Unicast grant time in seconds, between 30 and 7200",
+		},
+	}
+}
+
+func fabricPrecisionTimeResourceSchema() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"type": {
+			Type:         schema.TypeString,
+			Required:     true,
+			ForceNew:     true,
+			ValidateFunc: validation.StringInSlice([]string{"NTP", "PTP"}, false),
+			Description:  "Precision Time service type - NTP or PTP",
+		},
+		"href": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "Precision Time service URI",
+		},
+		"uuid": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "Equinix-assigned Precision Time service identifier",
+		},
+		"name": {
+			Type:         schema.TypeString,
+			Required:     true,
+			ValidateFunc: validation.StringLenBetween(1, 24),
+			Description:  "Precision Time service name",
+		},
+		"description": {
+			Type:        schema.TypeString,
+			Optional:    true,
+			Description: "Precision Time service description",
+		},
+		"state": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "Precision Time service state",
+		},
+		"package": {
+			Type:        schema.TypeList,
+			Required:    true,
+			MaxItems:    1,
+			Description: "Precision Time service package",
+			Elem: &schema.Resource{
+				Schema: precisionTimePackageSch(),
+			},
+		},
+		"connections": {
+			Type:        schema.TypeList,
+			Required:    true,
+			ForceNew:    true,
+			MinItems:    1,
+			MaxItems:    2,
+			Description: "Fabric connections delivering the Precision Time service, a second connection makes the service redundant",
+			Elem: &schema.Resource{
+				Schema: precisionTimeConnectionSch(),
+			},
+		},
+		"ipv4": {
+			Type:        schema.TypeList,
+			Required:    true,
+			MaxItems:    1,
+			Description: "IPv4 configuration of the timing servers",
+			Elem: &schema.Resource{
+				Schema: precisionTimeIpv4Sch(),
+			},
+		},
+		"ntp_advanced_configuration": {
+			Type:          schema.TypeList,
+			Optional:      true,
+			ConflictsWith: []string{"ptp_advanced_configuration"},
+			Description:   "MD5 authentication keys of an NTP service",
+			Elem: &schema.Resource{
+				Schema: precisionTimeNtpAdvancedConfigurationSch(),
+			},
+		},
+		"ptp_advanced_configuration": {
+			Type:          schema.TypeList,
+			Optional:      true,
+			Computed:      true,
+			MaxItems:      1,
+			ConflictsWith: []string{"ntp_advanced_configuration"},
+			Description:   "Advanced settings of a PTP service",
+			Elem: &schema.Resource{
+				Schema: precisionTimePtpAdvancedConfigurationSch(),
+			},
+		},
+		"project": {
+			Type:        schema.TypeSet,
+			Optional:    true,
+			Computed:    true,
+			ForceNew:    true,
+			MaxItems:    1,
+			Description: "Project information",
+			Elem: &schema.Resource{
+				Schema: equinix_fabric_schema.ProjectSch(),
+			},
+		},
+		"account": {
+			Type:        schema.TypeSet,
+			Computed:    true,
+			Description: "Customer account information that is associated with this Precision Time Service",
+			Elem: &schema.Resource{
+				Schema: equinix_fabric_schema.AccountSch(),
+			},
+		},
+	}
+}
+
+func resourceFabricPrecisionTime() *schema.Resource {
+	return &schema.Resource{
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(10 * time.Minute),
+			Update: schema.DefaultTimeout(10 * time.Minute),
+			Delete: schema.DefaultTimeout(10 * time.Minute),
+			Read:   schema.DefaultTimeout(6 * time.Minute),
+		},
+		ReadContext:   resourceFabricPrecisionTimeRead,
+		CreateContext: resourceFabricPrecisionTimeCreate,
+		UpdateContext: resourceFabricPrecisionTimeUpdate,
+		DeleteContext: resourceFabricPrecisionTimeDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+		Schema:        fabricPrecisionTimeResourceSchema(),
+		CustomizeDiff: validatePrecisionTimePackage,
+
+		Description: "Fabric V4 API compatible resource allows creation and management of Equinix Precision Time (EPT) services",
+	}
+}
+
+// validatePrecisionTimePackage rejects packages of another service type,
+// e.g. a PTP_STANDARD package for an NTP service.
+func validatePrecisionTimePackage(_ context.Context, d *schema.ResourceDiff, _ interface{}) error {
+	serviceType := d.Get("type").(string)
+	code := d.Get("package.0.code").(string)
+	if serviceType == "" || code == "" {
+		return nil
+	}
+	if !strings.HasPrefix(code, serviceType+"_") {
+		return fmt.Errorf("package %s can not be used with a %s service", code, serviceType)
+	}
+	if serviceType != "NTP" && len(d.Get("ntp_advanced_configuration").([]interface{})) > 0 {
+		return fmt.Errorf("ntp_advanced_configuration can only be set for NTP services")
+	}
+	return nil
+}
+
+func resourceFabricPrecisionTimeCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*config.Config).FabricClient
+	ctx = context.WithValue(ctx, v4.ContextAccessToken, meta.(*config.Config).FabricAuthToken)
+	createRequest := fabricPrecisionTimeToFabric(d)
+
+	ept, _, err := client.PrecisionTimeApi.CreateTimeServices(ctx, createRequest)
+	if err != nil {
+		return diag.FromErr(equinix_errors.FormatFabricError(err))
+	}
+	d.SetId(ept.Uuid)
+
+	if err = waitForPrecisionTimeState(ctx, d.Id(), meta, d.Timeout(schema.TimeoutCreate)); err != nil {
+		return diag.Errorf("error waiting for Precision Time service %s to be provisioned: %v", d.Id(), err)
+	}
+	return resourceFabricPrecisionTimeRead(ctx, d, meta)
+}
+
+func resourceFabricPrecisionTimeRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*config.Config).FabricClient
+	ctx = context.WithValue(ctx, v4.ContextAccessToken, meta.(*config.Config).FabricAuthToken)
+	ept, resp, err := client.PrecisionTimeApi.GetTimeServicesById(ctx, d.Id())
+	if err != nil {
+		if !d.IsNewResource() && equinix_errors.IsGone(resp, err) {
+			log.Printf("[WARN] Fabric Precision Time service %s not found, removing from state", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return diag.FromErr(equinix_errors.FormatFabricError(err))
+	}
+	if !d.IsNewResource() && ept.State == "DEPROVISIONED" {
+		log.Printf("[WARN] Fabric Precision Time service %s was deprovisioned, removing from state", d.Id())
+		d.SetId("")
+		return nil
+	}
+	d.SetId(ept.Uuid)
+	return setFabricPrecisionTimeMap(d, ept)
+}
+
+func resourceFabricPrecisionTimeUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*config.Config).FabricClient
+	ctx = context.WithValue(ctx, v4.ContextAccessToken, meta.(*config.Config).FabricAuthToken)
+	updates := getPrecisionTimeUpdateRequest(d)
+	if len(updates) == 0 {
+		return resourceFabricPrecisionTimeRead(ctx, d, meta)
+	}
+	if _, _, err := client.PrecisionTimeApi.UpdateTimeServicesById(ctx, updates, d.Id()); err != nil {
+		return diag.FromErr(equinix_errors.FormatFabricError(err))
+	}
+	if err := waitForPrecisionTimeState(ctx, d.Id(), meta, d.Timeout(schema.TimeoutUpdate)); err != nil {
+		return diag.Errorf("error waiting for Precision Time service %s to be updated: %v", d.Id(), err)
+	}
+	return resourceFabricPrecisionTimeRead(ctx, d, meta)
+}
+
+func resourceFabricPrecisionTimeDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*config.Config).FabricClient
+	ctx = context.WithValue(ctx, v4.ContextAccessToken, meta.(*config.Config).FabricAuthToken)
+	_, resp, err := client.PrecisionTimeApi.DeleteTimeServiceById(ctx, d.Id())
+	if err != nil {
+		if equinix_errors.IsGone(resp, err) {
+			return nil
+		}
+		return diag.FromErr(equinix_errors.FormatFabricError(err))
+	}
+
+	log.Printf("[DEBUG] Waiting for Precision Time service to be deprovisioned, uuid %s", d.Id())
+	stateConf := &retry.StateChangeConf{
+		Pending: []string{"DEPROVISIONING"},
+		Target:  []string{"DEPROVISIONED"},
+		Refresh: func() (interface{}, string, error) {
+			ept, resp, err := client.PrecisionTimeApi.GetTimeServicesById(ctx, d.Id())
+			if err != nil {
+				if equinix_errors.IsGone(resp, err) {
+					return ept, "DEPROVISIONED", nil
+				}
+				return "", "", equinix_errors.FormatFabricError(err)
+			}
+			return ept, ept.State, nil
+		},
+		Timeout:    d.Timeout(schema.TimeoutDelete),
+		MinTimeout: 10 * time.Second,
+	}
+	if _, err := stateConf.WaitForStateContext(ctx); err != nil {
+		return diag.Errorf("error waiting for Precision Time service %s to be deprovisioned: %v", d.Id(), err)
+	}
+	return nil
+}
+
+// waitForPrecisionTimeState waits for a Precision Time service to be
+// configured after a creation or an update.
+func waitForPrecisionTimeState(ctx context.Context, uuid string, meta interface{}, timeout time.Duration) error {
+	log.Printf("[DEBUG] Waiting for Precision Time service to be configured, uuid %s", uuid)
+	stateConf := &retry.StateChangeConf{
+		Pending: []string{"PROVISIONING", "CONFIGURING"},
+		Target:  []string{"PENDING_CONFIGURATION", "PROVISIONED", "ACTIVE"},
+		Refresh: func() (interface{}, string, error) {
+			client := meta.(*config.Config).FabricClient
+			ept, _, err := client.PrecisionTimeApi.GetTimeServicesById(ctx, uuid)
+			if err != nil {
+				return "", "", equinix_errors.FormatFabricError(err)
+			}
+			return ept, ept.State, nil
+		},
+		Timeout:    timeout,
+		MinTimeout: 10 * time.Second,
+	}
+	_, err := stateConf.WaitForStateContext(ctx)
+	return err
+}
+
+func fabricPrecisionTimeToFabric(d *schema.ResourceData) v4.PrecisionTimeServiceRequest {
+	request := v4.PrecisionTimeServiceRequest{
+		Type_:       d.Get("type").(string),
+		Name:        d.Get("name").(string),
+		Description: d.Get("description").(string),
+		Package_:    &v4.PackageRequest{Code: d.Get("package.0.code").(string)},
+		Connections: precisionTimeConnectionsToFabric(d.Get("connections").([]interface{})),
+		Ipv4:        precisionTimeIpv4ToFabric(d.Get("ipv4").([]interface{})),
+	}
+	advanced := &v4.AdvanceConfiguration{
+		Ntp: precisionTimeNtpToFabric(d.Get("ntp_advanced_configuration").([]interface{})),
+		Ptp: precisionTimePtpToFabric(d.Get("ptp_advanced_configuration").([]interface{})),
+	}
+	if advanced.Ntp != nil || advanced.Ptp != nil {
+		request.AdvanceConfiguration = advanced
+	}
+	if project := equinix_fabric_schema.ProjectToFabric(d.Get("project").(*schema.Set).List()); project.ProjectId != "" {
+		request.Project = &project
+	}
+	return request
+}
+
+func precisionTimeConnectionsToFabric(connections []interface{}) []v4.FabricConnectionUuid {
+	mapped := make([]v4.FabricConnectionUuid, 0, len(connections))
+	for _, c := range connections {
+		mapped = append(mapped, v4.FabricConnectionUuid{Uuid: c.(map[string]interface{})["uuid"].(string)})
+	}
+	return mapped
+}
+
+func precisionTimeConnectionsToTerra(connections []v4.FabricConnectionUuid) []interface{} {
+	mapped := make([]interface{}, 0, len(connections))
+	for _, c := range connections {
+		mapped = append(mapped, map[string]interface{}{
+			"uuid": c.Uuid,
+			"href": c.Href,
+			"type": c.Type_,
+		})
+	}
+	return mapped
+}
+
+func precisionTimeIpv4ToFabric(ipv4 []interface{}) *v4.Ipv4 {
+	if len(ipv4) == 0 || ipv4[0] == nil {
+		return nil
+	}
+	ipv4Map := ipv4[0].(map[string]interface{})
+	return &v4.Ipv4{
+		Primary:        ipv4Map["primary"].(string),
+		Secondary:      ipv4Map["secondary"].(string),
+		NetworkMask:    ipv4Map["network_mask"].(string),
+		DefaultGateway: ipv4Map["default_gateway"].(string),
+	}
+}
+
+func precisionTimeIpv4ToTerra(ipv4 *v4.Ipv4) []interface{} {
+	if ipv4 == nil {
+		return nil
+	}
+	return []interface{}{
+		map[string]interface{}{
+			"primary":         ipv4.Primary,
+			"secondary":       ipv4.Secondary,
+			"network_mask":    ipv4.NetworkMask,
+			"default_gateway": ipv4.DefaultGateway,
+		},
+	}
+}
+
+func precisionTimeNtpToFabric(keys []interface{}) *[]v4.Md5 {
+	if len(keys) == 0 {
+		return nil
+	}
+	mapped := make([]v4.Md5, 0, len(keys))
+	for _, k := range keys {
+		keyMap := k.(map[string]interface{})
+		mapped = append(mapped, v4.Md5{
+			Type_:    keyMap["type"].(string),
+			Id:       keyMap["key_number"].(string),
+			Password: keyMap["key"].(string),
+		})
+	}
+	return &mapped
+}
+
+// precisionTimeNtpToTerra maps the NTP MD5 keys. The API doesn't return the
+// keys themselves, so they are kept from the configuration.
+func precisionTimeNtpToTerra(keys *[]v4.Md5, configured []interface{}) []interface{} {
+	if keys == nil {
+		return nil
+	}
+	configuredKeys := map[string]string{}
+	for _, k := range configured {
+		keyMap := k.(map[string]interface{})
+		configuredKeys[keyMap["key_number"].(string)] = keyMap["key"].(string)
+	}
+	mapped := make([]interface{}, 0, len(*keys))
+	for _, k := range *keys {
+		key := k.Password
+		if key == "" {
+			key = configuredKeys[k.Id]
+		}
+		mapped = append(mapped, map[string]interface{}{
+			"type":       k.Type_,
+			"key_number": k.Id,
+			"key":        key,
+		})
+	}
+	return mapped
+}
+
+func precisionTimePtpToFabric(ptp []interface{}) *v4.PtpAdvanceConfiguration {
+	if len(ptp) == 0 || ptp[0] == nil {
+		return nil
+	}
+	ptpMap := ptp[0].(map[string]interface{})
+	return &v4.PtpAdvanceConfiguration{
+		TimeScale:           ptpMap["time_scale"].(string),
+		Domain:              int32(ptpMap["domain"].(int)),
+		Priority1:           int32(ptpMap["priority_1"].(int)),
+		Priority2:           int32(ptpMap["priority_2"].(int)),
+		LogAnnounceInterval: int32(ptpMap["log_announce_interval"].(int)),
+		LogSyncInterval:     int32(ptpMap["log_sync_interval"].(int)),
+		LogDelayReqInterval: int32(ptpMap["log_delay_req_interval"].(int)),
+		TransportMode:       ptpMap["transport_mode"].(string),
+		GrantTime:           int32(ptpMap["grant_time"].(int)),
+	}
+}
+
+func precisionTimePtpToTerra(ptp *v4.PtpAdvanceConfiguration) []interface{} {
+	if ptp == nil {
+		return nil
+	}
+	return []interface{}{
+		map[string]interface{}{
+			"time_scale":             ptp.TimeScale,
+			"domain":                 int(ptp.Domain),
+			"priority_1":             int(ptp.Priority1),
+			"priority_2":             int(ptp.Priority2),
+			"log_announce_interval":  int(ptp.LogAnnounceInterval),
+			"log_sync_interval":      int(ptp.LogSyncInterval),
+			"log_delay_req_interval": int(ptp.LogDelayReqInterval),
+			"transport_mode":         ptp.TransportMode,
+			"grant_time":             int(ptp.GrantTime),
+		},
+	}
+}
+
+func setFabricPrecisionTimeMap(d *schema.ResourceData, ept v4.PrecisionTimeServiceCreateResponse) diag.Diagnostics {
+	diags := diag.Diagnostics{}
+	serviceMap := map[string]interface{}{
+		"type":        ept.Type_,
+		"href":        ept.Href,
+		"uuid":        ept.Uuid,
+		"name":        ept.Name,
+		"description": ept.Description,
+		"state":       ept.State,
+		"connections": precisionTimeConnectionsToTerra(ept.Connections),
+		"ipv4":        precisionTimeIpv4ToTerra(ept.Ipv4),
+		"project":     equinix_fabric_schema.ProjectToTerra(ept.Project),
+		"account":     equinix_fabric_schema.AccountToTerra(ept.Account),
+	}
+	if ept.AdvanceConfiguration != nil {
+		serviceMap["ntp_advanced_configuration"] = precisionTimeNtpToTerra(ept.AdvanceConfiguration.Ntp, d.Get("ntp_advanced_configuration").([]interface{}))
+		serviceMap["ptp_advanced_configuration"] = precisionTimePtpToTerra(ept.AdvanceConfiguration.Ptp)
+	}
+	// The package code isn't decoded from the response, it is kept from the
+	// configuration
+	if err := equinix_schema.SetMap(d, serviceMap); err != nil {
+		return diag.FromErr(err)
+	}
+	return diags
+}
+
+func getPrecisionTimeUpdateRequest(d *schema.ResourceData) []v4.PrecisionTimeChangeOperation {
+	var changeOps []v4.PrecisionTimeChangeOperation
+	replace := func(path string, value interface{}) {
+		changeOps = append(changeOps, v4.PrecisionTimeChangeOperation{Op: "replace", Path: path, Value: &value})
+	}
+	if d.HasChange("name") {
+		replace("/name", d.Get("name").(string))
+	}
+	if d.HasChange("description") {
+		replace("/description", d.Get("description").(string))
+	}
+	if d.HasChange("package.0.code") {
+		replace("/package/code", d.Get("package.0.code").(string))
+	}
+	if d.HasChange("ipv4") {
+		replace("/ipv4", precisionTimeIpv4ToFabric(d.Get("ipv4").([]interface{})))
+	}
+	if d.HasChange("ntp_advanced_configuration") {
+		ntp := precisionTimeNtpToFabric(d.Get("ntp_advanced_configuration").([]interface{}))
+		if ntp == nil {
+			ntp = &[]v4.Md5{}
+		}
+		replace("/advanceConfiguration/ntp", ntp)
+	}
+	if d.HasChange("ptp_advanced_configuration") {
+		if ptp := precisionTimePtpToFabric(d.Get("ptp_advanced_configuration").([]interface{})); ptp != nil {
+			replace("/advanceConfiguration/ptp", ptp)
+		}
+	}
+	return changeOps
+}
@@ -0,0 +1,85 @@
+package equinix
+
+import (
+	"context"
+	"testing"
+
+	v4 "github.com/equinix-labs/fabric-go/fabric/v4"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testFabricPrecisionTimeConfig() map[string]interface{} {
+	return map[string]interface{}{
+		"type": "PTP",
+		"name": "tf-ept",
+		"package": []interface{}{
+			map[string]interface{}{"code": "PTP_STANDARD"},
+		},
+		"connections": []interface{}{
+			map[string]interface{}{"uuid": "8a8d4a4f-1c43-4cd5-9e3d-5e0c5c6b7a11"},
+		},
+		"ipv4": []interface{}{
+			map[string]interface{}{
+				"primary":      "192.168.254.241",
+				"secondary":    "192.168.254.242",
+				"network_mask": "255.255.255.240",
+			},
+		},
+		"ptp_advanced_configuration": []interface{}{
+			map[string]interface{}{
+				"time_scale":     "PTP",
+				"domain":         127,
+				"transport_mode": "Unicast",
+				"grant_time":     300,
+			},
+		},
+	}
+}
+
+func TestFabricPrecisionTime_create(t *testing.T) {
+	// given
+	c := newFakeAPIConfig(t)
+	d := schema.TestResourceDataRaw(t, resourceFabricPrecisionTime().Schema, testFabricPrecisionTimeConfig())
+	// when
+	diags := resourceFabricPrecisionTimeCreate(context.Background(), d, c)
+	// then
+	require.False(t, diags.HasError(), "Create does not fail: %v", diags)
+	assert.NotEmpty(t, d.Id(), "Precision Time service ID is set")
+	assert.Equal(t, "PROVISIONED", d.Get("state"), "Precision Time service state is read")
+	assert.Equal(t, "PTP_STANDARD", d.Get("package.0.code"), "Package is kept from the configuration")
+	assert.Equal(t, "8a8d4a4f-1c43-4cd5-9e3d-5e0c5c6b7a11", d.Get("connections.0.uuid"))
+	assert.Equal(t, "255.255.255.240", d.Get("ipv4.0.network_mask"))
+	assert.Equal(t, 127, d.Get("ptp_advanced_configuration.0.domain"))
+	assert.Equal(t, "Unicast", d.Get("ptp_advanced_configuration.0.transport_mode"))
+}
+
+func TestFabricPrecisionTime_delete(t *testing.T) {
+	// given
+	c := newFakeAPIConfig(t)
+	d := schema.TestResourceDataRaw(t, resourceFabricPrecisionTime().Schema, testFabricPrecisionTimeConfig())
+	require.False(t, resourceFabricPrecisionTimeCreate(context.Background(), d, c).HasError())
+	state := d.State()
+	// when
+	diags := resourceFabricPrecisionTimeDelete(context.Background(), d, c)
+	refreshed := resourceFabricPrecisionTime().Data(state)
+	readDiags := resourceFabricPrecisionTimeRead(context.Background(), refreshed, c)
+	// then
+	assert.False(t, diags.HasError(), "Delete does not fail: %v", diags)
+	assert.False(t, readDiags.HasError(), "Read of a deprovisioned service does not fail")
+	assert.Empty(t, refreshed.Id(), "Deprovisioned service is removed from state")
+}
+
+func TestFabricPrecisionTime_ntpToTerra(t *testing.T) {
+	// given
+	keys := &[]v4.Md5{{Type_: "ASCII", Id: "1"}, {Type_: "HEX", Id: "2", Password: "returned"}}
+	configured := []interface{}{map[string]interface{}{"type": "ASCII", "key_number": "1", "key": "secret"}}
+	// when
+	result := precisionTimeNtpToTerra(keys, configured)
+	// then
+	require.Len(t, result, 2)
+	assert.Equal(t, "secret", result[0].(map[string]interface{})["key"], "Key missing from the response is kept from the configuration")
+	assert.Equal(t, "returned", result[1].(map[string]interface{})["key"])
+	assert.Nil(t, precisionTimeNtpToTerra(nil, configured))
+}
@@ -4,10 +4,12 @@ import (
 	"context"
 	"fmt"
 	"log"
+	"net/http"
 	"strconv"
 	"strings"
 	"time"
 
+	equinix_diagnostics "github.com/equinix/terraform-provider-equinix/internal/diagnostics"
 	equinix_errors "github.com/equinix/terraform-provider-equinix/internal/errors"
 	equinix_fabric_schema "github.com/equinix/terraform-provider-equinix/internal/fabric/schema"
 	equinix_schema "github.com/equinix/terraform-provider-equinix/internal/schema"
@@ -46,7 +48,8 @@ func resourceFabricRoutingProtocol() *schema.Resource {
 				return []*schema.ResourceData{d}, nil
 			},
 		},
-		Schema: createFabricRoutingProtocolResourceSchema(),
+		Schema:        createFabricRoutingProtocolResourceSchema(),
+		CustomizeDiff: validateRoutingProtocolPrefixLimits,
 
 		Description: "Fabric V4 API compatible resource allows creation and management of Equinix Fabric connection",
 	}
@@ -56,13 +59,14 @@ func resourceFabricRoutingProtocolRead(ctx context.Context, d *schema.ResourceDa
 	client := meta.(*config.Config).FabricClient
 	ctx = context.WithValue(ctx, v4.ContextAccessToken, meta.(*config.Config).FabricAuthToken)
 	log.Printf("[WARN] Routing Protocol Connection uuid: %s", d.Get("connection_uuid").(string))
-	fabricRoutingProtocol, _, err := client.RoutingProtocolsApi.GetConnectionRoutingProtocolByUuid(ctx, d.Id(), d.Get("connection_uuid").(string))
+	fabricRoutingProtocol, resp, err := client.RoutingProtocolsApi.GetConnectionRoutingProtocolByUuid(ctx, d.Id(), d.Get("connection_uuid").(string))
 	if err != nil {
-		log.Printf("[WARN] Routing Protocol %s not found , error %s", d.Id(), err)
-		if !strings.Contains(err.Error(), "500") {
+		if !d.IsNewResource() && equinix_errors.IsGone(resp, err) {
+			log.Printf("[WARN] Routing Protocol %s not found, removing from state", d.Id())
 			d.SetId("")
+			return nil
 		}
-		return diag.FromErr(equinix_errors.FormatFabricError(err))
+		return equinix_diagnostics.SDKError(fmt.Sprintf("reading Fabric routing protocol %s", d.Id()), "", resp, err)
 	}
 	switch fabricRoutingProtocol.Type_ {
 	case "BGP":
@@ -78,15 +82,30 @@ func resourceFabricRoutingProtocolCreate(ctx context.Context, d *schema.Resource
 	client := meta.(*config.Config).FabricClient
 	ctx = context.WithValue(ctx, v4.ContextAccessToken, meta.(*config.Config).FabricAuthToken)
 	schemaBgpIpv4 := d.Get("bgp_ipv4").(*schema.Set).List()
-	bgpIpv4 := routingProtocolBgpIpv4ToFabric(schemaBgpIpv4)
+	bgpIpv4, err := routingProtocolBgpIpv4ToFabric(schemaBgpIpv4)
+	if err != nil {
+		return diag.FromErr(err)
+	}
 	schemaBgpIpv6 := d.Get("bgp_ipv6").(*schema.Set).List()
-	bgpIpv6 := routingProtocolBgpIpv6ToFabric(schemaBgpIpv6)
+	bgpIpv6, err := routingProtocolBgpIpv6ToFabric(schemaBgpIpv6)
+	if err != nil {
+		return diag.FromErr(err)
+	}
 	schemaDirectIpv4 := d.Get("direct_ipv4").(*schema.Set).List()
-	directIpv4 := routingProtocolDirectIpv4ToFabric(schemaDirectIpv4)
+	directIpv4, err := routingProtocolDirectIpv4ToFabric(schemaDirectIpv4)
+	if err != nil {
+		return diag.FromErr(err)
+	}
 	schemaDirectIpv6 := d.Get("direct_ipv6").(*schema.Set).List()
-	directIpv6 := routingProtocolDirectIpv6ToFabric(schemaDirectIpv6)
+	directIpv6, err := routingProtocolDirectIpv6ToFabric(schemaDirectIpv6)
+	if err != nil {
+		return diag.FromErr(err)
+	}
 	schemaBfd := d.Get("bfd").(*schema.Set).List()
-	bfd := routingProtocolBfdToFabric(schemaBfd)
+	bfd, err := routingProtocolBfdToFabric(schemaBfd)
+	if err != nil {
+		return diag.FromErr(err)
+	}
 	bgpAuthKey := d.Get("bgp_auth_key")
 	if bgpAuthKey == nil {
 		bgpAuthKey = ""
@@ -94,6 +113,20 @@ func resourceFabricRoutingProtocolCreate(ctx context.Context, d *schema.Resource
 
 	createRequest := v4.RoutingProtocolBase{}
 	if d.Get("type").(string) == "BGP" {
+		router, err := getConnectionCloudRouter(ctx, client, d.Get("connection_uuid").(string))
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		equinixAsn := int64(d.Get("equinix_asn").(int))
+		if router != nil {
+			ipv4Count := d.Get("ipv4_advertised_prefix_count").(int)
+			ipv6Count := d.Get("ipv6_advertised_prefix_count").(int)
+			if err := checkCloudRouterPrefixLimits(ctx, client, *router, ipv4Count, ipv6Count); err != nil {
+				return diag.FromErr(err)
+			}
+			// The Equinix side ASN of a Cloud Router connection is the ASN of the router
+			equinixAsn = router.EquinixAsn
+		}
 		createRequest = v4.RoutingProtocolBase{
 			Type_: d.Get("type").(string),
 			OneOfRoutingProtocolBase: v4.OneOfRoutingProtocolBase{
@@ -103,7 +136,7 @@ func resourceFabricRoutingProtocolCreate(ctx context.Context, d *schema.Resource
 					BgpIpv4:     &bgpIpv4,
 					BgpIpv6:     &bgpIpv6,
 					CustomerAsn: int64(d.Get("customer_asn").(int)),
-					EquinixAsn:  int64(d.Get("equinix_asn").(int)),
+					EquinixAsn:  equinixAsn,
 					BgpAuthKey:  bgpAuthKey.(string),
 					Bfd:         &bfd,
 				},
@@ -138,9 +171,9 @@ func resourceFabricRoutingProtocolCreate(ctx context.Context, d *schema.Resource
 			createRequest.DirectIpv6 = nil
 		}
 	}
-	fabricRoutingProtocol, _, err := client.RoutingProtocolsApi.CreateConnectionRoutingProtocol(ctx, createRequest, d.Get("connection_uuid").(string))
+	fabricRoutingProtocol, resp, err := client.RoutingProtocolsApi.CreateConnectionRoutingProtocol(ctx, createRequest, d.Get("connection_uuid").(string))
 	if err != nil {
-		return diag.FromErr(equinix_errors.FormatFabricError(err))
+		return equinix_diagnostics.SDKError("creating Fabric routing protocol", fabricErrorAttributePath(createFabricRoutingProtocolResourceSchema(), err), resp, err)
 	}
 
 	switch fabricRoutingProtocol.Type_ {
@@ -150,7 +183,7 @@ func resourceFabricRoutingProtocolCreate(ctx context.Context, d *schema.Resource
 		d.SetId(fabricRoutingProtocol.RoutingProtocolDirectData.Uuid)
 	}
 
-	if _, err = waitUntilRoutingProtocolIsProvisioned(d.Id(), d.Get("connection_uuid").(string), meta, ctx); err != nil {
+	if _, err = waitUntilRoutingProtocolIsProvisioned(d.Id(), d.Get("connection_uuid").(string), meta, ctx, d.Timeout(schema.TimeoutCreate)); err != nil {
 		return diag.Errorf("error waiting for RP (%s) to be created: %s", d.Id(), err)
 	}
 
@@ -162,15 +195,30 @@ func resourceFabricRoutingProtocolUpdate(ctx context.Context, d *schema.Resource
 	ctx = context.WithValue(ctx, v4.ContextAccessToken, meta.(*config.Config).FabricAuthToken)
 
 	schemaBgpIpv4 := d.Get("bgp_ipv4").(*schema.Set).List()
-	bgpIpv4 := routingProtocolBgpIpv4ToFabric(schemaBgpIpv4)
+	bgpIpv4, err := routingProtocolBgpIpv4ToFabric(schemaBgpIpv4)
+	if err != nil {
+		return diag.FromErr(err)
+	}
 	schemaBgpIpv6 := d.Get("bgp_ipv6").(*schema.Set).List()
-	bgpIpv6 := routingProtocolBgpIpv6ToFabric(schemaBgpIpv6)
+	bgpIpv6, err := routingProtocolBgpIpv6ToFabric(schemaBgpIpv6)
+	if err != nil {
+		return diag.FromErr(err)
+	}
 	schemaDirectIpv4 := d.Get("direct_ipv4").(*schema.Set).List()
-	directIpv4 := routingProtocolDirectIpv4ToFabric(schemaDirectIpv4)
+	directIpv4, err := routingProtocolDirectIpv4ToFabric(schemaDirectIpv4)
+	if err != nil {
+		return diag.FromErr(err)
+	}
 	schemaDirectIpv6 := d.Get("direct_ipv6").(*schema.Set).List()
-	directIpv6 := routingProtocolDirectIpv6ToFabric(schemaDirectIpv6)
+	directIpv6, err := routingProtocolDirectIpv6ToFabric(schemaDirectIpv6)
+	if err != nil {
+		return diag.FromErr(err)
+	}
 	schemaBfd := d.Get("bfd").(*schema.Set).List()
-	bfd := routingProtocolBfdToFabric(schemaBfd)
+	bfd, err := routingProtocolBfdToFabric(schemaBfd)
+	if err != nil {
+		return diag.FromErr(err)
+	}
 	bgpAuthKey := d.Get("bgp_auth_key")
 	if bgpAuthKey == nil {
 		bgpAuthKey = ""
@@ -220,9 +268,15 @@ func resourceFabricRoutingProtocolUpdate(ctx context.Context, d *schema.Resource
 		}
 	}
 
-	updatedRpResp, _, err := client.RoutingProtocolsApi.ReplaceConnectionRoutingProtocolByUuid(ctx, updateRequest, d.Id(), d.Get("connection_uuid").(string))
+	var updatedRpResp v4.RoutingProtocolData
+	var resp *http.Response
+	if patch, ok := getRoutingProtocolPatchRequest(d); ok {
+		updatedRpResp, resp, err = client.RoutingProtocolsApi.PatchConnectionRoutingProtocolByUuid(ctx, patch, d.Id(), d.Get("connection_uuid").(string))
+	} else {
+		updatedRpResp, resp, err = client.RoutingProtocolsApi.ReplaceConnectionRoutingProtocolByUuid(ctx, updateRequest, d.Id(), d.Get("connection_uuid").(string))
+	}
 	if err != nil {
-		return diag.FromErr(equinix_errors.FormatFabricError(err))
+		return equinix_diagnostics.SDKError(fmt.Sprintf("updating Fabric routing protocol %s", d.Id()), fabricErrorAttributePath(createFabricRoutingProtocolResourceSchema(), err), resp, err)
 	}
 
 	var changeUuid string
@@ -234,14 +288,14 @@ func resourceFabricRoutingProtocolUpdate(ctx context.Context, d *schema.Resource
 		changeUuid = updatedRpResp.RoutingProtocolDirectData.Change.Uuid
 		d.SetId(updatedRpResp.RoutingProtocolDirectData.Uuid)
 	}
-	_, err = waitForRoutingProtocolUpdateCompletion(changeUuid, d.Id(), d.Get("connection_uuid").(string), meta, ctx)
+	_, err = waitForRoutingProtocolUpdateCompletion(changeUuid, d.Id(), d.Get("connection_uuid").(string), meta, ctx, d.Timeout(schema.TimeoutUpdate))
 	if err != nil {
 		if !strings.Contains(err.Error(), "500") {
 			d.SetId("")
 		}
 		return diag.FromErr(fmt.Errorf("timeout updating routing protocol: %v", err))
 	}
-	updatedProvisionedRpResp, err := waitUntilRoutingProtocolIsProvisioned(d.Id(), d.Get("connection_uuid").(string), meta, ctx)
+	updatedProvisionedRpResp, err := waitUntilRoutingProtocolIsProvisioned(d.Id(), d.Get("connection_uuid").(string), meta, ctx, d.Timeout(schema.TimeoutUpdate))
 	if err != nil {
 		return diag.Errorf("error waiting for RP (%s) to be replace updated: %s", d.Id(), err)
 	}
@@ -249,11 +303,91 @@ func resourceFabricRoutingProtocolUpdate(ctx context.Context, d *schema.Resource
 	return setFabricRoutingProtocolMap(d, updatedProvisionedRpResp)
 }
 
+// getRoutingProtocolPatchRequest returns the PATCH operations of a BGP routing
+// protocol update that only enables or disables the address families or rotates
+// the authorization key, which don't need the routing protocol to be replaced.
+func getRoutingProtocolPatchRequest(d *schema.ResourceData) ([]v4.ConnectionChangeOperation, bool) {
+	if d.Get("type").(string) != "BGP" || d.HasChangesExcept("bgp_ipv4", "bgp_ipv6", "bgp_auth_key") {
+		return nil, false
+	}
+	oldIpv4, newIpv4 := d.GetChange("bgp_ipv4")
+	oldIpv6, newIpv6 := d.GetChange("bgp_ipv6")
+	oldAuthKey, newAuthKey := d.GetChange("bgp_auth_key")
+	oldBgp, err := routingProtocolBgpTypeToFabric(oldIpv4.(*schema.Set).List(), oldIpv6.(*schema.Set).List(), oldAuthKey.(string))
+	if err != nil {
+		return nil, false
+	}
+	newBgp, err := routingProtocolBgpTypeToFabric(newIpv4.(*schema.Set).List(), newIpv6.(*schema.Set).List(), newAuthKey.(string))
+	if err != nil {
+		return nil, false
+	}
+	return routingProtocolBgpPatchOperations(oldBgp, newBgp)
+}
+
+// routingProtocolBgpTypeToFabric returns the address families and the
+// authorization key of a BGP routing protocol, the parts of it that can be
+// changed with a PATCH.
+func routingProtocolBgpTypeToFabric(bgpIpv4Request, bgpIpv6Request []interface{}, bgpAuthKey string) (v4.RoutingProtocolBgpType, error) {
+	bgpIpv4, err := routingProtocolBgpIpv4ToFabric(bgpIpv4Request)
+	if err != nil {
+		return v4.RoutingProtocolBgpType{}, err
+	}
+	bgpIpv6, err := routingProtocolBgpIpv6ToFabric(bgpIpv6Request)
+	if err != nil {
+		return v4.RoutingProtocolBgpType{}, err
+	}
+	return v4.RoutingProtocolBgpType{
+		BgpIpv4:    bgpIpv4OrNil(bgpIpv4),
+		BgpIpv6:    bgpIpv6OrNil(bgpIpv6),
+		BgpAuthKey: bgpAuthKey,
+	}, nil
+}
+
+// routingProtocolBgpPatchOperations compares the address families and the
+// authorization key of two BGP routing protocols. It returns the operations
+// turning the old one into the new one, or false if the change can't be made
+// with a PATCH, e.g. when a peering IP changes or an address family is added.
+func routingProtocolBgpPatchOperations(old, new v4.RoutingProtocolBgpType) ([]v4.ConnectionChangeOperation, bool) {
+	var ops []v4.ConnectionChangeOperation
+	switch {
+	case old.BgpIpv4 == nil && new.BgpIpv4 == nil:
+	case old.BgpIpv4 == nil || new.BgpIpv4 == nil || old.BgpIpv4.CustomerPeerIp != new.BgpIpv4.CustomerPeerIp:
+		return nil, false
+	case old.BgpIpv4.Enabled != new.BgpIpv4.Enabled:
+		ops = append(ops, v4.ConnectionChangeOperation{Op: "replace", Path: "/bgpIpv4/enabled", Value: new.BgpIpv4.Enabled})
+	}
+	switch {
+	case old.BgpIpv6 == nil && new.BgpIpv6 == nil:
+	case old.BgpIpv6 == nil || new.BgpIpv6 == nil || old.BgpIpv6.CustomerPeerIp != new.BgpIpv6.CustomerPeerIp:
+		return nil, false
+	case old.BgpIpv6.Enabled != new.BgpIpv6.Enabled:
+		ops = append(ops, v4.ConnectionChangeOperation{Op: "replace", Path: "/bgpIpv6/enabled", Value: new.BgpIpv6.Enabled})
+	}
+	if old.BgpAuthKey != new.BgpAuthKey {
+		ops = append(ops, v4.ConnectionChangeOperation{Op: "replace", Path: "/bgpAuthKey", Value: new.BgpAuthKey})
+	}
+	return ops, len(ops) > 0
+}
+
+func bgpIpv4OrNil(bgpIpv4 v4.BgpConnectionIpv4) *v4.BgpConnectionIpv4 {
+	if bgpIpv4.CustomerPeerIp == "" {
+		return nil
+	}
+	return &bgpIpv4
+}
+
+func bgpIpv6OrNil(bgpIpv6 v4.BgpConnectionIpv6) *v4.BgpConnectionIpv6 {
+	if bgpIpv6.CustomerPeerIp == "" {
+		return nil
+	}
+	return &bgpIpv6
+}
+
 func resourceFabricRoutingProtocolDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	diags := diag.Diagnostics{}
 	client := meta.(*config.Config).FabricClient
 	ctx = context.WithValue(ctx, v4.ContextAccessToken, meta.(*config.Config).FabricAuthToken)
-	_, _, err := client.RoutingProtocolsApi.DeleteConnectionRoutingProtocolByUuid(ctx, d.Id(), d.Get("connection_uuid").(string))
+	_, resp, err := client.RoutingProtocolsApi.DeleteConnectionRoutingProtocolByUuid(ctx, d.Id(), d.Get("connection_uuid").(string))
 	if err != nil {
 		errors, ok := err.(v4.GenericSwaggerError).Model().([]v4.ModelError)
 		if ok {
@@ -262,10 +396,10 @@ func resourceFabricRoutingProtocolDelete(ctx context.Context, d *schema.Resource
 				return diags
 			}
 		}
-		return diag.FromErr(equinix_errors.FormatFabricError(err))
+		return equinix_diagnostics.SDKError(fmt.Sprintf("deleting Fabric routing protocol %s", d.Id()), "", resp, err)
 	}
 
-	err = WaitUntilRoutingProtocolIsDeprovisioned(d.Id(), d.Get("connection_uuid").(string), meta, ctx)
+	err = WaitUntilRoutingProtocolIsDeprovisioned(d.Id(), d.Get("connection_uuid").(string), meta, ctx, d.Timeout(schema.TimeoutDelete))
 	if err != nil {
 		return diag.FromErr(fmt.Errorf("API call failed while waiting for resource deletion. Error %v", err))
 	}
@@ -275,11 +409,19 @@ func resourceFabricRoutingProtocolDelete(ctx context.Context, d *schema.Resource
 
 func setFabricRoutingProtocolMap(d *schema.ResourceData, rp v4.RoutingProtocolData) diag.Diagnostics {
 	diags := diag.Diagnostics{}
+	err := equinix_schema.SetMap(d, fabricRoutingProtocolToTerra(rp))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	return diags
+}
 
-	err := error(nil)
+func fabricRoutingProtocolToTerra(rp v4.RoutingProtocolData) map[string]interface{} {
 	if rp.Type_ == "BGP" {
-		err = equinix_schema.SetMap(d, map[string]interface{}{
+		return map[string]interface{}{
 			"name":         rp.RoutingProtocolBgpData.Name,
+			"uuid":         rp.RoutingProtocolBgpData.Uuid,
 			"href":         rp.RoutingProtocolBgpData.Href,
 			"type":         rp.RoutingProtocolBgpData.Type_,
 			"state":        rp.RoutingProtocolBgpData.State,
@@ -292,10 +434,11 @@ func setFabricRoutingProtocolMap(d *schema.ResourceData, rp v4.RoutingProtocolDa
 			"bgp_auth_key": rp.BgpAuthKey,
 			"change":       routingProtocolChangeToTerra(rp.RoutingProtocolBgpData.Change),
 			"change_log":   equinix_fabric_schema.ChangeLogToTerra(rp.RoutingProtocolBgpData.Changelog),
-		})
+		}
 	} else if rp.Type_ == "DIRECT" {
-		err = equinix_schema.SetMap(d, map[string]interface{}{
+		return map[string]interface{}{
 			"name":        rp.RoutingProtocolDirectData.Name,
+			"uuid":        rp.RoutingProtocolDirectData.Uuid,
 			"href":        rp.RoutingProtocolDirectData.Href,
 			"type":        rp.RoutingProtocolDirectData.Type_,
 			"state":       rp.RoutingProtocolDirectData.State,
@@ -304,16 +447,12 @@ func setFabricRoutingProtocolMap(d *schema.ResourceData, rp v4.RoutingProtocolDa
 			"direct_ipv6": routingProtocolDirectConnectionIpv6ToTerra(rp.DirectIpv6),
 			"change":      routingProtocolChangeToTerra(rp.RoutingProtocolDirectData.Change),
 			"change_log":  equinix_fabric_schema.ChangeLogToTerra(rp.RoutingProtocolDirectData.Changelog),
-		})
-	}
-	if err != nil {
-		return diag.FromErr(err)
+		}
 	}
-
-	return diags
+	return map[string]interface{}{}
 }
 
-func waitUntilRoutingProtocolIsProvisioned(uuid string, connUuid string, meta interface{}, ctx context.Context) (v4.RoutingProtocolData, error) {
+func waitUntilRoutingProtocolIsProvisioned(uuid string, connUuid string, meta interface{}, ctx context.Context, timeout time.Duration) (v4.RoutingProtocolData, error) {
 	log.Printf("Waiting for routing protocol to be provisioned, uuid %s", uuid)
 	stateConf := &retry.StateChangeConf{
 		Pending: []string{
@@ -337,7 +476,7 @@ func waitUntilRoutingProtocolIsProvisioned(uuid string, connUuid string, meta in
 			}
 			return dbConn, state, nil
 		},
-		Timeout:    5 * time.Minute,
+		Timeout:    timeout,
 		Delay:      30 * time.Second,
 		MinTimeout: 30 * time.Second,
 	}
@@ -352,7 +491,7 @@ func waitUntilRoutingProtocolIsProvisioned(uuid string, connUuid string, meta in
 	return dbConn, err
 }
 
-func WaitUntilRoutingProtocolIsDeprovisioned(uuid string, connUuid string, meta interface{}, ctx context.Context) error {
+func WaitUntilRoutingProtocolIsDeprovisioned(uuid string, connUuid string, meta interface{}, ctx context.Context, timeout time.Duration) error {
 	log.Printf("Waiting for routing protocol to be deprovisioned, uuid %s", uuid)
 
 	/* check if resource is not found */
@@ -368,7 +507,7 @@ func WaitUntilRoutingProtocolIsDeprovisioned(uuid string, connUuid string, meta
 			return dbConn, strconv.Itoa(resp.StatusCode), nil
 
 		},
-		Timeout:    5 * time.Minute,
+		Timeout:    timeout,
 		Delay:      30 * time.Second,
 		MinTimeout: 30 * time.Second,
 	}
@@ -377,7 +516,7 @@ func WaitUntilRoutingProtocolIsDeprovisioned(uuid string, connUuid string, meta
 	return err
 }
 
-func waitForRoutingProtocolUpdateCompletion(rpChangeUuid string, uuid string, connUuid string, meta interface{}, ctx context.Context) (v4.RoutingProtocolChangeData, error) {
+func waitForRoutingProtocolUpdateCompletion(rpChangeUuid string, uuid string, connUuid string, meta interface{}, ctx context.Context, timeout time.Duration) (v4.RoutingProtocolChangeData, error) {
 	log.Printf("Waiting for routing protocol update to complete, uuid %s", uuid)
 	stateConf := &retry.StateChangeConf{
 		Target: []string{"COMPLETED"},
@@ -393,7 +532,7 @@ func waitForRoutingProtocolUpdateCompletion(rpChangeUuid string, uuid string, co
 			}
 			return dbConn, updatableState, nil
 		},
-		Timeout:    2 * time.Minute,
+		Timeout:    timeout,
 		Delay:      30 * time.Second,
 		MinTimeout: 30 * time.Second,
 	}
@@ -406,3 +545,165 @@ func waitForRoutingProtocolUpdateCompletion(rpChangeUuid string, uuid string, co
 	}
 	return dbConn, err
 }
+
+// validateRoutingProtocolPrefixLimits checks the advertised prefix counts of a new BGP routing
+// protocol against the package limits of the Fabric Cloud Router while planning, once the
+// connection and the counts are known. Routers or packages that can't be read are left to the
+// check done on creation.
+func validateRoutingProtocolPrefixLimits(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	if d.Id() != "" || d.Get("type").(string) != "BGP" {
+		return nil
+	}
+	for _, key := range []string{"connection_uuid", "ipv4_advertised_prefix_count", "ipv6_advertised_prefix_count"} {
+		if !d.NewValueKnown(key) {
+			return nil
+		}
+	}
+	ipv4Count := d.Get("ipv4_advertised_prefix_count").(int)
+	ipv6Count := d.Get("ipv6_advertised_prefix_count").(int)
+	if ipv4Count == 0 && ipv6Count == 0 {
+		return nil
+	}
+	c, ok := meta.(*config.Config)
+	if !ok || c.FabricClient == nil {
+		// Without a configured provider the API can't be queried
+		return nil
+	}
+	ctx = context.WithValue(ctx, v4.ContextAccessToken, c.FabricAuthToken)
+	connUuid := d.Get("connection_uuid").(string)
+	router, err := getConnectionCloudRouter(ctx, c.FabricClient, connUuid)
+	if err != nil {
+		log.Printf("[WARN] Cloud Router of connection %s can't be read, the advertised prefixes are checked on creation: %s", connUuid, err)
+		return nil
+	}
+	if router == nil {
+		return nil
+	}
+	packages, _, err := c.FabricClient.CloudRoutersApi.GetCloudRouterPackages(ctx, nil)
+	if err != nil {
+		log.Printf("[WARN] Cloud Router packages can't be read, the advertised prefixes are checked on creation: %s", equinix_errors.FormatFabricError(err))
+		return nil
+	}
+	return validateCloudRouterPrefixLimits(*router, packages.Data, ipv4Count, ipv6Count)
+}
+
+// getConnectionCloudRouter returns the Cloud Router the connection is attached to, or nil if
+// none of the connection sides is a Cloud Router access point.
+func getConnectionCloudRouter(ctx context.Context, client *v4.APIClient, connUuid string) (*v4.CloudRouter, error) {
+	conn, _, err := client.ConnectionsApi.GetConnectionByUuid(ctx, connUuid, nil)
+	if err != nil {
+		return nil, equinix_errors.FormatFabricError(err)
+	}
+	routerUuid := ""
+	for _, side := range []*v4.ConnectionSide{conn.ASide, conn.ZSide} {
+		if side == nil || side.AccessPoint == nil || side.AccessPoint.Router == nil {
+			continue
+		}
+		if side.AccessPoint.Type_ != nil && *side.AccessPoint.Type_ == v4.CLOUD_ROUTER_AccessPointType {
+			routerUuid = side.AccessPoint.Router.Uuid
+			break
+		}
+	}
+	if routerUuid == "" {
+		return nil, nil
+	}
+	router, _, err := client.CloudRoutersApi.GetCloudRouterByUuid(ctx, routerUuid)
+	if err != nil {
+		return nil, equinix_errors.FormatFabricError(err)
+	}
+	return &router, nil
+}
+
+// checkCloudRouterPrefixLimits verifies that the prefixes planned to be advertised over a BGP
+// routing protocol fit within the package limits of the Fabric Cloud Router the connection is
+// attached to. Connections that are not attached to a Cloud Router are not checked.
+func checkCloudRouterPrefixLimits(ctx context.Context, client *v4.APIClient, router v4.CloudRouter, ipv4Count, ipv6Count int) error {
+	if ipv4Count == 0 && ipv6Count == 0 {
+		return nil
+	}
+	packages, _, err := client.CloudRoutersApi.GetCloudRouterPackages(ctx, nil)
+	if err != nil {
+		return equinix_errors.FormatFabricError(err)
+	}
+	return validateCloudRouterPrefixLimits(router, packages.Data, ipv4Count, ipv6Count)
+}
+
+// validateCloudRouterPrefixLimits returns an error when the routes already learned by the router
+// plus the planned prefix counts exceed the limits of the router package. The error suggests the
+// smallest package able to accommodate the planned prefixes, if any.
+func validateCloudRouterPrefixLimits(router v4.CloudRouter, packages []v4.CloudRouterPackage, ipv4Count, ipv6Count int) error {
+	if router.Package_ == nil {
+		return nil
+	}
+	var current *v4.CloudRouterPackage
+	for i := range packages {
+		if packages[i].Code != nil && string(*packages[i].Code) == router.Package_.Code {
+			current = &packages[i]
+			break
+		}
+	}
+	if current == nil {
+		return nil
+	}
+	totalIpv4 := int(router.BgpIpv4RoutesCount) + ipv4Count
+	totalIpv6 := int(router.BgpIpv6RoutesCount) + ipv6Count
+	fits := func(p v4.CloudRouterPackage) bool {
+		return totalIpv4 <= int(p.TotalIPv4RoutesMax) && totalIpv6 <= int(p.TotalIPv6RoutesMax)
+	}
+	if fits(*current) {
+		return nil
+	}
+
+	var problems []string
+	if totalIpv4 > int(current.TotalIPv4RoutesMax) {
+		problems = append(problems, fmt.Sprintf("%d IPv4 routes (%d existing + %d planned) exceed the limit of %d", totalIpv4, router.BgpIpv4RoutesCount, ipv4Count, current.TotalIPv4RoutesMax))
+	}
+	if totalIpv6 > int(current.TotalIPv6RoutesMax) {
+		problems = append(problems, fmt.Sprintf("%d IPv6 routes (%d existing + %d planned) exceed the limit of %d", totalIpv6, router.BgpIpv6RoutesCount, ipv6Count, current.TotalIPv6RoutesMax))
+	}
+	msg := fmt.Sprintf("cloud router %s package %s can not accommodate the advertised prefixes: %s", router.Uuid, router.Package_.Code, strings.Join(problems, "; "))
+
+	var suggested *v4.CloudRouterPackage
+	for i := range packages {
+		p := packages[i]
+		if p.Code == nil || !fits(p) {
+			continue
+		}
+		if suggested == nil || p.TotalIPv4RoutesMax < suggested.TotalIPv4RoutesMax ||
+			(p.TotalIPv4RoutesMax == suggested.TotalIPv4RoutesMax && p.TotalIPv6RoutesMax < suggested.TotalIPv6RoutesMax) {
+			suggested = &packages[i]
+		}
+	}
+	if suggested != nil {
+		return fmt.Errorf("%s; upgrade the cloud router to the %s package to advertise these prefixes", msg, *suggested.Code)
+	}
+	return fmt.Errorf("%s; no cloud router package supports this number of routes", msg)
+}
+
+// reservedAsnRanges lists the ASN ranges that can not be used as customer ASN: AS 0, the 16-bit
+// and 32-bit ranges reserved for documentation (RFC 5398), AS_TRANS (RFC 6793) and the last ASN
+// of the 16-bit and 32-bit ranges (RFC 7300).
+var reservedAsnRanges = [][2]int64{
+	{0, 0},
+	{23456, 23456},
+	{64496, 64511},
+	{65535, 65535},
+	{65536, 65551},
+	{4294967295, 4294967295},
+}
+
+// validateCustomerAsn accepts both 2-byte and 4-byte ASNs that are not reserved.
+func validateCustomerAsn(v interface{}, k string) (ws []string, errors []error) {
+	asn := int64(v.(int))
+	if asn < 0 || asn > 4294967295 {
+		errors = append(errors, fmt.Errorf("expected %s to be a 4-byte ASN in the range 1 to 4294967294, got %d", k, asn))
+		return
+	}
+	for _, r := range reservedAsnRanges {
+		if asn >= r[0] && asn <= r[1] {
+			errors = append(errors, fmt.Errorf("expected %s to be a public or private ASN, got reserved ASN %d", k, asn))
+			return
+		}
+	}
+	return
+}
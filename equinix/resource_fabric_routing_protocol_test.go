@@ -0,0 +1,133 @@
+package equinix
+
+import (
+	"context"
+	"testing"
+
+	v4 "github.com/equinix-labs/fabric-go/fabric/v4"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testCloudRouterPackages() []v4.CloudRouterPackage {
+	codes := []v4.Code{v4.PREMIUM_Code, v4.BASIC_Code, v4.PRO_Code}
+	return []v4.CloudRouterPackage{
+		{Code: &codes[0], TotalIPv4RoutesMax: 10000, TotalIPv6RoutesMax: 10000},
+		{Code: &codes[1], TotalIPv4RoutesMax: 100, TotalIPv6RoutesMax: 100},
+		{Code: &codes[2], TotalIPv4RoutesMax: 1000, TotalIPv6RoutesMax: 1000},
+	}
+}
+
+func TestFabricRoutingProtocol_validateCloudRouterPrefixLimits(t *testing.T) {
+	// given
+	router := v4.CloudRouter{
+		Uuid:               "router-uuid",
+		Package_:           &v4.CloudRouterPackageType{Code: "BASIC"},
+		BgpIpv4RoutesCount: 50,
+		BgpIpv6RoutesCount: 10,
+	}
+	packages := testCloudRouterPackages()
+	// when
+	withinLimits := validateCloudRouterPrefixLimits(router, packages, 50, 90)
+	overIpv4 := validateCloudRouterPrefixLimits(router, packages, 51, 0)
+	overAll := validateCloudRouterPrefixLimits(router, packages, 20000, 0)
+	// then
+	assert.NoError(t, withinLimits, "Prefixes within package limits are accepted")
+	assert.ErrorContains(t, overIpv4, "101 IPv4 routes (50 existing + 51 planned) exceed the limit of 100")
+	assert.ErrorContains(t, overIpv4, "upgrade the cloud router to the PRO package")
+	assert.ErrorContains(t, overAll, "no cloud router package supports this number of routes")
+}
+
+func TestFabricRoutingProtocol_validateCloudRouterPrefixLimits_unknownPackage(t *testing.T) {
+	// given
+	router := v4.CloudRouter{
+		Package_: &v4.CloudRouterPackageType{Code: "LAB"},
+	}
+	// when
+	err := validateCloudRouterPrefixLimits(router, testCloudRouterPackages(), 1000000, 1000000)
+	// then
+	assert.NoError(t, err, "Routers with unknown package limits are not validated")
+}
+
+func TestFabricRoutingProtocol_validateRoutingProtocolPrefixLimits(t *testing.T) {
+	// given
+	c := newFakeAPIConfig(t)
+	ctx := context.WithValue(context.Background(), v4.ContextAccessToken, c.FabricAuthToken)
+	router, _, err := c.FabricClient.CloudRoutersApi.CreateCloudRouter(ctx, v4.CloudRouterPostRequest{
+		Name:     "test-router",
+		Package_: &v4.CloudRouterPackageType{Code: "LAB"},
+	})
+	require.NoError(t, err)
+	apType := v4.CLOUD_ROUTER_AccessPointType
+	conn, _, err := c.FabricClient.ConnectionsApi.CreateConnection(ctx, v4.ConnectionPostRequest{
+		Name:  "test-connection",
+		ASide: &v4.ConnectionSide{AccessPoint: &v4.AccessPoint{Type_: &apType, Router: &v4.CloudRouter{Uuid: router.Uuid}}},
+	})
+	require.NoError(t, err)
+	plan := func(ipv4Count int) error {
+		r := &schema.Resource{Schema: createFabricRoutingProtocolResourceSchema(), CustomizeDiff: validateRoutingProtocolPrefixLimits}
+		_, err := r.Diff(context.Background(), nil, terraform.NewResourceConfigRaw(map[string]interface{}{
+			"type":                         "BGP",
+			"connection_uuid":              conn.Uuid,
+			"ipv4_advertised_prefix_count": ipv4Count,
+		}), c)
+		return err
+	}
+	// when
+	withinLimits := plan(40)
+	overLimits := plan(60)
+	// then
+	assert.NoError(t, withinLimits, "Prefixes within package limits are planned")
+	assert.ErrorContains(t, overLimits, "60 IPv4 routes (0 existing + 60 planned) exceed the limit of 50", "Plan fails over the package limits")
+}
+
+func TestFabricRoutingProtocol_validateCustomerAsn(t *testing.T) {
+	// given
+	valid := []int{100, 64512, 65534, 65552, 4200000000, 4294967294}
+	invalid := []int{-1, 0, 23456, 64496, 64511, 65535, 65536, 65551, 4294967295, 4294967296}
+	// when / then
+	for _, asn := range valid {
+		_, errs := validateCustomerAsn(asn, "customer_asn")
+		assert.Empty(t, errs, "ASN %d is accepted", asn)
+	}
+	for _, asn := range invalid {
+		_, errs := validateCustomerAsn(asn, "customer_asn")
+		assert.Len(t, errs, 1, "ASN %d is rejected", asn)
+	}
+}
+
+func TestFabricRoutingProtocol_bgpPatchOperations(t *testing.T) {
+	// given
+	old := v4.RoutingProtocolBgpType{
+		BgpIpv4:    &v4.BgpConnectionIpv4{CustomerPeerIp: "192.168.100.2", Enabled: true},
+		BgpIpv6:    &v4.BgpConnectionIpv6{CustomerPeerIp: "2001:db8::2", Enabled: true},
+		BgpAuthKey: "old-key",
+	}
+	toggled := v4.RoutingProtocolBgpType{
+		BgpIpv4:    &v4.BgpConnectionIpv4{CustomerPeerIp: "192.168.100.2", Enabled: false},
+		BgpIpv6:    &v4.BgpConnectionIpv6{CustomerPeerIp: "2001:db8::2", Enabled: true},
+		BgpAuthKey: "new-key",
+	}
+	newPeer := v4.RoutingProtocolBgpType{
+		BgpIpv4:    &v4.BgpConnectionIpv4{CustomerPeerIp: "192.168.100.6", Enabled: true},
+		BgpIpv6:    old.BgpIpv6,
+		BgpAuthKey: "old-key",
+	}
+	withoutIpv6 := v4.RoutingProtocolBgpType{BgpIpv4: old.BgpIpv4, BgpAuthKey: "old-key"}
+	// when
+	ops, patchable := routingProtocolBgpPatchOperations(old, toggled)
+	_, peerPatchable := routingProtocolBgpPatchOperations(old, newPeer)
+	_, removalPatchable := routingProtocolBgpPatchOperations(old, withoutIpv6)
+	_, unchangedPatchable := routingProtocolBgpPatchOperations(old, old)
+	// then
+	assert.True(t, patchable, "Address family status and auth key changes are patched")
+	assert.Equal(t, []v4.ConnectionChangeOperation{
+		{Op: "replace", Path: "/bgpIpv4/enabled", Value: false},
+		{Op: "replace", Path: "/bgpAuthKey", Value: "new-key"},
+	}, ops)
+	assert.False(t, peerPatchable, "Peering IP changes replace the routing protocol")
+	assert.False(t, removalPatchable, "Address family removal replaces the routing protocol")
+	assert.False(t, unchangedPatchable, "Nothing to patch without changes")
+}
@@ -2,9 +2,13 @@ package equinix
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"log"
+	"regexp"
+	"sort"
 	"strconv"
 	"strings"
 	"time"
@@ -14,7 +18,6 @@ import (
 	equinix_fabric_schema "github.com/equinix/terraform-provider-equinix/internal/fabric/schema"
 	equinix_schema "github.com/equinix/terraform-provider-equinix/internal/schema"
 
-	"github.com/antihax/optional"
 	"github.com/equinix/terraform-provider-equinix/internal/config"
 
 	v4 "github.com/equinix-labs/fabric-go/fabric/v4"
@@ -23,6 +26,8 @@ import (
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
+const fabricServiceProfileConnectionsPageSize = 100
+
 func fabricServiceProfileSchema() map[string]*schema.Schema {
 	return map[string]*schema.Schema{
 		"href": {
@@ -147,6 +152,7 @@ func fabricServiceProfileSchema() map[string]*schema.Schema {
 		"project": {
 			Type:        schema.TypeSet,
 			Optional:    true,
+			Computed:    true,
 			Description: "Project information",
 			MaxItems:    1,
 			Elem: &schema.Resource{
@@ -537,18 +543,31 @@ func resourceFabricServiceProfile() *schema.Resource {
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
 		},
-		Schema:      fabricServiceProfileSchema(),
+		Schema:      resourceFabricServiceProfileSchema(),
 		Description: "Fabric V4 API compatible resource allows creation and management of Equinix Fabric Service Profile",
 	}
 }
 
+func resourceFabricServiceProfileSchema() map[string]*schema.Schema {
+	sch := fabricServiceProfileSchema()
+	sch["wait_for_connections_drain"] = &schema.Schema{
+		Type:        schema.TypeBool,
+		Optional:    true,
+		Default:     false,
+		Description: "Wait, up to the delete timeout, for the active connections of the service profile to be deprovisioned before deleting it, instead of failing the deletion",
+	}
+	return sch
+}
+
 func resourceFabricServiceProfileRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	client := meta.(*config.Config).FabricClient
 	ctx = context.WithValue(ctx, v4.ContextAccessToken, meta.(*config.Config).FabricAuthToken)
-	serviceProfile, _, err := client.ServiceProfilesApi.GetServiceProfileByUuid(ctx, d.Id(), nil)
+	serviceProfile, resp, err := client.ServiceProfilesApi.GetServiceProfileByUuid(ctx, d.Id(), nil)
 	if err != nil {
-		if !strings.Contains(err.Error(), "500") {
+		if !d.IsNewResource() && equinix_errors.IsGone(resp, err) {
+			log.Printf("[WARN] Service Profile %s not found, removing from state", d.Id())
 			d.SetId("")
+			return nil
 		}
 		return diag.FromErr(equinix_errors.FormatFabricError(err))
 	}
@@ -623,6 +642,9 @@ func getServiceProfileRequestPayload(d *schema.ResourceData) v4.ServiceProfileRe
 		Metros:                 spMetros,
 		SelfProfile:            d.Get("self_profile").(bool),
 	}
+	if project := equinix_fabric_schema.ProjectToFabric(d.Get("project").(*schema.Set).List()); project.ProjectId != "" {
+		createRequest.Project = &project
+	}
 	return createRequest
 }
 
@@ -729,7 +751,19 @@ func resourceFabricServiceProfileDelete(ctx context.Context, d *schema.ResourceD
 	if uuid == "" {
 		return diag.Errorf("No uuid found for Service Profile Deletion %v ", uuid)
 	}
-	_, _, err := client.ServiceProfilesApi.DeleteServiceProfileByUuid(ctx, uuid)
+	conns, err := getServiceProfileActiveConnections(ctx, client, uuid)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	if len(conns) > 0 {
+		if !d.Get("wait_for_connections_drain").(bool) {
+			return diag.Errorf("service profile %s has %d active connections, deprovision them or set wait_for_connections_drain before deleting it: %s", uuid, len(conns), formatServiceProfileConnections(conns))
+		}
+		if err := waitForServiceProfileConnectionsDrain(ctx, client, uuid, d.Timeout(schema.TimeoutDelete)); err != nil {
+			return diag.Errorf("Error while waiting for the connections of Service Profile %s to be deprovisioned: %v", uuid, err)
+		}
+	}
+	_, _, err = client.ServiceProfilesApi.DeleteServiceProfileByUuid(ctx, uuid)
 	if err != nil {
 		return diag.FromErr(equinix_errors.FormatFabricError(err))
 	}
@@ -742,6 +776,85 @@ func resourceFabricServiceProfileDelete(ctx context.Context, d *schema.ResourceD
 	return diags
 }
 
+// getServiceProfileActiveConnections returns the connections to the service
+// profile that have not been deprovisioned yet.
+func getServiceProfileActiveConnections(ctx context.Context, client *v4.APIClient, uuid string) ([]v4.Connection, error) {
+	property := v4.Z_SIDEACCESS_POINTPROFILEUUID_SearchFieldName
+	var conns []v4.Connection
+	for offset := 0; ; {
+		search := v4.SearchRequest{
+			Filter: &v4.Expression{
+				Property: &property,
+				Operator: "=",
+				Values:   []string{uuid},
+			},
+			Pagination: &v4.PaginationRequest{
+				Offset: int32(offset),
+				Limit:  fabricServiceProfileConnectionsPageSize,
+			},
+		}
+		resp, _, err := client.ConnectionsApi.SearchConnections(ctx, search)
+		if err != nil {
+			return nil, equinix_errors.FormatFabricError(err)
+		}
+		conns = append(conns, activeServiceProfileConnections(resp.Data)...)
+		offset += len(resp.Data)
+		if len(resp.Data) == 0 || resp.Pagination == nil || offset >= int(resp.Pagination.Total) {
+			return conns, nil
+		}
+	}
+}
+
+func activeServiceProfileConnections(conns []v4.Connection) []v4.Connection {
+	active := make([]v4.Connection, 0, len(conns))
+	for _, conn := range conns {
+		if conn.State != nil {
+			switch *conn.State {
+			case v4.DEPROVISIONED_ConnectionState, v4.CANCELLED_ConnectionState, v4.FAILED_ConnectionState:
+				continue
+			}
+		}
+		active = append(active, conn)
+	}
+	return active
+}
+
+func formatServiceProfileConnections(conns []v4.Connection) string {
+	descriptions := make([]string, len(conns))
+	for i, conn := range conns {
+		state := ""
+		if conn.State != nil {
+			state = string(*conn.State)
+		}
+		descriptions[i] = fmt.Sprintf("%s (uuid: %s, state: %s)", conn.Name, conn.Uuid, state)
+	}
+	return strings.Join(descriptions, ", ")
+}
+
+func waitForServiceProfileConnectionsDrain(ctx context.Context, client *v4.APIClient, uuid string, timeout time.Duration) error {
+	log.Printf("Waiting for the connections of service profile %s to be deprovisioned", uuid)
+	stateConf := &retry.StateChangeConf{
+		Pending: []string{"ACTIVE_CONNECTIONS"},
+		Target:  []string{"DRAINED"},
+		Refresh: func() (interface{}, string, error) {
+			conns, err := getServiceProfileActiveConnections(ctx, client, uuid)
+			if err != nil {
+				return nil, "", err
+			}
+			if len(conns) > 0 {
+				log.Printf("[DEBUG] Service profile %s still has active connections: %s", uuid, formatServiceProfileConnections(conns))
+				return conns, "ACTIVE_CONNECTIONS", nil
+			}
+			return conns, "DRAINED", nil
+		},
+		Timeout:    timeout,
+		Delay:      10 * time.Second,
+		MinTimeout: 30 * time.Second,
+	}
+	_, err := stateConf.WaitForStateContext(ctx)
+	return err
+}
+
 func WaitAndCheckServiceProfileDeleted(uuid string, client *v4.APIClient, ctx context.Context) error {
 	log.Printf("Waiting for service profile to be in deleted, uuid %s", uuid)
 	stateConf := &retry.StateChangeConf{
@@ -810,6 +923,7 @@ func fabricServiceProfileMap(serviceProfile *v4.ServiceProfile) map[string]inter
 		"custom_fields":             customFieldFabricSpToTerra(serviceProfile.CustomFields),
 		"marketing_info":            marketingInfoMappingToTerra(serviceProfile.MarketingInfo),
 		"ports":                     accessPointColoFabricSpToTerra(serviceProfile.Ports),
+		"virtual_devices":           accessPointVdFabricSpToTerra(serviceProfile.VirtualDevices),
 		"allowed_emails":            allowedEmailsFabricSpToTerra(serviceProfile.AllowedEmails),
 		"metros":                    serviceMetroFabricSpToTerra(serviceProfile.Metros),
 		"self_profile":              serviceProfile.SelfProfile,
@@ -821,46 +935,131 @@ func fabricServiceProfileMap(serviceProfile *v4.ServiceProfile) map[string]inter
 }
 
 func resourceServiceProfilesSearchRequest(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*config.Config).FabricClient
 	ctx = context.WithValue(ctx, v4.ContextAccessToken, meta.(*config.Config).FabricAuthToken)
-	schemaFilter := d.Get("filter").(*schema.Set).List()
-	filter := serviceProfilesSearchFilterRequestToFabric(schemaFilter)
-	var serviceProfileFlt v4.ServiceProfileFilter // Cast ServiceProfile search expression struct type to interface
-	serviceProfileFlt = filter
-	schemaSort := d.Get("sort").([]interface{})
-	sort := serviceProfilesSearchSortRequestToFabric(schemaSort)
 	schemaViewPoint := d.Get("view_point").(string)
 
 	if schemaViewPoint != "" && schemaViewPoint != string(v4.A_SIDE_ViewPoint) && schemaViewPoint != string(v4.Z_SIDE_ViewPoint) {
 		return diag.FromErr(errors.New("view_point can only be set to aSide or zSide. Omitting it will default to aSide"))
 	}
 
-	viewPoint := &v4.ServiceProfilesApiSearchServiceProfilesOpts{
-		ViewPoint: optional.NewString(schemaViewPoint),
+	filters := map[string]string{}
+	for key := range fabricServiceProfilesSearchFields {
+		if v, ok := d.GetOk(key); ok {
+			filters[key] = v.(string)
+		}
+	}
+	namePattern := d.Get("name").(string)
+	if namePattern != "" && !strings.Contains(namePattern, "*") {
+		filters["name"] = namePattern
+	}
+	createServiceProfilesSearchRequest := v4.ServiceProfileSearchRequest{
+		Filter: serviceProfilesSearchExpression(d.Get("filter").(*schema.Set).List(), filters),
+		Sort:   serviceProfilesSearchSortRequestToFabric(d.Get("sort").([]interface{})),
 	}
 
-	if schemaViewPoint == "" {
-		viewPoint = nil
+	offset, limit := 0, 0
+	if p, ok := d.GetOk("pagination"); ok {
+		page := p.(*schema.Set).List()[0].(map[string]interface{})
+		offset, limit = page["offset"].(int), page["limit"].(int)
 	}
 
-	createServiceProfilesSearchRequest := v4.ServiceProfileSearchRequest{
-		Filter: &serviceProfileFlt,
-		Sort:   sort,
+	var serviceProfiles v4.ServiceProfiles
+	for {
+		pageSize := fabricServiceProfilesPageSize
+		if limit > 0 {
+			pageSize = limit
+		}
+		createServiceProfilesSearchRequest.Pagination = &v4.PaginationRequest{
+			Offset: int32(offset + len(serviceProfiles.Data)),
+			Limit:  int32(pageSize),
+		}
+		resp, err := cachedFabricServiceProfilesSearch(ctx, meta.(*config.Config), createServiceProfilesSearchRequest, schemaViewPoint)
+		if err != nil {
+			if !strings.Contains(err.Error(), "500") {
+				d.SetId("")
+			}
+			return diag.FromErr(equinix_errors.FormatFabricError(err))
+		}
+		serviceProfiles.Data = append(serviceProfiles.Data, resp.Data...)
+		if limit > 0 || len(resp.Data) == 0 || resp.Pagination == nil || offset+len(serviceProfiles.Data) >= int(resp.Pagination.Total) {
+			break
+		}
 	}
-	serviceProfiles, _, err := client.ServiceProfilesApi.SearchServiceProfiles(ctx, createServiceProfilesSearchRequest, viewPoint)
+	if namePattern != "" && strings.Contains(namePattern, "*") {
+		serviceProfiles.Data = filterFabricServiceProfilesByName(serviceProfiles.Data, namePattern)
+	}
+
+	id, err := fabricServiceProfilesSearchId(createServiceProfilesSearchRequest, namePattern, schemaViewPoint, offset, limit)
 	if err != nil {
-		if !strings.Contains(err.Error(), "500") {
-			d.SetId("")
+		return diag.FromErr(err)
+	}
+	d.SetId(id)
+	return setFabricServiceProfilesListMap(d, serviceProfiles)
+}
+
+// serviceProfilesSearchExpression combines the filter block and the given
+// filters, keyed by the data source argument names, into a single search
+// expression matching all of them. It returns nil if there are no filters.
+func serviceProfilesSearchExpression(schemaFilter []interface{}, filters map[string]string) *v4.ServiceProfileFilter {
+	var and []v4.ServiceProfileSimpleExpression
+	if len(schemaFilter) != 0 {
+		and = append(and, serviceProfilesSearchFilterRequestToFabric(schemaFilter))
+	}
+	keys := make([]string, 0, len(filters))
+	for key := range filters {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		property := "/name"
+		if key != "name" {
+			property = fabricServiceProfilesSearchFields[key]
 		}
-		return diag.FromErr(equinix_errors.FormatFabricError(err))
+		and = append(and, v4.ServiceProfileSimpleExpression{
+			Property: property,
+			Operator: "=",
+			Values:   []string{filters[key]},
+		})
+	}
+	var filter v4.ServiceProfileFilter
+	switch len(and) {
+	case 0:
+		return nil
+	case 1:
+		filter = and[0]
+	default:
+		filter = v4.ServiceProfileAndFilter{And: and}
 	}
+	return &filter
+}
 
-	if len(serviceProfiles.Data) != 1 {
-		error := fmt.Errorf("incorrect # of records are found for the service profile search criteria - %d , please change the criteria", len(serviceProfiles.Data))
-		return diag.FromErr(error)
+// filterFabricServiceProfilesByName returns the service profiles with a name
+// matching the given pattern, in which * matches any sequence of characters.
+func filterFabricServiceProfilesByName(serviceProfiles []v4.ServiceProfile, pattern string) []v4.ServiceProfile {
+	parts := strings.Split(pattern, "*")
+	for i, part := range parts {
+		parts[i] = regexp.QuoteMeta(part)
+	}
+	nameRegexp := regexp.MustCompile("^" + strings.Join(parts, ".*") + "$")
+	filtered := make([]v4.ServiceProfile, 0, len(serviceProfiles))
+	for _, serviceProfile := range serviceProfiles {
+		if nameRegexp.MatchString(serviceProfile.Name) {
+			filtered = append(filtered, serviceProfile)
+		}
 	}
-	d.SetId(serviceProfiles.Data[0].Uuid)
-	return setFabricServiceProfilesListMap(d, serviceProfiles)
+	return filtered
+}
+
+// fabricServiceProfilesSearchId derives a stable data source ID from the
+// search request and the arguments that are not part of it.
+func fabricServiceProfilesSearchId(request v4.ServiceProfileSearchRequest, namePattern, viewPoint string, offset, limit int) (string, error) {
+	request.Pagination = nil
+	body, err := json.Marshal(request)
+	if err != nil {
+		return "", err
+	}
+	key := fmt.Sprintf("%s,name=%s,view_point=%s,offset=%d,limit=%d", body, namePattern, viewPoint, offset, limit)
+	return fmt.Sprintf("%x", sha256.Sum256([]byte(key))), nil
 }
 
 func customFieldFabricSpToTerra(customFieldl []v4.CustomField) []map[string]interface{} {
@@ -870,21 +1069,24 @@ func customFieldFabricSpToTerra(customFieldl []v4.CustomField) []map[string]inte
 	mappedCustomFieldl := make([]map[string]interface{}, len(customFieldl))
 	for index, customField := range customFieldl {
 		mappedCustomFieldl[index] = map[string]interface{}{
-			"label":       customField.Label,
-			"description": customField.Description,
-			"required":    customField.Required,
-			"data_type":   customField.DataType,
-			"options":     customField.Options,
+			"label":            customField.Label,
+			"description":      customField.Description,
+			"required":         customField.Required,
+			"data_type":        customField.DataType,
+			"options":          customField.Options,
+			"capture_in_email": customField.CaptureInEmail,
 		}
 	}
 	return mappedCustomFieldl
 }
 
-func processStepFabricSpToTerra(processStepl []v4.ProcessStep) []map[string]interface{} {
+// processStepFabricSpToTerra returns the process steps as a list of interfaces,
+// as hashing the marketing info set requires.
+func processStepFabricSpToTerra(processStepl []v4.ProcessStep) []interface{} {
 	if processStepl == nil {
 		return nil
 	}
-	mappedProcessStepl := make([]map[string]interface{}, len(processStepl))
+	mappedProcessStepl := make([]interface{}, len(processStepl))
 	for index, processStep := range processStepl {
 		mappedProcessStepl[index] = map[string]interface{}{
 			"title":       processStep.Title,
@@ -936,6 +1138,22 @@ func accessPointColoFabricSpToTerra(accessPointColol []v4.ServiceProfileAccessPo
 	return mappedAccessPointColol
 }
 
+func accessPointVdFabricSpToTerra(accessPointVdl []v4.ServiceProfileAccessPointVd) []map[string]interface{} {
+	if accessPointVdl == nil {
+		return nil
+	}
+	mappedAccessPointVdl := make([]map[string]interface{}, len(accessPointVdl))
+	for index, accessPointVd := range accessPointVdl {
+		mappedAccessPointVdl[index] = map[string]interface{}{
+			"type":           accessPointVd.Type_,
+			"uuid":           accessPointVd.Uuid,
+			"location":       equinix_fabric_schema.LocationToTerra(accessPointVd.Location),
+			"interface_uuid": accessPointVd.InterfaceUuid,
+		}
+	}
+	return mappedAccessPointVdl
+}
+
 func serviceMetroFabricSpToTerra(serviceMetrol []v4.ServiceMetro) []map[string]interface{} {
 	if serviceMetrol == nil {
 		return nil
@@ -1098,8 +1316,8 @@ func marketingInfoToFabric(schemaMarketingInfo []interface{}) v4.MarketingInfo {
 		miPromotion := marketingInfo.(map[string]interface{})["promotion"].(bool)
 
 		var miProcessSteps []v4.ProcessStep
-		if marketingInfo.(map[string]interface{})["process_steps"] != nil {
-			processStepsList := marketingInfo.(map[string]interface{})["process_steps"].([]interface{})
+		if marketingInfo.(map[string]interface{})["process_step"] != nil {
+			processStepsList := marketingInfo.(map[string]interface{})["process_step"].([]interface{})
 			miProcessSteps = processStepToFabric(processStepsList)
 		}
 
@@ -1194,7 +1412,11 @@ func metrosToFabric(schemaMetros []interface{}) []v4.ServiceMetro {
 		mIbxs := converters.IfArrToStringArr(ibxsRaw)
 		mInTrail := metro.(map[string]interface{})["in_trail"].(bool)
 		mDisplayName := metro.(map[string]interface{})["display_name"].(string)
-		mSellerRegions := metro.(map[string]interface{})["seller_regions"].(map[string]string)
+		sellerRegionsRaw, _ := metro.(map[string]interface{})["seller_regions"].(map[string]interface{})
+		mSellerRegions := make(map[string]string, len(sellerRegionsRaw))
+		for region, description := range sellerRegionsRaw {
+			mSellerRegions[region] = description.(string)
+		}
 		metros = append(metros, v4.ServiceMetro{
 			Code:          mCode,
 			Name:          mName,
@@ -0,0 +1,105 @@
+package equinix
+
+import (
+	"testing"
+
+	v4 "github.com/equinix-labs/fabric-go/fabric/v4"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFabricServiceProfileActiveConnections(t *testing.T) {
+	// given
+	state := func(s v4.ConnectionState) *v4.ConnectionState { return &s }
+	conns := []v4.Connection{
+		{Name: "active", Uuid: "1", State: state(v4.ACTIVE_ConnectionState)},
+		{Name: "deprovisioned", Uuid: "2", State: state(v4.DEPROVISIONED_ConnectionState)},
+		{Name: "deprovisioning", Uuid: "3", State: state(v4.DEPROVISIONING_ConnectionState)},
+		{Name: "cancelled", Uuid: "4", State: state(v4.CANCELLED_ConnectionState)},
+	}
+	// when
+	active := activeServiceProfileConnections(conns)
+	// then
+	assert.Equal(t, []v4.Connection{conns[0], conns[2]}, active, "Only connections not yet deprovisioned are active")
+	assert.Equal(t, "active (uuid: 1, state: ACTIVE), deprovisioning (uuid: 3, state: DEPROVISIONING)",
+		formatServiceProfileConnections(active), "Active connections are listed by name, uuid and state")
+}
+
+func TestFabricServiceProfile_marketplaceFields(t *testing.T) {
+	// given
+	d := schema.TestResourceDataRaw(t, fabricServiceProfileSchema(), map[string]interface{}{
+		"name":        "Seller profile",
+		"type":        "L2_PROFILE",
+		"description": "Seller profile",
+		"visibility":  "PUBLIC",
+		"marketing_info": []interface{}{map[string]interface{}{
+			"logo":      "logo.png",
+			"promotion": true,
+			"process_step": []interface{}{
+				map[string]interface{}{"title": "Order", "sub_title": "Fabric", "description": "Order a connection"},
+			},
+		}},
+		"custom_fields": []interface{}{map[string]interface{}{
+			"label":            "Account",
+			"required":         true,
+			"data_type":        "STRING",
+			"capture_in_email": true,
+		}},
+		"virtual_devices": []interface{}{map[string]interface{}{
+			"type":           "EDGE",
+			"uuid":           "device-uuid",
+			"interface_uuid": "interface-uuid",
+		}},
+		"metros": []interface{}{map[string]interface{}{
+			"code":           "SV",
+			"in_trail":       true,
+			"seller_regions": map[string]interface{}{"us-west-1": "N. California"},
+		}},
+	})
+	// when
+	request := getServiceProfileRequestPayload(d)
+	sp := v4.ServiceProfile{
+		MarketingInfo:  request.MarketingInfo,
+		CustomFields:   request.CustomFields,
+		VirtualDevices: request.VirtualDevices,
+		Metros:         request.Metros,
+	}
+	read := schema.TestResourceDataRaw(t, fabricServiceProfileSchema(), map[string]interface{}{})
+	diags := setFabricServiceProfileMap(read, sp)
+	// then
+	require.NotNil(t, request.MarketingInfo)
+	assert.Equal(t, []v4.ProcessStep{{Title: "Order", SubTitle: "Fabric", Description: "Order a connection"}}, request.MarketingInfo.ProcessSteps)
+	assert.True(t, request.CustomFields[0].CaptureInEmail)
+	assert.Equal(t, "interface-uuid", request.VirtualDevices[0].InterfaceUuid)
+	assert.Equal(t, map[string]string{"us-west-1": "N. California"}, request.Metros[0].SellerRegions)
+	require.False(t, diags.HasError(), "Service profile is mapped: %v", diags)
+	marketingInfo := read.Get("marketing_info").(*schema.Set).List()
+	require.Len(t, marketingInfo, 1)
+	processSteps := marketingInfo[0].(map[string]interface{})["process_step"].([]interface{})
+	require.Len(t, processSteps, 1, "Process steps are read back")
+	assert.Equal(t, "Order", processSteps[0].(map[string]interface{})["title"])
+	assert.Equal(t, true, read.Get("custom_fields.0.capture_in_email"))
+	assert.Equal(t, "device-uuid", read.Get("virtual_devices.0.uuid"), "Virtual devices are read back")
+	assert.Equal(t, "N. California", read.Get("metros.0.seller_regions.us-west-1"))
+}
+
+func TestFabricServiceProfile_project(t *testing.T) {
+	// given
+	withProject := schema.TestResourceDataRaw(t, fabricServiceProfileSchema(), map[string]interface{}{
+		"name":    "Seller profile",
+		"type":    "L2_PROFILE",
+		"project": []interface{}{map[string]interface{}{"project_id": "project-uuid"}},
+	})
+	withoutProject := schema.TestResourceDataRaw(t, fabricServiceProfileSchema(), map[string]interface{}{
+		"name": "Seller profile",
+		"type": "L2_PROFILE",
+	})
+	// when
+	request := getServiceProfileRequestPayload(withProject)
+	defaultRequest := getServiceProfileRequestPayload(withoutProject)
+	// then
+	require.NotNil(t, request.Project, "Service profile is created in the project")
+	assert.Equal(t, "project-uuid", request.Project.ProjectId)
+	assert.Nil(t, defaultRequest.Project, "Service profile without project lands in the default project")
+}
@@ -0,0 +1,482 @@
+package equinix
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	v4 "github.com/equinix-labs/fabric-go/fabric/v4"
+	"github.com/equinix/terraform-provider-equinix/internal/config"
+	"github.com/equinix/terraform-provider-equinix/internal/converters"
+	equinix_diagnostics "github.com/equinix/terraform-provider-equinix/internal/diagnostics"
+	equinix_errors "github.com/equinix/terraform-provider-equinix/internal/errors"
+	equinix_fabric_schema "github.com/equinix/terraform-provider-equinix/internal/fabric/schema"
+	equinix_schema "github.com/equinix/terraform-provider-equinix/internal/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func serviceTokenAccessPointPortSch() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"uuid": {
+			Type:        schema.TypeString,
+			Required:    true,
+			Description: "Equinix-assigned Port identifier",
+		},
+		"href": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "Unique Resource Identifier",
+		},
+		"type": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "Type of Port",
+		},
+	}
+}
+
+func serviceTokenAccessPointSelectorSch() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"type": {
+			Type:        schema.TypeString,
+			Optional:    true,
+			Default:     "COLO",
+			Description: "Type of Access point",
+		},
+		"port": {
+			Type:        schema.TypeList,
+			Required:    true,
+			MaxItems:    1,
+			Description: "Port of the access point",
+			Elem: &schema.Resource{
+				Schema: serviceTokenAccessPointPortSch(),
+			},
+		},
+	}
+}
+
+func serviceTokenSideSch() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"access_point_selectors": {
+			Type:        schema.TypeList,
+			Required:    true,
+			MinItems:    1,
+			Description: "List of criteria for selecting the access points of the connection side",
+			Elem: &schema.Resource{
+				Schema: serviceTokenAccessPointSelectorSch(),
+			},
+		},
+	}
+}
+
+func serviceTokenConnectionSch() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"type": {
+			Type:        schema.TypeString,
+			Required:    true,
+			ForceNew:    true,
+			Description: "Type of Connection, e.g. EVPL_VC",
+		},
+		"href": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "Unique Resource Identifier",
+		},
+		"uuid": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "Equinix-assigned connection identifier",
+		},
+		"allow_remote_connection": {
+			Type:        schema.TypeBool,
+			Optional:    true,
+			Default:     false,
+			Description: "Authorization to connect remotely",
+		},
+		"bandwidth_limit": {
+			Type:         schema.TypeInt,
+			Optional:     true,
+			ValidateFunc: validation.IntAtLeast(0),
+			Description:  "Connection bandwidth limit in Mbps",
+		},
+		"supported_bandwidths": {
+			Type:        schema.TypeList,
+			Optional:    true,
+			Description: "List of permitted bandwidths in Mbps",
+			Elem: &schema.Schema{
+				Type: schema.TypeInt,
+			},
+		},
+		"a_side": {
+			Type:         schema.TypeList,
+			Optional:     true,
+			ForceNew:     true,
+			MaxItems:     1,
+			Description:  "A-Side access point selection of an A-side service token",
+			ExactlyOneOf: []string{"service_token_connection.0.a_side", "service_token_connection.0.z_side"},
+			Elem: &schema.Resource{
+				Schema: serviceTokenSideSch(),
+			},
+		},
+		"z_side": {
+			Type:         schema.TypeList,
+			Optional:     true,
+			ForceNew:     true,
+			MaxItems:     1,
+			Description:  "Z-Side access point selection of a Z-side service token",
+			ExactlyOneOf: []string{"service_token_connection.0.a_side", "service_token_connection.0.z_side"},
+			Elem: &schema.Resource{
+				Schema: serviceTokenSideSch(),
+			},
+		},
+	}
+}
+
+func fabricServiceTokenResourceSchema() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"type": {
+			Type:         schema.TypeString,
+			Optional:     true,
+			ForceNew:     true,
+			Default:      string(v4.VC_TOKEN_ServiceTokenType),
+			ValidateFunc: validation.StringInSlice([]string{string(v4.VC_TOKEN_ServiceTokenType)}, false),
+			Description:  "Service token type - VC_TOKEN",
+		},
+		"href": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "Service token URI",
+		},
+		"uuid": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "Equinix-assigned service token identifier",
+		},
+		"name": {
+			Type:        schema.TypeString,
+			Optional:    true,
+			Description: "Service token name",
+		},
+		"description": {
+			Type:        schema.TypeString,
+			Optional:    true,
+			Description: "Service token description",
+		},
+		"expiration_date_time": {
+			Type:             schema.TypeString,
+			Required:         true,
+			ValidateFunc:     validation.IsRFC3339Time,
+			DiffSuppressFunc: suppressEquivalentServiceTokenExpiration,
+			Description:      "Expiration date and time of the service token in RFC3339 format, e.g. 2025-01-01T00:00:00Z",
+		},
+		"state": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "Service token state - ACTIVE, INACTIVE, EXPIRED, DELETED",
+		},
+		"service_token_connection": {
+			Type:        schema.TypeList,
+			Required:    true,
+			MaxItems:    1,
+			Description: "Connection that can be created with the service token",
+			Elem: &schema.Resource{
+				Schema: serviceTokenConnectionSch(),
+			},
+		},
+		"notifications": {
+			Type:        schema.TypeList,
+			Required:    true,
+			Description: "Preferences for notifications on the service token",
+			Elem: &schema.Resource{
+				Schema: equinix_fabric_schema.NotificationSch(),
+			},
+		},
+		"project": {
+			Type:        schema.TypeSet,
+			Optional:    true,
+			Computed:    true,
+			ForceNew:    true,
+			MaxItems:    1,
+			Description: "Project information",
+			Elem: &schema.Resource{
+				Schema: equinix_fabric_schema.ProjectSch(),
+			},
+		},
+		"account": {
+			Type:        schema.TypeSet,
+			Computed:    true,
+			Description: "Customer account information that is associated with this service token",
+			Elem: &schema.Resource{
+				Schema: equinix_fabric_schema.AccountSch(),
+			},
+		},
+		"change_log": {
+			Type:        schema.TypeSet,
+			Computed:    true,
+			Description: "Captures service token lifecycle change information",
+			Elem: &schema.Resource{
+				Schema: equinix_fabric_schema.ChangeLogSch(),
+			},
+		},
+	}
+}
+
+func resourceFabricServiceToken() *schema.Resource {
+	return &schema.Resource{
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(6 * time.Minute),
+			Update: schema.DefaultTimeout(6 * time.Minute),
+			Delete: schema.DefaultTimeout(6 * time.Minute),
+			Read:   schema.DefaultTimeout(6 * time.Minute),
+		},
+		ReadContext:   resourceFabricServiceTokenRead,
+		CreateContext: resourceFabricServiceTokenCreate,
+		UpdateContext: resourceFabricServiceTokenUpdate,
+		DeleteContext: resourceFabricServiceTokenDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+		Schema: fabricServiceTokenResourceSchema(),
+
+		Description: "Fabric V4 API compatible resource allows creation and management of Equinix Fabric Service Tokens",
+	}
+}
+
+func suppressEquivalentServiceTokenExpiration(k, old, new string, d *schema.ResourceData) bool {
+	oldTime, err := time.Parse(time.RFC3339, old)
+	if err != nil {
+		return false
+	}
+	newTime, err := time.Parse(time.RFC3339, new)
+	if err != nil {
+		return false
+	}
+	return oldTime.Equal(newTime)
+}
+
+func resourceFabricServiceTokenCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*config.Config).FabricClient
+	ctx = context.WithValue(ctx, v4.ContextAccessToken, meta.(*config.Config).FabricAuthToken)
+	createRequest, err := fabricServiceTokenToFabric(d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	serviceToken, resp, err := client.ServiceTokensApi.CreateServiceToken(ctx, createRequest)
+	if err != nil {
+		return equinix_diagnostics.SDKError("creating Fabric service token", fabricErrorAttributePath(fabricServiceTokenResourceSchema(), err), resp, err)
+	}
+	d.SetId(serviceToken.Uuid)
+	return resourceFabricServiceTokenRead(ctx, d, meta)
+}
+
+func resourceFabricServiceTokenRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*config.Config).FabricClient
+	ctx = context.WithValue(ctx, v4.ContextAccessToken, meta.(*config.Config).FabricAuthToken)
+	serviceToken, resp, err := client.ServiceTokensApi.GetServiceTokenByUuid(ctx, d.Id())
+	if err != nil {
+		if !d.IsNewResource() && equinix_errors.IsGone(resp, err) {
+			log.Printf("[WARN] Fabric Service Token %s not found, removing from state", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return equinix_diagnostics.SDKError(fmt.Sprintf("reading Fabric service token %s", d.Id()), "", resp, err)
+	}
+	if !d.IsNewResource() && serviceToken.State != nil && *serviceToken.State == v4.DELETED_ServiceTokenState {
+		log.Printf("[WARN] Fabric Service Token %s was deleted, removing from state", d.Id())
+		d.SetId("")
+		return nil
+	}
+	d.SetId(serviceToken.Uuid)
+	return setFabricServiceTokenMap(d, serviceToken)
+}
+
+func resourceFabricServiceTokenUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*config.Config).FabricClient
+	ctx = context.WithValue(ctx, v4.ContextAccessToken, meta.(*config.Config).FabricAuthToken)
+	updates := getServiceTokenUpdateRequest(d)
+	if len(updates) == 0 {
+		return resourceFabricServiceTokenRead(ctx, d, meta)
+	}
+	serviceToken, resp, err := client.ServiceTokensApi.UpdateServiceTokenByUuid(ctx, updates, d.Id())
+	if err != nil {
+		return equinix_diagnostics.SDKError(fmt.Sprintf("updating Fabric service token %s", d.Id()), fabricErrorAttributePath(fabricServiceTokenResourceSchema(), err), resp, err)
+	}
+	return setFabricServiceTokenMap(d, serviceToken)
+}
+
+func resourceFabricServiceTokenDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*config.Config).FabricClient
+	ctx = context.WithValue(ctx, v4.ContextAccessToken, meta.(*config.Config).FabricAuthToken)
+	resp, err := client.ServiceTokensApi.DeleteServiceTokenByUuid(ctx, d.Id())
+	if err != nil {
+		if equinix_errors.IsGone(resp, err) {
+			return nil
+		}
+		return equinix_diagnostics.SDKError(fmt.Sprintf("deleting Fabric service token %s", d.Id()), "", resp, err)
+	}
+	return nil
+}
+
+func fabricServiceTokenToFabric(d *schema.ResourceData) (v4.ServiceToken, error) {
+	expiration, err := time.Parse(time.RFC3339, d.Get("expiration_date_time").(string))
+	if err != nil {
+		return v4.ServiceToken{}, fmt.Errorf("invalid expiration_date_time: %s", err)
+	}
+	tokenType := v4.ServiceTokenType(d.Get("type").(string))
+	serviceToken := v4.ServiceToken{
+		Type_:              &tokenType,
+		Name:               d.Get("name").(string),
+		Description:        d.Get("description").(string),
+		ExpirationDateTime: expiration,
+		Connection:         serviceTokenConnectionToFabric(d.Get("service_token_connection").([]interface{})),
+		Notifications:      equinix_fabric_schema.NotificationsToFabric(d.Get("notifications").([]interface{})),
+	}
+	schemaProject := d.Get("project").(*schema.Set).List()
+	if len(schemaProject) != 0 {
+		project := equinix_fabric_schema.ProjectToFabric(schemaProject)
+		serviceToken.Project = &project
+	}
+	return serviceToken, nil
+}
+
+func serviceTokenConnectionToFabric(connectionList []interface{}) *v4.ServiceTokenConnection {
+	if len(connectionList) == 0 || connectionList[0] == nil {
+		return nil
+	}
+	connectionMap := connectionList[0].(map[string]interface{})
+	return &v4.ServiceTokenConnection{
+		Type_:                 connectionMap["type"].(string),
+		AllowRemoteConnection: connectionMap["allow_remote_connection"].(bool),
+		BandwidthLimit:        int32(connectionMap["bandwidth_limit"].(int)),
+		SupportedBandwidths:   converters.ListToInt32List(connectionMap["supported_bandwidths"].([]interface{})),
+		ASide:                 serviceTokenSideToFabric(connectionMap["a_side"].([]interface{})),
+		ZSide:                 serviceTokenSideToFabric(connectionMap["z_side"].([]interface{})),
+	}
+}
+
+func serviceTokenSideToFabric(sideList []interface{}) *v4.ServiceTokenSide {
+	if len(sideList) == 0 || sideList[0] == nil {
+		return nil
+	}
+	selectorList := sideList[0].(map[string]interface{})["access_point_selectors"].([]interface{})
+	selectors := make([]v4.AccessPointSelector, 0, len(selectorList))
+	for _, s := range selectorList {
+		selectorMap := s.(map[string]interface{})
+		selector := v4.AccessPointSelector{Type_: selectorMap["type"].(string)}
+		if portList := selectorMap["port"].([]interface{}); len(portList) != 0 && portList[0] != nil {
+			selector.Port = &v4.SimplifiedMetadataEntity{
+				Uuid: portList[0].(map[string]interface{})["uuid"].(string),
+			}
+		}
+		selectors = append(selectors, selector)
+	}
+	return &v4.ServiceTokenSide{AccessPointSelectors: selectors}
+}
+
+func serviceTokenConnectionToTerra(connection *v4.ServiceTokenConnection) []interface{} {
+	if connection == nil {
+		return nil
+	}
+	supportedBandwidths := make([]interface{}, 0, len(connection.SupportedBandwidths))
+	for _, bandwidth := range connection.SupportedBandwidths {
+		supportedBandwidths = append(supportedBandwidths, int(bandwidth))
+	}
+	return []interface{}{
+		map[string]interface{}{
+			"type":                    connection.Type_,
+			"href":                    connection.Href,
+			"uuid":                    connection.Uuid,
+			"allow_remote_connection": connection.AllowRemoteConnection,
+			"bandwidth_limit":         int(connection.BandwidthLimit),
+			"supported_bandwidths":    supportedBandwidths,
+			"a_side":                  serviceTokenSideToTerra(connection.ASide),
+			"z_side":                  serviceTokenSideToTerra(connection.ZSide),
+		},
+	}
+}
+
+func serviceTokenSideToTerra(side *v4.ServiceTokenSide) []interface{} {
+	if side == nil {
+		return nil
+	}
+	selectors := make([]interface{}, 0, len(side.AccessPointSelectors))
+	for _, selector := range side.AccessPointSelectors {
+		mappedSelector := map[string]interface{}{
+			"type": selector.Type_,
+		}
+		if selector.Port != nil {
+			mappedSelector["port"] = []interface{}{
+				map[string]interface{}{
+					"uuid": selector.Port.Uuid,
+					"href": selector.Port.Href,
+					"type": selector.Port.Type_,
+				},
+			}
+		}
+		selectors = append(selectors, mappedSelector)
+	}
+	return []interface{}{
+		map[string]interface{}{
+			"access_point_selectors": selectors,
+		},
+	}
+}
+
+func setFabricServiceTokenMap(d *schema.ResourceData, serviceToken v4.ServiceToken) diag.Diagnostics {
+	diags := diag.Diagnostics{}
+	expiration := ""
+	if !serviceToken.ExpirationDateTime.IsZero() {
+		expiration = serviceToken.ExpirationDateTime.Format(time.RFC3339)
+	}
+	err := equinix_schema.SetMap(d, map[string]interface{}{
+		"type":                     serviceToken.Type_,
+		"href":                     serviceToken.Href,
+		"uuid":                     serviceToken.Uuid,
+		"name":                     serviceToken.Name,
+		"description":              serviceToken.Description,
+		"expiration_date_time":     expiration,
+		"state":                    serviceToken.State,
+		"service_token_connection": serviceTokenConnectionToTerra(serviceToken.Connection),
+		"notifications":            equinix_fabric_schema.NotificationsToTerra(serviceToken.Notifications),
+		"project":                  equinix_fabric_schema.ProjectToTerra(serviceToken.Project),
+		"account":                  equinix_fabric_schema.AccountToTerra(serviceToken.Account),
+		"change_log":               equinix_fabric_schema.ChangeLogToTerra(serviceToken.Changelog),
+	})
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	return diags
+}
+
+func getServiceTokenUpdateRequest(d *schema.ResourceData) []v4.ServiceTokenChangeOperation {
+	var changeOps []v4.ServiceTokenChangeOperation
+	replace := func(path string, value interface{}) {
+		changeOps = append(changeOps, v4.ServiceTokenChangeOperation{Op: "replace", Path: path, Value: &value})
+	}
+	if d.HasChange("name") {
+		replace("/name", d.Get("name").(string))
+	}
+	if d.HasChange("description") {
+		replace("/description", d.Get("description").(string))
+	}
+	if d.HasChange("expiration_date_time") {
+		replace("/expirationDateTime", d.Get("expiration_date_time").(string))
+	}
+	if d.HasChange("notifications") {
+		replace("/notifications", equinix_fabric_schema.NotificationsToFabric(d.Get("notifications").([]interface{})))
+	}
+	if d.HasChange("service_token_connection.0.allow_remote_connection") {
+		replace("/connection/allowRemoteConnection", d.Get("service_token_connection.0.allow_remote_connection").(bool))
+	}
+	if d.HasChange("service_token_connection.0.bandwidth_limit") {
+		replace("/connection/bandwidthLimit", d.Get("service_token_connection.0.bandwidth_limit").(int))
+	}
+	if d.HasChange("service_token_connection.0.supported_bandwidths") {
+		replace("/connection/supportedBandwidths", converters.ListToInt32List(d.Get("service_token_connection.0.supported_bandwidths").([]interface{})))
+	}
+	return changeOps
+}
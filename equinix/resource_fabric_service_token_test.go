@@ -0,0 +1,101 @@
+package equinix
+
+import (
+	"context"
+	"testing"
+
+	v4 "github.com/equinix-labs/fabric-go/fabric/v4"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testFabricServiceTokenConfig() map[string]interface{} {
+	return map[string]interface{}{
+		"name":                 "zside-token",
+		"expiration_date_time": "2030-01-02T03:04:05Z",
+		"service_token_connection": []interface{}{
+			map[string]interface{}{
+				"type":                 "EVPL_VC",
+				"bandwidth_limit":      1000,
+				"supported_bandwidths": []interface{}{50, 200, 1000},
+				"z_side": []interface{}{
+					map[string]interface{}{
+						"access_point_selectors": []interface{}{
+							map[string]interface{}{
+								"port": []interface{}{
+									map[string]interface{}{"uuid": "c4d9350e-783c-83cd-1ce0-306a5c00a600"},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		"notifications": []interface{}{
+			map[string]interface{}{
+				"type":   "ALL",
+				"emails": []interface{}{"test@equinix.com"},
+			},
+		},
+	}
+}
+
+func TestFabricServiceToken_create(t *testing.T) {
+	// given
+	c := newFakeAPIConfig(t)
+	d := schema.TestResourceDataRaw(t, resourceFabricServiceToken().Schema, testFabricServiceTokenConfig())
+	// when
+	diags := resourceFabricServiceTokenCreate(context.Background(), d, c)
+	// then
+	require.False(t, diags.HasError(), "Create does not fail: %v", diags)
+	assert.NotEmpty(t, d.Id(), "Service token ID is set")
+	assert.Equal(t, "VC_TOKEN", d.Get("type"))
+	assert.Equal(t, "INACTIVE", d.Get("state"), "Service token state is read")
+	assert.Equal(t, "2030-01-02T03:04:05Z", d.Get("expiration_date_time"))
+	assert.Equal(t, 1000, d.Get("service_token_connection.0.bandwidth_limit"))
+	assert.Equal(t, "COLO", d.Get("service_token_connection.0.z_side.0.access_point_selectors.0.type"), "Access point selector type defaults to COLO")
+	assert.Equal(t, "c4d9350e-783c-83cd-1ce0-306a5c00a600", d.Get("service_token_connection.0.z_side.0.access_point_selectors.0.port.0.uuid"))
+	assert.Empty(t, d.Get("service_token_connection.0.a_side"), "Z-side token has no A-side")
+	assert.Equal(t, "test@equinix.com", d.Get("notifications.0.emails.0"))
+}
+
+func TestFabricServiceToken_delete(t *testing.T) {
+	// given
+	c := newFakeAPIConfig(t)
+	d := schema.TestResourceDataRaw(t, resourceFabricServiceToken().Schema, testFabricServiceTokenConfig())
+	require.False(t, resourceFabricServiceTokenCreate(context.Background(), d, c).HasError())
+	state := d.State()
+	// when
+	diags := resourceFabricServiceTokenDelete(context.Background(), d, c)
+	refreshed := resourceFabricServiceToken().Data(state)
+	readDiags := resourceFabricServiceTokenRead(context.Background(), refreshed, c)
+	// then
+	assert.False(t, diags.HasError(), "Delete does not fail")
+	assert.False(t, readDiags.HasError(), "Read of a deleted token does not fail")
+	assert.Empty(t, refreshed.Id(), "Deleted token is removed from state")
+}
+
+func TestFabricServiceToken_connectionToTerra(t *testing.T) {
+	// given
+	connection := &v4.ServiceTokenConnection{
+		Type_:               "EVPL_VC",
+		SupportedBandwidths: []int32{50, 100},
+		ASide: &v4.ServiceTokenSide{
+			AccessPointSelectors: []v4.AccessPointSelector{
+				{Type_: "COLO", Port: &v4.SimplifiedMetadataEntity{Uuid: "port-uuid", Type_: "XF_PORT"}},
+			},
+		},
+	}
+	// when
+	result := serviceTokenConnectionToTerra(connection)
+	// then
+	require.Len(t, result, 1)
+	mapped := result[0].(map[string]interface{})
+	assert.Equal(t, []interface{}{50, 100}, mapped["supported_bandwidths"])
+	assert.Nil(t, mapped["z_side"], "Missing side is not mapped")
+	selectors := mapped["a_side"].([]interface{})[0].(map[string]interface{})["access_point_selectors"].([]interface{})
+	port := selectors[0].(map[string]interface{})["port"].([]interface{})[0].(map[string]interface{})
+	assert.Equal(t, "port-uuid", port["uuid"])
+	assert.Equal(t, "XF_PORT", port["type"])
+}
@@ -2,6 +2,7 @@ package equinix
 
 import (
 	"context"
+	"crypto/rand"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -17,6 +18,7 @@ import (
 
 	"github.com/equinix/terraform-provider-equinix/internal/converters"
 	"github.com/equinix/terraform-provider-equinix/internal/network"
+	"github.com/equinix/terraform-provider-equinix/internal/tags"
 
 	equinix_errors "github.com/equinix/terraform-provider-equinix/internal/errors"
 
@@ -63,10 +65,21 @@ func resourceMetalDevice() *schema.Resource {
 			},
 
 			"hostname": {
-				Type:        schema.TypeString,
-				Description: "The device hostname used in deployments taking advantage of Layer3 DHCP or metadata service configuration.",
-				Optional:    true,
-				Computed:    true,
+				Type:          schema.TypeString,
+				Description:   "The device hostname used in deployments taking advantage of Layer3 DHCP or metadata service configuration.",
+				Optional:      true,
+				Computed:      true,
+				ValidateFunc:  validateDeviceHostname,
+				ConflictsWith: []string{"hostname_prefix"},
+			},
+
+			"hostname_prefix": {
+				Type:          schema.TypeString,
+				Description:   "Prefix of a hostname generated for the device by appending a random suffix, e.g. web- generates web-k3x9q2. Conflicts with hostname",
+				Optional:      true,
+				ForceNew:      true,
+				ValidateFunc:  validateDeviceHostnamePrefix,
+				ConflictsWith: []string{"hostname"},
 			},
 
 			"description": {
@@ -180,10 +193,25 @@ func resourceMetalDevice() *schema.Resource {
 			},
 			"root_password": {
 				Type:        schema.TypeString,
-				Description: "Root password to the server (disabled after 24 hours)",
+				Description: "Root password to the server, only available until root_password_expires_at",
 				Computed:    true,
 				Sensitive:   true,
 			},
+			"root_password_expires_at": {
+				Type:        schema.TypeString,
+				Description: "When the root password of the server expires, 24 hours after its creation. The root password is removed from the state once expired",
+				Computed:    true,
+			},
+			"spot_instance": {
+				Type:        schema.TypeBool,
+				Description: "Whether the device is a spot instance, created from a spot market request",
+				Computed:    true,
+			},
+			"spot_price_max": {
+				Type:        schema.TypeFloat,
+				Description: "Maximum price per hour bid for the device, for spot instances",
+				Computed:    true,
+			},
 			"locked": {
 				Type:        schema.TypeBool,
 				Description: "Whether the device is locked or unlocked. Locking a device prevents you from deleting or reinstalling the device or performing a firmware update on the device, and it prevents an instance with a termination time set from being reclaimed, even if the termination time was reached",
@@ -296,6 +324,13 @@ func resourceMetalDevice() *schema.Resource {
 				Sensitive:   true,
 				ForceNew:    false, // Computed; see CustomizeDiff below
 			},
+			"vendor_data": {
+				Type:        schema.TypeString,
+				Description: "A string of cloud-init configuration to provide alongside `user_data`, e.g. image-agnostic bootstrap configuration shared across devices. It is combined with `user_data` into a cloud-init multipart archive, and the `user_data` takes precedence where they overlap. Both must start with a cloud-init format header like `#cloud-config` or `#!`. Changes are handled like changes of `user_data`.",
+				Optional:    true,
+				Sensitive:   true,
+				ForceNew:    false, // Computed; see CustomizeDiff below
+			},
 			"custom_data": {
 				Type:        schema.TypeString,
 				Description: "A string of the desired Custom Data for the device.  By default, changing this attribute will cause the provider to destroy and recreate your device.  If `reinstall` is specified or `behavior.allow_changes` includes `\"custom_data\"`, the device will be updated in-place instead of recreated.",
@@ -314,6 +349,26 @@ func resourceMetalDevice() *schema.Resource {
 				Optional:    true,
 				Default:     false,
 			},
+			"custom_image_url": {
+				Type:         schema.TypeString,
+				Description:  "URL of the repository holding the custom image the device should be provisioned from",
+				Optional:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.IsURLWithHTTPorHTTPS,
+			},
+			"custom_image_tag": {
+				Type:         schema.TypeString,
+				Description:  "Tag (commit SHA) of the custom image in `custom_image_url` the device should be provisioned from",
+				Optional:     true,
+				ForceNew:     true,
+				RequiredWith: []string{"custom_image_url"},
+				ValidateFunc: validation.StringIsNotWhiteSpace,
+			},
+			"image_url": {
+				Type:        schema.TypeString,
+				Description: "URL of the image the device was provisioned from",
+				Computed:    true,
+			},
 			"deployed_hardware_reservation_id": {
 				Type:        schema.TypeString,
 				Description: "ID of hardware reservation where this device was deployed. It is useful when using the next-available hardware reservation",
@@ -329,12 +384,7 @@ func resourceMetalDevice() *schema.Resource {
 					return ok && dhwr == new
 				},
 			},
-			"tags": {
-				Type:        schema.TypeList,
-				Description: "Tags attached to the device",
-				Optional:    true,
-				Elem:        &schema.Schema{Type: schema.TypeString},
-			},
+			"tags": tags.Schema("Tags attached to the device"),
 			"storage": {
 				Type:        schema.TypeString,
 				Description: "JSON for custom partitioning. Only usable on reserved hardware. More information in in the [Custom Partitioning and RAID](https://metal.equinix.com/developers/docs/servers/custom-partitioning-raid/) doc",
@@ -351,14 +401,14 @@ func resourceMetalDevice() *schema.Resource {
 				Description: "Array of IDs of the project SSH keys which should be added to the device. If you specify this array, only the listed project SSH keys (and any SSH keys for the users specified in user_ssh_key_ids) will be added. If no SSH keys are specified (both user_ssh_keys_ids and project_ssh_key_ids are empty lists or omitted), all parent project keys, parent project members keys and organization members keys will be included.  Project SSH keys can be created with the [equinix_metal_project_ssh_key](equinix_metal_project_ssh_key.md) resource",
 				Optional:    true,
 				ForceNew:    true,
-				Elem:        &schema.Schema{Type: schema.TypeString},
+				Elem:        &schema.Schema{Type: schema.TypeString, ValidateFunc: validation.IsUUID},
 			},
 			"user_ssh_key_ids": {
 				Type:        schema.TypeList,
 				Description: "Array of IDs of the users whose SSH keys should be added to the device. If you specify this array, only the listed users' SSH keys (and any project SSH keys specified in project_ssh_key_ids) will be added. If no SSH keys are specified (both user_ssh_keys_ids and project_ssh_key_ids are empty lists or omitted), all parent project keys, parent project members keys and organization members keys will be included. User SSH keys can be created with the [equinix_metal_ssh_key](equinix_metal_ssh_key.md) resource",
 				Optional:    true,
 				ForceNew:    true,
-				Elem:        &schema.Schema{Type: schema.TypeString},
+				Elem:        &schema.Schema{Type: schema.TypeString, ValidateFunc: validation.IsUUID},
 			},
 			"ssh_key_ids": {
 				Type:        schema.TypeList,
@@ -373,6 +423,12 @@ func resourceMetalDevice() *schema.Resource {
 				Default:     false,
 				ForceNew:    false,
 			},
+			"check_plan_capacity": {
+				Type:        schema.TypeBool,
+				Description: "If set, changing the plan of the device first checks that the new plan can be provisioned, from the hardware reservations when `hardware_reservation_id` is set or from the metro capacity otherwise. The plan fails instead of destroying the existing device when it can't",
+				Optional:    true,
+				Default:     false,
+			},
 			"force_detach_volumes": {
 				Type:        schema.TypeBool,
 				Description: "Delete device even if it has volumes attached. Only applies for destroy action",
@@ -420,6 +476,13 @@ func resourceMetalDevice() *schema.Resource {
 					},
 				},
 			},
+			"reconcile_strategy": {
+				Type:         schema.TypeString,
+				Description:  "How a difference between the configured `operating_system` and the one reported by the API, e.g. after an out-of-band reinstall, is reconciled. One of `replace` (recreate the device, unless `reinstall` is enabled) or `reinstall` (reinstall the device in-place)",
+				Optional:     true,
+				Default:      deviceReconcileReplace,
+				ValidateFunc: validation.StringInSlice([]string{deviceReconcileReplace, deviceReconcileReinstall}, false),
+			},
 			"behavior": {
 				Type:     schema.TypeList,
 				MaxItems: 1,
@@ -450,11 +513,21 @@ func resourceMetalDevice() *schema.Resource {
 				Description: "The hostname to use for [Serial over SSH](https://deploy.equinix.com/developers/docs/metal/resilience-recovery/serial-over-ssh/) access to the device",
 				Computed:    true,
 			},
+			"sos_url": {
+				Type:        schema.TypeString,
+				Description: "The SSH URL of the [Serial over SSH](https://deploy.equinix.com/developers/docs/metal/resilience-recovery/serial-over-ssh/) console of the device, e.g. ssh://<device_id>@sos.<facility>.platformequinix.com. SOS accepts the SSH keys of the project and of its members",
+				Computed:    true,
+			},
 		},
 		CustomizeDiff: customdiff.Sequence(
 			customdiff.ForceNewIf("custom_data", reinstallDisabledAndNoChangesAllowed("custom_data")),
-			customdiff.ForceNewIf("operating_system", reinstallDisabled),
+			customdiff.ForceNewIf("operating_system", reinstallDisabledAndNotReconciled),
 			customdiff.ForceNewIf("user_data", reinstallDisabledAndNoChangesAllowed("user_data")),
+			customdiff.ForceNewIf("vendor_data", reinstallDisabledAndNoChangesAllowed("user_data")),
+			customdiff.ValidateValue("ip_address", validateDeviceIPAddresses),
+			generateDeviceHostname,
+			checkDevicePlanCapacity,
+			validateDeviceOperatingSystem,
 		),
 	}
 }
@@ -477,6 +550,12 @@ func reinstallDisabled(_ context.Context, d *schema.ResourceDiff, meta interface
 	return !reinstall_config["enabled"].(bool)
 }
 
+// This method returns true if reinstall is disabled and operating system changes are not
+// reconciled by reinstalling the device. This is used to set ForceNew on operating_system
+func reinstallDisabledAndNotReconciled(ctx context.Context, d *schema.ResourceDiff, meta interface{}) bool {
+	return reinstallDisabled(ctx, d, meta) && d.Get("reconcile_strategy").(string) != deviceReconcileReinstall
+}
+
 func reinstallDisabledAndNoChangesAllowed(attribute string) customdiff.ResourceConditionFunc {
 	return func(ctx context.Context, d *schema.ResourceDiff, meta interface{}) bool {
 		if reinstallDisabled(ctx, d, meta) {
@@ -579,7 +658,7 @@ func resourceMetalDeviceRead(ctx context.Context, d *schema.ResourceData, meta i
 		// If the device somehow already destroyed, mark as successfully gone.
 		// Checking d.IsNewResource prevents the creation of a resource from failing
 		// silently. Note d.IsNewResource is false in resource import operations.
-		if !d.IsNewResource() && (equinix_errors.IsNotFound(err) || equinix_errors.IsForbidden(err)) {
+		if !d.IsNewResource() && equinix_errors.IsGone(resp, err) {
 			log.Printf("[WARN] Device (%s) not found or in failed status, removing from state", d.Id())
 			d.SetId("")
 			return nil
@@ -595,6 +674,9 @@ func resourceMetalDeviceRead(ctx context.Context, d *schema.ResourceData, meta i
 	if device.Metro != nil {
 		d.Set("metro", device.Metro.GetCode())
 	}
+	if os, ok := d.GetOk("operating_system"); ok && os.(string) != device.OperatingSystem.GetSlug() {
+		log.Printf("[WARN] Device (%s) operating system changed outside of Terraform from %q to %q", d.Id(), os, device.OperatingSystem.GetSlug())
+	}
 	d.Set("operating_system", device.OperatingSystem.GetSlug())
 	d.Set("state", device.GetState())
 	d.Set("billing_cycle", device.GetBillingCycle())
@@ -603,9 +685,15 @@ func resourceMetalDeviceRead(ctx context.Context, d *schema.ResourceData, meta i
 	d.Set("updated", device.GetUpdatedAt().Format(time.RFC3339))
 	d.Set("ipxe_script_url", device.GetIpxeScriptUrl())
 	d.Set("always_pxe", device.GetAlwaysPxe())
-	d.Set("root_password", device.GetRootPassword())
+	d.Set("image_url", device.GetImageUrl())
+	rootPassword, rootPasswordExpiresAt := deviceRootPassword(*device, time.Now())
+	d.Set("root_password", rootPassword)
+	d.Set("root_password_expires_at", rootPasswordExpiresAt)
+	d.Set("spot_instance", device.GetSpotInstance())
+	d.Set("spot_price_max", deviceSpotPriceMax(*device))
 	d.Set("project_id", device.Project.GetId())
 	d.Set("sos_hostname", device.GetSos())
+	d.Set("sos_url", deviceSosURL(*device))
 	if device.Storage != nil {
 		rawStorageBytes, err := json.Marshal(device.Storage)
 		if err != nil {
@@ -640,12 +728,17 @@ func resourceMetalDeviceRead(ctx context.Context, d *schema.ResourceData, meta i
 	if _, ok := d.GetOk(tt); !ok {
 		d.Set(tt, nil)
 	}
+	if _, ok := d.GetOk("reconcile_strategy"); !ok {
+		d.Set("reconcile_strategy", deviceReconcileReplace)
+	}
 
 	d.Set("tags", device.Tags)
 	keyIDs := []string{}
 	for _, k := range device.SshKeys {
 		keyIDs = append(keyIDs, path.Base(k.Href))
 	}
+	// the API does not guarantee the order of the keys
+	sort.Strings(keyIDs)
 	d.Set("ssh_key_ids", keyIDs)
 	networkInfo := getNetworkInfo(device.IpAddresses)
 
@@ -689,8 +782,11 @@ func resourceMetalDeviceUpdate(ctx context.Context, d *schema.ResourceData, meta
 		dDesc := d.Get("description").(string)
 		ur.Description = &dDesc
 	}
-	if d.HasChange("user_data") {
-		dUserData := d.Get("user_data").(string)
+	if d.HasChange("user_data") || d.HasChange("vendor_data") {
+		dUserData, err := deviceUserdata(d.Get("user_data").(string), d.Get("vendor_data").(string))
+		if err != nil {
+			return diag.FromErr(err)
+		}
 		ur.Userdata = &dUserData
 	}
 	if d.HasChange("custom_data") {
@@ -699,6 +795,10 @@ func resourceMetalDeviceUpdate(ctx context.Context, d *schema.ResourceData, meta
 		if err != nil {
 			return diag.Errorf("error reading custom_data from state: %v", err)
 		}
+		customdata, err = addCustomImageToCustomdata(customdata, d.Get("custom_image_url").(string), d.Get("custom_image_tag").(string))
+		if err != nil {
+			return diag.FromErr(err)
+		}
 		ur.Customdata = customdata
 	}
 	if d.HasChange("hostname") {
@@ -706,18 +806,8 @@ func resourceMetalDeviceUpdate(ctx context.Context, d *schema.ResourceData, meta
 		ur.Hostname = &dHostname
 	}
 	if d.HasChange("tags") {
-		ts := d.Get("tags")
-		sts := []string{}
-
-		switch ts.(type) {
-		case []interface{}:
-			for _, v := range ts.([]interface{}) {
-				sts = append(sts, v.(string))
-			}
-			ur.Tags = sts
-		default:
-			return diag.Errorf("garbage in tags: %s", ts)
-		}
+		sts := tags.Expand(d.Get("tags"))
+		ur.Tags = sts
 	}
 	if d.HasChange("ipxe_script_url") {
 		dUrl := d.Get("ipxe_script_url").(string)
@@ -743,20 +833,22 @@ func resourceMetalDeviceUpdate(ctx context.Context, d *schema.ResourceData, meta
 }
 
 func doReinstall(ctx context.Context, client *metalv1.APIClient, d *schema.ResourceData, meta interface{}, start time.Time) error {
-	if d.HasChange("operating_system") || d.HasChange("user_data") || d.HasChange("custom_data") {
-		reinstall, ok := d.GetOk("reinstall")
-
-		if !ok {
-			// Assume we're here because behavior.allow_changes was set (not an error)
-			return nil
+	if d.HasChange("operating_system") || d.HasChange("user_data") || d.HasChange("vendor_data") || d.HasChange("custom_data") {
+		reinstall_config := map[string]interface{}{
+			"enabled":          false,
+			"preserve_data":    false,
+			"deprovision_fast": false,
+		}
+		if reinstall, ok := d.GetOk("reinstall"); ok {
+			reinstall_list := reinstall.([]interface{})
+			reinstall_config = reinstall_list[0].(map[string]interface{})
 		}
 
-		reinstall_list := reinstall.([]interface{})
-		reinstall_config := reinstall_list[0].(map[string]interface{})
+		reconcile := d.HasChange("operating_system") && d.Get("reconcile_strategy").(string) == deviceReconcileReinstall
 
-		if !reinstall_config["enabled"].(bool) {
-			// This means a reinstall block was provided, but reinstall was explicitly
-			// disabled.  Assume we're here because behavior.allow_changes was set (not an error)
+		if !reinstall_config["enabled"].(bool) && !reconcile {
+			// This means reinstall is disabled or no reinstall block was provided.
+			// Assume we're here because behavior.allow_changes was set (not an error)
 			return nil
 		}
 
@@ -905,16 +997,27 @@ func setupDeviceCreateRequest(d *schema.ResourceData, createRequest deviceCreate
 		createRequest.SetBillingCycle(*billingCycle)
 	}
 
-	if attr, ok := d.GetOk("user_data"); ok {
-		createRequest.SetUserdata(attr.(string))
+	userData, err := deviceUserdata(d.Get("user_data").(string), d.Get("vendor_data").(string))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	if userData != "" {
+		createRequest.SetUserdata(userData)
 	}
 
+	var customdata map[string]interface{}
 	if attr, ok := d.GetOk("custom_data"); ok {
-		var customdata map[string]interface{}
 		err := json.Unmarshal([]byte(attr.(string)), &customdata)
 		if err != nil {
 			return diag.FromErr(err)
 		}
+	}
+
+	customdata, err = addCustomImageToCustomdata(customdata, d.Get("custom_image_url").(string), d.Get("custom_image_tag").(string))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	if customdata != nil {
 		createRequest.SetCustomdata(customdata)
 	}
 
@@ -978,9 +1081,8 @@ func setupDeviceCreateRequest(d *schema.ResourceData, createRequest deviceCreate
 		createRequest.SetUserSshKeys(converters.IfArrToStringArr(d.Get("user_ssh_key_ids").([]interface{})))
 	}
 
-	tags := d.Get("tags.#").(int)
-	if tags > 0 {
-		createRequest.SetTags(converters.IfArrToStringArr(d.Get("tags").([]interface{})))
+	if tagsCount := d.Get("tags.#").(int); tagsCount > 0 {
+		createRequest.SetTags(tags.Expand(d.Get("tags")))
 	}
 
 	if attr, ok := d.GetOk("storage"); ok {
@@ -999,6 +1101,91 @@ func setupDeviceCreateRequest(d *schema.ResourceData, createRequest deviceCreate
 	return nil
 }
 
+// validateDeviceIPAddresses checks that each IP address type is requested at
+// most once and that the CIDR suffixes fit the address family. A device can be
+// provisioned with only a public IPv6 address, but requesting a public IPv4
+// address also requires a private IPv4 address.
+func validateDeviceIPAddresses(_ context.Context, v, _ interface{}) error {
+	types := map[string]bool{}
+	for _, raw := range v.([]interface{}) {
+		ia, ok := raw.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		at := ia["type"].(string)
+		if types[at] {
+			return fmt.Errorf("ip_address of type %q can only be specified once", at)
+		}
+		types[at] = true
+
+		maxCidr := 32
+		if at == "public_ipv6" {
+			maxCidr = 128
+		}
+		if cidr := ia["cidr"].(int); cidr < 0 || cidr > maxCidr {
+			return fmt.Errorf("cidr of ip_address of type %q must be between 0 and %d, got %d", at, maxCidr, cidr)
+		}
+		if reservations, ok := ia["reservation_ids"].([]interface{}); ok && len(reservations) > 0 && at != "public_ipv4" {
+			return fmt.Errorf("reservation_ids can only be specified for ip_address of type %q", "public_ipv4")
+		}
+	}
+	if types["public_ipv4"] && !types["private_ipv4"] {
+		return fmt.Errorf("ip_address of type %q requires an ip_address of type %q, omit both for an IPv6 only device", "public_ipv4", "private_ipv4")
+	}
+	return nil
+}
+
+const deviceHostnameSuffixLength = 6
+
+var deviceHostnameRegexp = regexp.MustCompile(`^[a-zA-Z0-9]([a-zA-Z0-9-]{0,61}[a-zA-Z0-9])?(\.[a-zA-Z0-9]([a-zA-Z0-9-]{0,61}[a-zA-Z0-9])?)*$`)
+
+// validateDeviceHostname checks that the hostname is a valid RFC 1123
+// hostname: dot separated labels of at most 63 letters, digits and hyphens,
+// that don't start or end with a hyphen, 253 characters long at most.
+func validateDeviceHostname(v interface{}, k string) (ws []string, errs []error) {
+	hostname := v.(string)
+	if len(hostname) > 253 || !deviceHostnameRegexp.MatchString(hostname) {
+		errs = append(errs, fmt.Errorf("%s %q is not a valid hostname, it must consist of dot separated labels of up to 63 letters, digits and hyphens, that don't start or end with a hyphen", k, hostname))
+	}
+	return ws, errs
+}
+
+// validateDeviceHostnamePrefix checks that the prefix followed by a generated
+// suffix is a valid hostname.
+func validateDeviceHostnamePrefix(v interface{}, k string) (ws []string, errs []error) {
+	prefix := v.(string)
+	if _, hostnameErrs := validateDeviceHostname(prefix+strings.Repeat("a", deviceHostnameSuffixLength), k); len(hostnameErrs) > 0 {
+		errs = append(errs, fmt.Errorf("%s %q can not be followed by a %d characters suffix to form a valid hostname", k, prefix, deviceHostnameSuffixLength))
+	}
+	return ws, errs
+}
+
+// generateDeviceHostname plans the hostname of a new device from its
+// hostname_prefix, so the hostname is known before the device is created.
+func generateDeviceHostname(_ context.Context, d *schema.ResourceDiff, _ interface{}) error {
+	prefix := d.Get("hostname_prefix").(string)
+	if d.Id() != "" || prefix == "" {
+		return nil
+	}
+	suffix, err := randomHostnameSuffix(deviceHostnameSuffixLength)
+	if err != nil {
+		return fmt.Errorf("error generating hostname from hostname_prefix: %s", err)
+	}
+	return d.SetNew("hostname", prefix+suffix)
+}
+
+func randomHostnameSuffix(length int) (string, error) {
+	const chars = "abcdefghijklmnopqrstuvwxyz0123456789"
+	b := make([]byte, length)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	for i := range b {
+		b[i] = chars[int(b[i])%len(chars)]
+	}
+	return string(b), nil
+}
+
 func getNewIPAddressSlice(arr []interface{}) []metalv1.IPAddress {
 	addressTypesSlice := make([]metalv1.IPAddress, len(arr))
 
@@ -0,0 +1,101 @@
+package equinix
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/equinix/equinix-sdk-go/services/metalv1"
+	"github.com/equinix/terraform-provider-equinix/internal/cache"
+	"github.com/equinix/terraform-provider-equinix/internal/config"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// validateDeviceOperatingSystem turns the most frequent provisioning failure
+// into a plan-time error: it checks that the operating system can be
+// installed on the plan of the device and, for on-demand devices in a metro,
+// that the metro has capacity for the plan.
+func validateDeviceOperatingSystem(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	if d.Id() != "" && !d.HasChanges("operating_system", "plan", "metro") {
+		return nil
+	}
+	if !d.NewValueKnown("operating_system") || !d.NewValueKnown("plan") || !d.NewValueKnown("metro") {
+		// The combination is not known until apply, it can't be checked
+		return nil
+	}
+	os, plan := d.Get("operating_system").(string), d.Get("plan").(string)
+	if os == "" || plan == "" {
+		return nil
+	}
+	c, ok := meta.(*config.Config)
+	if !ok || c.Metalgo == nil {
+		// Without a configured provider the API can't be queried
+		return nil
+	}
+
+	oss, err := cachedMetalGoOperatingSystems(ctx, c)
+	if err != nil {
+		return fmt.Errorf("error listing operating systems to validate %s on plan %s: %s", os, plan, err)
+	}
+	if err := operatingSystemPlanError(oss, os, plan); err != nil {
+		return err
+	}
+
+	metro := d.Get("metro").(string)
+	if d.Id() != "" || metro == "" || d.Get("hardware_reservation_id").(string) != "" {
+		// Capacity of existing devices is checked by check_plan_capacity,
+		// reserved hardware doesn't depend on the metro capacity
+		return nil
+	}
+	available, err := cachedMetroCapacity(ctx, c, metro, plan)
+	if err != nil {
+		return fmt.Errorf("error checking capacity of metro %s for plan %s: %s", metro, plan, err)
+	}
+	if !available {
+		return fmt.Errorf("plan %s is not available in metro %s, a device with operating system %s can't be provisioned there", plan, metro, os)
+	}
+	return nil
+}
+
+// operatingSystemPlanError returns an error if the operating system is
+// unknown or can't be installed on the plan.
+func operatingSystemPlanError(oss []metalv1.OperatingSystem, os, plan string) error {
+	for _, o := range oss {
+		if o.GetSlug() != os {
+			continue
+		}
+		for _, p := range o.GetProvisionableOn() {
+			if p == plan {
+				return nil
+			}
+		}
+		return fmt.Errorf("operating system %s can't be provisioned on plan %s, it is provisionable on: %v", os, plan, o.GetProvisionableOn())
+	}
+	return fmt.Errorf("unknown operating system %s, see the equinix_metal_operating_system data source to find its slug", os)
+}
+
+// cachedMetroCapacity checks the capacity of the metro for a device of the
+// plan once per provider configuration, so that planning many devices doesn't
+// check it for each of them.
+func cachedMetroCapacity(ctx context.Context, c *config.Config, metro, plan string) (bool, error) {
+	v, err := c.Catalog.Get(cache.Key("metal/capacity/metros", metro, plan), func() (interface{}, error) {
+		serverInfo := metalv1.ServerInfo{}
+		serverInfo.SetMetro(metro)
+		serverInfo.SetPlan(plan)
+		serverInfo.SetQuantity("1")
+		res, _, err := c.Metalgo.CapacityApi.CheckCapacityForMetro(ctx).
+			CapacityInput(metalv1.CapacityInput{Servers: []metalv1.ServerInfo{serverInfo}}).
+			Execute()
+		if err != nil {
+			return nil, err
+		}
+		available := true
+		for _, s := range res.Servers {
+			available = available && s.GetAvailable()
+		}
+		return available, nil
+	})
+	if err != nil {
+		return false, err
+	}
+	return v.(bool), nil
+}
@@ -0,0 +1,31 @@
+package equinix
+
+import (
+	"testing"
+
+	"github.com/equinix/equinix-sdk-go/services/metalv1"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMetalDevice_operatingSystemPlanError(t *testing.T) {
+	// given
+	operatingSystem := func(slug string, plans ...string) metalv1.OperatingSystem {
+		os := metalv1.OperatingSystem{}
+		os.SetSlug(slug)
+		os.SetProvisionableOn(plans)
+		return os
+	}
+	oss := []metalv1.OperatingSystem{
+		operatingSystem("ubuntu_22_04", "c3.small.x86", "m3.large.x86"),
+		operatingSystem("windows_2022", "m3.large.x86"),
+	}
+	// when
+	provisionable := operatingSystemPlanError(oss, "ubuntu_22_04", "c3.small.x86")
+	notProvisionable := operatingSystemPlanError(oss, "windows_2022", "c3.small.x86")
+	unknown := operatingSystemPlanError(oss, "ubuntu_99_04", "c3.small.x86")
+	// then
+	assert.NoError(t, provisionable, "Operating system provisionable on the plan is valid")
+	assert.ErrorContains(t, notProvisionable, "operating system windows_2022 can't be provisioned on plan c3.small.x86")
+	assert.ErrorContains(t, notProvisionable, "m3.large.x86", "Error lists the plans the operating system is provisionable on")
+	assert.ErrorContains(t, unknown, "unknown operating system ubuntu_99_04")
+}
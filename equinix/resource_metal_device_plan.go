@@ -0,0 +1,100 @@
+package equinix
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/equinix/equinix-sdk-go/services/metalv1"
+	"github.com/equinix/terraform-provider-equinix/internal/config"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// checkDevicePlanCapacity guards the replacement of a device when its plan
+// changes. If check_plan_capacity is enabled it verifies, before the existing
+// device is destroyed, that the new plan can be provisioned: either from a
+// hardware reservation, or from the on-demand capacity of the device metro.
+func checkDevicePlanCapacity(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	if d.Id() == "" || !d.HasChange("plan") || !d.Get("check_plan_capacity").(bool) {
+		return nil
+	}
+	plan := d.Get("plan").(string)
+	if plan == "" {
+		// The plan is not known until apply, it can't be checked
+		return nil
+	}
+	client := meta.(*config.Config).Metalgo
+
+	switch reservationID := d.Get("hardware_reservation_id").(string); reservationID {
+	case "":
+	case "next-available":
+		projectID := d.Get("project_id").(string)
+		reservations, err := client.HardwareReservationsApi.FindProjectHardwareReservations(ctx, projectID).
+			Provisionable(metalv1.FINDPROJECTHARDWARERESERVATIONSPROVISIONABLEPARAMETER_ONLY).
+			Include([]string{"plan"}).
+			ExecuteWithPagination()
+		if err != nil {
+			return fmt.Errorf("error checking hardware reservations of project %s for plan %s: %s", projectID, plan, err)
+		}
+		return checkReservationsForPlan(reservations.HardwareReservations, plan)
+	default:
+		reservation, _, err := client.HardwareReservationsApi.FindHardwareReservationById(ctx, reservationID).
+			Include([]string{"plan"}).
+			Execute()
+		if err != nil {
+			return fmt.Errorf("error checking hardware reservation %s for plan %s: %s", reservationID, plan, err)
+		}
+		if slug := reservation.Plan.GetSlug(); slug != plan {
+			return fmt.Errorf("hardware reservation %s is for plan %s, it can't be used for plan %s; the device was not replaced", reservationID, slug, plan)
+		}
+		return nil
+	}
+
+	serverInfo := metalv1.ServerInfo{}
+	serverInfo.SetPlan(plan)
+	serverInfo.SetQuantity("1")
+	if metro := d.Get("metro").(string); metro != "" {
+		serverInfo.SetMetro(metro)
+		res, _, err := client.CapacityApi.CheckCapacityForMetro(ctx).
+			CapacityInput(metalv1.CapacityInput{Servers: []metalv1.ServerInfo{serverInfo}}).
+			Execute()
+		if err != nil {
+			return fmt.Errorf("error checking capacity of metro %s for plan %s: %s", metro, plan, err)
+		}
+		for _, s := range res.Servers {
+			if !s.GetAvailable() {
+				return planCapacityError(plan, "metro "+metro)
+			}
+		}
+		return nil
+	}
+	if facility := d.Get("deployed_facility").(string); facility != "" {
+		serverInfo.SetFacility(facility)
+		res, _, err := client.CapacityApi.CheckCapacityForFacility(ctx).
+			CapacityInput(metalv1.CapacityInput{Servers: []metalv1.ServerInfo{serverInfo}}).
+			Execute()
+		if err != nil {
+			return fmt.Errorf("error checking capacity of facility %s for plan %s: %s", facility, plan, err)
+		}
+		for _, s := range res.Servers {
+			if !s.GetAvailable() {
+				return planCapacityError(plan, "facility "+facility)
+			}
+		}
+	}
+	return nil
+}
+
+// checkReservationsForPlan returns an error if none of the given provisionable
+// hardware reservations is for the plan.
+func checkReservationsForPlan(reservations []metalv1.HardwareReservation, plan string) error {
+	for _, r := range reservations {
+		if r.GetProvisionable() && r.Plan.GetSlug() == plan {
+			return nil
+		}
+	}
+	return fmt.Errorf("no provisionable hardware reservation is available for plan %s; the device was not replaced", plan)
+}
+
+func planCapacityError(plan, location string) error {
+	return fmt.Errorf("not enough capacity in %s for a device of plan %s; the device was not replaced", location, plan)
+}
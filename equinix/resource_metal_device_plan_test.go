@@ -0,0 +1,30 @@
+package equinix
+
+import (
+	"testing"
+
+	"github.com/equinix/equinix-sdk-go/services/metalv1"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMetalDevice_checkReservationsForPlan(t *testing.T) {
+	// given
+	reservation := func(plan string, provisionable bool) metalv1.HardwareReservation {
+		r := metalv1.HardwareReservation{Plan: &metalv1.Plan{}}
+		r.Plan.SetSlug(plan)
+		r.SetProvisionable(provisionable)
+		return r
+	}
+	reservations := []metalv1.HardwareReservation{
+		reservation("c3.small.x86", true),
+		reservation("m3.large.x86", false),
+	}
+	// when
+	available := checkReservationsForPlan(reservations, "c3.small.x86")
+	notProvisionable := checkReservationsForPlan(reservations, "m3.large.x86")
+	missing := checkReservationsForPlan(reservations, "s3.xlarge.x86")
+	// then
+	assert.NoError(t, available, "A provisionable reservation of the plan can be reused")
+	assert.ErrorContains(t, notProvisionable, "no provisionable hardware reservation is available for plan m3.large.x86")
+	assert.ErrorContains(t, missing, "the device was not replaced")
+}
@@ -0,0 +1,202 @@
+package equinix
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/equinix/equinix-sdk-go/services/metalv1"
+	"github.com/equinix/terraform-provider-equinix/internal/config"
+	equinix_errors "github.com/equinix/terraform-provider-equinix/internal/errors"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/retry"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+const (
+	devicePowerOn  = "on"
+	devicePowerOff = "off"
+)
+
+func resourceMetalDevicePower() *schema.Resource {
+	return &schema.Resource{
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(20 * time.Minute),
+			Update: schema.DefaultTimeout(20 * time.Minute),
+		},
+		CreateContext: resourceMetalDevicePowerCreate,
+		ReadContext:   resourceMetalDevicePowerRead,
+		UpdateContext: resourceMetalDevicePowerUpdate,
+		DeleteContext: resourceMetalDevicePowerDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+		CustomizeDiff: validateDevicePowerChange,
+
+		Schema: map[string]*schema.Schema{
+			"device_id": {
+				Type:        schema.TypeString,
+				Description: "The ID of the device whose power state should be managed",
+				Required:    true,
+				ForceNew:    true,
+			},
+			"power_state": {
+				Type:         schema.TypeString,
+				Description:  fmt.Sprintf("Desired power state of the device. Must be one of %q or %q", devicePowerOn, devicePowerOff),
+				Required:     true,
+				ValidateFunc: validation.StringInSlice([]string{devicePowerOn, devicePowerOff}, false),
+			},
+			"reboot_triggers": {
+				Type:        schema.TypeMap,
+				Description: "Arbitrary map of values that, when changed, will reboot a powered on device",
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+			"state": {
+				Type:        schema.TypeString,
+				Description: "The current state of the device",
+				Computed:    true,
+			},
+		},
+	}
+}
+
+// validateDevicePowerChange rejects a change of reboot_triggers planned
+// together with a change of power_state, since only the power state change
+// would be applied and the reboot would be skipped.
+func validateDevicePowerChange(_ context.Context, d *schema.ResourceDiff, _ interface{}) error {
+	if d.Id() == "" || !d.HasChange("power_state") || !d.HasChange("reboot_triggers") {
+		return nil
+	}
+	return fmt.Errorf("reboot_triggers can't be changed together with power_state, change power_state first and reboot_triggers in a later apply")
+}
+
+func resourceMetalDevicePowerCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	d.SetId(d.Get("device_id").(string))
+	if err := setDevicePowerState(ctx, d, meta, d.Timeout(schema.TimeoutCreate)); err != nil {
+		d.SetId("")
+		return diag.FromErr(err)
+	}
+	return resourceMetalDevicePowerRead(ctx, d, meta)
+}
+
+func resourceMetalDevicePowerRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	meta.(*config.Config).AddModuleToMetalGoUserAgent(d)
+	client := meta.(*config.Config).Metalgo
+
+	device, resp, err := client.DevicesApi.FindDeviceById(ctx, d.Id()).Execute()
+	if err != nil {
+		err = equinix_errors.FriendlyErrorForMetalGo(err, resp)
+
+		if !d.IsNewResource() && equinix_errors.IsNotFound(err) {
+			log.Printf("[WARN] Device (%s) for power request not found, removing from state", d.Id())
+			d.SetId("")
+			return nil
+		}
+
+		return diag.FromErr(err)
+	}
+
+	d.Set("device_id", device.GetId())
+	d.Set("state", string(device.GetState()))
+	switch device.GetState() {
+	case metalv1.DEVICESTATE_ACTIVE:
+		d.Set("power_state", devicePowerOn)
+	case metalv1.DEVICESTATE_INACTIVE:
+		d.Set("power_state", devicePowerOff)
+	}
+	return nil
+}
+
+func resourceMetalDevicePowerUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	timeout := d.Timeout(schema.TimeoutUpdate)
+	if d.HasChange("power_state") {
+		if err := setDevicePowerState(ctx, d, meta, timeout); err != nil {
+			return diag.FromErr(err)
+		}
+	} else if d.HasChange("reboot_triggers") && d.Get("power_state").(string) == devicePowerOn {
+		if err := performDevicePowerAction(ctx, d, meta, metalv1.DEVICEACTIONINPUTTYPE_REBOOT, timeout); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+	return resourceMetalDevicePowerRead(ctx, d, meta)
+}
+
+func resourceMetalDevicePowerDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	// Removing the resource leaves the device in its current power state
+	return nil
+}
+
+// setDevicePowerState powers the device on or off according to power_state,
+// doing nothing if the device is already in the desired state.
+func setDevicePowerState(ctx context.Context, d *schema.ResourceData, meta interface{}, timeout time.Duration) error {
+	meta.(*config.Config).AddModuleToMetalGoUserAgent(d)
+	client := meta.(*config.Config).Metalgo
+
+	device, resp, err := client.DevicesApi.FindDeviceById(ctx, d.Id()).Execute()
+	if err != nil {
+		return equinix_errors.FriendlyErrorForMetalGo(err, resp)
+	}
+
+	action := metalv1.DEVICEACTIONINPUTTYPE_POWER_ON
+	target := metalv1.DEVICESTATE_ACTIVE
+	if d.Get("power_state").(string) == devicePowerOff {
+		action = metalv1.DEVICEACTIONINPUTTYPE_POWER_OFF
+		target = metalv1.DEVICESTATE_INACTIVE
+	}
+	if device.GetState() == target {
+		return nil
+	}
+	return performDevicePowerAction(ctx, d, meta, action, timeout)
+}
+
+func performDevicePowerAction(ctx context.Context, d *schema.ResourceData, meta interface{}, action metalv1.DeviceActionInputType, timeout time.Duration) error {
+	client := meta.(*config.Config).Metalgo
+
+	resp, err := client.DevicesApi.PerformAction(ctx, d.Id()).DeviceActionInput(*metalv1.NewDeviceActionInput(action)).Execute()
+	if err != nil {
+		return equinix_errors.FriendlyErrorForMetalGo(err, resp)
+	}
+	return waitForDevicePowerState(ctx, d, meta, action, timeout)
+}
+
+func waitForDevicePowerState(ctx context.Context, d *schema.ResourceData, meta interface{}, action metalv1.DeviceActionInputType, timeout time.Duration) error {
+	target := string(metalv1.DEVICESTATE_ACTIVE)
+	pending := []string{string(metalv1.DEVICESTATE_INACTIVE), string(metalv1.DEVICESTATE_POWERING_ON)}
+	var delay time.Duration
+	switch action {
+	case metalv1.DEVICEACTIONINPUTTYPE_POWER_OFF:
+		target = string(metalv1.DEVICESTATE_INACTIVE)
+		pending = []string{string(metalv1.DEVICESTATE_ACTIVE), string(metalv1.DEVICESTATE_POWERING_OFF)}
+	case metalv1.DEVICEACTIONINPUTTYPE_REBOOT:
+		// A rebooting device may still be reported active right after the
+		// action was accepted, so give it some time before polling
+		delay = 10 * time.Second
+		pending = append(pending, string(metalv1.DEVICESTATE_POWERING_OFF))
+	}
+
+	stateConf := &retry.StateChangeConf{
+		Pending: pending,
+		Target:  []string{target},
+		Refresh: func() (interface{}, string, error) {
+			client := meta.(*config.Config).Metalgo
+
+			device, resp, err := client.DevicesApi.FindDeviceById(ctx, d.Id()).Execute()
+			if err != nil {
+				return nil, "", equinix_errors.FriendlyErrorForMetalGo(err, resp)
+			}
+			state := string(device.GetState())
+			return state, state, nil
+		},
+		Timeout:    timeout,
+		Delay:      delay,
+		MinTimeout: 3 * time.Second,
+	}
+
+	if _, err := waitForDeviceAttribute(ctx, d, stateConf); err != nil {
+		return fmt.Errorf("error waiting for device (%s) to be %s: %s", d.Id(), target, err)
+	}
+	return nil
+}
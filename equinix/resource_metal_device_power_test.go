@@ -0,0 +1,93 @@
+package equinix
+
+import (
+	"context"
+	"testing"
+
+	"github.com/equinix/equinix-sdk-go/services/metalv1"
+	"github.com/equinix/terraform-provider-equinix/internal/config"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func fakeMetalDevice(t *testing.T) (*config.Config, string) {
+	c := newFakeAPIConfig(t)
+
+	ctx := context.Background()
+	project, _, err := c.Metalgo.ProjectsApi.CreateProject(ctx).
+		ProjectCreateFromRootInput(*metalv1.NewProjectCreateFromRootInput("test")).Execute()
+	require.NoError(t, err)
+	input := metalv1.NewDeviceCreateInMetroInput("sv", "ubuntu_22_04", "c3.small.x86")
+	device, _, err := c.Metalgo.DevicesApi.CreateDevice(ctx, project.GetId()).
+		CreateDeviceRequest(metalv1.DeviceCreateInMetroInputAsCreateDeviceRequest(input)).Execute()
+	require.NoError(t, err)
+	return c, device.GetId()
+}
+
+func TestMetalDevicePower_offAndOn(t *testing.T) {
+	// given
+	c, deviceID := fakeMetalDevice(t)
+	ctx := context.Background()
+	off := schema.TestResourceDataRaw(t, resourceMetalDevicePower().Schema, map[string]interface{}{
+		"device_id":   deviceID,
+		"power_state": devicePowerOff,
+	})
+	on := schema.TestResourceDataRaw(t, resourceMetalDevicePower().Schema, map[string]interface{}{
+		"device_id":   deviceID,
+		"power_state": devicePowerOn,
+	})
+	on.SetId(deviceID)
+	// when
+	offDiags := resourceMetalDevicePowerCreate(ctx, off, c)
+	onDiags := resourceMetalDevicePowerUpdate(ctx, on, c)
+	// then
+	assert.False(t, offDiags.HasError(), "Device is powered off without errors")
+	assert.Equal(t, deviceID, off.Id(), "Resource ID is the device ID")
+	assert.Equal(t, string(metalv1.DEVICESTATE_INACTIVE), off.Get("state"), "Device is inactive")
+	assert.False(t, onDiags.HasError(), "Device is powered on without errors")
+	assert.Equal(t, string(metalv1.DEVICESTATE_ACTIVE), on.Get("state"), "Device is active")
+	assert.Equal(t, devicePowerOn, on.Get("power_state"), "Power state is on")
+}
+
+func TestMetalDevicePower_alreadyInState(t *testing.T) {
+	// given
+	c, deviceID := fakeMetalDevice(t)
+	d := schema.TestResourceDataRaw(t, resourceMetalDevicePower().Schema, map[string]interface{}{
+		"device_id":   deviceID,
+		"power_state": devicePowerOn,
+	})
+	// when
+	diags := resourceMetalDevicePowerCreate(context.Background(), d, c)
+	// then
+	assert.False(t, diags.HasError(), "Creating the resource for an active device succeeds")
+	assert.Equal(t, string(metalv1.DEVICESTATE_ACTIVE), d.Get("state"), "Device stays active")
+}
+
+func TestMetalDevicePower_validateDevicePowerChange(t *testing.T) {
+	// given
+	r := resourceMetalDevicePower()
+	old := schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{
+		"device_id":       "device",
+		"power_state":     devicePowerOff,
+		"reboot_triggers": map[string]interface{}{"version": "1"},
+	})
+	old.SetId("device")
+	plan := func(powerState, version string) error {
+		_, err := r.Diff(context.Background(), old.State(), terraform.NewResourceConfigRaw(map[string]interface{}{
+			"device_id":       "device",
+			"power_state":     powerState,
+			"reboot_triggers": map[string]interface{}{"version": version},
+		}), nil)
+		return err
+	}
+	// when
+	powerOnly := plan(devicePowerOn, "1")
+	rebootOnly := plan(devicePowerOff, "2")
+	both := plan(devicePowerOn, "2")
+	// then
+	assert.NoError(t, powerOnly, "Power state can be changed alone")
+	assert.NoError(t, rebootOnly, "Reboot triggers can be changed alone")
+	assert.ErrorContains(t, both, "reboot_triggers can't be changed together with power_state")
+}
@@ -0,0 +1,41 @@
+package equinix
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/equinix/equinix-sdk-go/services/metalv1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMetalDevice_importSpotInstance(t *testing.T) {
+	// given
+	c := newFakeAPIConfig(t)
+	ctx := context.Background()
+	project, _, err := c.Metalgo.ProjectsApi.CreateProject(ctx).
+		ProjectCreateFromRootInput(*metalv1.NewProjectCreateFromRootInput("test")).Execute()
+	require.NoError(t, err)
+	input := metalv1.NewDeviceCreateInMetroInput("sv", "ubuntu_22_04", "c3.small.x86")
+	input.SetSpotInstance(true)
+	input.SetSpotPriceMax(0.15)
+	input.SetTerminationTime(time.Now().Add(24 * time.Hour))
+	device, _, err := c.Metalgo.DevicesApi.CreateDevice(ctx, project.GetId()).
+		CreateDeviceRequest(metalv1.DeviceCreateInMetroInputAsCreateDeviceRequest(input)).Execute()
+	require.NoError(t, err)
+	d := resourceMetalDevice().Data(nil)
+	d.SetId(device.GetId())
+	// when
+	imported, err := resourceMetalDevice().Importer.StateContext(ctx, d, c)
+	require.NoError(t, err)
+	require.Len(t, imported, 1)
+	diags := resourceMetalDeviceRead(ctx, imported[0], c)
+	// then
+	require.False(t, diags.HasError(), "Read of the imported device does not fail: %v", diags)
+	assert.Equal(t, project.GetId(), imported[0].Get("project_id"))
+	assert.Equal(t, "c3.small.x86", imported[0].Get("plan"))
+	assert.Equal(t, true, imported[0].Get("spot_instance"), "Device is read as a spot instance")
+	assert.Equal(t, 0.15, imported[0].Get("spot_price_max"))
+	assert.Empty(t, imported[0].Get("termination_time"), "Termination time set by the spot market is not imported")
+}
@@ -0,0 +1,81 @@
+package equinix
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"fmt"
+	"mime/multipart"
+	"net/textproto"
+	"strings"
+)
+
+// cloudInitPartTypes maps the first line prefixes cloud-init recognizes in
+// user data to the content type of the matching multipart archive part.
+var cloudInitPartTypes = []struct {
+	prefix      string
+	contentType string
+}{
+	{"#cloud-config-archive", "text/cloud-config-archive"},
+	{"#cloud-config", "text/cloud-config"},
+	{"#cloud-boothook", "text/cloud-boothook"},
+	{"#include", "text/x-include-url"},
+	{"#part-handler", "text/part-handler"},
+	{"#!", "text/x-shellscript"},
+}
+
+// deviceUserdata returns the user data to send for a device. The metadata
+// service has no vendor data of its own, so if vendor_data is set it is
+// combined with user_data into a cloud-init multipart archive. The vendor data
+// comes first so that the user data overrides it when cloud-init merges them.
+func deviceUserdata(userData, vendorData string) (string, error) {
+	if vendorData == "" {
+		return userData, nil
+	}
+	if matchIPXEScript.MatchString(userData) {
+		return "", fmt.Errorf("\"vendor_data\" can't be combined with an iPXE script \"user_data\"")
+	}
+
+	parts := []struct{ name, content string }{{"vendor_data", vendorData}}
+	if userData != "" {
+		parts = append(parts, struct{ name, content string }{"user_data", userData})
+	}
+
+	var body bytes.Buffer
+	w := multipart.NewWriter(&body)
+	// A boundary derived from the content keeps the archive stable across
+	// applies
+	if err := w.SetBoundary(fmt.Sprintf("%x", sha256.Sum256([]byte(vendorData+userData)))[:40]); err != nil {
+		return "", err
+	}
+	for _, p := range parts {
+		contentType, err := cloudInitPartType(p.name, p.content)
+		if err != nil {
+			return "", err
+		}
+		header := textproto.MIMEHeader{}
+		header.Set("Content-Type", contentType+`; charset="utf-8"`)
+		header.Set("Content-Disposition", fmt.Sprintf(`attachment; filename="%s"`, p.name))
+		pw, err := w.CreatePart(header)
+		if err != nil {
+			return "", err
+		}
+		if _, err := pw.Write([]byte(p.content)); err != nil {
+			return "", err
+		}
+	}
+	if err := w.Close(); err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("Content-Type: multipart/mixed; boundary=%q\nMIME-Version: 1.0\n\n%s", w.Boundary(), body.String()), nil
+}
+
+// cloudInitPartType returns the content type of a multipart archive part from
+// the format header on the first line of its content.
+func cloudInitPartType(name, content string) (string, error) {
+	for _, t := range cloudInitPartTypes {
+		if strings.HasPrefix(content, t.prefix) {
+			return t.contentType, nil
+		}
+	}
+	return "", fmt.Errorf("\"%s\" must start with a cloud-init format header like #cloud-config or #! when \"vendor_data\" is set", name)
+}
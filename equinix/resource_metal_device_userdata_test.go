@@ -0,0 +1,64 @@
+package equinix
+
+import (
+	"io"
+	"mime"
+	"mime/multipart"
+	"net/mail"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMetalDevice_deviceUserdata(t *testing.T) {
+	// given
+	userData := "#cloud-config\nhostname: web\n"
+	vendorData := "#!/bin/sh\necho bootstrap\n"
+	// when
+	rendered, err := deviceUserdata(userData, vendorData)
+	again, _ := deviceUserdata(userData, vendorData)
+	// then
+	require.NoError(t, err)
+	assert.Equal(t, rendered, again, "The archive is stable across renders")
+	msg, err := mail.ReadMessage(strings.NewReader(rendered))
+	require.NoError(t, err)
+	mediaType, params, err := mime.ParseMediaType(msg.Header.Get("Content-Type"))
+	require.NoError(t, err)
+	assert.Equal(t, "multipart/mixed", mediaType)
+	r := multipart.NewReader(msg.Body, params["boundary"])
+	vendorPart, err := r.NextPart()
+	require.NoError(t, err)
+	assert.Equal(t, `text/x-shellscript; charset="utf-8"`, vendorPart.Header.Get("Content-Type"), "The vendor data comes first")
+	vendorContent, _ := io.ReadAll(vendorPart)
+	assert.Equal(t, vendorData, string(vendorContent))
+	userPart, err := r.NextPart()
+	require.NoError(t, err)
+	assert.Equal(t, `text/cloud-config; charset="utf-8"`, userPart.Header.Get("Content-Type"))
+	userContent, _ := io.ReadAll(userPart)
+	assert.Equal(t, userData, string(userContent))
+	_, err = r.NextPart()
+	assert.ErrorIs(t, err, io.EOF)
+}
+
+func TestMetalDevice_deviceUserdataPassthrough(t *testing.T) {
+	// given
+	userData := "#!ipxe\nchain http://example.com/boot.ipxe"
+	// when
+	rendered, err := deviceUserdata(userData, "")
+	// then
+	require.NoError(t, err)
+	assert.Equal(t, userData, rendered, "The user data is sent as is without vendor data")
+}
+
+func TestMetalDevice_deviceUserdataErrors(t *testing.T) {
+	// when
+	_, ipxeErr := deviceUserdata("#!ipxe\nchain http://example.com/boot.ipxe", "#cloud-config\n")
+	_, headerErr := deviceUserdata("plain text", "#cloud-config\n")
+	_, vendorHeaderErr := deviceUserdata("", "packages: [jq]")
+	// then
+	assert.ErrorContains(t, ipxeErr, "iPXE")
+	assert.ErrorContains(t, headerErr, `"user_data" must start with a cloud-init format header`)
+	assert.ErrorContains(t, vendorHeaderErr, `"vendor_data" must start with a cloud-init format header`)
+}
@@ -156,7 +156,7 @@ func resourceMetalPortRead(ctx context.Context, d *schema.ResourceData, meta int
 
 	port, err := getPortByResourceData(d, client)
 	if err != nil {
-		if equinix_errors.IsNotFound(err) || equinix_errors.IsForbidden(err) {
+		if equinix_errors.IsGone(nil, err) {
 			log.Printf("[WARN] Port (%s) not accessible, removing from state", d.Id())
 			d.SetId("")
 
@@ -3,18 +3,32 @@ package equinix
 import (
 	"fmt"
 	"log"
+	"net/http"
+	"time"
 
 	equinix_errors "github.com/equinix/terraform-provider-equinix/internal/errors"
 
 	"github.com/equinix/terraform-provider-equinix/internal/config"
 	"github.com/equinix/terraform-provider-equinix/internal/mutexkv"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/retry"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/packethost/packngo"
 )
 
+// portVlanAttachmentLockId returns the key serializing the VLAN changes of a
+// port. Equinix Metal doesn't allow concurrent VLAN changes on the same port.
+func portVlanAttachmentLockId(portID string) string {
+	return "vlan-attachment-" + portID
+}
+
 func resourceMetalPortVlanAttachment() *schema.Resource {
 	return &schema.Resource{
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(5 * time.Minute),
+			Update: schema.DefaultTimeout(5 * time.Minute),
+			Delete: schema.DefaultTimeout(5 * time.Minute),
+		},
 		Create: resourceMetalPortVlanAttachmentCreate,
 		Read:   resourceMetalPortVlanAttachmentRead,
 		Delete: resourceMetalPortVlanAttachmentDelete,
@@ -139,11 +153,10 @@ func resourceMetalPortVlanAttachmentCreate(d *schema.ResourceData, meta interfac
 
 		// Equinix Metal doesn't allow multiple VLANs to be assigned
 		// to the same port at the same time
-		lockId := "vlan-attachment-" + port.ID
+		lockId := portVlanAttachmentLockId(port.ID)
 		mutexkv.Metal.Lock(lockId)
-		defer mutexkv.Metal.Unlock(lockId)
-
 		_, _, err = client.DevicePorts.Assign(par)
+		mutexkv.Metal.Unlock(lockId)
 		if err != nil {
 			return err
 		}
@@ -153,7 +166,10 @@ func resourceMetalPortVlanAttachmentCreate(d *schema.ResourceData, meta interfac
 
 	native := d.Get("native").(bool)
 	if native {
-		_, _, err = client.DevicePorts.AssignNative(par)
+		err = changePortVlans(port.ID, d.Timeout(schema.TimeoutCreate), func() (*packngo.Response, error) {
+			_, resp, err := client.DevicePorts.AssignNative(par)
+			return resp, err
+		})
 		if err != nil {
 			return err
 		}
@@ -222,18 +238,18 @@ func resourceMetalPortVlanAttachmentUpdate(d *schema.ResourceData, meta interfac
 	if d.HasChange("native") {
 		native := d.Get("native").(bool)
 		portID := d.Get("port_id").(string)
-		if native {
-			vlanID := d.Get("vlan_id").(string)
-			par := &packngo.PortAssignRequest{PortID: portID, VirtualNetworkID: vlanID}
-			_, _, err := client.DevicePorts.AssignNative(par)
-			if err != nil {
-				return err
-			}
-		} else {
-			_, _, err := client.DevicePorts.UnassignNative(portID)
-			if err != nil {
-				return err
+		err := changePortVlans(portID, d.Timeout(schema.TimeoutUpdate), func() (*packngo.Response, error) {
+			if native {
+				vlanID := d.Get("vlan_id").(string)
+				par := &packngo.PortAssignRequest{PortID: portID, VirtualNetworkID: vlanID}
+				_, resp, err := client.DevicePorts.AssignNative(par)
+				return resp, err
 			}
+			_, resp, err := client.DevicePorts.UnassignNative(portID)
+			return resp, err
+		})
+		if err != nil {
+			return err
 		}
 	}
 	return resourceMetalPortVlanAttachmentRead(d, meta)
@@ -245,22 +261,32 @@ func resourceMetalPortVlanAttachmentDelete(d *schema.ResourceData, meta interfac
 	pID := d.Get("port_id").(string)
 	vlanID := d.Get("vlan_id").(string)
 	native := d.Get("native").(bool)
+	// The native VLAN is unassigned first, the other VLANs of the port are
+	// only unassigned once it is, so their changes are retried meanwhile
 	if native {
-		_, resp, err := client.DevicePorts.UnassignNative(pID)
-		if equinix_errors.IgnoreResponseErrors(equinix_errors.HttpForbidden, equinix_errors.HttpNotFound)(resp, err) != nil {
+		err := changePortVlans(pID, d.Timeout(schema.TimeoutDelete), func() (*packngo.Response, error) {
+			_, resp, err := client.DevicePorts.UnassignNative(pID)
+			return resp, equinix_errors.IgnoreResponseErrors(equinix_errors.HttpForbidden, equinix_errors.HttpNotFound)(resp, err)
+		})
+		if err != nil {
 			return err
 		}
 	}
 	par := &packngo.PortAssignRequest{PortID: pID, VirtualNetworkID: vlanID}
-	lockId := "vlan-detachment-" + pID
-	mutexkv.Metal.Lock(lockId)
-	defer mutexkv.Metal.Unlock(lockId)
-	portPtr, resp, err := client.DevicePorts.Unassign(par)
-	if equinix_errors.IgnoreResponseErrors(equinix_errors.HttpForbidden, equinix_errors.HttpNotFound, equinix_errors.IsNotAssigned)(resp, err) != nil {
+	var portPtr *packngo.Port
+	err := changePortVlans(pID, d.Timeout(schema.TimeoutDelete), func() (*packngo.Response, error) {
+		port, resp, err := client.DevicePorts.Unassign(par)
+		portPtr = port
+		return resp, equinix_errors.IgnoreResponseErrors(equinix_errors.HttpForbidden, equinix_errors.HttpNotFound, equinix_errors.IsNotAssigned)(resp, err)
+	})
+	if err != nil {
 		return err
 	}
+	lockId := portVlanAttachmentLockId(pID)
+	mutexkv.Metal.Lock(lockId)
+	defer mutexkv.Metal.Unlock(lockId)
 	forceBond := d.Get("force_bond").(bool)
-	if forceBond && (len(portPtr.AttachedVirtualNetworks) == 0) {
+	if forceBond && portPtr != nil && (len(portPtr.AttachedVirtualNetworks) == 0) {
 		deviceID := d.Get("device_id").(string)
 		portName := d.Get("port_name").(string)
 		port, err := client.DevicePorts.GetPortByName(deviceID, portName)
@@ -274,3 +300,26 @@ func resourceMetalPortVlanAttachmentDelete(d *schema.ResourceData, meta interfac
 	}
 	return nil
 }
+
+// changePortVlans applies a VLAN change to a port while holding the lock of
+// the port. Changes rejected as unprocessable are retried until the timeout,
+// without holding the lock in between, as they depend on the VLAN changes of
+// the other attachments of the port: a native VLAN can only be assigned once
+// the port has another VLAN, and the other VLANs of a port can only be
+// unassigned once its native VLAN is.
+func changePortVlans(portID string, timeout time.Duration, change func() (*packngo.Response, error)) error {
+	lockId := portVlanAttachmentLockId(portID)
+	return retry.Retry(timeout, func() *retry.RetryError {
+		mutexkv.Metal.Lock(lockId)
+		resp, err := change()
+		mutexkv.Metal.Unlock(lockId)
+		if err == nil {
+			return nil
+		}
+		if resp != nil && resp.Response != nil && resp.StatusCode == http.StatusUnprocessableEntity {
+			log.Printf("[DEBUG] VLAN change of port %s waits for the other attachments of the port: %s", portID, err)
+			return retry.RetryableError(err)
+		}
+		return retry.NonRetryableError(err)
+	})
+}
@@ -0,0 +1,40 @@
+package equinix
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/packethost/packngo"
+	"github.com/stretchr/testify/assert"
+)
+
+func testPortVlanResponse(status int) *packngo.Response {
+	return &packngo.Response{Response: &http.Response{StatusCode: status}}
+}
+
+func TestMetalPortVlanAttachment_changePortVlans(t *testing.T) {
+	// given
+	calls := 0
+	waitsForSiblings := func() (*packngo.Response, error) {
+		calls++
+		if calls < 2 {
+			return testPortVlanResponse(http.StatusUnprocessableEntity), errors.New("port has no other VLAN")
+		}
+		return testPortVlanResponse(http.StatusOK), nil
+	}
+	failures := 0
+	fails := func() (*packngo.Response, error) {
+		failures++
+		return testPortVlanResponse(http.StatusForbidden), errors.New("forbidden")
+	}
+	// when
+	err := changePortVlans("port-id", time.Minute, waitsForSiblings)
+	failedErr := changePortVlans("port-id", time.Minute, fails)
+	// then
+	assert.NoError(t, err)
+	assert.Equal(t, 2, calls, "Unprocessable change is retried")
+	assert.ErrorContains(t, failedErr, "forbidden")
+	assert.Equal(t, 1, failures, "Other errors are not retried")
+}
@@ -10,6 +10,7 @@ import (
 	"time"
 
 	"github.com/equinix/terraform-provider-equinix/internal/converters"
+	"github.com/equinix/terraform-provider-equinix/internal/tags"
 
 	equinix_errors "github.com/equinix/terraform-provider-equinix/internal/errors"
 	equinix_schema "github.com/equinix/terraform-provider-equinix/internal/schema"
@@ -171,7 +172,7 @@ func resourceMetalReservedIPBlock() *schema.Resource {
 		ForceNew:    false,
 		Description: "Tags attached to the reserved block",
 		Optional:    true,
-		Elem:        &schema.Schema{Type: schema.TypeString},
+		Elem:        &schema.Schema{Type: schema.TypeString, ValidateFunc: tags.Validate},
 	}
 
 	reservedBlockSchema["custom_data"] = &schema.Schema{
@@ -279,9 +280,7 @@ func resourceMetalReservedIPBlockCreate(ctx context.Context, d *schema.ResourceD
 	}
 
 	if tagsRaw, tagsOk := d.GetOk("tags"); tagsOk {
-		for _, tag := range tagsRaw.(*schema.Set).List() {
-			req.Tags = append(req.Tags, tag.(string))
-		}
+		req.Tags = tags.Expand(tagsRaw)
 	}
 
 	projectID := d.Get("project_id").(string)
@@ -328,13 +327,8 @@ func resourceMetalReservedIPBlockUpdate(ctx context.Context, d *schema.ResourceD
 	id := d.Id()
 	req := &packngo.IPAddressUpdateRequest{}
 	if d.HasChange("tags") {
-		tags := []string{}
-		if tagsRaw, tagsOk := d.GetOk("tags"); tagsOk {
-			for _, tag := range tagsRaw.(*schema.Set).List() {
-				tags = append(tags, tag.(string))
-			}
-		}
-		req.Tags = &tags
+		ts := tags.Expand(d.Get("tags"))
+		req.Tags = &ts
 	}
 
 	if d.HasChange("description") {
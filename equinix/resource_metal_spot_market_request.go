@@ -26,6 +26,7 @@ func resourceMetalSpotMarketRequest() *schema.Resource {
 	return &schema.Resource{
 		CreateContext: resourceMetalSpotMarketRequestCreate,
 		ReadContext:   resourceMetalSpotMarketRequestRead,
+		UpdateContext: resourceMetalSpotMarketRequestUpdate,
 		DeleteContext: resourceMetalSpotMarketRequestDelete,
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
@@ -212,6 +213,12 @@ func resourceMetalSpotMarketRequest() *schema.Resource {
 				Optional:    true,
 				ForceNew:    true,
 			},
+			"keep_devices": {
+				Type:        schema.TypeBool,
+				Description: "On resource destruction - keep the devices created from the request instead of terminating them, e.g. to import them as equinix_metal_device resources",
+				Optional:    true,
+				Default:     false,
+			},
 		},
 		Timeouts: &schema.ResourceTimeout{
 			Create: schema.DefaultTimeout(30 * time.Minute),
@@ -405,9 +412,22 @@ func resourceMetalSpotMarketRequestRead(ctx context.Context, d *schema.ResourceD
 	return diag.FromErr(err)
 }
 
+// resourceMetalSpotMarketRequestUpdate only stores the new value of
+// keep_devices, every other argument forces a new request.
+func resourceMetalSpotMarketRequestUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	return resourceMetalSpotMarketRequestRead(ctx, d, meta)
+}
+
 func resourceMetalSpotMarketRequestDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	meta.(*config.Config).AddModuleToMetalUserAgent(d)
 	client := meta.(*config.Config).Metal
+
+	if d.Get("keep_devices").(bool) {
+		log.Printf("[DEBUG] Deleting spot market request %s, keeping its devices", d.Id())
+		resp, err := client.SpotMarketRequests.Delete(d.Id(), false)
+		return diag.FromErr(equinix_errors.IgnoreResponseErrors(equinix_errors.HttpForbidden, equinix_errors.HttpNotFound)(resp, err))
+	}
+
 	var waitForDevices bool
 
 	if val, ok := d.GetOk("wait_for_devices"); ok {
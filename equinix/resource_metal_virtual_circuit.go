@@ -8,12 +8,11 @@ import (
 	"strconv"
 	"time"
 
-	"github.com/equinix/terraform-provider-equinix/internal/converters"
-
 	equinix_errors "github.com/equinix/terraform-provider-equinix/internal/errors"
 	equinix_schema "github.com/equinix/terraform-provider-equinix/internal/schema"
 
 	"github.com/equinix/terraform-provider-equinix/internal/config"
+	"github.com/equinix/terraform-provider-equinix/internal/tags"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/retry"
@@ -67,12 +66,7 @@ func resourceMetalVirtualCircuit() *schema.Resource {
 				Computed:    true,
 				// TODO: implement SuppressDiffFunc for input with units to bps without units
 			},
-			"tags": {
-				Type:        schema.TypeList,
-				Description: "Tags attached to the virtual circuit",
-				Optional:    true,
-				Elem:        &schema.Schema{Type: schema.TypeString},
-			},
+			"tags": tags.Schema("Tags attached to the virtual circuit"),
 			"nni_vlan": {
 				Type:        schema.TypeInt,
 				Description: "Equinix Metal network-to-network VLAN ID (optional when the connection has mode=tunnel)",
@@ -165,9 +159,8 @@ func resourceMetalVirtualCircuitCreate(ctx context.Context, d *schema.ResourceDa
 	portId := d.Get("port_id").(string)
 	projectId := d.Get("project_id").(string)
 
-	tags := d.Get("tags.#").(int)
-	if tags > 0 {
-		vncr.Tags = converters.IfArrToStringArr(d.Get("tags").([]interface{}))
+	if tagsCount := d.Get("tags.#").(int); tagsCount > 0 {
+		vncr.Tags = tags.Expand(d.Get("tags"))
 	}
 
 	if nniVlan, ok := d.GetOk("nni_vlan"); ok {
@@ -314,18 +307,8 @@ func resourceMetalVirtualCircuitUpdate(ctx context.Context, d *schema.ResourceDa
 	}
 
 	if d.HasChange("tags") {
-		ts := d.Get("tags")
-		sts := []string{}
-
-		switch ts.(type) {
-		case []interface{}:
-			for _, v := range ts.([]interface{}) {
-				sts = append(sts, v.(string))
-			}
-			ur.Tags = &sts
-		default:
-			return diag.Errorf("garbage in tags: %s", ts)
-		}
+		sts := tags.Expand(d.Get("tags"))
+		ur.Tags = &sts
 	}
 
 	if !reflect.DeepEqual(ur, packngo.VCUpdateRequest{}) {
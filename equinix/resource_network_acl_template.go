@@ -4,14 +4,13 @@ import (
 	"context"
 	"fmt"
 	"log"
-	"net/http"
 
 	"github.com/equinix/terraform-provider-equinix/internal/config"
 	"github.com/equinix/terraform-provider-equinix/internal/converters"
+	equinix_errors "github.com/equinix/terraform-provider-equinix/internal/errors"
 	equinix_validation "github.com/equinix/terraform-provider-equinix/internal/validation"
 
 	"github.com/equinix/ne-go"
-	"github.com/equinix/rest-go"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
@@ -257,11 +256,10 @@ func resourceNetworkACLTemplateRead(ctx context.Context, d *schema.ResourceData,
 	var diags diag.Diagnostics
 	template, err := client.GetACLTemplate(d.Id())
 	if err != nil {
-		if restErr, ok := err.(rest.Error); ok {
-			if restErr.HTTPCode == http.StatusNotFound {
-				d.SetId("")
-				return diags
-			}
+		if !d.IsNewResource() && equinix_errors.IsGone(nil, err) {
+			log.Printf("[WARN] Network ACL template %s not found, removing from state", d.Id())
+			d.SetId("")
+			return nil
 		}
 		return diag.FromErr(err)
 	}
@@ -276,6 +274,11 @@ func resourceNetworkACLTemplateUpdate(ctx context.Context, d *schema.ResourceDat
 	m.(*config.Config).AddModuleToNEUserAgent(&client, d)
 	var diags diag.Diagnostics
 	template := createACLTemplate(d)
+	// Template is replaced as a whole, so removed description has to be sent explicitly
+	// to be cleared
+	if template.Description == nil && d.HasChange(networkACLTemplateSchemaNames["Description"]) {
+		template.Description = ne.String("")
+	}
 	if err := client.ReplaceACLTemplate(d.Id(), template); err != nil {
 		return diag.FromErr(err)
 	}
@@ -3,10 +3,12 @@ package equinix
 import (
 	"context"
 	"fmt"
+	"log"
 	"net/http"
 	"time"
 
 	"github.com/equinix/terraform-provider-equinix/internal/config"
+	equinix_errors "github.com/equinix/terraform-provider-equinix/internal/errors"
 
 	"github.com/equinix/ne-go"
 	"github.com/equinix/rest-go"
@@ -158,6 +160,11 @@ func resourceNetworkBGPRead(ctx context.Context, d *schema.ResourceData, m inter
 	var diags diag.Diagnostics
 	bgp, err := client.GetBGPConfiguration(d.Id())
 	if err != nil {
+		if !d.IsNewResource() && equinix_errors.IsGone(nil, err) {
+			log.Printf("[WARN] Network BGP configuration %s not found, removing from state", d.Id())
+			d.SetId("")
+			return nil
+		}
 		return diag.FromErr(err)
 	}
 	if err := updateNetworkBGPResource(bgp, d); err != nil {
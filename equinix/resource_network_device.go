@@ -5,12 +5,15 @@ import (
 	"fmt"
 	"io"
 	"log"
+	"net/http"
 	"os"
 	"path/filepath"
 	"time"
 
 	"github.com/equinix/terraform-provider-equinix/internal/config"
 	"github.com/equinix/terraform-provider-equinix/internal/converters"
+	equinix_diagnostics "github.com/equinix/terraform-provider-equinix/internal/diagnostics"
+	equinix_errors "github.com/equinix/terraform-provider-equinix/internal/errors"
 	equinix_schema "github.com/equinix/terraform-provider-equinix/internal/schema"
 	equinix_validation "github.com/equinix/terraform-provider-equinix/internal/validation"
 
@@ -43,6 +46,8 @@ var neDeviceSchemaNames = map[string]string{
 	"LicenseStatus":       "license_status",
 	"ACLTemplateUUID":     "acl_template_id",
 	"MgmtAclTemplateUuid": "mgmt_acl_template_uuid",
+	"ACLStatus":           "acl_status",
+	"MgmtACLStatus":       "mgmt_acl_status",
 	"SSHIPAddress":        "ssh_ip_address",
 	"SSHIPFqdn":           "ssh_ip_fqdn",
 	"AccountNumber":       "account_number",
@@ -63,6 +68,7 @@ var neDeviceSchemaNames = map[string]string{
 	"UserPublicKey":       "ssh_key",
 	"ASN":                 "asn",
 	"ZoneCode":            "zone_code",
+	"AdminPassword":       "admin_password",
 	"Secondary":           "secondary_device",
 	"ClusterDetails":      "cluster_details",
 	"ValidStatusList":     "valid_status_list",
@@ -90,6 +96,8 @@ var neDeviceDescriptions = map[string]string{
 	"LicenseStatus":       "Device license registration status",
 	"ACLTemplateUUID":     "Unique identifier of applied ACL template",
 	"MgmtAclTemplateUuid": "Unique identifier of applied MGMT ACL template",
+	"ACLStatus":           "Provisioning status of the WAN interface ACL template on the device",
+	"MgmtACLStatus":       "Provisioning status of the MGMT interface ACL template on the device",
 	"SSHIPAddress":        "IP address of SSH enabled interface on the device",
 	"SSHIPFqdn":           "FQDN of SSH enabled interface on the device",
 	"AccountNumber":       "Device billing account number",
@@ -109,6 +117,7 @@ var neDeviceDescriptions = map[string]string{
 	"UserPublicKey":       "Definition of SSH key that will be provisioned on a device",
 	"ASN":                 "Autonomous system number",
 	"ZoneCode":            "Device location zone code",
+	"AdminPassword":       "Administrative password of the device generated by Equinix, if the device type provides one. It is kept in state when the API does not return it",
 	"Secondary":           "Definition of secondary device applicable for HA setup",
 	"ClusterDetails":      "An object that has the cluster details",
 	"ValidStatusList":     "Comma Separated List of states to be considered valid when searching by name",
@@ -207,7 +216,8 @@ func resourceNetworkDevice() *schema.Resource {
 		Importer: &schema.ResourceImporter{
 			State: schema.ImportStatePassthrough,
 		},
-		Schema: createNetworkDeviceSchema(),
+		Schema:        createNetworkDeviceSchema(),
+		CustomizeDiff: validateNetworkDeviceProjectChange,
 		Timeouts: &schema.ResourceTimeout{
 			Create: schema.DefaultTimeout(90 * time.Minute),
 			Update: schema.DefaultTimeout(90 * time.Minute),
@@ -356,6 +366,16 @@ func createNetworkDeviceSchema() map[string]*schema.Schema {
 			ValidateFunc: validation.StringIsNotEmpty,
 			Description:  neDeviceDescriptions["MgmtAclTemplateUuid"],
 		},
+		neDeviceSchemaNames["ACLStatus"]: {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: neDeviceDescriptions["ACLStatus"],
+		},
+		neDeviceSchemaNames["MgmtACLStatus"]: {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: neDeviceDescriptions["MgmtACLStatus"],
+		},
 		neDeviceSchemaNames["SSHIPAddress"]: {
 			Type:        schema.TypeString,
 			Computed:    true,
@@ -409,7 +429,6 @@ func createNetworkDeviceSchema() map[string]*schema.Schema {
 		neDeviceSchemaNames["ProjectID"]: {
 			Type:         schema.TypeString,
 			Optional:     true,
-			ForceNew:     true,
 			Computed:     true,
 			ValidateFunc: validation.IsUUID,
 			Description:  neDeviceDescriptions["ProjectID"],
@@ -495,6 +514,12 @@ func createNetworkDeviceSchema() map[string]*schema.Schema {
 			Computed:    true,
 			Description: neDeviceDescriptions["ZoneCode"],
 		},
+		neDeviceSchemaNames["AdminPassword"]: {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Sensitive:   true,
+			Description: neDeviceDescriptions["AdminPassword"],
+		},
 		neDeviceSchemaNames["Connectivity"]: {
 			Type:         schema.TypeString,
 			Optional:     true,
@@ -603,6 +628,16 @@ func createNetworkDeviceSchema() map[string]*schema.Schema {
 						ValidateFunc: validation.StringIsNotEmpty,
 						Description:  neDeviceDescriptions["MgmtAclTemplateUuid"],
 					},
+					neDeviceSchemaNames["ACLStatus"]: {
+						Type:        schema.TypeString,
+						Computed:    true,
+						Description: neDeviceDescriptions["ACLStatus"],
+					},
+					neDeviceSchemaNames["MgmtACLStatus"]: {
+						Type:        schema.TypeString,
+						Computed:    true,
+						Description: neDeviceDescriptions["MgmtACLStatus"],
+					},
 					neDeviceSchemaNames["SSHIPAddress"]: {
 						Type:        schema.TypeString,
 						Computed:    true,
@@ -910,28 +945,24 @@ func resourceNetworkDeviceCreate(ctx context.Context, d *schema.ResourceData, m
 		primary.UUID, err = client.CreateDevice(*primary)
 	}
 	if err != nil {
-		return diag.FromErr(err)
+		attributePath := ""
+		if ne.StringValue(primary.ProjectID) != "" {
+			attributePath = neDeviceSchemaNames["ProjectID"]
+		}
+		return equinix_diagnostics.SDKError("creating Network Edge device", attributePath, nil, networkDeviceProjectPermissionError(err, primary.ProjectID))
 	}
 	d.SetId(ne.StringValue(primary.UUID))
 	waitConfigs := []*retry.StateChangeConf{
 		createNetworkDeviceStatusProvisioningWaitConfiguration(client.GetDevice, ne.StringValue(primary.UUID), 5*time.Second, d.Timeout(schema.TimeoutCreate)),
 		createNetworkDeviceLicenseStatusWaitConfiguration(client.GetDevice, ne.StringValue(primary.UUID), 5*time.Second, d.Timeout(schema.TimeoutCreate)),
 	}
-	if ne.StringValue(primary.ACLTemplateUUID) != "" || ne.StringValue(primary.MgmtAclTemplateUuid) != "" {
-		waitConfigs = append(waitConfigs,
-			createNetworkDeviceACLStatusWaitConfiguration(client.GetDeviceACLDetails, ne.StringValue(primary.UUID), 1*time.Second, d.Timeout(schema.TimeoutUpdate)),
-		)
-	}
+	waitConfigs = append(waitConfigs, getNetworkDeviceACLWaitConfigurations(client, primary, d.Timeout(schema.TimeoutUpdate))...)
 	if secondary != nil {
 		waitConfigs = append(waitConfigs,
 			createNetworkDeviceStatusProvisioningWaitConfiguration(client.GetDevice, ne.StringValue(secondary.UUID), 5*time.Second, d.Timeout(schema.TimeoutCreate)),
 			createNetworkDeviceLicenseStatusWaitConfiguration(client.GetDevice, ne.StringValue(secondary.UUID), 5*time.Second, d.Timeout(schema.TimeoutCreate)),
 		)
-		if ne.StringValue(secondary.ACLTemplateUUID) != "" || ne.StringValue(secondary.MgmtAclTemplateUuid) != "" {
-			waitConfigs = append(waitConfigs,
-				createNetworkDeviceACLStatusWaitConfiguration(client.GetDeviceACLDetails, ne.StringValue(secondary.UUID), 1*time.Second, d.Timeout(schema.TimeoutUpdate)),
-			)
-		}
+		waitConfigs = append(waitConfigs, getNetworkDeviceACLWaitConfigurations(client, secondary, d.Timeout(schema.TimeoutUpdate))...)
 	}
 	for _, config := range waitConfigs {
 		if config == nil {
@@ -945,6 +976,33 @@ func resourceNetworkDeviceCreate(ctx context.Context, d *schema.ResourceData, m
 	return diags
 }
 
+// validateNetworkDeviceProjectChange rejects moving an existing device to
+// another project, which the Network Edge API does not support. Replacing the
+// device instead would lose its configuration and connections.
+func validateNetworkDeviceProjectChange(_ context.Context, d *schema.ResourceDiff, _ interface{}) error {
+	if d.Id() == "" || !d.HasChange(neDeviceSchemaNames["ProjectID"]) {
+		return nil
+	}
+	oldProject, newProject := d.GetChange(neDeviceSchemaNames["ProjectID"])
+	if newProject.(string) == "" {
+		return nil
+	}
+	return fmt.Errorf("network device %s can't be moved from project %s to project %s, Network Edge does not support moving devices between projects. Recreate the device, e.g. with terraform apply -replace, to create it in the new project", d.Id(), oldProject, newProject)
+}
+
+// networkDeviceProjectPermissionError explains device creation failures due
+// to missing permissions on the requested project.
+func networkDeviceProjectPermissionError(err error, projectID *string) error {
+	restErr, ok := err.(rest.Error)
+	if !ok || ne.StringValue(projectID) == "" {
+		return err
+	}
+	if restErr.HTTPCode != http.StatusUnauthorized && restErr.HTTPCode != http.StatusForbidden {
+		return err
+	}
+	return fmt.Errorf("could not create network device in project %s, check that the user has permissions to create devices in the project: %w", ne.StringValue(projectID), err)
+}
+
 func resourceNetworkDeviceRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	client := m.(*config.Config).Ne
 	m.(*config.Config).AddModuleToNEUserAgent(&client, d)
@@ -953,7 +1011,12 @@ func resourceNetworkDeviceRead(ctx context.Context, d *schema.ResourceData, m in
 	var primary, secondary *ne.Device
 	primary, err = client.GetDevice(d.Id())
 	if err != nil {
-		return diag.Errorf("cannot fetch primary network device due to %v", err)
+		if !d.IsNewResource() && equinix_errors.IsGone(nil, err) {
+			log.Printf("[WARN] Network device %s not found, removing from state", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return equinix_diagnostics.SDKError(fmt.Sprintf("reading Network Edge device %s", d.Id()), "", nil, err)
 	}
 	if isStringInSlice(ne.StringValue(primary.Status), []string{ne.DeviceStateDeprovisioning, ne.DeviceStateDeprovisioned}) {
 		d.SetId("")
@@ -962,12 +1025,15 @@ func resourceNetworkDeviceRead(ctx context.Context, d *schema.ResourceData, m in
 	if ne.StringValue(primary.RedundantUUID) != "" {
 		secondary, err = client.GetDevice(ne.StringValue(primary.RedundantUUID))
 		if err != nil {
-			return diag.Errorf("cannot fetch secondary network device due to %v", err)
+			return equinix_diagnostics.SDKError(fmt.Sprintf("reading secondary Network Edge device %s", ne.StringValue(primary.RedundantUUID)), "", nil, err)
 		}
 	}
 	if err = updateNetworkDeviceResource(primary, secondary, d); err != nil {
 		return diag.FromErr(err)
 	}
+	if err = updateNetworkDeviceACLStatuses(client.GetACLTemplate, primary, secondary, d); err != nil {
+		return diag.FromErr(err)
+	}
 	return diags
 }
 
@@ -991,14 +1057,14 @@ func resourceNetworkDeviceUpdate(ctx context.Context, d *schema.ResourceData, m
 		}
 	}
 	if err := fillNetworkDeviceUpdateRequest(updateReq, primaryChanges).Execute(); err != nil {
-		return diag.FromErr(err)
+		return equinix_diagnostics.SDKError(fmt.Sprintf("updating Network Edge device %s", d.Id()), "", nil, err)
 	}
 	var secondaryChanges map[string]interface{}
 	if v, ok := d.GetOk(neDeviceSchemaNames["RedundantUUID"]); ok {
-		secondaryChanges = equinix_schema.GetResourceDataListElementChanges(supportedChanges, neDeviceSchemaNames["Secondary"], 0, d)
+		secondaryChanges = getNetworkDeviceSecondaryChanges(supportedChanges, primaryChanges, d)
 		secondaryUpdateReq := client.NewDeviceUpdateRequest(v.(string))
 		if err := fillNetworkDeviceUpdateRequest(secondaryUpdateReq, secondaryChanges).Execute(); err != nil {
-			return diag.FromErr(err)
+			return equinix_diagnostics.SDKError(fmt.Sprintf("updating secondary Network Edge device %s", v), neDeviceSchemaNames["Secondary"], nil, err)
 		}
 	}
 	for _, stateChangeConf := range getNetworkDeviceStateChangeConfigs(client, d.Id(), d.Timeout(schema.TimeoutUpdate), primaryChanges) {
@@ -1037,7 +1103,7 @@ func resourceNetworkDeviceDelete(ctx context.Context, d *schema.ResourceData, m
 				}
 			}
 		}
-		return diag.FromErr(err)
+		return equinix_diagnostics.SDKError(fmt.Sprintf("deleting Network Edge device %s", d.Id()), "", nil, err)
 	}
 	for _, config := range waitConfigs {
 		if _, err := config.WaitForStateContext(ctx); err != nil {
@@ -1136,6 +1202,10 @@ func createNetworkDevices(d *schema.ResourceData) (*ne.Device, *ne.Device) {
 	}
 	if v, ok := d.GetOk(neDeviceSchemaNames["Secondary"]); ok {
 		secondary = expandNetworkDeviceSecondary(v.([]interface{}))
+		// The secondary device is created in the project of the primary device
+		if secondary != nil && secondary.ProjectID == nil {
+			secondary.ProjectID = primary.ProjectID
+		}
 	}
 	if v, ok := d.GetOk(neDeviceSchemaNames["ClusterDetails"]); ok {
 		primary.ClusterDetails = expandNetworkDeviceClusterDetails(v.([]interface{}))
@@ -1252,12 +1322,17 @@ func updateNetworkDeviceResource(primary *ne.Device, secondary *ne.Device, d *sc
 	if err := d.Set(neDeviceSchemaNames["ZoneCode"], primary.ZoneCode); err != nil {
 		return fmt.Errorf("error reading ZoneCode: %s", err)
 	}
+	adminPassword := networkDeviceAdminPassword(primary.VendorConfiguration, d.Get(neDeviceSchemaNames["AdminPassword"]).(string))
+	if err := d.Set(neDeviceSchemaNames["AdminPassword"], adminPassword); err != nil {
+		return fmt.Errorf("error reading AdminPassword: %s", err)
+	}
 	if secondary != nil {
 		if v, ok := d.GetOk(neDeviceSchemaNames["Secondary"]); ok {
 			secondaryFromSchema := expandNetworkDeviceSecondary(v.([]interface{}))
 			secondary.LicenseFile = secondaryFromSchema.LicenseFile
 			secondary.LicenseToken = secondaryFromSchema.LicenseToken
 			secondary.CloudInitFileID = secondaryFromSchema.CloudInitFileID
+			secondary.MgmtAclTemplateUuid = secondaryFromSchema.MgmtAclTemplateUuid
 		}
 		if err := d.Set(neDeviceSchemaNames["Secondary"], flattenNetworkDeviceSecondary(secondary)); err != nil {
 			return fmt.Errorf("error reading Secondary: %s", err)
@@ -1278,6 +1353,17 @@ func updateNetworkDeviceResource(primary *ne.Device, secondary *ne.Device, d *sc
 	return nil
 }
 
+// networkDeviceAdminPassword returns the administrative password generated by
+// Equinix for a device, as found in its vendor configuration. The API doesn't
+// return it in all the device states, the previously read password is kept
+// when it is missing.
+func networkDeviceAdminPassword(vendorConfig map[string]string, previous string) string {
+	if v := vendorConfig["adminPassword"]; v != "" {
+		return v
+	}
+	return previous
+}
+
 func flattenNetworkDeviceSecondary(device *ne.Device) interface{} {
 	transformed := make(map[string]interface{})
 	transformed[neDeviceSchemaNames["UUID"]] = device.UUID
@@ -1293,6 +1379,7 @@ func flattenNetworkDeviceSecondary(device *ne.Device) interface{} {
 	transformed[neDeviceSchemaNames["LicenseToken"]] = device.LicenseToken
 	transformed[neDeviceSchemaNames["CloudInitFileID"]] = device.CloudInitFileID
 	transformed[neDeviceSchemaNames["ACLTemplateUUID"]] = device.ACLTemplateUUID
+	transformed[neDeviceSchemaNames["MgmtAclTemplateUuid"]] = device.MgmtAclTemplateUuid
 	transformed[neDeviceSchemaNames["SSHIPAddress"]] = device.SSHIPAddress
 	transformed[neDeviceSchemaNames["SSHIPFqdn"]] = device.SSHIPFqdn
 	transformed[neDeviceSchemaNames["AccountNumber"]] = device.AccountNumber
@@ -1525,6 +1612,16 @@ func expandVendorConfiguration(vendorConfigs []interface{}) map[string]string {
 	return transformed
 }
 
+// getNetworkDeviceSecondaryChanges returns the changes of the secondary device block. Term length
+// is configured once for the redundant pair, so its change is applied to the secondary device too.
+func getNetworkDeviceSecondaryChanges(supportedChanges []string, primaryChanges map[string]interface{}, d *schema.ResourceData) map[string]interface{} {
+	changes := equinix_schema.GetResourceDataListElementChanges(supportedChanges, neDeviceSchemaNames["Secondary"], 0, d)
+	if v, ok := primaryChanges[neDeviceSchemaNames["TermLength"]]; ok {
+		changes[neDeviceSchemaNames["TermLength"]] = v
+	}
+	return changes
+}
+
 func fillNetworkDeviceUpdateRequest(updateReq ne.DeviceUpdateRequest, changes map[string]interface{}) ne.DeviceUpdateRequest {
 	for change, changeValue := range changes {
 		switch change {
@@ -1558,11 +1655,12 @@ func getNetworkDeviceStateChangeConfigs(c ne.Client, deviceID string, timeout ti
 				createNetworkDeviceACLStatusWaitConfiguration(c.GetDeviceACLDetails, deviceID, 1*time.Second, timeout),
 			)
 		}
-	} else if changeValue, found := changes[neDeviceSchemaNames["MgmtAclTemplateUuid"]]; found {
+	}
+	if changeValue, found := changes[neDeviceSchemaNames["MgmtAclTemplateUuid"]]; found {
 		mgmtAclTemplateUuid, ok := changeValue.(string)
 		if ok && mgmtAclTemplateUuid != "" {
 			configs = append(configs,
-				createNetworkDeviceACLStatusWaitConfiguration(c.GetDeviceACLDetails, deviceID, 1*time.Second, timeout),
+				createNetworkDeviceACLTemplateStatusWaitConfiguration(c.GetACLTemplate, mgmtAclTemplateUuid, deviceID, 1*time.Second, timeout),
 			)
 		}
 	}
@@ -1609,6 +1707,7 @@ func uploadDeviceLicenseFile(openFunc openFile, uploadFunc uploadLicenseFile, ty
 type (
 	getDevice                     func(uuid string) (*ne.Device, error)
 	getACL                        func(uuid string) (*ne.DeviceACLDetails, error)
+	getACLTemplate                func(uuid string) (*ne.ACLTemplate, error)
 	getAdditionalBandwidthDetails func(uuid string) (*ne.DeviceAdditionalBandwidthDetails, error)
 )
 
@@ -1714,6 +1813,112 @@ func createNetworkDeviceACLStatusWaitConfiguration(fetchFunc getACL, id string,
 	}
 }
 
+// createNetworkDeviceACLTemplateStatusWaitConfiguration waits for the given
+// ACL template to be provisioned on the device. Unlike the device ACL status,
+// that covers all the ACL templates of the device, it tracks a single
+// template, e.g. the MGMT interface one.
+func createNetworkDeviceACLTemplateStatusWaitConfiguration(fetchFunc getACLTemplate, templateID, deviceID string, delay time.Duration, timeout time.Duration) *retry.StateChangeConf {
+	return &retry.StateChangeConf{
+		Pending: []string{
+			"",
+			ne.ACLDeviceStatusProvisioning,
+		},
+		Target: []string{
+			ne.ACLDeviceStatusProvisioned,
+		},
+		Timeout:    timeout,
+		Delay:      0,
+		MinTimeout: delay,
+		Refresh: func() (interface{}, string, error) {
+			resp, err := fetchFunc(templateID)
+			if err != nil {
+				return nil, "", err
+			}
+			return resp, networkDeviceACLTemplateStatus(resp, deviceID), nil
+		},
+	}
+}
+
+// networkDeviceACLTemplateStatus returns the provisioning status of the ACL
+// template on the device, or an empty string if it is not applied to it.
+func networkDeviceACLTemplateStatus(template *ne.ACLTemplate, deviceID string) string {
+	for _, device := range template.DeviceDetails {
+		if ne.StringValue(device.UUID) == deviceID {
+			return ne.StringValue(device.ACLStatus)
+		}
+	}
+	return ""
+}
+
+// getNetworkDeviceACLWaitConfigurations returns the configurations to wait
+// for the WAN and MGMT interface ACL templates of a new device.
+func getNetworkDeviceACLWaitConfigurations(c ne.Client, device *ne.Device, timeout time.Duration) []*retry.StateChangeConf {
+	var configs []*retry.StateChangeConf
+	if ne.StringValue(device.ACLTemplateUUID) != "" {
+		configs = append(configs,
+			createNetworkDeviceACLStatusWaitConfiguration(c.GetDeviceACLDetails, ne.StringValue(device.UUID), 1*time.Second, timeout),
+		)
+	}
+	if ne.StringValue(device.MgmtAclTemplateUuid) != "" {
+		configs = append(configs,
+			createNetworkDeviceACLTemplateStatusWaitConfiguration(c.GetACLTemplate, ne.StringValue(device.MgmtAclTemplateUuid), ne.StringValue(device.UUID), 1*time.Second, timeout),
+		)
+	}
+	return configs
+}
+
+// updateNetworkDeviceACLStatuses sets the provisioning statuses of the WAN
+// and MGMT interface ACL templates of the primary and secondary devices.
+func updateNetworkDeviceACLStatuses(fetchFunc getACLTemplate, primary *ne.Device, secondary *ne.Device, d *schema.ResourceData) error {
+	statuses := func(device *ne.Device, mgmtTemplateID string) (string, string, error) {
+		var aclStatus, mgmtACLStatus string
+		if templateID := ne.StringValue(device.ACLTemplateUUID); templateID != "" {
+			template, err := fetchFunc(templateID)
+			if err != nil {
+				return "", "", fmt.Errorf("error reading ACL template %s: %s", templateID, err)
+			}
+			aclStatus = networkDeviceACLTemplateStatus(template, ne.StringValue(device.UUID))
+		}
+		if mgmtTemplateID != "" {
+			template, err := fetchFunc(mgmtTemplateID)
+			if err != nil {
+				return "", "", fmt.Errorf("error reading MGMT ACL template %s: %s", mgmtTemplateID, err)
+			}
+			mgmtACLStatus = networkDeviceACLTemplateStatus(template, ne.StringValue(device.UUID))
+		}
+		return aclStatus, mgmtACLStatus, nil
+	}
+	aclStatus, mgmtACLStatus, err := statuses(primary, d.Get(neDeviceSchemaNames["MgmtAclTemplateUuid"]).(string))
+	if err != nil {
+		return err
+	}
+	if err := d.Set(neDeviceSchemaNames["ACLStatus"], aclStatus); err != nil {
+		return fmt.Errorf("error reading ACLStatus: %s", err)
+	}
+	if err := d.Set(neDeviceSchemaNames["MgmtACLStatus"], mgmtACLStatus); err != nil {
+		return fmt.Errorf("error reading MgmtACLStatus: %s", err)
+	}
+	if secondary == nil {
+		return nil
+	}
+	secondaryList, ok := d.Get(neDeviceSchemaNames["Secondary"]).([]interface{})
+	if !ok || len(secondaryList) == 0 || secondaryList[0] == nil {
+		return nil
+	}
+	secondaryMap := secondaryList[0].(map[string]interface{})
+	mgmtTemplateID, _ := secondaryMap[neDeviceSchemaNames["MgmtAclTemplateUuid"]].(string)
+	aclStatus, mgmtACLStatus, err = statuses(secondary, mgmtTemplateID)
+	if err != nil {
+		return err
+	}
+	secondaryMap[neDeviceSchemaNames["ACLStatus"]] = aclStatus
+	secondaryMap[neDeviceSchemaNames["MgmtACLStatus"]] = mgmtACLStatus
+	if err := d.Set(neDeviceSchemaNames["Secondary"], secondaryList); err != nil {
+		return fmt.Errorf("error reading Secondary: %s", err)
+	}
+	return nil
+}
+
 func createNetworkDeviceAdditionalBandwidthStatusWaitConfiguration(fetchFunc getAdditionalBandwidthDetails, deviceID string, delay time.Duration, timeout time.Duration) *retry.StateChangeConf {
 	return &retry.StateChangeConf{
 		Pending: []string{
@@ -3,6 +3,7 @@ package equinix
 import (
 	"context"
 	"fmt"
+	"log"
 	"time"
 
 	"github.com/equinix/terraform-provider-equinix/internal/config"
@@ -32,7 +33,7 @@ var networkDeviceLinkSchemaNames = map[string]string{
 var networkDeviceLinkDescriptions = map[string]string{
 	"UUID":      "Device link unique identifier",
 	"Name":      "Device link name",
-	"Subnet":    "Device link subnet CIDR.",
+	"Subnet":    "Device link subnet CIDR. Assigned by Equinix if not set",
 	"Devices":   "Definition of one or more devices belonging to the device link",
 	"Links":     "Definition of one or more, inter metro connections belonging to the device link",
 	"Status":    "Device link provisioning status",
@@ -50,7 +51,7 @@ var networkDeviceLinkDeviceSchemaNames = map[string]string{
 var networkDeviceLinkDeviceDescriptions = map[string]string{
 	"DeviceID":    "Device identifier",
 	"ASN":         "Device ASN number",
-	"InterfaceID": "Device network interface identifier to use for device link connection",
+	"InterfaceID": "Device network interface identifier to use for device link connection. Assigned by Equinix if not set",
 	"Status":      "Device link connection provisioning status",
 	"IPAddress":   "Assigned IP address from device link subnet",
 }
@@ -127,6 +128,7 @@ func createNetworkDeviceLinkResourceSchema() map[string]*schema.Schema {
 		networkDeviceLinkSchemaNames["Subnet"]: {
 			Type:         schema.TypeString,
 			Optional:     true,
+			Computed:     true,
 			ValidateFunc: validation.IsCIDR,
 			Description:  networkDeviceLinkSchemaNames["Subnet"],
 		},
@@ -169,7 +171,7 @@ func createNetworkDeviceLinkDeviceResourceSchema() map[string]*schema.Schema {
 		networkDeviceLinkDeviceSchemaNames["InterfaceID"]: {
 			Type:         schema.TypeInt,
 			Optional:     true,
-			ForceNew:     true,
+			Computed:     true,
 			ValidateFunc: validation.IntAtLeast(1),
 			Description:  networkDeviceLinkDeviceDescriptions["InterfaceID"],
 		},
@@ -263,10 +265,12 @@ func resourceNetworkDeviceLinkRead(ctx context.Context, d *schema.ResourceData,
 	var diags diag.Diagnostics
 	link, err := client.GetDeviceLinkGroup(d.Id())
 	if err != nil {
-		if equinix_errors.IsRestNotFoundError(err) {
+		if !d.IsNewResource() && equinix_errors.IsGone(nil, err) {
+			log.Printf("[WARN] Network device link %s not found, removing from state", d.Id())
 			d.SetId("")
 			return nil
 		}
+		return diag.FromErr(err)
 	}
 	for i, linkDevice := range link.Devices {
 		device, err := client.GetDevice(ne.StringValue(linkDevice.DeviceID))
@@ -391,9 +395,12 @@ func expandNetworkDeviceLinkDevices(devices *schema.Set) []ne.DeviceLinkGroupDev
 	for i := range deviceList {
 		deviceMap := deviceList[i].(map[string]interface{})
 		transformed[i] = ne.DeviceLinkGroupDevice{
-			DeviceID:    ne.String(deviceMap[networkDeviceLinkDeviceSchemaNames["DeviceID"]].(string)),
-			ASN:         ne.Int(deviceMap[networkDeviceLinkDeviceSchemaNames["ASN"]].(int)),
-			InterfaceID: ne.Int(deviceMap[networkDeviceLinkDeviceSchemaNames["InterfaceID"]].(int)),
+			DeviceID: ne.String(deviceMap[networkDeviceLinkDeviceSchemaNames["DeviceID"]].(string)),
+			ASN:      ne.Int(deviceMap[networkDeviceLinkDeviceSchemaNames["ASN"]].(int)),
+		}
+		// Without an interface identifier Equinix assigns one
+		if interfaceID := deviceMap[networkDeviceLinkDeviceSchemaNames["InterfaceID"]].(int); interfaceID > 0 {
+			transformed[i].InterfaceID = ne.Int(interfaceID)
 		}
 	}
 	return transformed
@@ -498,14 +505,15 @@ func createDeviceLinkStatusDeleteWaitConfiguration(fetchFunc getDeviceLinkGroup,
 	}
 }
 
+// networkDeviceLinkDeviceKey identifies a device of a device link by its
+// identifier only: a device is linked once, and its interface identifier can
+// be assigned by Equinix or updated in place.
 func networkDeviceLinkDeviceKey(v interface{}) string {
 	if v, ok := v.(ne.DeviceLinkGroupDevice); ok {
-		return fmt.Sprintf("%s-%d", ne.StringValue(v.DeviceID), ne.IntValue(v.InterfaceID))
+		return ne.StringValue(v.DeviceID)
 	}
 	if v, ok := v.(map[string]interface{}); ok {
-		return fmt.Sprintf("%s-%d",
-			v[networkDeviceLinkDeviceSchemaNames["DeviceID"]],
-			v[networkDeviceLinkDeviceSchemaNames["InterfaceID"]])
+		return fmt.Sprintf("%s", v[networkDeviceLinkDeviceSchemaNames["DeviceID"]])
 	}
 	return fmt.Sprintf("%v", v)
 }
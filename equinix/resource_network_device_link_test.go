@@ -96,3 +96,35 @@ func TestNetworkDeviceLink_updateResourceData(t *testing.T) {
 	assert.Equal(t, input.Devices, expandNetworkDeviceLinkDevices(d.Get(networkDeviceLinkSchemaNames["Devices"]).(*schema.Set)), "Device matches")
 	assert.Equal(t, input.Links, expandNetworkDeviceLinkConnections(d.Get(networkDeviceLinkSchemaNames["Links"]).(*schema.Set)), "Links matches")
 }
+
+func TestNetworkDeviceLink_expandDevicesAssignedInterface(t *testing.T) {
+	// given
+	devices := schema.NewSet(networkDeviceLinkDeviceHash, []interface{}{
+		map[string]interface{}{
+			networkDeviceLinkDeviceSchemaNames["DeviceID"]:    "3eee8518-b19d-4de5-afd8-afd9b67e6e8c",
+			networkDeviceLinkDeviceSchemaNames["ASN"]:         0,
+			networkDeviceLinkDeviceSchemaNames["InterfaceID"]: 0,
+		},
+	})
+	// when
+	result := expandNetworkDeviceLinkDevices(devices)
+	// then
+	assert.Nil(t, result[0].InterfaceID, "Interface identifier is left to Equinix when not set")
+}
+
+func TestNetworkDeviceLink_deviceHashIgnoresInterface(t *testing.T) {
+	// given
+	device := map[string]interface{}{
+		networkDeviceLinkDeviceSchemaNames["DeviceID"]:    "3eee8518-b19d-4de5-afd8-afd9b67e6e8c",
+		networkDeviceLinkDeviceSchemaNames["InterfaceID"]: 5,
+	}
+	assigned := ne.DeviceLinkGroupDevice{
+		DeviceID:    ne.String("3eee8518-b19d-4de5-afd8-afd9b67e6e8c"),
+		InterfaceID: ne.Int(7),
+	}
+	// when
+	configured := networkDeviceLinkDeviceHash(device)
+	read := networkDeviceLinkDeviceHash(assigned)
+	// then
+	assert.Equal(t, configured, read, "Device is identified regardless of its interface")
+}
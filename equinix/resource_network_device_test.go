@@ -9,8 +9,10 @@ import (
 	"time"
 
 	"github.com/equinix/ne-go"
+	"github.com/equinix/rest-go"
 	"github.com/equinix/terraform-provider-equinix/internal/converters"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -226,6 +228,7 @@ func TestNetworkDevice_flattenSecondary(t *testing.T) {
 			neDeviceSchemaNames["LicenseFile"]:         input.LicenseFile,
 			neDeviceSchemaNames["CloudInitFileID"]:     input.CloudInitFileID,
 			neDeviceSchemaNames["ACLTemplateUUID"]:     input.ACLTemplateUUID,
+			neDeviceSchemaNames["MgmtAclTemplateUuid"]: input.MgmtAclTemplateUuid,
 			neDeviceSchemaNames["SSHIPAddress"]:        input.SSHIPAddress,
 			neDeviceSchemaNames["SSHIPFqdn"]:           input.SSHIPFqdn,
 			neDeviceSchemaNames["AccountNumber"]:       input.AccountNumber,
@@ -447,6 +450,59 @@ func TestNetworkDevice_ACLStatusWaitConfiguration(t *testing.T) {
 	assert.Equal(t, delay, waitConfig.MinTimeout, "Device status wait configuration min timeout matches")
 }
 
+func TestNetworkDevice_ACLTemplateStatusWaitConfiguration(t *testing.T) {
+	// given
+	deviceUUID, templateUUID := "device", "mgmt-template"
+	var receivedTemplateUUID string
+	fetchFunc := func(uuid string) (*ne.ACLTemplate, error) {
+		receivedTemplateUUID = uuid
+		return &ne.ACLTemplate{DeviceDetails: []ne.ACLTemplateDeviceDetails{
+			{UUID: ne.String("other"), ACLStatus: ne.String(ne.ACLDeviceStatusProvisioning)},
+			{UUID: ne.String(deviceUUID), ACLStatus: ne.String(ne.ACLDeviceStatusProvisioned)},
+		}}, nil
+	}
+	delay := 100 * time.Millisecond
+	timeout := 10 * time.Minute
+	// when
+	waitConfig := createNetworkDeviceACLTemplateStatusWaitConfiguration(fetchFunc, templateUUID, deviceUUID, delay, timeout)
+	_, err := waitConfig.WaitForStateContext(context.Background())
+	// then
+	assert.Nil(t, err, "WaitForState does not return an error")
+	assert.Equal(t, templateUUID, receivedTemplateUUID, "Queried template id matches")
+	assert.Equal(t, timeout, waitConfig.Timeout, "ACL template status wait configuration timeout matches")
+	assert.Equal(t, delay, waitConfig.MinTimeout, "ACL template status wait configuration min timeout matches")
+}
+
+func TestNetworkDevice_updateACLStatuses(t *testing.T) {
+	// given
+	templates := map[string]*ne.ACLTemplate{
+		"wan": {DeviceDetails: []ne.ACLTemplateDeviceDetails{
+			{UUID: ne.String("primary"), ACLStatus: ne.String(ne.ACLDeviceStatusProvisioned)},
+			{UUID: ne.String("secondary"), ACLStatus: ne.String(ne.ACLDeviceStatusProvisioned)},
+		}},
+		"mgmt": {DeviceDetails: []ne.ACLTemplateDeviceDetails{
+			{UUID: ne.String("primary"), ACLStatus: ne.String(ne.ACLDeviceStatusProvisioning)},
+		}},
+	}
+	fetchFunc := func(uuid string) (*ne.ACLTemplate, error) {
+		return templates[uuid], nil
+	}
+	primary := &ne.Device{UUID: ne.String("primary"), ACLTemplateUUID: ne.String("wan")}
+	secondary := &ne.Device{UUID: ne.String("secondary"), ACLTemplateUUID: ne.String("wan")}
+	d := schema.TestResourceDataRaw(t, createNetworkDeviceSchema(), map[string]interface{}{
+		neDeviceSchemaNames["MgmtAclTemplateUuid"]: "mgmt",
+	})
+	d.Set(neDeviceSchemaNames["Secondary"], flattenNetworkDeviceSecondary(secondary))
+	// when
+	err := updateNetworkDeviceACLStatuses(fetchFunc, primary, secondary, d)
+	// then
+	assert.Nil(t, err, "Update of ACL statuses does not return error")
+	assert.Equal(t, ne.ACLDeviceStatusProvisioned, d.Get(neDeviceSchemaNames["ACLStatus"]), "WAN ACL status matches")
+	assert.Equal(t, ne.ACLDeviceStatusProvisioning, d.Get(neDeviceSchemaNames["MgmtACLStatus"]), "MGMT ACL status is tracked separately")
+	assert.Equal(t, ne.ACLDeviceStatusProvisioned, d.Get(neDeviceSchemaNames["Secondary"]+".0."+neDeviceSchemaNames["ACLStatus"]), "Secondary WAN ACL status matches")
+	assert.Empty(t, d.Get(neDeviceSchemaNames["Secondary"]+".0."+neDeviceSchemaNames["MgmtACLStatus"]), "Secondary without MGMT ACL has no status")
+}
+
 func TestNetworkDevice_AdditionalBandwidthStatusWaitConfiguration(t *testing.T) {
 	// given
 	deviceID := "test"
@@ -466,3 +522,96 @@ func TestNetworkDevice_AdditionalBandwidthStatusWaitConfiguration(t *testing.T)
 	assert.Equal(t, timeout, waitConfig.Timeout, "Additional bandwidth status wait configuration timeout matches")
 	assert.Equal(t, delay, waitConfig.MinTimeout, "Additional bandwidth wait configuration min timeout matches")
 }
+
+func TestNetworkDevice_secondaryChangesIncludeTermLength(t *testing.T) {
+	// given
+	rawData := map[string]interface{}{
+		neDeviceSchemaNames["TermLength"]: 24,
+	}
+	d := schema.TestResourceDataRaw(t, createNetworkDeviceSchema(), rawData)
+	primaryChanges := map[string]interface{}{
+		neDeviceSchemaNames["TermLength"]: 24,
+		neDeviceSchemaNames["CoreCount"]:  4,
+	}
+	// when
+	changes := getNetworkDeviceSecondaryChanges([]string{neDeviceSchemaNames["Name"]}, primaryChanges, d)
+	// then
+	assert.Equal(t, map[string]interface{}{neDeviceSchemaNames["TermLength"]: 24}, changes, "Secondary device changes include primary term length change")
+}
+
+func TestNetworkDevice_secondaryProject(t *testing.T) {
+	// given
+	rawData := map[string]interface{}{
+		neDeviceSchemaNames["Name"]:      "device",
+		neDeviceSchemaNames["ProjectID"]: "68ccfd49-39b1-478e-957a-67c72f719d7a",
+		neDeviceSchemaNames["Secondary"]: []interface{}{
+			map[string]interface{}{
+				neDeviceSchemaNames["Name"]:      "device-secondary",
+				neDeviceSchemaNames["MetroCode"]: "SV",
+			},
+		},
+	}
+	d := schema.TestResourceDataRaw(t, createNetworkDeviceSchema(), rawData)
+	// when
+	primary, secondary := createNetworkDevices(d)
+	// then
+	assert.NotNil(t, secondary, "Secondary device is not nil")
+	assert.Equal(t, primary.ProjectID, secondary.ProjectID, "Secondary device is created in the project of the primary device")
+}
+
+func TestNetworkDevice_projectChange(t *testing.T) {
+	// given
+	state := &terraform.InstanceState{
+		ID: "0452fa68-8246-48b1-a1b2-817fb4baddcb",
+		Attributes: map[string]string{
+			neDeviceSchemaNames["Name"]:      "device",
+			neDeviceSchemaNames["ProjectID"]: "68ccfd49-39b1-478e-957a-67c72f719d7a",
+		},
+	}
+	moved := terraform.NewResourceConfigRaw(map[string]interface{}{
+		neDeviceSchemaNames["Name"]:      "device",
+		neDeviceSchemaNames["ProjectID"]: "a2f3c8e4-1d7b-4f1e-9c3a-5b6d7e8f9a0b",
+	})
+	unchanged := terraform.NewResourceConfigRaw(map[string]interface{}{
+		neDeviceSchemaNames["Name"]: "renamed",
+	})
+	// when
+	_, movedErr := resourceNetworkDevice().Diff(context.Background(), state, moved, nil)
+	_, unchangedErr := resourceNetworkDevice().Diff(context.Background(), state, unchanged, nil)
+	// then
+	assert.ErrorContains(t, movedErr, "can't be moved", "Moving a device to another project is rejected")
+	assert.NoError(t, unchangedErr, "Project is kept when not configured")
+}
+
+func TestNetworkDevice_projectPermissionError(t *testing.T) {
+	// given
+	forbidden := rest.Error{HTTPCode: 403, Message: "Forbidden"}
+	badRequest := rest.Error{HTTPCode: 400, Message: "Bad request"}
+	// when
+	projectErr := networkDeviceProjectPermissionError(forbidden, ne.String("68ccfd49-39b1-478e-957a-67c72f719d7a"))
+	noProjectErr := networkDeviceProjectPermissionError(forbidden, nil)
+	otherErr := networkDeviceProjectPermissionError(badRequest, ne.String("68ccfd49-39b1-478e-957a-67c72f719d7a"))
+	// then
+	assert.ErrorContains(t, projectErr, "permissions to create devices in the project")
+	var restErr rest.Error
+	assert.ErrorAs(t, projectErr, &restErr, "Original error is wrapped")
+	assert.Equal(t, forbidden, noProjectErr, "Errors without project are returned as is")
+	assert.Equal(t, badRequest, otherErr, "Other errors are returned as is")
+}
+
+func TestNetworkDevice_adminPassword(t *testing.T) {
+	// given
+	d := schema.TestResourceDataRaw(t, createNetworkDeviceSchema(), make(map[string]interface{}))
+	provisioned := &ne.Device{VendorConfiguration: map[string]string{"adminPassword": "s3cr3t"}}
+	later := &ne.Device{VendorConfiguration: map[string]string{"hostname": "test"}}
+	// when
+	provisionedErr := updateNetworkDeviceResource(provisioned, nil, d)
+	provisionedPassword := d.Get(neDeviceSchemaNames["AdminPassword"])
+	laterErr := updateNetworkDeviceResource(later, nil, d)
+	// then
+	assert.NoError(t, provisionedErr)
+	assert.NoError(t, laterErr)
+	assert.Equal(t, "s3cr3t", provisionedPassword, "Generated password is surfaced")
+	assert.Equal(t, "s3cr3t", d.Get(neDeviceSchemaNames["AdminPassword"]), "Password is kept when no longer returned")
+	assert.True(t, createNetworkDeviceSchema()[neDeviceSchemaNames["AdminPassword"]].Sensitive, "Password is sensitive")
+}
@@ -3,12 +3,12 @@ package equinix
 import (
 	"context"
 	"fmt"
-	"net/http"
+	"log"
 	"strings"
 
 	"github.com/equinix/ne-go"
-	"github.com/equinix/rest-go"
 	"github.com/equinix/terraform-provider-equinix/internal/config"
+	equinix_errors "github.com/equinix/terraform-provider-equinix/internal/errors"
 	equinix_validation "github.com/equinix/terraform-provider-equinix/internal/validation"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
@@ -135,11 +135,10 @@ func resourceNetworkFileRead(ctx context.Context, d *schema.ResourceData, m inte
 	var diags diag.Diagnostics
 	file, err := client.GetFile(d.Id())
 	if err != nil {
-		if restErr, ok := err.(rest.Error); ok {
-			if restErr.HTTPCode == http.StatusNotFound {
-				d.SetId("")
-				return diags
-			}
+		if !d.IsNewResource() && equinix_errors.IsGone(nil, err) {
+			log.Printf("[WARN] Network file %s not found, removing from state", d.Id())
+			d.SetId("")
+			return nil
 		}
 		return diag.FromErr(err)
 	}
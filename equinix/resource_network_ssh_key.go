@@ -3,10 +3,11 @@ package equinix
 import (
 	"context"
 	"fmt"
-	"net/http"
+	"log"
 	"time"
 
 	"github.com/equinix/terraform-provider-equinix/internal/config"
+	equinix_errors "github.com/equinix/terraform-provider-equinix/internal/errors"
 
 	"github.com/equinix/ne-go"
 	"github.com/equinix/rest-go"
@@ -107,11 +108,10 @@ func resourceNetworkSSHKeyRead(ctx context.Context, d *schema.ResourceData, m in
 	var diags diag.Diagnostics
 	key, err := client.GetSSHPublicKey(d.Id())
 	if err != nil {
-		if restErr, ok := err.(rest.Error); ok {
-			if restErr.HTTPCode == http.StatusNotFound {
-				d.SetId("")
-				return nil
-			}
+		if !d.IsNewResource() && equinix_errors.IsGone(nil, err) {
+			log.Printf("[WARN] Network SSH key %s not found, removing from state", d.Id())
+			d.SetId("")
+			return nil
 		}
 		return diag.FromErr(err)
 	}
@@ -3,9 +3,11 @@ package equinix
 import (
 	"context"
 	"fmt"
+	"log"
 
 	"github.com/equinix/terraform-provider-equinix/internal/config"
 	"github.com/equinix/terraform-provider-equinix/internal/converters"
+	equinix_errors "github.com/equinix/terraform-provider-equinix/internal/errors"
 
 	"github.com/equinix/ne-go"
 	"github.com/hashicorp/go-cty/cty"
@@ -110,6 +112,11 @@ func resourceNetworkSSHUserRead(ctx context.Context, d *schema.ResourceData, m i
 	var diags diag.Diagnostics
 	user, err := client.GetSSHUser(d.Id())
 	if err != nil {
+		if !d.IsNewResource() && equinix_errors.IsGone(nil, err) {
+			log.Printf("[WARN] Network SSH user %s not found, removing from state", d.Id())
+			d.SetId("")
+			return nil
+		}
 		return diag.FromErr(err)
 	}
 	if err := updateNetworkSSHUserResource(user, d); err != nil {
@@ -0,0 +1,74 @@
+// Package cache memoizes the responses of read-only catalog endpoints, e.g.
+// the Metal plans or the Network Edge device types, for the lifetime of a
+// configured provider, so that configurations instantiating a module many
+// times don't fetch the same catalog again for every data source.
+package cache
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"strings"
+	"sync"
+)
+
+// Cache is a key/value store of fetched responses. The zero value is not
+// usable, use New. A nil Cache fetches on every Get.
+type Cache struct {
+	lock    sync.Mutex
+	entries map[string]*entry
+}
+
+type entry struct {
+	lock  sync.Mutex
+	done  bool
+	value interface{}
+}
+
+// New returns an empty Cache.
+func New() *Cache {
+	return &Cache{entries: make(map[string]*entry)}
+}
+
+// Get returns the value cached under the key. The first caller of a key calls
+// fetch while concurrent callers of the same key wait for it. Errors are not
+// cached, the next caller fetches again.
+func (c *Cache) Get(key string, fetch func() (interface{}, error)) (interface{}, error) {
+	if c == nil {
+		return fetch()
+	}
+	c.lock.Lock()
+	e, ok := c.entries[key]
+	if !ok {
+		e = &entry{}
+		c.entries[key] = e
+	}
+	c.lock.Unlock()
+
+	e.lock.Lock()
+	defer e.lock.Unlock()
+	if e.done {
+		log.Printf("[DEBUG] Using cached response of %s", key)
+		return e.value, nil
+	}
+	value, err := fetch()
+	if err != nil {
+		return nil, err
+	}
+	e.value, e.done = value, true
+	return value, nil
+}
+
+// Key builds the key of an endpoint called with the given parameters.
+// Parameters are encoded as JSON, so structs of request options can be used.
+func Key(endpoint string, params ...interface{}) string {
+	parts := []string{endpoint}
+	for _, p := range params {
+		encoded, err := json.Marshal(p)
+		if err != nil {
+			encoded = []byte(fmt.Sprintf("%#v", p))
+		}
+		parts = append(parts, string(encoded))
+	}
+	return strings.Join(parts, " ")
+}
@@ -0,0 +1,84 @@
+package cache
+
+import (
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCache_Get(t *testing.T) {
+	// given
+	c := New()
+	var calls int32
+	fetch := func() (interface{}, error) {
+		atomic.AddInt32(&calls, 1)
+		return []string{"c3.small.x86"}, nil
+	}
+	// when
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, _ = c.Get(Key("metal/plans"), fetch)
+		}()
+	}
+	wg.Wait()
+	value, err := c.Get(Key("metal/plans"), fetch)
+	// then
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"c3.small.x86"}, value)
+	assert.Equal(t, int32(1), calls, "Catalog is fetched once")
+}
+
+func TestCache_GetError(t *testing.T) {
+	// given
+	c := New()
+	calls := 0
+	fetch := func() (interface{}, error) {
+		calls++
+		if calls == 1 {
+			return nil, errors.New("service unavailable")
+		}
+		return "types", nil
+	}
+	// when
+	_, firstErr := c.Get("ne/deviceTypes", fetch)
+	value, err := c.Get("ne/deviceTypes", fetch)
+	// then
+	assert.Error(t, firstErr)
+	assert.NoError(t, err, "Errors are not cached")
+	assert.Equal(t, "types", value)
+}
+
+func TestCache_nil(t *testing.T) {
+	// given
+	var c *Cache
+	calls := 0
+	fetch := func() (interface{}, error) {
+		calls++
+		return calls, nil
+	}
+	// when
+	_, _ = c.Get("metal/metros", fetch)
+	_, _ = c.Get("metal/metros", fetch)
+	// then
+	assert.Equal(t, 2, calls, "Nil cache fetches every time")
+}
+
+func TestCache_Key(t *testing.T) {
+	// given
+	type opts struct {
+		Offset int
+		Limit  int
+	}
+	// when
+	first := Key("fabric/metros", opts{Offset: 0, Limit: 100})
+	second := Key("fabric/metros", opts{Offset: 100, Limit: 100})
+	// then
+	assert.NotEqual(t, first, second, "Keys differ by parameters")
+	assert.Equal(t, first, Key("fabric/metros", opts{Offset: 0, Limit: 100}))
+}
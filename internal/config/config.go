@@ -19,6 +19,8 @@ import (
 	"github.com/equinix/equinix-sdk-go/services/metalv1"
 	"github.com/equinix/ne-go"
 	"github.com/equinix/oauth2-go"
+	"github.com/equinix/terraform-provider-equinix/internal/cache"
+	"github.com/equinix/terraform-provider-equinix/internal/tracing"
 	"github.com/equinix/terraform-provider-equinix/version"
 	"github.com/hashicorp/go-retryablehttp"
 	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
@@ -80,6 +82,10 @@ var (
 	DefaultBaseURL   = "https://api.equinix.com"
 	DefaultTimeout   = 30
 	redirectsErrorRe = regexp.MustCompile(`stopped after \d+ redirects\z`)
+
+	// reservedHeaders are managed by the provider itself and can not be
+	// overridden through the additional_headers provider argument
+	reservedHeaders = []string{"Authorization", "X-Auth-Token"}
 )
 
 // Config is the configuration structure used to instantiate the Equinix
@@ -95,6 +101,27 @@ type Config struct {
 	PageSize       int
 	Token          string
 
+	AdditionalHeaders map[string]string
+
+	// FabricDeprovisionedAsError makes reads of Fabric connections that were
+	// deprovisioned outside of Terraform fail instead of removing them from state
+	FabricDeprovisionedAsError bool
+
+	// FabricCorrelationPrefix is prepended to the correlation ID generated for
+	// every Equinix Fabric request
+	FabricCorrelationPrefix string
+
+	// FabricDryRun makes the orders of Fabric connections and cloud routers,
+	// i.e. their creation and bandwidth or package changes, validate against
+	// the price API instead of being placed
+	FabricDryRun bool
+
+	// DisableMetal, DisableFabric and DisableNetworkEdge skip the construction
+	// of the clients of a service, and the requirement of its credentials
+	DisableMetal       bool
+	DisableFabric      bool
+	DisableNetworkEdge bool
+
 	Ecx     ecx.Client
 	Ne      ne.Client
 	Metal   *packngo.Client
@@ -108,6 +135,10 @@ type Config struct {
 	TerraformVersion string
 	FabricClient     *v4.APIClient
 	FabricAuthToken  string
+
+	// Catalog caches the responses of read-only catalog endpoints, e.g. plans
+	// and device types, for the lifetime of the configured provider
+	Catalog *cache.Cache
 }
 
 // Load function validates configuration structure fields and configures
@@ -117,8 +148,21 @@ func (c *Config) Load(ctx context.Context) error {
 		return fmt.Errorf("'baseURL' cannot be empty")
 	}
 
-	if c.Token == "" && (c.ClientID == "" || c.ClientSecret == "") && c.AuthToken == "" {
-		return fmt.Errorf(emptyCredentialsError)
+	if err := c.validateCredentials(); err != nil {
+		return err
+	}
+
+	if err := ValidateAdditionalHeaders(c.AdditionalHeaders); err != nil {
+		return err
+	}
+
+	c.Catalog = cache.New()
+	if !c.DisableMetal {
+		c.Metal = c.NewMetalClient()
+		c.Metalgo = c.NewMetalGoClient()
+	}
+	if c.DisableFabric && c.DisableNetworkEdge {
+		return nil
 	}
 
 	var authClient *http.Client
@@ -156,6 +200,7 @@ func (c *Config) Load(ctx context.Context) error {
 	}
 	authClient.Timeout = c.requestTimeout()
 	authClient.Transport = logging.NewTransport("Equinix", authClient.Transport)
+	authClient.Transport = tracing.NewTransport("Equinix", authClient.Transport)
 	ecxClient := ecx.NewClient(ctx, c.BaseURL, authClient)
 	neClient := ne.NewClient(ctx, c.BaseURL, authClient)
 
@@ -164,35 +209,95 @@ func (c *Config) Load(ctx context.Context) error {
 		neClient.SetPageSize(c.PageSize)
 	}
 	c.ecxUserAgent = c.fullUserAgent("equinix/ecx-go")
-	ecxClient.SetHeaders(map[string]string{
+	ecxClient.SetHeaders(c.withAdditionalHeaders(map[string]string{
 		"User-agent": c.ecxUserAgent,
-	})
+	}))
 	c.neUserAgent = c.fullUserAgent("equinix/ecx-go")
-	neClient.SetHeaders(map[string]string{
+	neClient.SetHeaders(c.withAdditionalHeaders(map[string]string{
 		"User-agent": c.neUserAgent,
-	})
+	}))
+
+	if !c.DisableFabric {
+		c.Ecx = ecxClient
+		c.FabricClient = c.NewFabricClient()
+	}
+	if !c.DisableNetworkEdge {
+		c.Ne = neClient
+	}
+	return nil
+}
 
-	c.Ecx = ecxClient
-	c.Ne = neClient
-	c.Metal = c.NewMetalClient()
-	c.Metalgo = c.NewMetalGoClient()
-	c.FabricClient = c.NewFabricClient()
+// validateCredentials checks that the credentials of the enabled services are
+// set. With all the services enabled, the credentials of any of them are
+// enough, as the provider may only be used for some of them.
+func (c *Config) validateCredentials() error {
+	if c.DisableMetal && c.DisableFabric && c.DisableNetworkEdge {
+		return fmt.Errorf("all the services are disabled, at least one of enable_metal, enable_fabric or enable_network_edge must be true")
+	}
+	hasEquinixCredentials := c.Token != "" || (c.ClientID != "" && c.ClientSecret != "")
+	if !c.DisableMetal && !c.DisableFabric && !c.DisableNetworkEdge {
+		if !hasEquinixCredentials && c.AuthToken == "" {
+			return fmt.Errorf(emptyCredentialsError)
+		}
+		return nil
+	}
+	if !c.DisableMetal && c.AuthToken == "" {
+		return fmt.Errorf(`"auth_token" must be set to interact with Equinix Metal, or Metal disabled with enable_metal = false`)
+	}
+	for _, service := range []struct {
+		name     string
+		arg      string
+		disabled bool
+	}{
+		{"Equinix Fabric", "enable_fabric", c.DisableFabric},
+		{"Network Edge", "enable_network_edge", c.DisableNetworkEdge},
+	} {
+		if !service.disabled && !hasEquinixCredentials {
+			return fmt.Errorf(`one of pair "client_id" - "client_secret" or "token" must be set to interact with %s, or it disabled with %s = false`, service.name, service.arg)
+		}
+	}
 	return nil
 }
 
+// DisabledServiceError returns an error if the resource or data source of the
+// given type belongs to a service disabled in the provider configuration, and
+// nil otherwise.
+func (c *Config) DisabledServiceError(typeName string) error {
+	var service, arg string
+	switch {
+	case strings.HasPrefix(typeName, "equinix_metal_") && c.DisableMetal:
+		service, arg = "Equinix Metal", "enable_metal"
+	case (strings.HasPrefix(typeName, "equinix_fabric_") || strings.HasPrefix(typeName, "equinix_ecx_")) && c.DisableFabric:
+		service, arg = "Equinix Fabric", "enable_fabric"
+	case strings.HasPrefix(typeName, "equinix_network_") && c.DisableNetworkEdge:
+		service, arg = "Network Edge", "enable_network_edge"
+	default:
+		return nil
+	}
+	return fmt.Errorf("%s can't be used as %s is disabled with %s = false in the provider configuration", typeName, service, arg)
+}
+
 // NewFabricClient returns a new client for accessing Equinix Fabric's v4 API.
 // uncomment the funct when migrating Fabric resources to use
 // functions from internal/
 func (c *Config) NewFabricClient() *v4.APIClient {
-	transport := logging.NewTransport("Equinix Fabric", http.DefaultTransport)
+	transport := tracing.NewTransport("Equinix Fabric", logging.NewTransport("Equinix Fabric", http.DefaultTransport))
+	retryClient := retryablehttp.NewClient()
+	retryClient.HTTPClient.Transport = &correlationIdTransport{prefix: c.FabricCorrelationPrefix, next: transport}
+	retryClient.HTTPClient.Timeout = c.requestTimeout()
+	retryClient.RetryMax = c.MaxRetries
+	retryClient.RetryWaitMin = time.Second
+	retryClient.RetryWaitMax = c.MaxRetryWait
+	retryClient.CheckRetry = FabricRetryPolicy
+	// Return the last response once retries are exhausted, so that the Fabric
+	// error of the response is reported rather than a generic one
+	retryClient.ErrorHandler = retryablehttp.PassthroughErrorHandler
 	authClient := &http.Client{
-		Transport: transport,
-	}
-	authClient.Timeout = c.requestTimeout()
-	fabricHeaderMap := map[string]string{
-		"X-SOURCE":         "API",
-		"X-CORRELATION-ID": correlationId(25),
+		Transport: &requestMethodTransport{next: &retryablehttp.RoundTripper{Client: retryClient}},
 	}
+	fabricHeaderMap := c.withAdditionalHeaders(map[string]string{
+		"X-SOURCE": "API",
+	})
 	v4Configuration := v4.Configuration{
 		BasePath:      c.BaseURL,
 		DefaultHeader: fabricHeaderMap,
@@ -208,6 +313,7 @@ func (c *Config) NewMetalClient() *packngo.Client {
 	transport := http.DefaultTransport
 	// transport = &DumpTransport{http.DefaultTransport} // Debug only
 	transport = logging.NewTransport("Equinix Metal (packngo)", transport)
+	transport = tracing.NewTransport("Equinix Metal (packngo)", transport)
 	retryClient := retryablehttp.NewClient()
 	retryClient.HTTPClient.Transport = transport
 	retryClient.RetryMax = c.MaxRetries
@@ -227,6 +333,7 @@ func (c *Config) NewMetalClient() *packngo.Client {
 func (c *Config) NewMetalGoClient() *metalv1.APIClient {
 	transport := http.DefaultTransport
 	transport = logging.NewTransport("Equinix Metal (metal-go)", transport)
+	transport = tracing.NewTransport("Equinix Metal (metal-go)", transport)
 	retryClient := retryablehttp.NewClient()
 	retryClient.HTTPClient.Transport = transport
 	retryClient.RetryMax = c.MaxRetries
@@ -252,6 +359,33 @@ func (c *Config) NewMetalGoClient() *metalv1.APIClient {
 	return client
 }
 
+// ValidateAdditionalHeaders returns an error if any of the given headers would
+// override the authentication headers managed by the provider.
+func ValidateAdditionalHeaders(headers map[string]string) error {
+	for name := range headers {
+		for _, reserved := range reservedHeaders {
+			if http.CanonicalHeaderKey(name) == reserved {
+				return fmt.Errorf("additional_headers can not override the %q header", reserved)
+			}
+		}
+	}
+	return nil
+}
+
+// withAdditionalHeaders returns the given headers extended with the headers
+// configured through the additional_headers provider argument. Headers set
+// by the provider take precedence over the additional ones.
+func (c *Config) withAdditionalHeaders(headers map[string]string) map[string]string {
+	merged := make(map[string]string, len(headers)+len(c.AdditionalHeaders))
+	for k, v := range c.AdditionalHeaders {
+		merged[k] = v
+	}
+	for k, v := range headers {
+		merged[k] = v
+	}
+	return merged
+}
+
 func (c *Config) requestTimeout() time.Duration {
 	if c.RequestTimeout == 0 {
 		return 5 * time.Second
@@ -282,6 +416,61 @@ func MetalRetryPolicy(ctx context.Context, resp *http.Response, err error) (bool
 	return false, nil
 }
 
+// idempotentMethods are the HTTP methods of the requests that can be sent
+// again without side effects
+var idempotentMethods = map[string]bool{
+	http.MethodGet:     true,
+	http.MethodHead:    true,
+	http.MethodPut:     true,
+	http.MethodDelete:  true,
+	http.MethodOptions: true,
+}
+
+type requestMethodContextKey struct{}
+
+// requestMethodTransport keeps the method of the request in its context, so
+// that the retry policy knows it when the request failed without response
+type requestMethodTransport struct {
+	next http.RoundTripper
+}
+
+func (t *requestMethodTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	return t.next.RoundTrip(req.WithContext(context.WithValue(req.Context(), requestMethodContextKey{}, req.Method)))
+}
+
+// requestMethod returns the method of the request, empty if unknown.
+func requestMethod(ctx context.Context, resp *http.Response) string {
+	if resp != nil && resp.Request != nil {
+		return resp.Request.Method
+	}
+	method, _ := ctx.Value(requestMethodContextKey{}).(string)
+	return method
+}
+
+// FabricRetryPolicy retries the requests to the Fabric API rejected with a
+// rate limit, honoring the Retry-After header. Idempotent requests are also
+// retried when they failed like MetalRetryPolicy retries them or got a server
+// error. Other requests, e.g. orders, may have been processed by then, sending
+// them again could place a duplicate order.
+func FabricRetryPolicy(ctx context.Context, resp *http.Response, err error) (bool, error) {
+	if ctx.Err() != nil {
+		return false, ctx.Err()
+	}
+	if err == nil && resp != nil && resp.StatusCode == http.StatusTooManyRequests {
+		return true, nil
+	}
+	if !idempotentMethods[requestMethod(ctx, resp)] {
+		return false, nil
+	}
+	if err != nil || resp == nil {
+		return MetalRetryPolicy(ctx, resp, err)
+	}
+	if resp.StatusCode >= http.StatusInternalServerError && resp.StatusCode != http.StatusNotImplemented {
+		return true, nil
+	}
+	return false, nil
+}
+
 func terraformUserAgent(version string) string {
 	ua := fmt.Sprintf("HashiCorp Terraform/%s (+https://www.terraform.io) Terraform Plugin SDK/%s",
 		version, meta.SDKVersionString())
@@ -0,0 +1,190 @@
+package config
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	v4 "github.com/equinix-labs/fabric-go/fabric/v4"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateAdditionalHeaders(t *testing.T) {
+	// given
+	valid := map[string]string{
+		"X-Billing-Tag": "team-a",
+		"X-Route-Hint":  "emea",
+	}
+	invalid := []map[string]string{
+		{"Authorization": "Bearer token"},
+		{"authorization": "Bearer token"},
+		{"x-auth-token": "metal-token"},
+	}
+	// when
+	err := ValidateAdditionalHeaders(valid)
+	// then
+	assert.Nil(t, err, "Headers without authentication keys are valid")
+	for _, headers := range invalid {
+		assert.Error(t, ValidateAdditionalHeaders(headers), "Authentication headers can not be overridden")
+	}
+}
+
+func TestConfig_withAdditionalHeaders(t *testing.T) {
+	// given
+	c := Config{
+		AdditionalHeaders: map[string]string{
+			"X-Billing-Tag": "team-a",
+			"X-SOURCE":      "CUSTOM",
+		},
+	}
+	// when
+	headers := c.withAdditionalHeaders(map[string]string{
+		"X-SOURCE": "API",
+	})
+	// then
+	assert.Equal(t, "team-a", headers["X-Billing-Tag"], "Additional header is included")
+	assert.Equal(t, "API", headers["X-SOURCE"], "Provider managed header takes precedence")
+	assert.Len(t, c.AdditionalHeaders, 2, "Configured headers are not modified")
+}
+
+func TestCorrelationIdTransport(t *testing.T) {
+	// given
+	ids := []string{}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ids = append(ids, r.Header.Get(correlationIdHeader))
+	}))
+	defer srv.Close()
+	client := &http.Client{
+		Transport: &correlationIdTransport{prefix: "ci-1234-", next: http.DefaultTransport},
+	}
+	// when
+	for i := 0; i < 2; i++ {
+		resp, err := client.Get(srv.URL)
+		assert.NoError(t, err)
+		resp.Body.Close()
+	}
+	// then
+	assert.Len(t, ids, 2)
+	for _, id := range ids {
+		assert.True(t, strings.HasPrefix(id, "ci-1234-"), "Correlation ID is prefixed")
+		assert.Len(t, id, len("ci-1234-")+correlationIdLength)
+	}
+	assert.NotEqual(t, ids[0], ids[1], "Correlation ID is generated per request")
+}
+
+func TestConfig_LoadDisabledServices(t *testing.T) {
+	// given
+	fabricOnly := &Config{BaseURL: "https://api.equinix.com", Token: "fabric-token", DisableMetal: true}
+	metalOnly := &Config{BaseURL: "https://api.equinix.com", AuthToken: "metal-token", DisableFabric: true, DisableNetworkEdge: true}
+	invalid := []*Config{
+		{BaseURL: "https://api.equinix.com", AuthToken: "metal-token", DisableMetal: true},
+		{BaseURL: "https://api.equinix.com", Token: "fabric-token", DisableFabric: true, DisableNetworkEdge: true},
+		{BaseURL: "https://api.equinix.com", ClientID: "id", DisableMetal: true, DisableNetworkEdge: true},
+		{BaseURL: "https://api.equinix.com", Token: "fabric-token", AuthToken: "metal-token", DisableMetal: true, DisableFabric: true, DisableNetworkEdge: true},
+	}
+	// when
+	fabricErr := fabricOnly.Load(context.Background())
+	metalErr := metalOnly.Load(context.Background())
+	// then
+	assert.NoError(t, fabricErr, "Metal credentials are not required when Metal is disabled")
+	assert.Nil(t, fabricOnly.Metal, "Metal client is not constructed")
+	assert.Nil(t, fabricOnly.Metalgo, "Metal client is not constructed")
+	assert.NotNil(t, fabricOnly.FabricClient)
+	assert.NotNil(t, fabricOnly.Ne)
+	assert.NoError(t, metalErr, "Fabric credentials are not required when Fabric and Network Edge are disabled")
+	assert.NotNil(t, metalOnly.Metal)
+	assert.Nil(t, metalOnly.FabricClient, "Fabric client is not constructed")
+	assert.Nil(t, metalOnly.Ecx, "ECX client is not constructed")
+	assert.Nil(t, metalOnly.Ne, "Network Edge client is not constructed")
+	for _, c := range invalid {
+		assert.Error(t, c.Load(context.Background()), "Credentials of the enabled services are required")
+	}
+}
+
+func TestConfig_DisabledServiceError(t *testing.T) {
+	// given
+	c := Config{DisableMetal: true, DisableFabric: true}
+	// when / then
+	assert.ErrorContains(t, c.DisabledServiceError("equinix_metal_device"), "enable_metal = false")
+	assert.ErrorContains(t, c.DisabledServiceError("equinix_fabric_connection"), "enable_fabric = false")
+	assert.ErrorContains(t, c.DisabledServiceError("equinix_ecx_l2_connection"), "enable_fabric = false")
+	assert.NoError(t, c.DisabledServiceError("equinix_network_device"), "Network Edge is enabled")
+	assert.NoError(t, (&Config{}).DisabledServiceError("equinix_metal_device"), "Services are enabled by default")
+}
+
+func TestFabricRetryPolicy(t *testing.T) {
+	// given
+	response := func(method string, code int) *http.Response {
+		return &http.Response{StatusCode: code, Request: &http.Request{Method: method}}
+	}
+	retried := []int{http.StatusTooManyRequests, http.StatusInternalServerError, http.StatusBadGateway, http.StatusServiceUnavailable}
+	notRetried := []int{http.StatusOK, http.StatusBadRequest, http.StatusNotFound, http.StatusNotImplemented}
+	postCtx := context.WithValue(context.Background(), requestMethodContextKey{}, http.MethodPost)
+	getCtx := context.WithValue(context.Background(), requestMethodContextKey{}, http.MethodGet)
+	// when / then
+	for _, code := range retried {
+		retry, err := FabricRetryPolicy(context.Background(), response(http.MethodGet, code), nil)
+		assert.NoError(t, err)
+		assert.True(t, retry, "Status %d is retried", code)
+	}
+	for _, code := range notRetried {
+		retry, err := FabricRetryPolicy(context.Background(), response(http.MethodGet, code), nil)
+		assert.NoError(t, err)
+		assert.False(t, retry, "Status %d is not retried", code)
+	}
+	for _, code := range []int{http.StatusInternalServerError, http.StatusBadGateway} {
+		retry, _ := FabricRetryPolicy(context.Background(), response(http.MethodPost, code), nil)
+		assert.False(t, retry, "Status %d of POST requests is not retried", code)
+	}
+	retry, _ := FabricRetryPolicy(context.Background(), response(http.MethodPost, http.StatusTooManyRequests), nil)
+	assert.True(t, retry, "Rate limited POST requests are retried")
+	retry, _ = FabricRetryPolicy(postCtx, nil, errors.New("timeout"))
+	assert.False(t, retry, "Failed POST requests are not retried")
+	retry, _ = FabricRetryPolicy(getCtx, nil, errors.New("timeout"))
+	assert.True(t, retry, "Failed GET requests are retried")
+}
+
+func TestConfig_NewFabricClientDoesNotRetryPost(t *testing.T) {
+	// given
+	calls := 0
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusInternalServerError)
+		_, _ = w.Write([]byte(`[]`))
+	}))
+	defer srv.Close()
+	c := &Config{BaseURL: srv.URL, MaxRetries: 2, MaxRetryWait: time.Second}
+	// when
+	_, _, err := c.NewFabricClient().ConnectionsApi.CreateConnection(context.Background(), v4.ConnectionPostRequest{Name: "conn"})
+	// then
+	assert.Error(t, err)
+	assert.Equal(t, 1, calls, "Order answered with a server error is not sent again")
+}
+
+func TestConfig_NewFabricClientRetries(t *testing.T) {
+	// given
+	calls := 0
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		if calls == 1 {
+			w.Header().Set("Retry-After", "0")
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"code": "SV"}`))
+	}))
+	defer srv.Close()
+	c := &Config{BaseURL: srv.URL, MaxRetries: 2, MaxRetryWait: time.Second}
+	// when
+	metro, _, err := c.NewFabricClient().MetrosApi.GetMetroByCode(context.Background(), "SV")
+	// then
+	assert.NoError(t, err, "Rate limited request is retried")
+	assert.Equal(t, "SV", metro.Code)
+	assert.Equal(t, 2, calls)
+}
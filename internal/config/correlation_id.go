@@ -2,16 +2,36 @@ package config
 
 import (
 	"math/rand"
+	"net/http"
+	"regexp"
+	"sync"
 	"time"
 )
 
-const allowed_charset = "abcdefghijklmnopqrstuvwxyz" +
-	"ABCDEFGHIJKLMNOPQRSTUVWXYZ0123456789#$&@"
+const (
+	allowed_charset = "abcdefghijklmnopqrstuvwxyz" +
+		"ABCDEFGHIJKLMNOPQRSTUVWXYZ0123456789#$&@"
 
-var seededRand = rand.New(
-	rand.NewSource(time.Now().UnixNano()))
+	correlationIdHeader = "X-CORRELATION-ID"
+	correlationIdLength = 25
+
+	// CorrelationPrefixMaxLength is the maximum length of the prefix configured
+	// with the fabric_correlation_prefix provider argument
+	CorrelationPrefixMaxLength = 40
+)
+
+// CorrelationPrefixRe matches the values allowed as correlation ID prefix
+var CorrelationPrefixRe = regexp.MustCompile(`^[a-zA-Z0-9#$&@._-]*$`)
+
+var (
+	seededRand = rand.New(
+		rand.NewSource(time.Now().UnixNano()))
+	seededRandMu sync.Mutex
+)
 
 func correlationIdWithCharset(length int, charset string) string {
+	seededRandMu.Lock()
+	defer seededRandMu.Unlock()
 	b := make([]byte, length)
 	for i := range b {
 		b[i] = charset[seededRand.Intn(len(charset))]
@@ -22,3 +42,16 @@ func correlationIdWithCharset(length int, charset string) string {
 func correlationId(length int) string {
 	return correlationIdWithCharset(length, allowed_charset)
 }
+
+// correlationIdTransport sets a new correlation ID, optionally prefixed, on
+// every request so each API call can be traced individually
+type correlationIdTransport struct {
+	prefix string
+	next   http.RoundTripper
+}
+
+func (t *correlationIdTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	req = req.Clone(req.Context())
+	req.Header.Set(correlationIdHeader, t.prefix+correlationId(correlationIdLength))
+	return t.next.RoundTrip(req)
+}
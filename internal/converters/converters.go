@@ -16,6 +16,21 @@ func StringArrToIfArr(sli []string) []interface{} {
 	return arr
 }
 
+// SliceToIfArr converts each element of the slice with the given function
+// into a slice of the same length, e.g. to build the list of a nested block.
+// The elements are set by index, so the result never contains leading zero
+// values. A nil slice is converted to nil.
+func SliceToIfArr[T any](sli []T, convert func(T) interface{}) []interface{} {
+	if sli == nil {
+		return nil
+	}
+	arr := make([]interface{}, len(sli))
+	for i, v := range sli {
+		arr[i] = convert(v)
+	}
+	return arr
+}
+
 func IfArrToStringArr(ifaceArr []interface{}) []string {
 	arr := make([]string, len(ifaceArr))
 	for i, v := range ifaceArr {
@@ -0,0 +1,19 @@
+package converters
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSliceToIfArr(t *testing.T) {
+	// given
+	numbers := []int32{50, 100}
+	toInt := func(n int32) interface{} { return int(n) }
+	// when
+	converted := SliceToIfArr(numbers, toInt)
+	// then
+	assert.Equal(t, []interface{}{50, 100}, converted, "Elements are converted in order, without padding")
+	assert.Nil(t, SliceToIfArr(nil, toInt), "Nil slice converts to nil")
+	assert.Equal(t, []interface{}{}, SliceToIfArr([]int32{}, toInt), "Empty slice converts to an empty slice")
+}
@@ -53,6 +53,7 @@ func NewResource(config *ResourceConfig) *schema.Resource {
 		newAttributeSchema.Computed = true
 		newAttributeSchema.Required = false
 		newAttributeSchema.Optional = false
+		newAttributeSchema.ValidateFunc = nil
 		recordSchema[attributeName] = newAttributeSchema
 	}
 
@@ -131,9 +132,14 @@ func computeFilterAttributes(recordSchema map[string]*schema.Schema) []string {
 	var filterAttributes []string
 
 	for attr, schemaForAttr := range recordSchema {
-		if schemaForAttr.Type != schema.TypeMap {
-			filterAttributes = append(filterAttributes, attr)
+		if schemaForAttr.Type == schema.TypeMap {
+			continue
 		}
+		// nested blocks can not be matched against filter values
+		if _, ok := schemaForAttr.Elem.(*schema.Resource); ok {
+			continue
+		}
+		filterAttributes = append(filterAttributes, attr)
 	}
 
 	return filterAttributes
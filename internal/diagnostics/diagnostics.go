@@ -0,0 +1,170 @@
+// Package diagnostics turns errors of API operations into diagnostics of
+// both the SDKv2 and the framework providers, so that resources report errors
+// in the same format whichever plugin protocol they are implemented with.
+package diagnostics
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	fabric "github.com/equinix-labs/fabric-go/fabric/v4"
+	"github.com/equinix/equinix-sdk-go/services/metalv1"
+	"github.com/equinix/rest-go"
+	equinix_errors "github.com/equinix/terraform-provider-equinix/internal/errors"
+	"github.com/hashicorp/go-cty/cty"
+	fwdiag "github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/packethost/packngo"
+)
+
+const (
+	// correlationIdHeader is set on the Fabric requests by the provider
+	correlationIdHeader = "X-CORRELATION-ID"
+	// requestIdHeader is set on the responses of Equinix Metal
+	requestIdHeader = "X-Request-Id"
+)
+
+// SDKError returns the diagnostics of the failed API operation, e.g.
+// "creating Fabric connection", for SDKv2 resources. The attribute path of the
+// argument the error is about, e.g. "z_side.0.access_point.0.port.0.uuid", is
+// optional. The response of the operation may be nil.
+func SDKError(operation, attributePath string, resp *http.Response, err error) diag.Diagnostics {
+	summary, detail := Format(operation, resp, err)
+	return diag.Diagnostics{{
+		Severity:      diag.Error,
+		Summary:       summary,
+		Detail:        detail,
+		AttributePath: sdkPath(attributePath),
+	}}
+}
+
+// FrameworkError returns the diagnostics of the failed API operation for
+// framework resources, see SDKError.
+func FrameworkError(operation, attributePath string, resp *http.Response, err error) fwdiag.Diagnostics {
+	summary, detail := Format(operation, resp, err)
+	var diags fwdiag.Diagnostics
+	if attributePath == "" {
+		diags.AddError(summary, detail)
+	} else {
+		diags.AddAttributeError(frameworkPath(attributePath), summary, detail)
+	}
+	return diags
+}
+
+// Format returns the summary and the detail of the diagnostics of the failed
+// API operation. The detail holds the error message of the API followed by the
+// HTTP status and the correlation or request ID of the operation when known.
+func Format(operation string, resp *http.Response, err error) (summary, detail string) {
+	if resp == nil {
+		var packngoErr *packngo.ErrorResponse
+		if errors.As(err, &packngoErr) && packngoErr.Response != nil {
+			resp = packngoErr.Response
+		}
+	}
+	lines := []string{errorMessage(err)}
+	if status := statusCode(resp, err); status != 0 {
+		lines = append(lines, fmt.Sprintf("HTTP status: %d %s", status, http.StatusText(status)))
+	}
+	if resp != nil {
+		if resp.Request != nil && resp.Request.Header.Get(correlationIdHeader) != "" {
+			lines = append(lines, "Correlation ID: "+resp.Request.Header.Get(correlationIdHeader))
+		} else if resp.Header.Get(requestIdHeader) != "" {
+			lines = append(lines, "Request ID: "+resp.Header.Get(requestIdHeader))
+		}
+	}
+	return "Error " + operation, strings.Join(lines, "\n")
+}
+
+// errorMessage returns the message of the error, with the error details
+// returned by the Fabric and Equinix Metal APIs.
+func errorMessage(err error) string {
+	var fabricErr fabric.GenericSwaggerError
+	if errors.As(err, &fabricErr) {
+		message := equinix_errors.FormatFabricError(fabricErr).Error()
+		if err.Error() != fabricErr.Error() {
+			// The error is wrapped with more context
+			message = strings.Replace(err.Error(), fabricErr.Error(), message, 1)
+		}
+		return message
+	}
+	var metalErr *metalv1.GenericOpenAPIError
+	if errors.As(err, &metalErr) {
+		if details := metalErrorDetails(metalErr.Body()); details != "" {
+			return err.Error() + ": " + details
+		}
+	}
+	return err.Error()
+}
+
+// metalErrorDetails returns the errors listed in the body of an Equinix Metal
+// error response, empty if there are none.
+func metalErrorDetails(body []byte) string {
+	var errorBody struct {
+		Errors []string `json:"errors"`
+		Error  string   `json:"error"`
+	}
+	if json.Unmarshal(body, &errorBody) != nil {
+		return ""
+	}
+	if errorBody.Error != "" {
+		errorBody.Errors = append(errorBody.Errors, errorBody.Error)
+	}
+	return strings.Join(errorBody.Errors, "; ")
+}
+
+// statusCode returns the HTTP status of the failed operation, 0 if unknown.
+// Clients not returning their response, like the Network Edge one, keep it in
+// their errors.
+func statusCode(resp *http.Response, err error) int {
+	if resp != nil {
+		return resp.StatusCode
+	}
+	var restErr rest.Error
+	if errors.As(err, &restErr) {
+		return restErr.HTTPCode
+	}
+	var errResp *equinix_errors.ErrorResponse
+	if errors.As(err, &errResp) {
+		return errResp.StatusCode
+	}
+	return 0
+}
+
+// pathSteps splits the attribute path into its attribute names and list
+// indexes.
+func pathSteps(attributePath string) []string {
+	if attributePath == "" {
+		return nil
+	}
+	return strings.Split(attributePath, ".")
+}
+
+func sdkPath(attributePath string) cty.Path {
+	var p cty.Path
+	for _, step := range pathSteps(attributePath) {
+		if i, err := strconv.Atoi(step); err == nil {
+			p = p.IndexInt(i)
+		} else {
+			p = p.GetAttr(step)
+		}
+	}
+	return p
+}
+
+func frameworkPath(attributePath string) path.Path {
+	steps := pathSteps(attributePath)
+	p := path.Root(steps[0])
+	for _, step := range steps[1:] {
+		if i, err := strconv.Atoi(step); err == nil {
+			p = p.AtListIndex(i)
+		} else {
+			p = p.AtName(step)
+		}
+	}
+	return p
+}
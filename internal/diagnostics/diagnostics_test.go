@@ -0,0 +1,75 @@
+package diagnostics
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/equinix/rest-go"
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFormat_fabricResponse(t *testing.T) {
+	// given
+	req, _ := http.NewRequest(http.MethodPost, "https://api.equinix.com/fabric/v4/connections", nil)
+	req.Header.Set(correlationIdHeader, "ci-1234-abc")
+	resp := &http.Response{StatusCode: http.StatusBadRequest, Request: req, Header: http.Header{}}
+	// when
+	summary, detail := Format("creating Fabric connection", resp, errors.New("400 Bad Request"))
+	// then
+	assert.Equal(t, "Error creating Fabric connection", summary)
+	assert.Equal(t, "400 Bad Request\nHTTP status: 400 Bad Request\nCorrelation ID: ci-1234-abc", detail)
+}
+
+func TestFormat_metalResponse(t *testing.T) {
+	// given
+	resp := &http.Response{StatusCode: http.StatusForbidden, Header: http.Header{requestIdHeader: []string{"req-1"}}}
+	// when
+	_, detail := Format("reading SSH key", resp, errors.New("403 Forbidden"))
+	// then
+	assert.Equal(t, "403 Forbidden\nHTTP status: 403 Forbidden\nRequest ID: req-1", detail)
+}
+
+func TestFormat_statusOfWrappedError(t *testing.T) {
+	// given
+	err := fmt.Errorf("could not create network device: %w", rest.Error{HTTPCode: http.StatusUnauthorized, Message: "unauthorized"})
+	// when
+	_, detail := Format("creating Network Edge device", nil, err)
+	// then
+	assert.Contains(t, detail, "HTTP status: 401 Unauthorized", "Status is read from errors of clients not returning their response")
+	_, detail = Format("creating Network Edge device", nil, errors.New("timeout"))
+	assert.Equal(t, "timeout", detail, "Unknown status is left out")
+}
+
+func TestMetalErrorDetails(t *testing.T) {
+	// when / then
+	assert.Equal(t, "Name can't be blank; Key is invalid", metalErrorDetails([]byte(`{"errors":["Name can't be blank","Key is invalid"]}`)))
+	assert.Equal(t, "Not found", metalErrorDetails([]byte(`{"error":"Not found"}`)))
+	assert.Empty(t, metalErrorDetails([]byte(`<html></html>`)))
+}
+
+func TestErrorAttributePath(t *testing.T) {
+	// given
+	err := errors.New("invalid port")
+	// when
+	sdkDiags := SDKError("creating Fabric connection", "z_side.0.access_point.0.port.0.uuid", nil, err)
+	fwDiags := FrameworkError("creating SSH key", "public_key", nil, err)
+	noPathDiags := FrameworkError("creating SSH key", "", nil, err)
+	// then
+	require.Len(t, sdkDiags, 1)
+	assert.Equal(t, cty.GetAttrPath("z_side").IndexInt(0).GetAttr("access_point").IndexInt(0).GetAttr("port").IndexInt(0).GetAttr("uuid"), sdkDiags[0].AttributePath)
+	assert.Equal(t, "Error creating Fabric connection", sdkDiags[0].Summary)
+	assert.Equal(t, "z_side[0].access_point[0].port[0].uuid", frameworkPath("z_side.0.access_point.0.port.0.uuid").String())
+	require.Len(t, fwDiags, 1)
+	assert.Equal(t, sdkDiags[0].Detail, fwDiags[0].Detail(), "Both protocols report the same detail")
+	withPath, ok := fwDiags[0].(interface{ Path() path.Path })
+	require.True(t, ok)
+	assert.Equal(t, path.Root("public_key"), withPath.Path())
+	require.Len(t, noPathDiags, 1)
+	_, ok = noPathDiags[0].(interface{ Path() path.Path })
+	assert.False(t, ok)
+}
@@ -3,9 +3,11 @@ package errors
 import (
 	"fmt"
 	"net/http"
+	"strconv"
 	"strings"
 
 	fabric "github.com/equinix-labs/fabric-go/fabric/v4"
+	"github.com/equinix/equinix-sdk-go/services/metalv1"
 	"github.com/equinix/rest-go"
 	"github.com/packethost/packngo"
 )
@@ -107,6 +109,28 @@ func IsNotFound(err error) bool {
 	return false
 }
 
+// IsGone reports whether an error returned while reading a resource means the
+// resource was deleted outside of Terraform, so that it can be removed from
+// state instead of failing the refresh. Equinix Metal responds with 403 for
+// resources of deleted projects, so Metal errors are gone on 403 and 404, while
+// Fabric and Network Edge errors are only gone on 404.
+func IsGone(resp *http.Response, err error) bool {
+	switch e := err.(type) {
+	case rest.Error:
+		return e.HTTPCode == http.StatusNotFound
+	case fabric.GenericSwaggerError:
+		if resp != nil {
+			return resp.StatusCode == http.StatusNotFound
+		}
+		return strings.HasPrefix(e.Error(), strconv.Itoa(http.StatusNotFound))
+	case *metalv1.GenericOpenAPIError:
+		return resp != nil && (resp.StatusCode == http.StatusNotFound || resp.StatusCode == http.StatusForbidden)
+	case *ErrorResponse, *packngo.ErrorResponse:
+		return IsNotFound(err) || IsForbidden(err)
+	}
+	return false
+}
+
 type Errors []string
 
 func (e Errors) Error() string {
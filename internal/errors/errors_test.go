@@ -5,8 +5,11 @@ import (
 	"net/http"
 	"testing"
 
+	fabric "github.com/equinix-labs/fabric-go/fabric/v4"
+	"github.com/equinix/equinix-sdk-go/services/metalv1"
 	"github.com/equinix/rest-go"
 	"github.com/hashicorp/terraform-plugin-testing/helper/acctest"
+	"github.com/packethost/packngo"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -47,3 +50,34 @@ func TestProvider_IsRestNotFoundError(t *testing.T) {
 	// then
 	assert.Equal(t, expected, result, "Result matches expected output")
 }
+
+func TestProvider_IsGone(t *testing.T) {
+	// given
+	notFound := &http.Response{StatusCode: http.StatusNotFound}
+	forbidden := &http.Response{StatusCode: http.StatusForbidden}
+	input := []struct {
+		resp *http.Response
+		err  error
+	}{
+		{nil, rest.Error{HTTPCode: http.StatusNotFound}},
+		{nil, rest.Error{HTTPCode: http.StatusForbidden}},
+		{notFound, fabric.GenericSwaggerError{}},
+		{forbidden, fabric.GenericSwaggerError{}},
+		{notFound, &ErrorResponse{StatusCode: http.StatusNotFound, IsAPIError: true}},
+		{forbidden, &ErrorResponse{StatusCode: http.StatusForbidden}},
+		{nil, &packngo.ErrorResponse{Response: notFound}},
+		{nil, &packngo.ErrorResponse{Response: &http.Response{StatusCode: http.StatusInternalServerError}}},
+		{forbidden, &metalv1.GenericOpenAPIError{}},
+		{nil, &metalv1.GenericOpenAPIError{}},
+		{notFound, fmt.Errorf("some bogus error")},
+		{nil, nil},
+	}
+	expected := []bool{true, false, true, false, true, true, true, false, true, false, false, false}
+	// when
+	result := make([]bool, len(input))
+	for i := range input {
+		result[i] = IsGone(input[i].resp, input[i].err)
+	}
+	// then
+	assert.Equal(t, expected, result, "Result matches expected output")
+}
@@ -0,0 +1,63 @@
+package schema
+
+import (
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// Units of the bandwidth attributes of Fabric resources. The Fabric API
+// always expects and returns bandwidths in Mbps.
+const (
+	BandwidthUnitMbps = "MBPS"
+	BandwidthUnitGbps = "GBPS"
+)
+
+// BandwidthUnitSch returns the schema of the unit of the bandwidth attribute
+// with the given name. The unit attribute is named after it with a "_unit"
+// suffix.
+func BandwidthUnitSch(bandwidthAttribute string) *schema.Schema {
+	return &schema.Schema{
+		Type:             schema.TypeString,
+		Optional:         true,
+		Default:          BandwidthUnitMbps,
+		ValidateFunc:     validation.StringInSlice([]string{BandwidthUnitMbps, BandwidthUnitGbps}, true),
+		StateFunc:        func(v interface{}) string { return strings.ToUpper(v.(string)) },
+		DiffSuppressFunc: SuppressEquivalentBandwidth(bandwidthAttribute),
+		Description:      "Unit of the " + bandwidthAttribute + " value - MBPS or GBPS. Bandwidths are sent to the API in Mbps",
+	}
+}
+
+// BandwidthToMbps converts a bandwidth in the given unit to Mbps.
+func BandwidthToMbps(bandwidth int, unit string) int {
+	if strings.EqualFold(unit, BandwidthUnitGbps) {
+		return bandwidth * 1000
+	}
+	return bandwidth
+}
+
+// BandwidthFromMbps converts a bandwidth in Mbps to the given unit. A
+// bandwidth that is not a whole number of Gbps is returned in Mbps, together
+// with the unit it is expressed in.
+func BandwidthFromMbps(mbps int, unit string) (int, string) {
+	if strings.EqualFold(unit, BandwidthUnitGbps) && mbps%1000 == 0 {
+		return mbps / 1000, BandwidthUnitGbps
+	}
+	return mbps, BandwidthUnitMbps
+}
+
+// SuppressEquivalentBandwidth suppresses the diff of a bandwidth attribute or
+// of its unit when the old and new values are the same number of Mbps, e.g.
+// when 50000 MBPS is changed to 50 GBPS.
+func SuppressEquivalentBandwidth(bandwidthAttribute string) schema.SchemaDiffSuppressFunc {
+	return func(k, old, new string, d *schema.ResourceData) bool {
+		if d.Id() == "" {
+			return false
+		}
+		prefix := k[:strings.LastIndex(k, ".")+1]
+		oldBandwidth, newBandwidth := d.GetChange(prefix + bandwidthAttribute)
+		oldUnit, newUnit := d.GetChange(prefix + bandwidthAttribute + "_unit")
+		return BandwidthToMbps(oldBandwidth.(int), oldUnit.(string)) == BandwidthToMbps(newBandwidth.(int), newUnit.(string))
+	}
+}
@@ -0,0 +1,61 @@
+package schema
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBandwidthToMbps(t *testing.T) {
+	assert.Equal(t, 50000, BandwidthToMbps(50, BandwidthUnitGbps))
+	assert.Equal(t, 50000, BandwidthToMbps(50, "gbps"), "Unit is case insensitive")
+	assert.Equal(t, 50, BandwidthToMbps(50, BandwidthUnitMbps))
+	assert.Equal(t, 50, BandwidthToMbps(50, ""), "Bandwidth without unit is in Mbps")
+}
+
+func TestBandwidthFromMbps(t *testing.T) {
+	bandwidth, unit := BandwidthFromMbps(10000, BandwidthUnitGbps)
+	assert.Equal(t, 10, bandwidth)
+	assert.Equal(t, BandwidthUnitGbps, unit)
+
+	bandwidth, unit = BandwidthFromMbps(500, BandwidthUnitGbps)
+	assert.Equal(t, 500, bandwidth, "Bandwidth that is not a whole number of Gbps stays in Mbps")
+	assert.Equal(t, BandwidthUnitMbps, unit)
+}
+
+func TestSuppressEquivalentBandwidth(t *testing.T) {
+	// given
+	r := &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"bandwidth": {
+				Type:             schema.TypeInt,
+				Required:         true,
+				DiffSuppressFunc: SuppressEquivalentBandwidth("bandwidth"),
+			},
+			"bandwidth_unit": BandwidthUnitSch("bandwidth"),
+		},
+	}
+	state := &terraform.InstanceState{
+		ID:         "id",
+		Attributes: map[string]string{"bandwidth": "50000", "bandwidth_unit": BandwidthUnitMbps},
+	}
+	// when
+	equivalent, err := r.Diff(context.Background(), state, terraform.NewResourceConfigRaw(map[string]interface{}{
+		"bandwidth":      50,
+		"bandwidth_unit": "GBPS",
+	}), nil)
+	require.NoError(t, err)
+	changed, err := r.Diff(context.Background(), state, terraform.NewResourceConfigRaw(map[string]interface{}{
+		"bandwidth":      10,
+		"bandwidth_unit": "GBPS",
+	}), nil)
+	require.NoError(t, err)
+	// then
+	assert.True(t, equivalent.Empty(), "50 GBPS is the same bandwidth as 50000 MBPS")
+	require.NotNil(t, changed)
+	assert.Equal(t, "10", changed.Attributes["bandwidth"].New, "10 GBPS is a different bandwidth")
+}
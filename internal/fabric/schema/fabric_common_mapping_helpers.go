@@ -1,6 +1,8 @@
 package schema
 
 import (
+	"strconv"
+
 	v4 "github.com/equinix-labs/fabric-go/fabric/v4"
 	"github.com/equinix/terraform-provider-equinix/internal/converters"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
@@ -26,24 +28,20 @@ func OrderToTerra(order *v4.Order) *schema.Set {
 	if order == nil {
 		return nil
 	}
-	orders := []*v4.Order{order}
-	mappedOrders := make([]interface{}, len(orders))
-	for _, order := range orders {
-		mappedOrder := make(map[string]interface{})
-		mappedOrder["purchase_order_number"] = order.PurchaseOrderNumber
-		mappedOrder["billing_tier"] = order.BillingTier
-		mappedOrder["order_id"] = order.OrderId
-		mappedOrder["order_number"] = order.OrderNumber
-		mappedOrders = append(mappedOrders, mappedOrder)
+	mappedOrder := map[string]interface{}{
+		"purchase_order_number": order.PurchaseOrderNumber,
+		"billing_tier":          order.BillingTier,
+		"order_id":              order.OrderId,
+		"order_number":          order.OrderNumber,
 	}
 	orderSet := schema.NewSet(
 		schema.HashResource(&schema.Resource{Schema: OrderSch()}),
-		mappedOrders,
+		[]interface{}{mappedOrder},
 	)
 	return orderSet
 }
 
-func AccountToTerra[Account *v4.SimplifiedAccount | *v4.AllOfServiceProfileAccount](account Account) *schema.Set {
+func AccountToTerra[Account *v4.SimplifiedAccount | *v4.AllOfServiceProfileAccount | *v4.Account](account Account) *schema.Set {
 	if account == nil {
 		return nil
 	}
@@ -73,6 +71,14 @@ func AccountToTerra[Account *v4.SimplifiedAccount | *v4.AllOfServiceProfileAccou
 			"global_cust_id":           allSPAccount.GlobalCustId,
 			"ucm_id":                   allSPAccount.UcmId,
 		}
+	case *v4.Account:
+		fullAccount := any(account).(*v4.Account)
+		orgId, _ := strconv.Atoi(fullAccount.OrgId)
+		mappedAccount = map[string]interface{}{
+			"account_number": int(fullAccount.AccountNumber),
+			"org_id":         orgId,
+			"global_org_id":  fullAccount.GlobalOrgId,
+		}
 	default:
 		return nil
 	}
@@ -140,19 +146,15 @@ func LocationToTerra(location *v4.SimplifiedLocation) *schema.Set {
 	if location == nil {
 		return nil
 	}
-	locations := []*v4.SimplifiedLocation{location}
-	mappedLocations := make([]interface{}, len(locations))
-	for i, location := range locations {
-		mappedLocations[i] = map[string]interface{}{
-			"region":     location.Region,
-			"metro_name": location.MetroName,
-			"metro_code": location.MetroCode,
-			"ibx":        location.Ibx,
-		}
+	mappedLocation := map[string]interface{}{
+		"region":     location.Region,
+		"metro_name": location.MetroName,
+		"metro_code": location.MetroCode,
+		"ibx":        location.Ibx,
 	}
 	locationSet := schema.NewSet(
 		schema.HashResource(&schema.Resource{Schema: LocationSch()}),
-		mappedLocations,
+		[]interface{}{mappedLocation},
 	)
 	return locationSet
 }
@@ -168,18 +170,17 @@ func LocationWithoutIBXToFabric(locationList []interface{}) v4.SimplifiedLocatio
 }
 
 func LocationWithoutIBXToTerra(location *v4.SimplifiedLocationWithoutIbx) *schema.Set {
-	locations := []*v4.SimplifiedLocationWithoutIbx{location}
-	mappedLocations := make([]interface{}, len(locations))
-	for i, location := range locations {
-		mappedLocations[i] = map[string]interface{}{
-			"region":     location.Region,
-			"metro_name": location.MetroName,
-			"metro_code": location.MetroCode,
-		}
+	if location == nil {
+		return nil
+	}
+	mappedLocation := map[string]interface{}{
+		"region":     location.Region,
+		"metro_name": location.MetroName,
+		"metro_code": location.MetroCode,
 	}
 	locationSet := schema.NewSet(
 		schema.HashResource(&schema.Resource{Schema: LocationSch()}),
-		mappedLocations,
+		[]interface{}{mappedLocation},
 	)
 	return locationSet
 }
@@ -201,16 +202,12 @@ func ProjectToTerra(project *v4.Project) *schema.Set {
 	if project == nil {
 		return nil
 	}
-	projects := []*v4.Project{project}
-	mappedProjects := make([]interface{}, len(projects))
-	for _, project := range projects {
-		mappedProject := make(map[string]interface{})
-		mappedProject["project_id"] = project.ProjectId
-		mappedProjects = append(mappedProjects, mappedProject)
+	mappedProject := map[string]interface{}{
+		"project_id": project.ProjectId,
 	}
 	projectSet := schema.NewSet(
 		schema.HashResource(&schema.Resource{Schema: ProjectSch()}),
-		mappedProjects)
+		[]interface{}{mappedProject})
 	return projectSet
 }
 
@@ -261,12 +258,8 @@ func ChangeLogToTerra[ChangeLog *v4.Changelog | *v4.AllOfServiceProfileChangeLog
 }
 
 func ErrorToTerra(errors []v4.ModelError) []interface{} {
-	if errors == nil {
-		return nil
-	}
-	mappedErrors := make([]interface{}, len(errors))
-	for index, mError := range errors {
-		mappedErrors[index] = map[string]interface{}{
+	return converters.SliceToIfArr(errors, func(mError v4.ModelError) interface{} {
+		return map[string]interface{}{
 			"error_code":      mError.ErrorCode,
 			"error_message":   mError.ErrorMessage,
 			"correlation_id":  mError.CorrelationId,
@@ -274,20 +267,14 @@ func ErrorToTerra(errors []v4.ModelError) []interface{} {
 			"help":            mError.Help,
 			"additional_info": ErrorAdditionalInfoToTerra(mError.AdditionalInfo),
 		}
-	}
-	return mappedErrors
+	})
 }
 
 func ErrorAdditionalInfoToTerra(additionalInfol []v4.PriceErrorAdditionalInfo) []interface{} {
-	if additionalInfol == nil {
-		return nil
-	}
-	mappedAdditionalInfol := make([]interface{}, len(additionalInfol))
-	for index, additionalInfo := range additionalInfol {
-		mappedAdditionalInfol[index] = map[string]interface{}{
+	return converters.SliceToIfArr(additionalInfol, func(additionalInfo v4.PriceErrorAdditionalInfo) interface{} {
+		return map[string]interface{}{
 			"property": additionalInfo.Property,
 			"reason":   additionalInfo.Reason,
 		}
-	}
-	return mappedAdditionalInfol
+	})
 }
@@ -0,0 +1,66 @@
+package schema
+
+import (
+	"testing"
+
+	v4 "github.com/equinix-labs/fabric-go/fabric/v4"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestProjectToTerra(t *testing.T) {
+	// given
+	project := &v4.Project{ProjectId: "12345"}
+	// when
+	projectSet := ProjectToTerra(project)
+	// then
+	assert.Equal(t, 1, projectSet.Len(), "Project set has a single element")
+	assert.Equal(t, "12345", projectSet.List()[0].(map[string]interface{})["project_id"])
+	assert.Nil(t, ProjectToTerra(nil), "Nil project maps to nil set")
+}
+
+func TestAccountToTerra(t *testing.T) {
+	// given
+	simplified := &v4.SimplifiedAccount{AccountNumber: 123, AccountName: "acme", OrgId: 456}
+	full := &v4.Account{AccountNumber: 123, OrgId: "456", GlobalOrgId: "0016u000003JZ4tAAG"}
+	// when
+	simplifiedSet := AccountToTerra(simplified)
+	fullSet := AccountToTerra(full)
+	// then
+	simplifiedAccount := simplifiedSet.List()[0].(map[string]interface{})
+	assert.Equal(t, 123, simplifiedAccount["account_number"])
+	assert.Equal(t, "acme", simplifiedAccount["account_name"])
+	fullAccount := fullSet.List()[0].(map[string]interface{})
+	assert.Equal(t, 123, fullAccount["account_number"])
+	assert.Equal(t, 456, fullAccount["org_id"], "Organization id is converted to a number")
+	assert.Equal(t, "0016u000003JZ4tAAG", fullAccount["global_org_id"])
+	assert.Nil(t, AccountToTerra((*v4.Account)(nil)), "Nil account maps to nil set")
+}
+
+func TestOrderToTerra(t *testing.T) {
+	// given
+	order := &v4.Order{PurchaseOrderNumber: "1-129105284100", BillingTier: "Up to 50 MB"}
+	// when
+	orderSet := OrderToTerra(order)
+	// then
+	assert.Equal(t, 1, orderSet.Len(), "Order set has a single element")
+	assert.NotContains(t, orderSet.List(), nil, "Order set has no empty element")
+	assert.Equal(t, "1-129105284100", orderSet.List()[0].(map[string]interface{})["purchase_order_number"])
+	assert.Nil(t, OrderToTerra(nil), "Nil order maps to nil set")
+}
+
+func TestErrorToTerra(t *testing.T) {
+	// given
+	errors := []v4.ModelError{
+		{ErrorCode: "EQ-3142102", AdditionalInfo: []v4.PriceErrorAdditionalInfo{{Property: "/bandwidth", Reason: "too high"}}},
+		{ErrorCode: "EQ-3142103"},
+	}
+	// when
+	mappedErrors := ErrorToTerra(errors)
+	// then
+	assert.Len(t, mappedErrors, 2, "Errors are not padded")
+	first := mappedErrors[0].(map[string]interface{})
+	assert.Equal(t, "EQ-3142102", first["error_code"])
+	assert.Equal(t, []interface{}{map[string]interface{}{"property": "/bandwidth", "reason": "too high"}}, first["additional_info"])
+	assert.Equal(t, "EQ-3142103", mappedErrors[1].(map[string]interface{})["error_code"])
+	assert.Nil(t, ErrorToTerra(nil), "Nil errors map to nil")
+}
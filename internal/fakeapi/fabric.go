@@ -0,0 +1,340 @@
+package fakeapi
+
+import (
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+)
+
+const (
+	fabricConnections    = "connections"
+	fabricNetworks       = "networks"
+	fabricPorts          = "ports"
+	fabricRouters        = "routers"
+	fabricRouterPackages = "routerPackages"
+	fabricServiceTokens  = "serviceTokens"
+	fabricProfiles       = "serviceProfiles"
+	fabricTimeServices   = "timeServices"
+)
+
+// fabricRouterPackageLimits lists the Fabric Cloud Router packages served by
+// the fake together with their IPv4 and IPv6 route limits.
+var fabricRouterPackageLimits = []struct {
+	code       string
+	routesIPv4 int
+	routesIPv6 int
+}{
+	{"LAB", 50, 50},
+	{"BASIC", 100, 100},
+	{"PRO", 1000, 1000},
+	{"PREMIUM", 10000, 10000},
+}
+
+func (s *Server) serveFabric(w http.ResponseWriter, r *http.Request, segments []string) {
+	collection := len(segments) > 0 &&
+		(segments[0] == fabricConnections || segments[0] == fabricRouters || segments[0] == fabricServiceTokens ||
+			segments[0] == fabricTimeServices || segments[0] == fabricProfiles || segments[0] == fabricNetworks)
+	switch {
+	case len(segments) == 1 && collection:
+		s.serveFabricCollection(w, r, segments[0])
+	case len(segments) == 2 && (segments[0] == fabricProfiles || segments[0] == fabricNetworks) && segments[1] == "search" && r.Method == http.MethodPost:
+		s.serveFabricSearch(w, r, segments[0])
+	case len(segments) == 2 && collection:
+		s.serveFabricObject(w, r, segments[0], segments[1])
+	case len(segments) == 3 && segments[0] == fabricConnections && segments[2] == "actions" && r.Method == http.MethodPost:
+		s.serveFabricConnectionAction(w, r, segments[1])
+	case len(segments) == 3 && segments[0] == fabricPorts && segments[2] == "linkProtocols" && r.Method == http.MethodGet:
+		s.serveFabricPortLinkProtocols(w, segments[1])
+	case len(segments) >= 1 && segments[0] == fabricRouterPackages && r.Method == http.MethodGet:
+		s.serveFabricRouterPackages(w, segments[1:])
+	default:
+		writeFabricError(w, http.StatusNotFound, "EQ-3000000", "Not found")
+	}
+}
+
+func (s *Server) serveFabricCollection(w http.ResponseWriter, r *http.Request, kind string) {
+	if r.Method != http.MethodPost {
+		writeFabricError(w, http.StatusMethodNotAllowed, "EQ-3000001", "Method not allowed")
+		return
+	}
+	obj := map[string]interface{}{}
+	if err := decodeBody(r, &obj); err != nil {
+		writeFabricError(w, http.StatusBadRequest, "EQ-3000002", err.Error())
+		return
+	}
+	id := s.create(kind, obj)
+	obj["uuid"] = id
+	obj["href"] = fmt.Sprintf("%s/%s/%s", fabricBasePath, kind, id)
+	obj["changeLog"] = map[string]interface{}{
+		"createdDateTime": s.timestamp(),
+		"updatedDateTime": s.timestamp(),
+	}
+	switch kind {
+	case fabricConnections:
+		obj["state"] = "ACTIVE"
+		obj["operation"] = map[string]interface{}{
+			"providerStatus": "PROVISIONED",
+			"equinixStatus":  "PROVISIONED",
+		}
+	case fabricRouters:
+		obj["state"] = "PROVISIONED"
+	case fabricServiceTokens:
+		obj["state"] = "INACTIVE"
+	case fabricTimeServices:
+		obj["state"] = "PROVISIONED"
+	case fabricProfiles:
+		obj["state"] = "ACTIVE"
+	case fabricNetworks:
+		obj["state"] = "ACTIVE"
+		obj["operation"] = map[string]interface{}{
+			"equinixStatus": "PROVISIONED",
+		}
+	}
+	writeJSON(w, http.StatusCreated, obj)
+}
+
+func (s *Server) serveFabricObject(w http.ResponseWriter, r *http.Request, kind, id string) {
+	obj, ok := s.get(kind, id)
+	if !ok {
+		writeFabricError(w, http.StatusNotFound, "EQ-3000000", "Not found")
+		return
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+		writeJSON(w, http.StatusOK, obj)
+	case http.MethodPatch:
+		var ops []map[string]interface{}
+		if err := decodeBody(r, &ops); err != nil {
+			writeFabricError(w, http.StatusBadRequest, "EQ-3000002", err.Error())
+			return
+		}
+		for _, op := range ops {
+			path, _ := op["path"].(string)
+			switch op["op"] {
+			case "replace", "add":
+				setPath(obj, path, op["value"])
+			case "remove":
+				setPath(obj, path, nil)
+			}
+		}
+		obj["change"] = map[string]interface{}{
+			"type":   strings.ToUpper(strings.TrimSuffix(kind, "s")) + "_UPDATE",
+			"status": "COMPLETED",
+		}
+		writeJSON(w, http.StatusOK, obj)
+	case http.MethodDelete:
+		// Deleted Fabric objects remain readable in a DEPROVISIONED state,
+		// or DELETED for service tokens.
+		obj["state"] = "DEPROVISIONED"
+		if kind == fabricServiceTokens {
+			obj["state"] = "DELETED"
+		}
+		writeJSON(w, http.StatusOK, obj)
+	default:
+		writeFabricError(w, http.StatusMethodNotAllowed, "EQ-3000001", "Method not allowed")
+	}
+}
+
+// serveFabricSearch lists the objects of a kind matching the filter of a
+// search request, sorted by name and paginated as requested. Filters support
+// the and operator and simple expressions with the = operator.
+func (s *Server) serveFabricSearch(w http.ResponseWriter, r *http.Request, kind string) {
+	search := map[string]interface{}{}
+	if err := decodeBody(r, &search); err != nil {
+		writeFabricError(w, http.StatusBadRequest, "EQ-3000002", err.Error())
+		return
+	}
+	filter, _ := search["filter"].(map[string]interface{})
+	matching := s.list(kind, func(obj map[string]interface{}) bool {
+		return fabricFilterMatches(obj, filter)
+	})
+	sort.Slice(matching, func(i, j int) bool {
+		return fmt.Sprint(matching[i]["name"]) < fmt.Sprint(matching[j]["name"])
+	})
+	offset, limit := 0, len(matching)
+	if pagination, ok := search["pagination"].(map[string]interface{}); ok {
+		if v, ok := pagination["offset"].(float64); ok {
+			offset = int(v)
+		}
+		if v, ok := pagination["limit"].(float64); ok && v > 0 {
+			limit = int(v)
+		}
+	}
+	page := []map[string]interface{}{}
+	for i := offset; i < len(matching) && i < offset+limit; i++ {
+		page = append(page, matching[i])
+	}
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"pagination": map[string]interface{}{"offset": offset, "limit": limit, "total": len(matching)},
+		"data":       page,
+	})
+}
+
+// fabricFilterMatches reports whether an object matches a search filter.
+// Properties going through lists match if any of the list elements match.
+func fabricFilterMatches(obj map[string]interface{}, filter map[string]interface{}) bool {
+	if filter == nil {
+		return true
+	}
+	if and, ok := filter["and"].([]interface{}); ok {
+		for _, f := range and {
+			if expression, _ := f.(map[string]interface{}); !fabricFilterMatches(obj, expression) {
+				return false
+			}
+		}
+		return true
+	}
+	property, _ := filter["property"].(string)
+	values, _ := filter["values"].([]interface{})
+	for _, found := range fabricPropertyValues(obj, pathSegments(property)) {
+		for _, v := range values {
+			if fmt.Sprint(found) == fmt.Sprint(v) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func fabricPropertyValues(value interface{}, keys []string) []interface{} {
+	if list, ok := value.([]interface{}); ok {
+		var values []interface{}
+		for _, elem := range list {
+			values = append(values, fabricPropertyValues(elem, keys)...)
+		}
+		return values
+	}
+	if len(keys) == 0 {
+		return []interface{}{value}
+	}
+	obj, ok := value.(map[string]interface{})
+	if !ok {
+		return nil
+	}
+	return fabricPropertyValues(obj[keys[0]], keys[1:])
+}
+
+// fabricConnectionActionStatuses maps the connection actions handled by the
+// fake to the Equinix and provider statuses of the connection they result in.
+var fabricConnectionActionStatuses = map[string]string{
+	"CONNECTION_CREATION_ACCEPTANCE": "PROVISIONED",
+	"CONNECTION_CREATION_REJECTION":  "REJECTED",
+}
+
+func (s *Server) serveFabricConnectionAction(w http.ResponseWriter, r *http.Request, id string) {
+	obj, ok := s.get(fabricConnections, id)
+	if !ok {
+		writeFabricError(w, http.StatusNotFound, "EQ-3000000", "Not found")
+		return
+	}
+	action := map[string]interface{}{}
+	if err := decodeBody(r, &action); err != nil {
+		writeFabricError(w, http.StatusBadRequest, "EQ-3000002", err.Error())
+		return
+	}
+	actionType, _ := action["type"].(string)
+	status, ok := fabricConnectionActionStatuses[actionType]
+	if !ok {
+		writeFabricError(w, http.StatusBadRequest, "EQ-3000002", fmt.Sprintf("Unsupported action type %q", actionType))
+		return
+	}
+	obj["operation"] = map[string]interface{}{
+		"providerStatus": status,
+		"equinixStatus":  status,
+	}
+	if data, ok := action["data"].(map[string]interface{}); ok {
+		if zSide, ok := data["zSide"].(map[string]interface{}); ok {
+			obj["zSide"] = zSide
+		}
+	}
+	action["uuid"] = id
+	action["href"] = fmt.Sprintf("%s/%s/%s/actions", fabricBasePath, fabricConnections, id)
+	writeJSON(w, http.StatusCreated, action)
+}
+
+// serveFabricPortLinkProtocols lists the link protocols of the connections
+// not deprovisioned that have an access point on the given port.
+func (s *Server) serveFabricPortLinkProtocols(w http.ResponseWriter, portID string) {
+	linkProtocols := []interface{}{}
+	for _, conn := range s.list(fabricConnections, func(obj map[string]interface{}) bool {
+		return obj["state"] != "DEPROVISIONED"
+	}) {
+		for _, side := range []string{"aSide", "zSide"} {
+			sideObj, _ := conn[side].(map[string]interface{})
+			accessPoint, _ := sideObj["accessPoint"].(map[string]interface{})
+			port, _ := accessPoint["port"].(map[string]interface{})
+			if port == nil || port["uuid"] != portID {
+				continue
+			}
+			if lp, ok := accessPoint["linkProtocol"].(map[string]interface{}); ok {
+				linkProtocols = append(linkProtocols, map[string]interface{}{
+					"type":       lp["type"],
+					"state":      "RESERVED",
+					"vlanTag":    lp["vlanTag"],
+					"vlanSTag":   lp["vlanSTag"],
+					"vlanCTag":   lp["vlanCTag"],
+					"connection": map[string]interface{}{"uuid": conn["uuid"]},
+				})
+			}
+		}
+	}
+	writeJSON(w, http.StatusOK, map[string]interface{}{"data": linkProtocols})
+}
+
+func (s *Server) serveFabricRouterPackages(w http.ResponseWriter, codes []string) {
+	packages := []map[string]interface{}{}
+	for _, p := range fabricRouterPackageLimits {
+		packages = append(packages, map[string]interface{}{
+			"href":               fmt.Sprintf("%s/%s/%s", fabricBasePath, fabricRouterPackages, p.code),
+			"type":               "ROUTER_PACKAGE",
+			"code":               p.code,
+			"totalIPv4RoutesMax": p.routesIPv4,
+			"totalIPv6RoutesMax": p.routesIPv6,
+		})
+	}
+	if len(codes) == 0 {
+		writeJSON(w, http.StatusOK, map[string]interface{}{"data": packages})
+		return
+	}
+	for _, p := range packages {
+		if p["code"] == codes[0] {
+			writeJSON(w, http.StatusOK, p)
+			return
+		}
+	}
+	writeFabricError(w, http.StatusNotFound, "EQ-3000000", "Not found")
+}
+
+// setPath sets the value at the given JSON pointer like path, creating
+// intermediate objects as needed. A nil value removes the field.
+func setPath(obj map[string]interface{}, path string, value interface{}) {
+	keys := pathSegments(path)
+	if len(keys) == 0 {
+		return
+	}
+	for _, key := range keys[:len(keys)-1] {
+		next, ok := obj[key].(map[string]interface{})
+		if !ok {
+			next = map[string]interface{}{}
+			obj[key] = next
+		}
+		obj = next
+	}
+	last := keys[len(keys)-1]
+	if value == nil {
+		delete(obj, last)
+		return
+	}
+	obj[last] = value
+}
+
+func writeFabricError(w http.ResponseWriter, status int, code, message string) {
+	writeJSON(w, status, []map[string]interface{}{
+		{
+			"errorCode":    code,
+			"errorMessage": message,
+		},
+	})
+}
@@ -0,0 +1,178 @@
+package fakeapi
+
+import (
+	"fmt"
+	"net/http"
+)
+
+const (
+	metalProjects = "projects"
+	metalDevices  = "devices"
+	metalSSHKeys  = "ssh-keys"
+	metalActions  = "actions"
+)
+
+// metalDeviceActionStates maps the supported device actions to the state the
+// device is left in once the action completes.
+var metalDeviceActionStates = map[string]string{
+	"power_on":  "active",
+	"power_off": "inactive",
+	"reboot":    "active",
+	"reinstall": "active",
+}
+
+func (s *Server) serveMetal(w http.ResponseWriter, r *http.Request, segments []string) {
+	switch {
+	case len(segments) == 1 && segments[0] == metalProjects:
+		s.serveMetalCollection(w, r, metalProjects, nil)
+	case len(segments) == 1 && segments[0] == metalSSHKeys:
+		s.serveMetalCollection(w, r, metalSSHKeys, nil)
+	case len(segments) == 2 && (segments[0] == metalProjects || segments[0] == metalDevices || segments[0] == metalSSHKeys):
+		s.serveMetalObject(w, r, segments[0], segments[1])
+	case len(segments) == 3 && segments[0] == metalDevices && segments[2] == metalActions && r.Method == http.MethodPost:
+		s.serveMetalDeviceAction(w, r, segments[1])
+	case len(segments) == 3 && segments[0] == metalProjects && (segments[2] == metalDevices || segments[2] == metalSSHKeys):
+		projectID := segments[1]
+		if _, ok := s.get(metalProjects, projectID); !ok {
+			writeMetalError(w, http.StatusNotFound, "Not found")
+			return
+		}
+		s.serveMetalCollection(w, r, segments[2], map[string]interface{}{
+			"id":   projectID,
+			"href": fmt.Sprintf("%s/%s/%s", metalBasePath, metalProjects, projectID),
+		})
+	default:
+		writeMetalError(w, http.StatusNotFound, "Not found")
+	}
+}
+
+func (s *Server) serveMetalCollection(w http.ResponseWriter, r *http.Request, kind string, project map[string]interface{}) {
+	switch r.Method {
+	case http.MethodGet:
+		items := s.list(kind, func(obj map[string]interface{}) bool {
+			if project == nil {
+				return true
+			}
+			p, ok := obj["project"].(map[string]interface{})
+			return ok && p["id"] == project["id"]
+		})
+		writeJSON(w, http.StatusOK, map[string]interface{}{
+			metalListKey(kind): items,
+			"meta": map[string]interface{}{
+				"total":        len(items),
+				"current_page": 1,
+				"last_page":    1,
+			},
+		})
+	case http.MethodPost:
+		obj := map[string]interface{}{}
+		if err := decodeBody(r, &obj); err != nil {
+			writeMetalError(w, http.StatusUnprocessableEntity, err.Error())
+			return
+		}
+		id := s.create(kind, obj)
+		obj["id"] = id
+		obj["href"] = fmt.Sprintf("%s/%s/%s", metalBasePath, kind, id)
+		obj["created_at"] = s.timestamp()
+		obj["updated_at"] = s.timestamp()
+		if project != nil {
+			obj["project"] = project
+		}
+		if kind == metalDevices {
+			normalizeMetalDevice(obj)
+		}
+		writeJSON(w, http.StatusCreated, obj)
+	default:
+		writeMetalError(w, http.StatusMethodNotAllowed, "Method not allowed")
+	}
+}
+
+func (s *Server) serveMetalObject(w http.ResponseWriter, r *http.Request, kind, id string) {
+	obj, ok := s.get(kind, id)
+	if !ok {
+		writeMetalError(w, http.StatusNotFound, "Not found")
+		return
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+		writeJSON(w, http.StatusOK, obj)
+	case http.MethodPut:
+		update := map[string]interface{}{}
+		if err := decodeBody(r, &update); err != nil {
+			writeMetalError(w, http.StatusUnprocessableEntity, err.Error())
+			return
+		}
+		merge(obj, update)
+		obj["updated_at"] = s.timestamp()
+		writeJSON(w, http.StatusOK, obj)
+	case http.MethodDelete:
+		s.delete(kind, id)
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		writeMetalError(w, http.StatusMethodNotAllowed, "Method not allowed")
+	}
+}
+
+func (s *Server) serveMetalDeviceAction(w http.ResponseWriter, r *http.Request, id string) {
+	device, ok := s.get(metalDevices, id)
+	if !ok {
+		writeMetalError(w, http.StatusNotFound, "Not found")
+		return
+	}
+	action := map[string]interface{}{}
+	if err := decodeBody(r, &action); err != nil {
+		writeMetalError(w, http.StatusUnprocessableEntity, err.Error())
+		return
+	}
+	actionType, _ := action["type"].(string)
+	state, ok := metalDeviceActionStates[actionType]
+	if !ok {
+		writeMetalError(w, http.StatusUnprocessableEntity, fmt.Sprintf("Unsupported action %q", actionType))
+		return
+	}
+	if os, ok := action["operating_system"].(string); ok && actionType == "reinstall" {
+		device["operating_system"] = map[string]interface{}{"slug": os}
+	}
+	device["state"] = state
+	device["updated_at"] = s.timestamp()
+	w.WriteHeader(http.StatusAccepted)
+}
+
+// normalizeMetalDevice converts the fields of a device creation request into
+// the shape returned by the API for a provisioned device.
+func normalizeMetalDevice(device map[string]interface{}) {
+	if plan, ok := device["plan"].(string); ok {
+		device["plan"] = map[string]interface{}{"slug": plan}
+	}
+	if os, ok := device["operating_system"].(string); ok {
+		device["operating_system"] = map[string]interface{}{"slug": os}
+	}
+	if metro, ok := device["metro"].(string); ok {
+		device["metro"] = map[string]interface{}{"code": metro}
+		device["facility"] = map[string]interface{}{"code": metro + "1"}
+	}
+	if facilities, ok := device["facility"].([]interface{}); ok && len(facilities) > 0 {
+		device["facility"] = map[string]interface{}{"code": facilities[0]}
+	}
+	if _, ok := device["billing_cycle"]; !ok {
+		device["billing_cycle"] = "hourly"
+	}
+	device["state"] = "active"
+	device["ip_addresses"] = []interface{}{}
+	device["network_ports"] = []interface{}{}
+	device["ssh_keys"] = []interface{}{}
+}
+
+func metalListKey(kind string) string {
+	if kind == metalSSHKeys {
+		return "ssh_keys"
+	}
+	return kind
+}
+
+func writeMetalError(w http.ResponseWriter, status int, message string) {
+	writeJSON(w, status, map[string]interface{}{
+		"errors": []string{message},
+	})
+}
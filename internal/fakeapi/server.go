@@ -0,0 +1,139 @@
+// Package fakeapi provides an in-memory fake of the most commonly used Equinix
+// Metal and Fabric API endpoints. It is meant for contract tests of the
+// provider and for offline plan validation of modules in CI pipelines, where
+// the provider `endpoint` argument can be pointed at the fake server.
+//
+// The fake keeps all objects in memory, completes every operation
+// synchronously and does not validate credentials.
+package fakeapi
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	metalBasePath  = "/metal/v1"
+	fabricBasePath = "/fabric/v4"
+)
+
+// Server is a fake Equinix API server backed by an in-memory store.
+type Server struct {
+	mu      sync.Mutex
+	objects map[string]map[string]map[string]interface{}
+	now     func() time.Time
+}
+
+// NewServer returns an empty fake API server.
+func NewServer() *Server {
+	return &Server{
+		objects: map[string]map[string]map[string]interface{}{},
+		now:     time.Now,
+	}
+}
+
+// NewTestServer starts the fake API server on a local loopback address. The
+// caller should call Close on the returned server when finished.
+func NewTestServer() (*Server, *httptest.Server) {
+	s := NewServer()
+	return s, httptest.NewServer(s)
+}
+
+// ServeHTTP routes the request to the Metal or Fabric fake handlers.
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	switch {
+	case strings.HasPrefix(r.URL.Path, metalBasePath+"/"):
+		s.serveMetal(w, r, pathSegments(strings.TrimPrefix(r.URL.Path, metalBasePath)))
+	case strings.HasPrefix(r.URL.Path, fabricBasePath+"/"):
+		s.serveFabric(w, r, pathSegments(strings.TrimPrefix(r.URL.Path, fabricBasePath)))
+	default:
+		http.NotFound(w, r)
+	}
+}
+
+// Objects returns a copy of all stored objects of the given kind, keyed by
+// identifier. Kinds are named after the API collections, e.g. "devices" or
+// "connections".
+func (s *Server) Objects(kind string) map[string]map[string]interface{} {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	result := map[string]map[string]interface{}{}
+	for id, obj := range s.objects[kind] {
+		result[id] = obj
+	}
+	return result
+}
+
+func (s *Server) create(kind string, obj map[string]interface{}) string {
+	id := uuid.NewString()
+	if s.objects[kind] == nil {
+		s.objects[kind] = map[string]map[string]interface{}{}
+	}
+	s.objects[kind][id] = obj
+	return id
+}
+
+func (s *Server) get(kind, id string) (map[string]interface{}, bool) {
+	obj, ok := s.objects[kind][id]
+	return obj, ok
+}
+
+func (s *Server) list(kind string, filter func(map[string]interface{}) bool) []map[string]interface{} {
+	result := []map[string]interface{}{}
+	for _, obj := range s.objects[kind] {
+		if filter == nil || filter(obj) {
+			result = append(result, obj)
+		}
+	}
+	return result
+}
+
+func (s *Server) delete(kind, id string) {
+	delete(s.objects[kind], id)
+}
+
+func (s *Server) timestamp() string {
+	return s.now().UTC().Format(time.RFC3339)
+}
+
+func pathSegments(p string) []string {
+	return strings.FieldsFunc(p, func(r rune) bool { return r == '/' })
+}
+
+func decodeBody(r *http.Request, v interface{}) error {
+	if r.Body == nil {
+		return fmt.Errorf("request body is empty")
+	}
+	defer r.Body.Close()
+	return json.NewDecoder(r.Body).Decode(v)
+}
+
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+// merge copies the values of src into dst, merging nested objects.
+func merge(dst, src map[string]interface{}) {
+	for k, v := range src {
+		srcMap, srcOk := v.(map[string]interface{})
+		dstMap, dstOk := dst[k].(map[string]interface{})
+		if srcOk && dstOk {
+			merge(dstMap, srcMap)
+			continue
+		}
+		dst[k] = v
+	}
+}
@@ -0,0 +1,98 @@
+package fakeapi
+
+import (
+	"context"
+	"testing"
+
+	v4 "github.com/equinix-labs/fabric-go/fabric/v4"
+	"github.com/equinix/equinix-sdk-go/services/metalv1"
+	"github.com/equinix/terraform-provider-equinix/internal/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestConfig(t *testing.T) *config.Config {
+	_, srv := NewTestServer()
+	t.Cleanup(srv.Close)
+
+	c := &config.Config{
+		BaseURL:   srv.URL,
+		AuthToken: "metal-token",
+		Token:     "fabric-token",
+	}
+	require.NoError(t, c.Load(context.Background()))
+	return c
+}
+
+func TestServer_metalDevice(t *testing.T) {
+	// given
+	client := newTestConfig(t).Metalgo
+	ctx := context.Background()
+	project, _, err := client.ProjectsApi.CreateProject(ctx).
+		ProjectCreateFromRootInput(*metalv1.NewProjectCreateFromRootInput("test")).Execute()
+	require.NoError(t, err)
+	input := metalv1.NewDeviceCreateInMetroInput("sv", "ubuntu_22_04", "c3.small.x86")
+	input.SetHostname("test-device")
+	// when
+	created, _, err := client.DevicesApi.CreateDevice(ctx, project.GetId()).
+		CreateDeviceRequest(metalv1.DeviceCreateInMetroInputAsCreateDeviceRequest(input)).Execute()
+	require.NoError(t, err)
+	device, _, err := client.DevicesApi.FindDeviceById(ctx, created.GetId()).Execute()
+	require.NoError(t, err)
+	_, err = client.DevicesApi.DeleteDevice(ctx, created.GetId()).Execute()
+	require.NoError(t, err)
+	_, resp, err := client.DevicesApi.FindDeviceById(ctx, created.GetId()).Execute()
+	// then
+	assert.Equal(t, "test-device", device.GetHostname())
+	assert.Equal(t, "c3.small.x86", device.Plan.GetSlug())
+	assert.Equal(t, "ubuntu_22_04", device.OperatingSystem.GetSlug())
+	assert.Equal(t, "sv", device.Metro.GetCode())
+	assert.Equal(t, metalv1.DEVICESTATE_ACTIVE, device.GetState())
+	assert.Equal(t, project.GetId(), device.Project.GetId())
+	assert.Error(t, err, "Deleted device is not found")
+	assert.Equal(t, 404, resp.StatusCode)
+}
+
+func TestServer_fabricConnection(t *testing.T) {
+	// given
+	c := newTestConfig(t)
+	ctx := context.WithValue(context.Background(), v4.ContextAccessToken, c.FabricAuthToken)
+	connType := v4.EVPL_VC_ConnectionType
+	request := v4.ConnectionPostRequest{
+		Type_:     &connType,
+		Name:      "test-connection",
+		Bandwidth: 50,
+	}
+	// when
+	created, _, err := c.FabricClient.ConnectionsApi.CreateConnection(ctx, request)
+	require.NoError(t, err)
+	_, _, err = c.FabricClient.ConnectionsApi.UpdateConnectionByUuid(ctx, []v4.ConnectionChangeOperation{
+		{Op: "replace", Path: "/name", Value: "renamed-connection"},
+	}, created.Uuid)
+	require.NoError(t, err)
+	conn, _, err := c.FabricClient.ConnectionsApi.GetConnectionByUuid(ctx, created.Uuid, nil)
+	require.NoError(t, err)
+	deleted, _, err := c.FabricClient.ConnectionsApi.DeleteConnectionByUuid(ctx, created.Uuid)
+	require.NoError(t, err)
+	// then
+	assert.Equal(t, "renamed-connection", conn.Name)
+	assert.Equal(t, int32(50), conn.Bandwidth)
+	assert.Equal(t, v4.ACTIVE_ConnectionState, *conn.State)
+	assert.Equal(t, v4.PROVISIONED_ProviderStatus, *conn.Operation.ProviderStatus)
+	assert.Equal(t, v4.DEPROVISIONED_ConnectionState, *deleted.State)
+}
+
+func TestServer_fabricRouterPackages(t *testing.T) {
+	// given
+	c := newTestConfig(t)
+	ctx := context.WithValue(context.Background(), v4.ContextAccessToken, c.FabricAuthToken)
+	// when
+	pkg, _, err := c.FabricClient.CloudRoutersApi.GetCloudRouterPackageByCode(ctx, v4.PRO_RouterPackageCode)
+	require.NoError(t, err)
+	packages, _, err := c.FabricClient.CloudRoutersApi.GetCloudRouterPackages(ctx, nil)
+	require.NoError(t, err)
+	// then
+	assert.Equal(t, v4.PRO_Code, *pkg.Code)
+	assert.Equal(t, int32(1000), pkg.TotalIPv4RoutesMax)
+	assert.Len(t, packages.Data, len(fabricRouterPackageLimits))
+}
@@ -66,6 +66,9 @@ func (r *BaseDataSource) Configure(
 	if resp.Diagnostics.HasError() {
 		return
 	}
+	if err := r.Meta.DisabledServiceError(r.Config.Name); err != nil {
+		resp.Diagnostics.AddError("Disabled Service", err.Error())
+	}
 }
 
 func (r *BaseDataSource) Metadata(
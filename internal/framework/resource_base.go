@@ -71,6 +71,9 @@ func (r *BaseResource) Configure(
 	if resp.Diagnostics.HasError() {
 		return
 	}
+	if err := r.Meta.DisabledServiceError(r.Config.Name); err != nil {
+		resp.Diagnostics.AddError("Disabled Service", err.Error())
+	}
 }
 
 func (r *BaseResource) Metadata(
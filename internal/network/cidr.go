@@ -0,0 +1,95 @@
+package network
+
+import (
+	"fmt"
+	"net/netip"
+	"sort"
+)
+
+// SplitCIDR finds free subnets of a block, one for each of the requested
+// prefix lengths, that don't overlap the used CIDRs nor each other. Larger
+// subnets are placed first to limit fragmentation, but the subnets are
+// returned in the order of the requested sizes.
+func SplitCIDR(block string, used []string, sizes []int) ([]string, error) {
+	blockPrefix, err := netip.ParsePrefix(block)
+	if err != nil {
+		return nil, fmt.Errorf("invalid block CIDR %q: %w", block, err)
+	}
+	blockPrefix = blockPrefix.Masked()
+
+	taken := make([]netip.Prefix, 0, len(used)+len(sizes))
+	for _, u := range used {
+		p, err := netip.ParsePrefix(u)
+		if err != nil {
+			return nil, fmt.Errorf("invalid used CIDR %q: %w", u, err)
+		}
+		if p.Overlaps(blockPrefix) {
+			taken = append(taken, p.Masked())
+		}
+	}
+
+	order := make([]int, len(sizes))
+	for i, size := range sizes {
+		if size < blockPrefix.Bits() || size > blockPrefix.Addr().BitLen() {
+			return nil, fmt.Errorf("subnet size /%d does not fit in block %s", size, blockPrefix)
+		}
+		order[i] = i
+	}
+	sort.SliceStable(order, func(i, j int) bool { return sizes[order[i]] < sizes[order[j]] })
+
+	subnets := make([]string, len(sizes))
+	for _, i := range order {
+		subnet, ok := freeSubnet(blockPrefix, taken, sizes[i])
+		if !ok {
+			return nil, fmt.Errorf("no free /%d subnet left in block %s", sizes[i], blockPrefix)
+		}
+		taken = append(taken, subnet)
+		subnets[i] = subnet.String()
+	}
+	return subnets, nil
+}
+
+// freeSubnet returns the lowest subnet of the given size in block that doesn't
+// overlap any of the taken prefixes.
+func freeSubnet(block netip.Prefix, taken []netip.Prefix, size int) (netip.Prefix, bool) {
+	candidate := netip.PrefixFrom(block.Addr(), size)
+	for block.Contains(candidate.Addr()) {
+		overlapped := false
+		next := lastAddr(candidate).Next()
+		for _, t := range taken {
+			if !t.Overlaps(candidate) {
+				continue
+			}
+			overlapped = true
+			if t.Bits() < size {
+				// the taken prefix spans the candidate, skip past it
+				next = lastAddr(t).Next()
+			}
+			break
+		}
+		if !overlapped {
+			return candidate, true
+		}
+		if !next.IsValid() {
+			return netip.Prefix{}, false
+		}
+		candidate = netip.PrefixFrom(next, size).Masked()
+		if candidate.Addr() != next {
+			if next = lastAddr(candidate).Next(); !next.IsValid() {
+				return netip.Prefix{}, false
+			}
+			candidate = netip.PrefixFrom(next, size)
+		}
+	}
+	return netip.Prefix{}, false
+}
+
+// lastAddr returns the last address of a prefix.
+func lastAddr(p netip.Prefix) netip.Addr {
+	a := p.Masked().Addr().AsSlice()
+	for bit := p.Bits(); bit < len(a)*8; bit++ {
+		a[bit/8] |= 0x80 >> (bit % 8)
+	}
+	last, _ := netip.AddrFromSlice(a)
+	return last
+}
@@ -0,0 +1,40 @@
+package network
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSplitCIDR(t *testing.T) {
+	// given
+	used := []string{"10.0.0.0/30", "10.0.0.8/29", "192.168.0.0/24"}
+	sizes := []int{30, 28, 29}
+	// when
+	subnets, err := SplitCIDR("10.0.0.0/26", used, sizes)
+	// then
+	require.NoError(t, err)
+	assert.Equal(t, []string{"10.0.0.4/30", "10.0.0.16/28", "10.0.0.32/29"}, subnets, "Free subnets are returned in the requested order")
+}
+
+func TestSplitCIDR_ipv6(t *testing.T) {
+	// given
+	used := []string{"2604:1380:4641:c500::/57"}
+	// when
+	subnets, err := SplitCIDR("2604:1380:4641:c500::/56", used, []int{64, 127})
+	// then
+	require.NoError(t, err)
+	assert.Equal(t, []string{"2604:1380:4641:c580::/64", "2604:1380:4641:c581::/127"}, subnets, "Free IPv6 subnets are returned")
+}
+
+func TestSplitCIDR_full(t *testing.T) {
+	_, err := SplitCIDR("10.0.0.0/29", []string{"10.0.0.0/30"}, []int{30, 30})
+	assert.Error(t, err, "Block without enough free space can not be split")
+
+	_, err = SplitCIDR("10.0.0.0/29", nil, []int{28})
+	assert.Error(t, err, "Subnets can not be larger than the block")
+
+	_, err = SplitCIDR("10.0.0.0/29", []string{"bogus"}, []int{30})
+	assert.Error(t, err, "Used CIDRs must be valid")
+}
@@ -17,6 +17,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/provider/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
 )
 
 var urlRE = regexp.MustCompile(`^https?://(?:www\.)?[a-zA-Z0-9./]+$`)
@@ -92,6 +93,39 @@ func (p *FrameworkProvider) Schema(
 				Optional:    true,
 				Description: "Maximum number of seconds to wait before retrying a request.",
 			},
+			"additional_headers": schema.MapAttribute{
+				Optional:    true,
+				ElementType: types.StringType,
+				Description: "Map of additional HTTP headers to include in every Equinix Fabric and Network Edge API request. Authentication headers can not be overridden.",
+			},
+			"fabric_deprovisioned_as_error": schema.BoolAttribute{
+				Optional:    true,
+				Description: "Report an error instead of removing an Equinix Fabric connection from state when it is found DEPROVISIONED outside of Terraform. Defaults to false",
+			},
+			"fabric_correlation_prefix": schema.StringAttribute{
+				Optional:    true,
+				Description: "Prefix prepended to the random correlation ID sent with every Equinix Fabric API request, e.g. a CI job ID, to map Equinix support logs back to pipeline runs",
+				Validators: []validator.String{
+					stringvalidator.LengthAtMost(config.CorrelationPrefixMaxLength),
+					stringvalidator.RegexMatches(config.CorrelationPrefixRe, "must only contain letters, digits and the characters #$&@._-"),
+				},
+			},
+			"dry_run": schema.BoolAttribute{
+				Optional:    true,
+				Description: "Validate Equinix Fabric orders, i.e. the creation of connections and cloud routers, against the Fabric price API instead of placing them. The apply of a new order reports the validation result and fails without creating anything. Defaults to false",
+			},
+			"enable_metal": schema.BoolAttribute{
+				Optional:    true,
+				Description: "Construct the Equinix Metal clients and require their credentials. Metal resources and data sources can't be used when false. Defaults to true",
+			},
+			"enable_fabric": schema.BoolAttribute{
+				Optional:    true,
+				Description: "Construct the Equinix Fabric clients and require their credentials. Fabric and ECX resources and data sources can't be used when false. Defaults to true",
+			},
+			"enable_network_edge": schema.BoolAttribute{
+				Optional:    true,
+				Description: "Construct the Network Edge client and require its credentials. Network Edge resources and data sources can't be used when false. Defaults to true",
+			},
 		},
 	}
 }
@@ -24,9 +24,21 @@ type FrameworkProviderConfig struct {
 	PageSize            types.Int64  `tfsdk:"response_max_page_size"`
 	MaxRetries          types.Int64  `tfsdk:"max_retries"`
 	MaxRetryWaitSeconds types.Int64  `tfsdk:"max_retry_wait_seconds"`
+	AdditionalHeaders   types.Map    `tfsdk:"additional_headers"`
+	DeprovisionedError  types.Bool   `tfsdk:"fabric_deprovisioned_as_error"`
+	CorrelationPrefix   types.String `tfsdk:"fabric_correlation_prefix"`
+	DryRun              types.Bool   `tfsdk:"dry_run"`
+	EnableMetal         types.Bool   `tfsdk:"enable_metal"`
+	EnableFabric        types.Bool   `tfsdk:"enable_fabric"`
+	EnableNetworkEdge   types.Bool   `tfsdk:"enable_network_edge"`
 }
 
-func (c *FrameworkProviderConfig) toOldStyleConfig() *config.Config {
+func (c *FrameworkProviderConfig) toOldStyleConfig(ctx context.Context, diags *diag.Diagnostics) *config.Config {
+	headers := make(map[string]string)
+	if !c.AdditionalHeaders.IsNull() {
+		diags.Append(c.AdditionalHeaders.ElementsAs(ctx, &headers, false)...)
+	}
+
 	// this immitates func configureProvider in proivder.go
 	return &config.Config{
 		AuthToken:      c.AuthToken.ValueString(),
@@ -38,6 +50,14 @@ func (c *FrameworkProviderConfig) toOldStyleConfig() *config.Config {
 		PageSize:       int(c.PageSize.ValueInt64()),
 		MaxRetries:     int(c.MaxRetries.ValueInt64()),
 		MaxRetryWait:   time.Duration(c.MaxRetryWaitSeconds.ValueInt64()) * time.Second,
+
+		AdditionalHeaders:          headers,
+		FabricDeprovisionedAsError: c.DeprovisionedError.ValueBool(),
+		FabricCorrelationPrefix:    c.CorrelationPrefix.ValueString(),
+		FabricDryRun:               c.DryRun.ValueBool(),
+		DisableMetal:               !c.EnableMetal.IsNull() && !c.EnableMetal.ValueBool(),
+		DisableFabric:              !c.EnableFabric.IsNull() && !c.EnableFabric.ValueBool(),
+		DisableNetworkEdge:         !c.EnableNetworkEdge.IsNull() && !c.EnableNetworkEdge.ValueBool(),
 	}
 }
 
@@ -88,7 +108,10 @@ func (fp *FrameworkProvider) Configure(
 		return
 	}
 
-	oldStyleConfig := fwconfig.toOldStyleConfig()
+	oldStyleConfig := fwconfig.toOldStyleConfig(ctx, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
 	err := oldStyleConfig.Load(ctx)
 	if err != nil {
 		resp.Diagnostics.AddError(
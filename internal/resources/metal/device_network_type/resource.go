@@ -1,4 +1,4 @@
-package equinix
+package device_network_type
 
 import (
 	"log"
@@ -13,7 +13,15 @@ import (
 	"github.com/packethost/packngo"
 )
 
-func resourceMetalDeviceNetworkType() *schema.Resource {
+// Resources returns the resources of the package by type name, registered by
+// the provider.
+func Resources() map[string]*schema.Resource {
+	return map[string]*schema.Resource{
+		"equinix_metal_device_network_type": Resource(),
+	}
+}
+
+func Resource() *schema.Resource {
 	return &schema.Resource{
 		Create: resourceMetalDeviceNetworkTypeCreate,
 		Read:   resourceMetalDeviceNetworkTypeRead,
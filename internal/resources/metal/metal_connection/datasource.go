@@ -71,7 +71,7 @@ func connectionPortSchema() *schema.Resource {
 			"status": {
 				Type:        schema.TypeString,
 				Computed:    true,
-				Description: "Port status",
+				Description: "Port status - requested, active, deleting, expired or delete_failed",
 			},
 			"link_status": {
 				Type:        schema.TypeString,
@@ -88,6 +88,14 @@ func connectionPortSchema() *schema.Resource {
 	}
 }
 
+// DataSources returns the data sources of the package by type name, registered by
+// the provider.
+func DataSources() map[string]*schema.Resource {
+	return map[string]*schema.Resource{
+		"equinix_metal_connection": DataSource(),
+	}
+}
+
 func DataSource() *schema.Resource {
 	speeds := []string{}
 	for _, allowedSpeed := range allowedSpeeds {
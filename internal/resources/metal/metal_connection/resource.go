@@ -1,31 +1,48 @@
 package metal_connection
 
 import (
+	"context"
 	"fmt"
 	"math"
 	"reflect"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/equinix/terraform-provider-equinix/internal/converters"
+	"github.com/equinix/terraform-provider-equinix/internal/tags"
 
 	equinix_errors "github.com/equinix/terraform-provider-equinix/internal/errors"
 	equinix_schema "github.com/equinix/terraform-provider-equinix/internal/schema"
 
 	"github.com/equinix/terraform-provider-equinix/internal/config"
 
+	"github.com/equinix/equinix-sdk-go/services/metalv1"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/retry"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 	"github.com/packethost/packngo"
 	"golang.org/x/exp/slices"
 )
 
+// Resources returns the resources of the package by type name, registered by
+// the provider.
+func Resources() map[string]*schema.Resource {
+	return map[string]*schema.Resource{
+		"equinix_metal_connection": Resource(),
+	}
+}
+
 func Resource() *schema.Resource {
 	speeds := []string{}
 	for _, allowedSpeed := range allowedSpeeds {
 		speeds = append(speeds, allowedSpeed.Str)
 	}
 	return &schema.Resource{
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(30 * time.Minute),
+			Update: schema.DefaultTimeout(30 * time.Minute),
+		},
 		Read:   resourceMetalConnectionRead,
 		Create: resourceMetalConnectionCreate,
 		Delete: resourceMetalConnectionDelete,
@@ -73,7 +90,6 @@ func Resource() *schema.Resource {
 				Description: "The preferred email used for communication and notifications about the Equinix Fabric interconnection. Required when using a Project API key. Optional and defaults to the primary user email address when using a User API key",
 				Optional:    true,
 				Computed:    true,
-				ForceNew:    true, // TODO(displague) packngo needs updating
 			},
 			"type": {
 				Type:        schema.TypeString,
@@ -112,12 +128,7 @@ func Resource() *schema.Resource {
 					string(packngo.ConnectionModeTunnel),
 				}, false),
 			},
-			"tags": {
-				Type:        schema.TypeList,
-				Description: "Tags attached to the connection",
-				Optional:    true,
-				Elem:        &schema.Schema{Type: schema.TypeString},
-			},
+			"tags": tags.Schema("Tags attached to the connection"),
 			"vlans": {
 				Type:        schema.TypeList,
 				Description: "Only used with shared connection. VLANs to attach. Pass one vlan for Primary/Single connection and two vlans for Redundant connection",
@@ -161,6 +172,12 @@ func Resource() *schema.Resource {
 				Computed:    true,
 				Elem:        serviceTokenSchema(),
 			},
+			"wait_for_active": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Wait, on create and on redundancy changes, for the connection and all its ports to be active",
+			},
 		},
 	}
 }
@@ -216,10 +233,8 @@ func resourceMetalConnectionCreate(d *schema.ResourceData, meta interface{}) err
 		connReq.Speed = speed
 	}
 
-	// this could be generalized, see $ grep "d.Get(\"tags" *
-	tags := d.Get("tags.#").(int)
-	if tags > 0 {
-		connReq.Tags = converters.IfArrToStringArr(d.Get("tags").([]interface{}))
+	if tagsCount := d.Get("tags.#").(int); tagsCount > 0 {
+		connReq.Tags = tags.Expand(d.Get("tags"))
 	}
 
 	if metOk {
@@ -279,6 +294,11 @@ func resourceMetalConnectionCreate(d *schema.ResourceData, meta interface{}) err
 		d.SetId(conn.ID)
 	}
 
+	if d.Get("wait_for_active").(bool) {
+		if err := waitForConnectionActive(client, d.Id(), d.Timeout(schema.TimeoutCreate)); err != nil {
+			return err
+		}
+	}
 	return resourceMetalConnectionRead(d, meta)
 }
 
@@ -314,22 +334,9 @@ func resourceMetalConnectionUpdate(d *schema.ResourceData, meta interface{}) err
 		ur.Redundancy = redundancy
 	}
 
-	// TODO(displague) packngo does not implement ContactEmail for update
-	// if d.HasChange("contact_email" {}
-
 	if d.HasChange("tags") {
-		ts := d.Get("tags")
-		sts := []string{}
-
-		switch ts.(type) {
-		case []interface{}:
-			for _, v := range ts.([]interface{}) {
-				sts = append(sts, v.(string))
-			}
-			ur.Tags = sts
-		default:
-			return equinix_errors.FriendlyError(fmt.Errorf("garbage in tags: %s", ts))
-		}
+		sts := tags.Expand(d.Get("tags"))
+		ur.Tags = sts
 	}
 
 	if !reflect.DeepEqual(ur, packngo.ConnectionUpdateRequest{}) {
@@ -338,6 +345,23 @@ func resourceMetalConnectionUpdate(d *schema.ResourceData, meta interface{}) err
 		}
 	}
 
+	// packngo doesn't implement the update of the contact email
+	if d.HasChange("contact_email") {
+		contactEmail := d.Get("contact_email").(string)
+		_, _, err := meta.(*config.Config).Metalgo.InterconnectionsApi.UpdateInterconnection(context.Background(), d.Id()).
+			InterconnectionUpdateInput(metalv1.InterconnectionUpdateInput{ContactEmail: &contactEmail}).
+			Execute()
+		if err != nil {
+			return equinix_errors.FriendlyError(err)
+		}
+	}
+
+	if d.HasChange("redundancy") && d.Get("wait_for_active").(bool) {
+		if err := waitForConnectionActive(client, d.Id(), d.Timeout(schema.TimeoutUpdate)); err != nil {
+			return err
+		}
+	}
+
 	// Don't update VLANs until _after_ the main ConnectionUpdateRequest has succeeded
 	if d.HasChange("vlans") {
 		connType := packngo.ConnectionType(d.Get("type").(string))
@@ -380,6 +404,41 @@ func resourceMetalConnectionUpdate(d *schema.ResourceData, meta interface{}) err
 	return resourceMetalConnectionRead(d, meta)
 }
 
+// connectionActivationStatus returns "active" once the connection and all its
+// ports are active, or else the first status that is not.
+func connectionActivationStatus(conn *packngo.Connection) string {
+	if conn.Status != string(metalv1.INTERCONNECTIONPORTSTATUS_ACTIVE) {
+		return conn.Status
+	}
+	for _, p := range conn.Ports {
+		if p.Status != "" && p.Status != string(metalv1.INTERCONNECTIONPORTSTATUS_ACTIVE) {
+			return p.Status
+		}
+	}
+	return conn.Status
+}
+
+func waitForConnectionActive(client *packngo.Client, id string, timeout time.Duration) error {
+	stateConf := &retry.StateChangeConf{
+		Pending: []string{"requested", "pending", "provisioning", "activating"},
+		Target:  []string{string(metalv1.INTERCONNECTIONPORTSTATUS_ACTIVE)},
+		Refresh: func() (interface{}, string, error) {
+			conn, _, err := client.Connections.Get(id, nil)
+			if err != nil {
+				return nil, "", equinix_errors.FriendlyError(err)
+			}
+			return conn, connectionActivationStatus(conn), nil
+		},
+		Timeout:    timeout,
+		Delay:      10 * time.Second,
+		MinTimeout: 10 * time.Second,
+	}
+	if _, err := stateConf.WaitForStateContext(context.Background()); err != nil {
+		return fmt.Errorf("error waiting for connection %s to be active: %s", id, err)
+	}
+	return nil
+}
+
 func updateHiddenVirtualCircuitVNID(client *packngo.Client, port map[string]interface{}, newVNID string) (*packngo.VirtualCircuit, *packngo.Response, error) {
 	// This function is used to update the implicit virtual circuits attached to a shared `metal_connection` resource
 	// Do not use this function for a non-shared `metal_connection`
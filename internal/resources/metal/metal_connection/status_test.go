@@ -0,0 +1,47 @@
+package metal_connection
+
+import (
+	"testing"
+
+	"github.com/packethost/packngo"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConnectionActivationStatus(t *testing.T) {
+	// given
+	testCases := map[string]struct {
+		conn     *packngo.Connection
+		expected string
+	}{
+		"requested connection": {
+			conn:     &packngo.Connection{Status: "requested"},
+			expected: "requested",
+		},
+		"active connection with active ports": {
+			conn: &packngo.Connection{Status: "active", Ports: []packngo.ConnectionPort{
+				{Role: "primary", Status: "active"},
+				{Role: "secondary", Status: "active"},
+			}},
+			expected: "active",
+		},
+		"active connection with a requested port": {
+			conn: &packngo.Connection{Status: "active", Ports: []packngo.ConnectionPort{
+				{Role: "primary", Status: "active"},
+				{Role: "secondary", Status: "requested"},
+			}},
+			expected: "requested",
+		},
+		"active connection with ports without status": {
+			conn:     &packngo.Connection{Status: "active", Ports: []packngo.ConnectionPort{{Role: "primary"}}},
+			expected: "active",
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			// when
+			status := connectionActivationStatus(tc.conn)
+			// then
+			assert.Equal(t, tc.expected, status)
+		})
+	}
+}
@@ -1,4 +1,4 @@
-package equinix
+package organization_member
 
 import (
 	"fmt"
@@ -31,7 +31,15 @@ func (m *member) isInvitation() bool {
 	return m.Invitation != nil
 }
 
-func resourceMetalOrganizationMember() *schema.Resource {
+// Resources returns the resources of the package by type name, registered by
+// the provider.
+func Resources() map[string]*schema.Resource {
+	return map[string]*schema.Resource{
+		"equinix_metal_organization_member": Resource(),
+	}
+}
+
+func Resource() *schema.Resource {
 	return &schema.Resource{
 		Create: resourceMetalOrganizationMemberCreate,
 		Read:   resourceMetalOrganizationMemberRead,
@@ -14,6 +14,14 @@ import (
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
+// DataSources returns the data sources of the package by type name, registered by
+// the provider.
+func DataSources() map[string]*schema.Resource {
+	return map[string]*schema.Resource{
+		"equinix_metal_project": DataSource(),
+	}
+}
+
 func DataSource() *schema.Resource {
 	return &schema.Resource{
 		ReadWithoutTimeout: dataSourceMetalProjectRead,
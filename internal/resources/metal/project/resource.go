@@ -17,6 +17,14 @@ import (
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
+// Resources returns the resources of the package by type name, registered by
+// the provider.
+func Resources() map[string]*schema.Resource {
+	return map[string]*schema.Resource{
+		"equinix_metal_project": Resource(),
+	}
+}
+
 func Resource() *schema.Resource {
 	return &schema.Resource{
 		CreateWithoutTimeout: resourceMetalProjectCreate,
@@ -26,6 +34,7 @@ func Resource() *schema.Resource {
 		Importer: &schema.ResourceImporter{
 			State: schema.ImportStatePassthrough,
 		},
+		CustomizeDiff: validateOrganizationTransfer,
 		Schema: map[string]*schema.Schema{
 			"name": {
 				Type:        schema.TypeString,
@@ -63,15 +72,20 @@ func Resource() *schema.Resource {
 			},
 			"organization_id": {
 				Type:        schema.TypeString,
-				Description: "The UUID of organization under which you want to create the project. If you leave it out, the project will be create under your the default organization of your account",
+				Description: "The UUID of organization under which you want to create the project. If you leave it out, the project will be create under your the default organization of your account. Changing it transfers the project to the new organization, which requires allow_organization_transfer to be set",
 				Optional:    true,
 				Computed:    true,
-				ForceNew:    true,
 				DiffSuppressFunc: func(k, old, new string, d *schema.ResourceData) bool {
 					return strings.EqualFold(strings.Trim(old, `"`), strings.Trim(new, `"`))
 				},
 				ValidateFunc: validation.IsUUID,
 			},
+			"allow_organization_transfer": {
+				Type:        schema.TypeBool,
+				Description: "Confirms that changing organization_id transfers the existing project to the new organization, rather than failing the plan. The transfer request is accepted on behalf of the new organization, so the credentials of the provider need to be allowed to accept it",
+				Optional:    true,
+				Default:     false,
+			},
 			"bgp_config": {
 				Type:        schema.TypeList,
 				Description: "Optional BGP settings. Refer to [Equinix Metal guide for BGP](https://metal.equinix.com/developers/docs/networking/local-global-bgp/)",
@@ -264,6 +278,11 @@ func resourceMetalProjectUpdate(ctx context.Context, d *schema.ResourceData, met
 		pBT := d.Get("backend_transfer").(bool)
 		updateRequest.BackendTransferEnabled = &pBT
 	}
+	if d.HasChange("organization_id") {
+		if err := transferProject(ctx, client, d.Id(), d.Get("organization_id").(string)); err != nil {
+			return diag.FromErr(err)
+		}
+	}
 	if d.HasChange("bgp_config") {
 		o, n := d.GetChange("bgp_config")
 		oldarr := o.([]interface{})
@@ -0,0 +1,47 @@
+package project
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/equinix/equinix-sdk-go/services/metalv1"
+	equinix_errors "github.com/equinix/terraform-provider-equinix/internal/errors"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// validateOrganizationTransfer fails the plan of an organization change of an
+// existing project unless the transfer is confirmed with
+// allow_organization_transfer.
+func validateOrganizationTransfer(_ context.Context, d *schema.ResourceDiff, _ interface{}) error {
+	if d.Id() == "" || !d.HasChange("organization_id") || !d.NewValueKnown("organization_id") {
+		return nil
+	}
+	o, n := d.GetChange("organization_id")
+	return organizationTransferError(o.(string), n.(string), d.Get("allow_organization_transfer").(bool))
+}
+
+// organizationTransferError returns an error if the project would be
+// transferred from the old to the new organization without confirmation.
+func organizationTransferError(oldOrg, newOrg string, allowTransfer bool) error {
+	if newOrg == "" || strings.EqualFold(oldOrg, newOrg) || allowTransfer {
+		return nil
+	}
+	return fmt.Errorf("changing organization_id from %s to %s transfers the project to the new organization, set allow_organization_transfer to confirm the transfer", oldOrg, newOrg)
+}
+
+// transferProject requests the transfer of the project to the organization
+// and accepts the request on behalf of the organization.
+func transferProject(ctx context.Context, client *metalv1.APIClient, projectId, organizationId string) error {
+	transferRequest, resp, err := client.ProjectsApi.CreateTransferRequest(ctx, projectId).
+		TransferRequestInput(metalv1.TransferRequestInput{TargetOrganizationId: &organizationId}).
+		Execute()
+	if err != nil {
+		return fmt.Errorf("error requesting the transfer of project %s to organization %s: %w", projectId, organizationId, equinix_errors.FriendlyErrorForMetalGo(err, resp))
+	}
+	resp, err = client.TransferRequestsApi.AcceptTransferRequest(ctx, transferRequest.GetId()).Execute()
+	if err != nil {
+		return fmt.Errorf("error accepting the transfer request %s of project %s to organization %s, it has to be accepted by the organization: %w", transferRequest.GetId(), projectId, organizationId, equinix_errors.FriendlyErrorForMetalGo(err, resp))
+	}
+	return nil
+}
@@ -0,0 +1,61 @@
+package project
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOrganizationTransferError(t *testing.T) {
+	// given
+	testCases := map[string]struct {
+		oldOrg, newOrg string
+		allowTransfer  bool
+		expectError    bool
+	}{
+		"unchanged organization":        {oldOrg: "org-1", newOrg: "org-1"},
+		"organization case change":      {oldOrg: "ORG-1", newOrg: "org-1"},
+		"default organization":          {oldOrg: "org-1", newOrg: ""},
+		"confirmed transfer":            {oldOrg: "org-1", newOrg: "org-2", allowTransfer: true},
+		"transfer without confirmation": {oldOrg: "org-1", newOrg: "org-2", expectError: true},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			// when
+			err := organizationTransferError(tc.oldOrg, tc.newOrg, tc.allowTransfer)
+			// then
+			if tc.expectError {
+				assert.ErrorContains(t, err, "allow_organization_transfer")
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestValidateOrganizationTransfer(t *testing.T) {
+	// given
+	r := Resource()
+	old := schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{
+		"name":            "test",
+		"organization_id": "3a58dd05-f46d-4b1d-a154-2e85c396ea62",
+	})
+	old.SetId("project")
+	plan := func(allowTransfer bool) error {
+		_, err := r.Diff(context.Background(), old.State(), terraform.NewResourceConfigRaw(map[string]interface{}{
+			"name":                        "test",
+			"organization_id":             "7e1f5c1c-4f3e-4d9a-9a53-1b5d7d3e2f10",
+			"allow_organization_transfer": allowTransfer,
+		}), nil)
+		return err
+	}
+	// when
+	unconfirmed := plan(false)
+	confirmed := plan(true)
+	// then
+	assert.ErrorContains(t, unconfirmed, "set allow_organization_transfer to confirm the transfer", "Unconfirmed transfer fails the plan")
+	assert.NoError(t, confirmed, "Confirmed transfer is planned")
+}
@@ -0,0 +1,111 @@
+package project_backend_transfer
+
+import (
+	"context"
+	"log"
+
+	"github.com/equinix/equinix-sdk-go/services/metalv1"
+	"github.com/equinix/terraform-provider-equinix/internal/config"
+	equinix_errors "github.com/equinix/terraform-provider-equinix/internal/errors"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// Resources returns the resources of the package by type name, registered by
+// the provider.
+func Resources() map[string]*schema.Resource {
+	return map[string]*schema.Resource{
+		"equinix_metal_project_backend_transfer": Resource(),
+	}
+}
+
+func Resource() *schema.Resource {
+	return &schema.Resource{
+		CreateWithoutTimeout: resourceMetalProjectBackendTransferCreate,
+		ReadWithoutTimeout:   resourceMetalProjectBackendTransferRead,
+		UpdateWithoutTimeout: resourceMetalProjectBackendTransferUpdate,
+		DeleteWithoutTimeout: resourceMetalProjectBackendTransferDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+		Schema: map[string]*schema.Schema{
+			"project_id": {
+				Type:        schema.TypeString,
+				Description: "The UUID of the project for which Backend Transfer is managed",
+				Required:    true,
+				ForceNew:    true,
+			},
+			"enabled": {
+				Type:        schema.TypeBool,
+				Description: "Enable or disable [Backend Transfer](https://metal.equinix.com/developers/docs/networking/backend-transfer/) in the project, default is true",
+				Optional:    true,
+				Default:     true,
+			},
+		},
+	}
+}
+
+func resourceMetalProjectBackendTransferCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	projectID := d.Get("project_id").(string)
+	if err := setBackendTransfer(ctx, d, meta, projectID, d.Get("enabled").(bool)); err != nil {
+		return diag.FromErr(err)
+	}
+	d.SetId(projectID)
+	return resourceMetalProjectBackendTransferRead(ctx, d, meta)
+}
+
+func resourceMetalProjectBackendTransferRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	meta.(*config.Config).AddModuleToMetalGoUserAgent(d)
+	client := meta.(*config.Config).Metalgo
+
+	proj, resp, err := client.ProjectsApi.FindProjectById(ctx, d.Id()).Execute()
+	if err != nil {
+		err = equinix_errors.FriendlyErrorForMetalGo(err, resp)
+
+		// If the project somehow already destroyed, mark as successfully gone.
+		if !d.IsNewResource() && equinix_errors.IsNotFound(err) {
+			log.Printf("[WARN] Project (%s) for Backend Transfer not found, removing from state", d.Id())
+			d.SetId("")
+			return nil
+		}
+
+		return diag.FromErr(err)
+	}
+
+	// No backend_transfer_enabled property in API spec
+	enabled, _ := proj.AdditionalProperties["backend_transfer_enabled"].(bool)
+	d.Set("project_id", proj.GetId())
+	d.Set("enabled", enabled)
+	return nil
+}
+
+func resourceMetalProjectBackendTransferUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	if d.HasChange("enabled") {
+		if err := setBackendTransfer(ctx, d, meta, d.Id(), d.Get("enabled").(bool)); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+	return resourceMetalProjectBackendTransferRead(ctx, d, meta)
+}
+
+func resourceMetalProjectBackendTransferDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	err := setBackendTransfer(ctx, d, meta, d.Id(), false)
+	if err != nil && !equinix_errors.IsNotFound(err) {
+		return diag.FromErr(err)
+	}
+	return nil
+}
+
+func setBackendTransfer(ctx context.Context, d *schema.ResourceData, meta interface{}, projectID string, enabled bool) error {
+	meta.(*config.Config).AddModuleToMetalGoUserAgent(d)
+	client := meta.(*config.Config).Metalgo
+
+	updateRequest := metalv1.ProjectUpdateInput{
+		BackendTransferEnabled: &enabled,
+	}
+	_, resp, err := client.ProjectsApi.UpdateProject(ctx, projectID).ProjectUpdateInput(updateRequest).Execute()
+	if err != nil {
+		return equinix_errors.FriendlyErrorForMetalGo(err, resp)
+	}
+	return nil
+}
@@ -0,0 +1,85 @@
+package project_backend_transfer_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/equinix/terraform-provider-equinix/internal/acceptance"
+	"github.com/equinix/terraform-provider-equinix/internal/config"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+)
+
+func TestAccMetalProjectBackendTransfer_basic(t *testing.T) {
+	rInt := acctest.RandInt()
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:          func() { acceptance.TestAccPreCheckMetal(t) },
+		ExternalProviders: acceptance.TestExternalProviders,
+		Providers:         acceptance.TestAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccMetalProjectBackendTransferConfig(rInt, true),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(
+						"equinix_metal_project_backend_transfer.test", "enabled", "true"),
+					resource.TestCheckResourceAttrPair(
+						"equinix_metal_project_backend_transfer.test", "project_id",
+						"equinix_metal_project.test", "id"),
+					testAccMetalProjectBackendTransferEnabled("equinix_metal_project.test", true),
+				),
+			},
+			{
+				ResourceName:      "equinix_metal_project_backend_transfer.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			{
+				Config: testAccMetalProjectBackendTransferConfig(rInt, false),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(
+						"equinix_metal_project_backend_transfer.test", "enabled", "false"),
+					testAccMetalProjectBackendTransferEnabled("equinix_metal_project.test", false),
+				),
+			},
+		},
+	})
+}
+
+func testAccMetalProjectBackendTransferEnabled(n string, enabled bool) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("Not found: %s", n)
+		}
+
+		client := acceptance.TestAccProvider.Meta().(*config.Config).Metal
+
+		project, _, err := client.Projects.Get(rs.Primary.ID, nil)
+		if err != nil {
+			return err
+		}
+		if project.BackendTransfer != enabled {
+			return fmt.Errorf("expected backend transfer of project %s to be %t, got %t", project.ID, enabled, project.BackendTransfer)
+		}
+		return nil
+	}
+}
+
+func testAccMetalProjectBackendTransferConfig(r int, enabled bool) string {
+	return fmt.Sprintf(`
+resource "equinix_metal_project" "test" {
+	name = "tfacc-project-bt-%d"
+
+	lifecycle {
+		ignore_changes = [backend_transfer]
+	}
+}
+
+resource "equinix_metal_project_backend_transfer" "test" {
+	project_id = equinix_metal_project.test.id
+	enabled    = %t
+}`, r, enabled)
+}
@@ -3,10 +3,12 @@ package project_ssh_key
 import (
 	"context"
 	"fmt"
+	"strings"
 
 	"github.com/equinix/equinix-sdk-go/services/metalv1"
 	equinix_errors "github.com/equinix/terraform-provider-equinix/internal/errors"
 	"github.com/equinix/terraform-provider-equinix/internal/framework"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 )
 
@@ -194,3 +196,64 @@ func (r *Resource) Delete(
 		)
 	}
 }
+
+// ImportState accepts either the ID of the key or the project ID and the
+// label of the key separated by a slash, e.g. for legacy keys whose ID
+// wasn't recorded.
+func (r *Resource) ImportState(
+	ctx context.Context,
+	req resource.ImportStateRequest,
+	resp *resource.ImportStateResponse,
+) {
+	projectID, label, found := strings.Cut(req.ID, "/")
+	if !found {
+		r.BaseResource.ImportState(ctx, req, resp)
+		return
+	}
+	if projectID == "" || label == "" {
+		resp.Diagnostics.AddError(
+			"Invalid import ID",
+			fmt.Sprintf("expected the key ID or project_id/label, got %q", req.ID),
+		)
+		return
+	}
+
+	client := r.Meta.Metalgo
+	keysList, _, err := client.SSHKeysApi.FindProjectSSHKeys(context.Background(), projectID).Query(label).Execute()
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error listing project ssh keys",
+			equinix_errors.FriendlyError(err).Error(),
+		)
+		return
+	}
+
+	id, err := findKeyIDByLabel(keysList.GetSshKeys(), label)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			fmt.Sprintf("Failed to import Project SSHKey %q of project %s", label, projectID),
+			err.Error(),
+		)
+		return
+	}
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), id)...)
+}
+
+// findKeyIDByLabel returns the ID of the only key with the given label. The
+// key search of the API also matches partial labels and fingerprints.
+func findKeyIDByLabel(keys []metalv1.SSHKey, label string) (string, error) {
+	id := ""
+	for _, key := range keys {
+		if key.GetLabel() != label {
+			continue
+		}
+		if id != "" {
+			return "", fmt.Errorf("more than one SSH key is labelled %q, import it by ID instead", label)
+		}
+		id = key.GetId()
+	}
+	if id == "" {
+		return "", fmt.Errorf("no SSH key is labelled %q", label)
+	}
+	return id, nil
+}
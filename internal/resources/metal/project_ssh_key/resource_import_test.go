@@ -0,0 +1,25 @@
+package project_ssh_key
+
+import (
+	"testing"
+
+	"github.com/equinix/equinix-sdk-go/services/metalv1"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMetalProjectSSHKey_findKeyIDByLabel(t *testing.T) {
+	key := func(id, label string) metalv1.SSHKey {
+		return metalv1.SSHKey{Id: &id, Label: &label}
+	}
+	keys := []metalv1.SSHKey{key("1", "deploy-legacy"), key("2", "deploy"), key("3", "ci"), key("4", "ci")}
+
+	id, err := findKeyIDByLabel(keys, "deploy")
+	assert.NoError(t, err)
+	assert.Equal(t, "2", id, "Only the exact label matches")
+
+	_, err = findKeyIDByLabel(keys, "ci")
+	assert.ErrorContains(t, err, "more than one", "Ambiguous labels are rejected")
+
+	_, err = findKeyIDByLabel(keys, "missing")
+	assert.ErrorContains(t, err, "no SSH key", "Missing labels are rejected")
+}
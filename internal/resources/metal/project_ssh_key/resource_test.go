@@ -79,6 +79,20 @@ func TestAccMetalProjectSSHKey_basic(t *testing.T) {
 					),
 				),
 			},
+			{
+				ResourceName:      "equinix_metal_project_ssh_key.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			{
+				ResourceName: "equinix_metal_project_ssh_key.test",
+				ImportStateIdFunc: func(s *terraform.State) (string, error) {
+					rs := s.RootModule().Resources["equinix_metal_project_ssh_key.test"]
+					return rs.Primary.Attributes["project_id"] + "/" + rs.Primary.Attributes["name"], nil
+				},
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
 		},
 	})
 }
@@ -5,6 +5,7 @@ import (
 	"fmt"
 
 	"github.com/equinix/equinix-sdk-go/services/metalv1"
+	equinix_diagnostics "github.com/equinix/terraform-provider-equinix/internal/diagnostics"
 	equinix_errors "github.com/equinix/terraform-provider-equinix/internal/errors"
 	"github.com/equinix/terraform-provider-equinix/internal/framework"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
@@ -48,12 +49,9 @@ func (r *Resource) Create(
 	}
 
 	// Create API resource
-	key, _, err := client.SSHKeysApi.CreateSSHKey(context.Background()).SSHKeyCreateInput(*createRequest).Execute()
+	key, createResp, err := client.SSHKeysApi.CreateSSHKey(context.Background()).SSHKeyCreateInput(*createRequest).Execute()
 	if err != nil {
-		resp.Diagnostics.AddError(
-			"Failed to create SSH Key",
-			equinix_errors.FriendlyError(err).Error(),
-		)
+		resp.Diagnostics.Append(equinix_diagnostics.FrameworkError("creating SSH key", "", createResp, err)...)
 		return
 	}
 
@@ -86,7 +84,7 @@ func (r *Resource) Read(
 	id := state.ID.ValueString()
 
 	// Use API client to get the current state of the resource
-	key, _, err := client.SSHKeysApi.FindSSHKeyById(context.Background(), id).Include(nil).Execute()
+	key, readResp, err := client.SSHKeysApi.FindSSHKeyById(context.Background(), id).Include(nil).Execute()
 	if err != nil {
 		err = equinix_errors.FriendlyError(err)
 
@@ -100,10 +98,8 @@ func (r *Resource) Read(
 			resp.State.RemoveResource(ctx)
 			return
 		}
-		resp.Diagnostics.AddError(
-			fmt.Sprintf("Failed to get SSHKey %s", id),
-			err.Error(),
-		)
+		resp.Diagnostics.Append(equinix_diagnostics.FrameworkError(fmt.Sprintf("reading SSH key %s", id), "", readResp, err)...)
+		return
 	}
 
 	// Set state to fully populated data
@@ -144,13 +140,9 @@ func (r *Resource) Update(
 	}
 
 	// Update the resource
-	key, _, err := client.SSHKeysApi.UpdateSSHKey(context.Background(), id).SSHKeyInput(*updateRequest).Execute()
+	key, updateResp, err := client.SSHKeysApi.UpdateSSHKey(context.Background(), id).SSHKeyInput(*updateRequest).Execute()
 	if err != nil {
-		err = equinix_errors.FriendlyError(err)
-		resp.Diagnostics.AddError(
-			"Error updating resource",
-			"Could not update resource with ID "+id+": "+err.Error(),
-		)
+		resp.Diagnostics.Append(equinix_diagnostics.FrameworkError(fmt.Sprintf("updating SSH key %s", id), "", updateResp, err)...)
 		return
 	}
 
@@ -185,10 +177,6 @@ func (r *Resource) Delete(
 	// Use API client to delete the resource
 	deleteResp, err := client.SSHKeysApi.DeleteSSHKey(context.Background(), id).Execute()
 	if equinix_errors.IgnoreHttpResponseErrors(equinix_errors.HttpForbidden, equinix_errors.HttpNotFound)(deleteResp, err) != nil {
-		err = equinix_errors.FriendlyError(err)
-		resp.Diagnostics.AddError(
-			fmt.Sprintf("Failed to delete SSHKey %s", id),
-			err.Error(),
-		)
+		resp.Diagnostics.Append(equinix_diagnostics.FrameworkError(fmt.Sprintf("deleting SSH key %s", id), "", deleteResp, err)...)
 	}
 }
@@ -7,6 +7,14 @@ import (
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
+// DataSources returns the data sources of the package by type name, registered by
+// the provider.
+func DataSources() map[string]*schema.Resource {
+	return map[string]*schema.Resource{
+		"equinix_metal_vrf": DataSource(),
+	}
+}
+
 func DataSource() *schema.Resource {
 	return &schema.Resource{
 		ReadWithoutTimeout: dataSourceMetalVRFRead,
@@ -14,6 +14,14 @@ import (
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
+// Resources returns the resources of the package by type name, registered by
+// the provider.
+func Resources() map[string]*schema.Resource {
+	return map[string]*schema.Resource{
+		"equinix_metal_vrf": Resource(),
+	}
+}
+
 func Resource() *schema.Resource {
 	return &schema.Resource{
 		ReadWithoutTimeout:   resourceMetalVRFRead,
@@ -125,12 +133,12 @@ func resourceMetalVRFRead(ctx context.Context, d *schema.ResourceData, meta inte
 	meta.(*config.Config).AddModuleToMetalUserAgent(d)
 	client := meta.(*config.Config).Metalgo
 
-	vrf, _, err := client.VRFsApi.
+	vrf, resp, err := client.VRFsApi.
 		FindVrfById(ctx, d.Id()).
 		Include([]string{"project", "metro"}).
 		Execute()
 	if err != nil {
-		if equinix_errors.IsNotFound(err) || equinix_errors.IsForbidden(err) {
+		if equinix_errors.IsGone(resp, err) {
 			log.Printf("[WARN] VRF (%s) not accessible, removing from state", d.Id())
 			d.SetId("")
 
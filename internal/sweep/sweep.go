@@ -0,0 +1,94 @@
+// Package sweep holds the helpers shared by the acceptance test sweepers,
+// which remove the resources left behind by failed acceptance test runs.
+package sweep
+
+import (
+	"fmt"
+	"log"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	// MinAgeEnvVar sets how old a test resource must be to be swept, as a Go
+	// duration like 6h. Younger resources may still be used by a running test.
+	MinAgeEnvVar = "EQUINIX_SWEEP_MIN_AGE"
+	// DryRunEnvVar makes the sweepers only log the resources they would delete.
+	DryRunEnvVar = "EQUINIX_SWEEP_DRY_RUN"
+
+	DefaultMinAge = 6 * time.Hour
+)
+
+// testResourceNamePrefixes and testResourceNameSuffixes identify the names of
+// the resources created by the acceptance tests.
+var (
+	testResourceNamePrefixes = []string{"tfacc"}
+	testResourceNameSuffixes = []string{"_PFCR", "_PNFV"}
+)
+
+// Options control which test resources the sweepers delete.
+type Options struct {
+	MinAge time.Duration
+	DryRun bool
+}
+
+// OptionsFromEnv returns the sweeper options set in the environment.
+func OptionsFromEnv() (Options, error) {
+	opts := Options{MinAge: DefaultMinAge}
+	if v := os.Getenv(MinAgeEnvVar); v != "" {
+		minAge, err := time.ParseDuration(v)
+		if err != nil || minAge < 0 {
+			return opts, fmt.Errorf("'%s' must be a positive duration like 6h, got %q", MinAgeEnvVar, v)
+		}
+		opts.MinAge = minAge
+	}
+	if v := os.Getenv(DryRunEnvVar); v != "" {
+		dryRun, err := strconv.ParseBool(v)
+		if err != nil {
+			return opts, fmt.Errorf("'%s' must be a boolean, got %q", DryRunEnvVar, v)
+		}
+		opts.DryRun = dryRun
+	}
+	return opts, nil
+}
+
+// IsTestResource returns true if the name is the one of a resource created by
+// the acceptance tests.
+func IsTestResource(name string) bool {
+	for _, prefix := range testResourceNamePrefixes {
+		if strings.HasPrefix(name, prefix) {
+			return true
+		}
+	}
+	for _, suffix := range testResourceNameSuffixes {
+		if strings.HasSuffix(name, suffix) {
+			return true
+		}
+	}
+	return false
+}
+
+// IsOrphaned returns true if a test resource created at the given time is old
+// enough to be swept. Resources of unknown age are never swept.
+func (o Options) IsOrphaned(name string, created, now time.Time) bool {
+	if !IsTestResource(name) || created.IsZero() {
+		return false
+	}
+	return now.Sub(created) >= o.MinAge
+}
+
+// Delete deletes a resource with the given function, or only logs it in dry
+// run mode.
+func (o Options) Delete(kind, name, id string, deleteFunc func() error) error {
+	if o.DryRun {
+		log.Printf("[INFO][SWEEPER_LOG] Dry run, not deleting %s %s (%s)", kind, name, id)
+		return nil
+	}
+	log.Printf("[INFO][SWEEPER_LOG] Deleting %s %s (%s)", kind, name, id)
+	if err := deleteFunc(); err != nil {
+		return fmt.Errorf("error deleting %s %s (%s): %s", kind, name, id, err)
+	}
+	return nil
+}
@@ -0,0 +1,67 @@
+package sweep
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOptionsFromEnv(t *testing.T) {
+	// given
+	t.Setenv(MinAgeEnvVar, "")
+	t.Setenv(DryRunEnvVar, "")
+	// when
+	defaults, err := OptionsFromEnv()
+	// then
+	require.NoError(t, err)
+	assert.Equal(t, Options{MinAge: DefaultMinAge}, defaults)
+
+	// given
+	t.Setenv(MinAgeEnvVar, "48h")
+	t.Setenv(DryRunEnvVar, "true")
+	// when
+	opts, err := OptionsFromEnv()
+	// then
+	require.NoError(t, err)
+	assert.Equal(t, Options{MinAge: 48 * time.Hour, DryRun: true}, opts)
+
+	// given
+	t.Setenv(MinAgeEnvVar, "two days")
+	// when
+	_, err = OptionsFromEnv()
+	// then
+	assert.Error(t, err, "Min age must be a duration")
+}
+
+func TestOptions_IsOrphaned(t *testing.T) {
+	// given
+	opts := Options{MinAge: 6 * time.Hour}
+	now := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+	// when / then
+	assert.True(t, opts.IsOrphaned("fcr_acc_test_PFCR", now.Add(-7*time.Hour), now), "Old test resource")
+	assert.True(t, opts.IsOrphaned("tfacc-token", now.Add(-6*time.Hour), now), "Test resource of exactly the min age")
+	assert.False(t, opts.IsOrphaned("fcr_acc_test_PFCR", now.Add(-time.Hour), now), "Test resource that may still be in use")
+	assert.False(t, opts.IsOrphaned("production-router", now.Add(-30*24*time.Hour), now), "Resource not created by tests")
+	assert.False(t, opts.IsOrphaned("tfacc-token", time.Time{}, now), "Resource of unknown age")
+}
+
+func TestOptions_Delete(t *testing.T) {
+	// given
+	deleted := 0
+	deleteFunc := func() error {
+		deleted++
+		return nil
+	}
+	// when
+	dryRunErr := Options{DryRun: true}.Delete("cloud router", "tfacc-fcr", "uuid", deleteFunc)
+	err := Options{}.Delete("cloud router", "tfacc-fcr", "uuid", deleteFunc)
+	failedErr := Options{}.Delete("cloud router", "tfacc-fcr", "uuid", func() error { return errors.New("locked") })
+	// then
+	assert.NoError(t, dryRunErr)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, deleted, "Resource is only deleted out of dry run mode")
+	assert.ErrorContains(t, failedErr, "locked")
+}
@@ -0,0 +1,102 @@
+// Package tags implements the validation and normalization of the tags
+// attached to Equinix Metal resources.
+//
+// The Metal API does not preserve the order of tags and may change their case,
+// so tags are compared as case insensitive sets to avoid spurious diffs.
+package tags
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// MaxLength is the maximum length of a single tag
+const MaxLength = 80
+
+// Validate checks that a tag is not empty, is at most MaxLength characters
+// long, does not contain commas or control characters and does not start or
+// end with whitespace.
+var Validate = validation.All(
+	validation.StringLenBetween(1, MaxLength),
+	validation.StringMatch(
+		regexp.MustCompile(`^[^\s,]([^\x00-\x1f\x7f,]*[^\s,])?$`),
+		"tags can not contain commas or control characters, nor start or end with whitespace",
+	),
+)
+
+// Schema returns the schema of an optional list of tags.
+func Schema(description string) *schema.Schema {
+	return &schema.Schema{
+		Type:             schema.TypeList,
+		Description:      description,
+		Optional:         true,
+		Elem:             &schema.Schema{Type: schema.TypeString, ValidateFunc: Validate},
+		DiffSuppressFunc: suppressEquivalentTags,
+	}
+}
+
+// Expand converts the list or set of tags of a resource to normalized tags
+// that can be sent to the API.
+func Expand(raw interface{}) []string {
+	var list []interface{}
+	switch v := raw.(type) {
+	case []interface{}:
+		list = v
+	case *schema.Set:
+		list = v.List()
+	}
+	tags := make([]string, 0, len(list))
+	for _, t := range list {
+		tags = append(tags, fmt.Sprint(t))
+	}
+	return Normalize(tags)
+}
+
+// Normalize trims the tags, removes case insensitive duplicates, keeping the
+// first occurrence, and sorts them case insensitively.
+func Normalize(tags []string) []string {
+	normalized := make([]string, 0, len(tags))
+	seen := make(map[string]bool, len(tags))
+	for _, t := range tags {
+		t = strings.TrimSpace(t)
+		key := strings.ToLower(t)
+		if t == "" || seen[key] {
+			continue
+		}
+		seen[key] = true
+		normalized = append(normalized, t)
+	}
+	sort.SliceStable(normalized, func(i, j int) bool {
+		return strings.ToLower(normalized[i]) < strings.ToLower(normalized[j])
+	})
+	return normalized
+}
+
+// Equal reports whether both lists contain the same tags, ignoring order, case
+// and duplicates.
+func Equal(a, b []string) bool {
+	na, nb := Normalize(a), Normalize(b)
+	if len(na) != len(nb) {
+		return false
+	}
+	for i := range na {
+		if !strings.EqualFold(na[i], nb[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+func suppressEquivalentTags(k, old, new string, d *schema.ResourceData) bool {
+	attr := k
+	if i := strings.LastIndex(k, "."); i >= 0 {
+		attr = k[:i]
+	}
+	o, n := d.GetChange(attr)
+	return Equal(Expand(o), Expand(n))
+}
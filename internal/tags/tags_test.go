@@ -0,0 +1,48 @@
+package tags
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNormalize(t *testing.T) {
+	// given
+	input := []string{"web", " Prod", "db", "prod", "", "Web"}
+	// when
+	result := Normalize(input)
+	// then
+	assert.Equal(t, []string{"db", "Prod", "web"}, result, "Tags are trimmed, deduplicated and sorted")
+}
+
+func TestEqual(t *testing.T) {
+	assert.True(t, Equal([]string{"b", "A"}, []string{"a", "B"}), "Reordered tags with different case are equal")
+	assert.True(t, Equal(nil, []string{}), "Empty tags are equal")
+	assert.False(t, Equal([]string{"a"}, []string{"a", "b"}), "Tags with different elements are not equal")
+}
+
+func TestValidate(t *testing.T) {
+	valid := []string{"web", "env:prod", "owner=team a", strings.Repeat("a", MaxLength)}
+	invalid := []string{"", " web", "web ", "a,b", "new\nline", strings.Repeat("a", MaxLength+1)}
+	for _, tag := range valid {
+		_, errs := Validate(tag, "tags")
+		assert.Empty(t, errs, "Tag %q is valid", tag)
+	}
+	for _, tag := range invalid {
+		_, errs := Validate(tag, "tags")
+		assert.NotEmpty(t, errs, "Tag %q is invalid", tag)
+	}
+}
+
+func TestExpand(t *testing.T) {
+	// given
+	set := schema.NewSet(schema.HashString, []interface{}{"b", "a"})
+	// when
+	fromList := Expand([]interface{}{"b", "a", "B"})
+	fromSet := Expand(set)
+	// then
+	assert.Equal(t, []string{"a", "b"}, fromList, "Tags are expanded from a list")
+	assert.Equal(t, []string{"a", "b"}, fromSet, "Tags are expanded from a set")
+}
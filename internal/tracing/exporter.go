@@ -0,0 +1,293 @@
+package tracing
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/equinix/terraform-provider-equinix/version"
+)
+
+const (
+	defaultServiceName  = "terraform-provider-equinix"
+	instrumentationName = "github.com/equinix/terraform-provider-equinix"
+
+	exportInterval  = 2 * time.Second
+	exportBatchSize = 128
+	maxQueueSize    = 2048
+	exportTimeout   = 10 * time.Second
+
+	spanKindClient  = 3
+	statusCodeError = 2
+)
+
+// exporter batches recorded spans and sends them to an OTLP/HTTP collector in
+// the background.
+type exporter struct {
+	endpoint   string
+	headers    map[string]string
+	resource   map[string]interface{}
+	httpClient *http.Client
+
+	mu      sync.Mutex
+	queue   []*span
+	flushCh chan struct{}
+	done    chan struct{}
+	stopped bool
+	wg      sync.WaitGroup
+}
+
+// newExporterFromEnv configures an exporter from the standard OTEL_*
+// environment variables. It returns nil if tracing is disabled.
+func newExporterFromEnv(getenv func(string) string) *exporter {
+	if strings.EqualFold(getenv("OTEL_SDK_DISABLED"), "true") {
+		return nil
+	}
+	if exporters := getenv("OTEL_TRACES_EXPORTER"); exporters != "" && !containsValue(exporters, "otlp") {
+		return nil
+	}
+	endpoint := getenv("OTEL_EXPORTER_OTLP_TRACES_ENDPOINT")
+	if endpoint == "" {
+		base := getenv("OTEL_EXPORTER_OTLP_ENDPOINT")
+		if base == "" {
+			return nil
+		}
+		endpoint = strings.TrimSuffix(base, "/") + "/v1/traces"
+	}
+	protocol := getenv("OTEL_EXPORTER_OTLP_TRACES_PROTOCOL")
+	if protocol == "" {
+		protocol = getenv("OTEL_EXPORTER_OTLP_PROTOCOL")
+	}
+	if protocol != "" && protocol != "http/json" {
+		// Collectors expecting grpc or http/protobuf would reject JSON payloads
+		log.Printf("[WARN] OTLP protocol %q is not supported, API call traces are not exported; set OTEL_EXPORTER_OTLP_TRACES_PROTOCOL=http/json to export them", protocol)
+		return nil
+	}
+
+	headers := parseKeyValues(getenv("OTEL_EXPORTER_OTLP_HEADERS"))
+	for k, v := range parseKeyValues(getenv("OTEL_EXPORTER_OTLP_TRACES_HEADERS")) {
+		headers[k] = v
+	}
+
+	resource := map[string]interface{}{
+		"service.version": version.ProviderVersion,
+	}
+	for k, v := range parseKeyValues(getenv("OTEL_RESOURCE_ATTRIBUTES")) {
+		resource[k] = v
+	}
+	resource["service.name"] = defaultServiceName
+	if name := getenv("OTEL_SERVICE_NAME"); name != "" {
+		resource["service.name"] = name
+	}
+
+	e := &exporter{
+		endpoint:   endpoint,
+		headers:    headers,
+		resource:   resource,
+		httpClient: &http.Client{Timeout: exportTimeout},
+		flushCh:    make(chan struct{}, 1),
+		done:       make(chan struct{}),
+	}
+	e.wg.Add(1)
+	go e.run()
+	return e
+}
+
+// record queues a span for export. Spans are dropped once the queue is full
+// or the exporter is shut down, so a slow collector never blocks API calls.
+func (e *exporter) record(s *span) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if e.stopped || len(e.queue) >= maxQueueSize {
+		return
+	}
+	e.queue = append(e.queue, s)
+	if len(e.queue) >= exportBatchSize {
+		select {
+		case e.flushCh <- struct{}{}:
+		default:
+		}
+	}
+}
+
+func (e *exporter) run() {
+	defer e.wg.Done()
+	ticker := time.NewTicker(exportInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+		case <-e.flushCh:
+		case <-e.done:
+			e.export(context.Background())
+			return
+		}
+		e.export(context.Background())
+	}
+}
+
+// shutdown stops the background export and sends the remaining spans.
+func (e *exporter) shutdown(ctx context.Context) error {
+	e.mu.Lock()
+	if e.stopped {
+		e.mu.Unlock()
+		return nil
+	}
+	e.stopped = true
+	e.mu.Unlock()
+
+	close(e.done)
+	finished := make(chan struct{})
+	go func() {
+		e.wg.Wait()
+		close(finished)
+	}()
+	select {
+	case <-finished:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (e *exporter) export(ctx context.Context) {
+	for {
+		e.mu.Lock()
+		n := len(e.queue)
+		if n > exportBatchSize {
+			n = exportBatchSize
+		}
+		batch := e.queue[:n]
+		e.queue = e.queue[n:]
+		e.mu.Unlock()
+		if len(batch) == 0 {
+			return
+		}
+		if err := e.send(ctx, batch); err != nil {
+			log.Printf("[WARN] Failed to export %d API call spans: %s", len(batch), err)
+			return
+		}
+	}
+}
+
+func (e *exporter) send(ctx context.Context, batch []*span) error {
+	body, err := json.Marshal(e.tracesData(batch))
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, e.endpoint, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	for k, v := range e.headers {
+		req.Header.Set(k, v)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := e.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	resp.Body.Close()
+	if resp.StatusCode >= http.StatusBadRequest {
+		return fmt.Errorf("collector responded with %s", resp.Status)
+	}
+	return nil
+}
+
+// tracesData encodes the spans as an OTLP ExportTraceServiceRequest in the
+// protobuf JSON mapping.
+func (e *exporter) tracesData(batch []*span) map[string]interface{} {
+	spans := make([]interface{}, 0, len(batch))
+	for _, s := range batch {
+		otlpSpan := map[string]interface{}{
+			"traceId":           hex.EncodeToString(s.traceID[:]),
+			"spanId":            hex.EncodeToString(s.spanID[:]),
+			"name":              s.name,
+			"kind":              spanKindClient,
+			"startTimeUnixNano": strconv.FormatInt(s.start.UnixNano(), 10),
+			"endTimeUnixNano":   strconv.FormatInt(s.end.UnixNano(), 10),
+			"attributes":        otlpAttributes(s.attributes),
+		}
+		if len(s.parentSpanID) > 0 {
+			otlpSpan["parentSpanId"] = hex.EncodeToString(s.parentSpanID)
+		}
+		if s.err != "" {
+			otlpSpan["status"] = map[string]interface{}{
+				"code":    statusCodeError,
+				"message": s.err,
+			}
+		}
+		spans = append(spans, otlpSpan)
+	}
+	return map[string]interface{}{
+		"resourceSpans": []interface{}{
+			map[string]interface{}{
+				"resource": map[string]interface{}{
+					"attributes": otlpAttributes(e.resource),
+				},
+				"scopeSpans": []interface{}{
+					map[string]interface{}{
+						"scope": map[string]interface{}{
+							"name":    instrumentationName,
+							"version": version.ProviderVersion,
+						},
+						"spans": spans,
+					},
+				},
+			},
+		},
+	}
+}
+
+func otlpAttributes(attributes map[string]interface{}) []interface{} {
+	result := make([]interface{}, 0, len(attributes))
+	for k, v := range attributes {
+		var value map[string]interface{}
+		switch v := v.(type) {
+		case int:
+			value = map[string]interface{}{"intValue": strconv.Itoa(v)}
+		default:
+			value = map[string]interface{}{"stringValue": fmt.Sprint(v)}
+		}
+		result = append(result, map[string]interface{}{"key": k, "value": value})
+	}
+	return result
+}
+
+// parseKeyValues parses a comma separated list of URL encoded key=value pairs,
+// the format of OTEL_EXPORTER_OTLP_HEADERS and OTEL_RESOURCE_ATTRIBUTES.
+// Malformed pairs are skipped.
+func parseKeyValues(value string) map[string]string {
+	result := map[string]string{}
+	for _, pair := range strings.Split(value, ",") {
+		k, v, ok := strings.Cut(pair, "=")
+		if !ok {
+			continue
+		}
+		k, kErr := url.PathUnescape(strings.TrimSpace(k))
+		v, vErr := url.PathUnescape(strings.TrimSpace(v))
+		if kErr != nil || vErr != nil || k == "" {
+			continue
+		}
+		result[k] = v
+	}
+	return result
+}
+
+func containsValue(list, value string) bool {
+	for _, item := range strings.Split(list, ",") {
+		if strings.EqualFold(strings.TrimSpace(item), value) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,159 @@
+// Package tracing records OpenTelemetry compatible spans for the Equinix API
+// calls made by the provider and exports them to an OTLP/HTTP collector.
+//
+// Tracing is disabled unless an OTLP endpoint is configured with the standard
+// OTEL_EXPORTER_OTLP_ENDPOINT or OTEL_EXPORTER_OTLP_TRACES_ENDPOINT environment
+// variables. Spans are exported in the OTLP JSON encoding, so the collector
+// must accept the http/json protocol.
+package tracing
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"os"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	traceparentHeader   = "Traceparent"
+	correlationIdHeader = "X-Correlation-Id"
+
+	// traceparentEnvVar can hold a W3C trace context, e.g. set by a CI
+	// pipeline, so the API call spans join the trace of the whole run
+	traceparentEnvVar = "TRACEPARENT"
+)
+
+var traceparentRe = regexp.MustCompile(`^00-([0-9a-f]{32})-([0-9a-f]{16})-[0-9a-f]{2}$`)
+
+var (
+	defaultExporter     *exporter
+	defaultParent       spanContext
+	defaultExporterOnce sync.Once
+)
+
+// exporterFromEnv returns the process wide exporter configured from the
+// environment, or nil if tracing is disabled, and the parent of the API call
+// spans. Without a TRACEPARENT, all the API calls of the provider process
+// share a new trace.
+func exporterFromEnv() (*exporter, spanContext) {
+	defaultExporterOnce.Do(func() {
+		defaultExporter = newExporterFromEnv(os.Getenv)
+		if parent := parentFromTraceparent(os.Getenv(traceparentEnvVar)); parent != nil {
+			defaultParent = *parent
+		} else {
+			rand.Read(defaultParent.traceID[:])
+		}
+	})
+	return defaultExporter, defaultParent
+}
+
+// NewTransport returns a transport recording a span for every request made
+// through next on behalf of the named service. next is returned unchanged if
+// tracing is disabled.
+func NewTransport(service string, next http.RoundTripper) http.RoundTripper {
+	e, parent := exporterFromEnv()
+	if e == nil {
+		return next
+	}
+	return newTransport(service, next, e, parent)
+}
+
+// Shutdown exports the spans recorded so far and stops the exporter.
+func Shutdown(ctx context.Context) error {
+	e, _ := exporterFromEnv()
+	if e == nil {
+		return nil
+	}
+	return e.shutdown(ctx)
+}
+
+// spanContext identifies a span within a trace. The span ID of a trace
+// without a parent span is all zeros.
+type spanContext struct {
+	traceID [16]byte
+	spanID  [8]byte
+}
+
+func (sc spanContext) traceparent() string {
+	return fmt.Sprintf("00-%s-%s-01", hex.EncodeToString(sc.traceID[:]), hex.EncodeToString(sc.spanID[:]))
+}
+
+// parentFromTraceparent parses a W3C traceparent value. It returns nil for
+// empty or malformed values.
+func parentFromTraceparent(value string) *spanContext {
+	m := traceparentRe.FindStringSubmatch(strings.TrimSpace(value))
+	if m == nil {
+		return nil
+	}
+	var sc spanContext
+	hex.Decode(sc.traceID[:], []byte(m[1]))
+	hex.Decode(sc.spanID[:], []byte(m[2]))
+	return &sc
+}
+
+// span is an API call recorded by the transport.
+type span struct {
+	spanContext
+	parentSpanID []byte
+	name         string
+	start        time.Time
+	end          time.Time
+	attributes   map[string]interface{}
+	err          string
+}
+
+type transport struct {
+	service  string
+	next     http.RoundTripper
+	exporter *exporter
+	parent   spanContext
+}
+
+func newTransport(service string, next http.RoundTripper, e *exporter, parent spanContext) *transport {
+	return &transport{service: service, next: next, exporter: e, parent: parent}
+}
+
+func (t *transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	s := &span{
+		name:  t.service + " " + req.Method,
+		start: time.Now(),
+		attributes: map[string]interface{}{
+			"equinix.service":     t.service,
+			"equinix.operation":   req.Method + " " + req.URL.Path,
+			"http.request.method": req.Method,
+			"server.address":      req.URL.Hostname(),
+			"url.path":            req.URL.Path,
+		},
+	}
+	if id := req.Header.Get(correlationIdHeader); id != "" {
+		s.attributes["equinix.correlation_id"] = id
+	}
+	s.traceID = t.parent.traceID
+	rand.Read(s.spanID[:])
+	if t.parent.spanID != [8]byte{} {
+		s.parentSpanID = t.parent.spanID[:]
+	}
+
+	req = req.Clone(req.Context())
+	req.Header.Set(traceparentHeader, s.traceparent())
+	resp, err := t.next.RoundTrip(req)
+
+	s.end = time.Now()
+	switch {
+	case err != nil:
+		s.err = err.Error()
+	case resp.StatusCode >= http.StatusBadRequest:
+		s.attributes["http.response.status_code"] = resp.StatusCode
+		s.err = resp.Status
+	default:
+		s.attributes["http.response.status_code"] = resp.StatusCode
+	}
+	t.exporter.record(s)
+	return resp, err
+}
@@ -0,0 +1,144 @@
+package tracing
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewExporterFromEnv(t *testing.T) {
+	tests := []struct {
+		name     string
+		env      map[string]string
+		endpoint string
+	}{
+		{"disabled without endpoint", map[string]string{}, ""},
+		{"base endpoint", map[string]string{"OTEL_EXPORTER_OTLP_ENDPOINT": "http://collector:4318/"}, "http://collector:4318/v1/traces"},
+		{"traces endpoint", map[string]string{
+			"OTEL_EXPORTER_OTLP_ENDPOINT":        "http://collector:4318",
+			"OTEL_EXPORTER_OTLP_TRACES_ENDPOINT": "http://traces:4318/custom",
+		}, "http://traces:4318/custom"},
+		{"sdk disabled", map[string]string{
+			"OTEL_EXPORTER_OTLP_ENDPOINT": "http://collector:4318",
+			"OTEL_SDK_DISABLED":           "true",
+		}, ""},
+		{"other exporter", map[string]string{
+			"OTEL_EXPORTER_OTLP_ENDPOINT": "http://collector:4318",
+			"OTEL_TRACES_EXPORTER":        "none",
+		}, ""},
+		{"grpc protocol", map[string]string{
+			"OTEL_EXPORTER_OTLP_ENDPOINT": "http://collector:4317",
+			"OTEL_EXPORTER_OTLP_PROTOCOL": "grpc",
+		}, ""},
+		{"http/protobuf traces protocol", map[string]string{
+			"OTEL_EXPORTER_OTLP_ENDPOINT":        "http://collector:4318",
+			"OTEL_EXPORTER_OTLP_PROTOCOL":        "http/json",
+			"OTEL_EXPORTER_OTLP_TRACES_PROTOCOL": "http/protobuf",
+		}, ""},
+		{"http/json protocol", map[string]string{
+			"OTEL_EXPORTER_OTLP_ENDPOINT": "http://collector:4318",
+			"OTEL_EXPORTER_OTLP_PROTOCOL": "http/json",
+		}, "http://collector:4318/v1/traces"},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// when
+			e := newExporterFromEnv(env(tc.env).get)
+			// then
+			if tc.endpoint == "" {
+				assert.Nil(t, e)
+				return
+			}
+			require.NotNil(t, e)
+			defer e.shutdown(context.Background())
+			assert.Equal(t, tc.endpoint, e.endpoint)
+		})
+	}
+}
+
+func TestParentFromTraceparent(t *testing.T) {
+	// given
+	value := "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01"
+	// when
+	parent := parentFromTraceparent(value)
+	// then
+	require.NotNil(t, parent)
+	assert.Equal(t, value, parent.traceparent())
+	assert.Nil(t, parentFromTraceparent("not-a-traceparent"))
+}
+
+func TestTransport(t *testing.T) {
+	// given
+	var (
+		mu        sync.Mutex
+		exported  []map[string]interface{}
+		headers   []http.Header
+		apiHeader []string
+	)
+	collector := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body map[string]interface{}
+		json.NewDecoder(r.Body).Decode(&body)
+		mu.Lock()
+		exported = append(exported, body)
+		headers = append(headers, r.Header)
+		mu.Unlock()
+	}))
+	defer collector.Close()
+	api := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		apiHeader = append(apiHeader, r.Header.Get(traceparentHeader))
+		if strings.HasSuffix(r.URL.Path, "/missing") {
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer api.Close()
+
+	e := newExporterFromEnv(env{
+		"OTEL_EXPORTER_OTLP_ENDPOINT": collector.URL,
+		"OTEL_EXPORTER_OTLP_HEADERS":  "x-api-key=secret%20key",
+		"OTEL_SERVICE_NAME":           "ci-apply",
+	}.get)
+	require.NotNil(t, e)
+	parent := parentFromTraceparent("00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01")
+	client := &http.Client{Transport: newTransport("Equinix Fabric", http.DefaultTransport, e, *parent)}
+
+	// when
+	for _, path := range []string{"/fabric/v4/connections/found", "/fabric/v4/connections/missing"} {
+		resp, err := client.Get(api.URL + path)
+		require.NoError(t, err)
+		resp.Body.Close()
+	}
+	require.NoError(t, e.shutdown(context.Background()))
+
+	// then
+	require.Len(t, exported, 1, "Spans are exported in a single batch")
+	assert.Equal(t, "secret key", headers[0].Get("X-Api-Key"), "OTLP headers are decoded")
+	resourceSpans := exported[0]["resourceSpans"].([]interface{})[0].(map[string]interface{})
+	assert.Contains(t, resourceSpans["resource"].(map[string]interface{})["attributes"], map[string]interface{}{
+		"key": "service.name", "value": map[string]interface{}{"stringValue": "ci-apply"},
+	})
+	spans := resourceSpans["scopeSpans"].([]interface{})[0].(map[string]interface{})["spans"].([]interface{})
+	require.Len(t, spans, 2)
+	for i, s := range spans {
+		span := s.(map[string]interface{})
+		assert.Equal(t, "Equinix Fabric GET", span["name"])
+		assert.Equal(t, "4bf92f3577b34da6a3ce929d0e0e4736", span["traceId"], "Span joins the parent trace")
+		assert.Equal(t, "00f067aa0ba902b7", span["parentSpanId"])
+		assert.Equal(t, "00-4bf92f3577b34da6a3ce929d0e0e4736-"+span["spanId"].(string)+"-01", apiHeader[i], "Trace context is propagated to the API")
+	}
+	assert.Nil(t, spans[0].(map[string]interface{})["status"])
+	assert.Contains(t, spans[1].(map[string]interface{})["attributes"], map[string]interface{}{
+		"key": "http.response.status_code", "value": map[string]interface{}{"intValue": "404"},
+	})
+	assert.Equal(t, float64(statusCodeError), spans[1].(map[string]interface{})["status"].(map[string]interface{})["code"], "Failed calls have an error status")
+}
+
+type env map[string]string
+
+func (e env) get(k string) string { return e[k] }
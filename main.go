@@ -4,9 +4,11 @@ import (
 	"context"
 	"flag"
 	"log"
+	"time"
 
 	"github.com/equinix/terraform-provider-equinix/equinix"
 	"github.com/equinix/terraform-provider-equinix/internal/provider"
+	"github.com/equinix/terraform-provider-equinix/internal/tracing"
 	"github.com/equinix/terraform-provider-equinix/version"
 
 	"github.com/hashicorp/terraform-plugin-framework/providerserver"
@@ -48,6 +50,12 @@ func main() {
 		serveOpts...,
 	)
 
+	shutdownCtx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+	if err := tracing.Shutdown(shutdownCtx); err != nil {
+		log.Printf("[WARN] Failed to export API call traces: %s", err)
+	}
+
 	if err != nil {
 		log.Fatal(err)
 	}